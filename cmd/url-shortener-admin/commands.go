@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	auth_command "github.com/brunoibarbosa/url-shortener/internal/app/auth/command"
+	url_command "github.com/brunoibarbosa/url-shortener/internal/app/url/command"
+	"github.com/brunoibarbosa/url-shortener/internal/config"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/redis"
+	pg_audit_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/audit"
+	pg_outbox_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/outbox"
+	pg_session_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/session"
+	pg_url_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/url"
+	pg_user_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/user"
+	redis_url_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/redis/url"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/crypto"
+	"github.com/brunoibarbosa/url-shortener/internal/validation"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+func connectPostgres(cfg *config.Config) *pg.Postgres {
+	return pg.NewPostgres(pg.PostgresConnection{
+		Host:            cfg.Postgres.Host,
+		User:            cfg.Postgres.User,
+		Password:        cfg.Postgres.Password,
+		Name:            cfg.Postgres.Name,
+		Port:            cfg.Postgres.Port,
+		MaxConns:        2,
+		MinConns:        0,
+		MaxConnIdleTime: cfg.Postgres.MaxConnIdleTime,
+	})
+}
+
+// connectRedis fails when Redis is down: unlike the server, the commands
+// must not finish without invalidating the cache.
+func connectRedis(cfg *config.Config) (goredis.UniversalClient, error) {
+	client, err := redis.NewRedis(cfg.Redis.Connection())
+	if err != nil {
+		if client != nil {
+			client.Close()
+		}
+		return nil, fmt.Errorf("connect to Redis: %w", err)
+	}
+	return client, nil
+}
+
+func runMigrate(ctx context.Context, cfg *config.Config, args []string) error {
+	fs := newFlagSet("migrate", "up|down|status")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("expected one of up, down or status")
+	}
+
+	postgres := connectPostgres(cfg)
+	defer postgres.Pool.Close()
+
+	migrator, err := pg.NewMigrator(postgres.Pool)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	switch fs.Arg(0) {
+	case "up":
+		results, err := migrator.Up(ctx)
+		for _, r := range results {
+			fmt.Println(r)
+		}
+		if err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			fmt.Println("no pending migrations")
+		}
+	case "down":
+		result, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Println(result)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if !s.AppliedAt.IsZero() {
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%-19s %s\n", appliedAt, s.Source.Path)
+		}
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown migrate direction %q", fs.Arg(0))
+	}
+
+	return nil
+}
+
+func runCreateUser(ctx context.Context, cfg *config.Config, args []string) error {
+	fs := newFlagSet("create-user", "--email <email> --password <password> [--name <name>]")
+	email := fs.String("email", "", "email used to log in")
+	password := fs.String("password", "", "initial password")
+	name := fs.String("name", "", "display name")
+	fs.Parse(args)
+
+	if err := validation.ValidateEmail(*email); err != nil {
+		return err
+	}
+	if err := validation.ValidatePassword(*password); err != nil {
+		return err
+	}
+
+	postgres := connectPostgres(cfg)
+	defer postgres.Pool.Close()
+
+	handler := auth_command.NewRegisterUserHandler(
+		pg.NewTxManager(postgres.Pool),
+		pg_user_repo.NewUserRepository(postgres.Pool),
+		pg_user_repo.NewUserProviderRepository(postgres.Pool),
+		pg_user_repo.NewUserProfileRepository(postgres.Pool),
+		crypto.NewUserPasswordEncrypter(cfg.Auth.BcryptCost),
+	)
+
+	user, err := handler.Handle(ctx, auth_command.RegisterUserCommand{
+		Email:    *email,
+		Password: *password,
+		Name:     *name,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("created user %s (%s)\n", user.ID, user.Email)
+	return nil
+}
+
+func runDisableURL(ctx context.Context, cfg *config.Config, args []string) error {
+	fs := newFlagSet("disable-url", "--code <short-code>")
+	code := fs.String("code", "", "short code to disable")
+	fs.Parse(args)
+
+	postgres := connectPostgres(cfg)
+	defer postgres.Pool.Close()
+
+	// The relay of the running servers clears the cache and notifies
+	// webhooks once the outbox message is committed.
+	handler := url_command.NewDisableShortCodeHandler(
+		pg.NewTxManager(postgres.Pool),
+		pg_url_repo.NewURLRepository(postgres.Pool),
+		pg_outbox_repo.NewOutboxRepository(postgres.Pool),
+		pg_audit_repo.NewAuditRepository(postgres.Pool),
+	)
+
+	if err := handler.Handle(ctx, url_command.DisableShortCodeCommand{ShortCode: *code}); err != nil {
+		return err
+	}
+
+	fmt.Printf("disabled %s\n", *code)
+	return nil
+}
+
+func runRevokeSessions(ctx context.Context, cfg *config.Config, args []string) error {
+	fs := newFlagSet("revoke-sessions", "--user <user-id>")
+	user := fs.String("user", "", "ID of the user whose sessions are revoked")
+	fs.Parse(args)
+
+	userID, err := uuid.Parse(*user)
+	if err != nil {
+		return fmt.Errorf("invalid --user: %w", err)
+	}
+
+	postgres := connectPostgres(cfg)
+	defer postgres.Pool.Close()
+
+	handler := auth_command.NewRevokeUserSessionsHandler(
+		pg_session_repo.NewSessionRepository(postgres.Pool),
+		pg_audit_repo.NewAuditRepository(postgres.Pool),
+	)
+
+	revoked, err := handler.Handle(ctx, auth_command.RevokeUserSessionsCommand{UserID: userID})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("revoked %d session(s); issued access tokens stay valid for up to %s\n", revoked, cfg.Auth.AccessTokenDuration)
+	return nil
+}
+
+func runReencryptURLs(ctx context.Context, cfg *config.Config, args []string) error {
+	fs := newFlagSet("reencrypt-urls", "[--after <short-code>] [--batch-size <n>] [--dry-run]")
+	after := fs.String("after", "", "resume after this short code, as printed by an interrupted run")
+	batchSize := fs.Int("batch-size", cfg.URL.ReencryptBatchSize, "rows read per query")
+	dryRun := fs.Bool("dry-run", false, "report what would change without writing")
+	fs.Parse(args)
+
+	if *batchSize <= 0 {
+		return errors.New("--batch-size must be positive")
+	}
+
+	keyring, err := cfg.URL.Keyring(ctx)
+	if err != nil {
+		return err
+	}
+
+	postgres := connectPostgres(cfg)
+	defer postgres.Pool.Close()
+	redisClient, err := connectRedis(cfg)
+	if err != nil {
+		return err
+	}
+	defer redisClient.Close()
+
+	handler := url_command.NewReencryptURLsHandler(
+		pg_url_repo.NewURLRepository(postgres.Pool),
+		redis_url_repo.NewURLCacheRepository(redisClient),
+		crypto.NewURLEncrypter(keyring),
+	)
+
+	total, err := handler.Run(ctx, url_command.ReencryptURLsCommand{
+		After:     *after,
+		BatchSize: *batchSize,
+		DryRun:    *dryRun,
+	}, cfg.URL.ReencryptPause, func(batch url_command.ReencryptURLsResult) {
+		for _, code := range batch.Failed {
+			fmt.Printf("failed %s: cannot be decrypted to a valid URL with the configured keys\n", code)
+		}
+	})
+
+	fmt.Printf("re-encrypted %d, already on key %q %d, conflicts %d, failed %d",
+		total.Reencrypted, keyring.ActiveKeyID(), total.Current, total.Conflicts, len(total.Failed))
+	if *dryRun {
+		fmt.Print(" (dry run)")
+	}
+	fmt.Println()
+	if err != nil && total.LastShortCode != "" {
+		fmt.Printf("interrupted; resume with --after %s\n", total.LastShortCode)
+	}
+	return err
+}
+
+func runGenerateKey(ctx context.Context, cfg *config.Config, args []string) error {
+	fs := newFlagSet("generate-key", "--id <key-id> [--import <base64>]")
+	id := fs.String("id", "", "identifier stored in front of every ciphertext sealed with the key")
+	imported := fs.String("import", "", "wrap this existing base64 key instead of generating one")
+	fs.Parse(args)
+
+	provider, err := cfg.URL.NewKeyProvider()
+	if err != nil {
+		return err
+	}
+
+	var key string
+	if *imported != "" {
+		secret, decodeErr := base64.StdEncoding.DecodeString(*imported)
+		if decodeErr != nil {
+			return fmt.Errorf("--import: %w", decodeErr)
+		}
+		key, err = crypto.WrapDataKey(ctx, provider, *id, secret)
+	} else {
+		key, err = crypto.NewDataKey(ctx, provider, *id)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(key)
+	return nil
+}
+
+func runGenerateMasterKey(_ context.Context, _ *config.Config, args []string) error {
+	fs := newFlagSet("generate-master-key", "--id <key-id>")
+	id := fs.String("id", "", "identifier of the master key in the key file")
+	fs.Parse(args)
+
+	key, err := crypto.GenerateKey(*id)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(key)
+	return nil
+}
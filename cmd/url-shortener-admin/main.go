@@ -0,0 +1,115 @@
+// Command url-shortener-admin runs database migrations and operational tasks
+// against the same configuration as the API server.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/brunoibarbosa/url-shortener/internal/config"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/logger"
+	"github.com/joho/godotenv"
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, cfg *config.Config, args []string) error
+	config  configMode
+}
+
+type configMode int
+
+const (
+	configRequired configMode = iota
+	// configPartial tolerates validation errors, for commands that help
+	// produce a valid config.
+	configPartial
+	// configNone runs without loading the config.
+	configNone
+)
+
+var commands = []command{
+	{"migrate", "apply, roll back one or list database migrations", runMigrate, configRequired},
+	{"create-user", "create a user with email/password login", runCreateUser, configRequired},
+	{"disable-url", "disable a short code and evict it from the cache", runDisableURL, configRequired},
+	{"revoke-sessions", "revoke every active session of a user", runRevokeSessions, configRequired},
+	{"reencrypt-urls", "re-encrypt stored URLs with the active encryption key", runReencryptURLs, configRequired},
+	{"generate-key", "print a new URL data key wrapped by the key provider", runGenerateKey, configPartial},
+	{"generate-master-key", "print a new random master key for the local key provider", runGenerateMasterKey, configNone},
+}
+
+func main() {
+	flag.Usage = usage
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML or TOML config file (env: CONFIG_FILE)")
+	flag.Parse()
+
+	slog.SetDefault(logger.New(os.Stderr, slog.LevelWarn))
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == flag.Arg(0) {
+			cmd = &commands[i]
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	var cfg *config.Config
+	if cmd.config != configNone {
+		if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			fatal(err)
+		}
+		loaded, err := config.Load(config.Options{File: *configFile})
+		if err != nil && cmd.config == configRequired {
+			fatal(err)
+		}
+		cfg = loaded
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := cmd.run(ctx, cfg, flag.Args()[1:]); err != nil {
+		fatal(err)
+	}
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: url-shortener-admin [--config <file>] <command> [flags]\n\nCommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(out, "  %-20s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintf(out, "\nGlobal flags:\n")
+	flag.PrintDefaults()
+}
+
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: url-shortener-admin %s %s\n", name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "error:", err)
+	os.Exit(1)
+}
@@ -1,67 +1,247 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	stdhttp "net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
+	"time"
 
+	outbox_command "github.com/brunoibarbosa/url-shortener/internal/app/outbox/command"
+	session_command "github.com/brunoibarbosa/url-shortener/internal/app/session/command"
+	session_subscriber "github.com/brunoibarbosa/url-shortener/internal/app/session/subscriber"
+	url_command "github.com/brunoibarbosa/url-shortener/internal/app/url/command"
+	url_subscriber "github.com/brunoibarbosa/url-shortener/internal/app/url/subscriber"
+	webhook_command "github.com/brunoibarbosa/url-shortener/internal/app/webhook/command"
+	webhook_subscriber "github.com/brunoibarbosa/url-shortener/internal/app/webhook/subscriber"
+	"github.com/brunoibarbosa/url-shortener/internal/config"
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
+	session_domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
+	url_domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
 	"github.com/brunoibarbosa/url-shortener/internal/i18n"
 	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
 	"github.com/brunoibarbosa/url-shortener/internal/infra/database/redis"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/logger"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/metrics"
+	memory_url_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/memory/url"
+	pg_outbox_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/outbox"
+	pg_session_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/session"
+	pg_shortcode_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/shortcode"
+	pg_url_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/url"
+	pg_webhook_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/webhook"
+	redis_session_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/redis/session"
+	redis_shortcode_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/redis/shortcode"
+	redis_url_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/redis/url"
+	resilient_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/resilient"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/resilience"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/scheduler"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/crypto"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/shortcode"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/webhook"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/tracing"
 	"github.com/brunoibarbosa/url-shortener/internal/server/http"
 	http_middleware "github.com/brunoibarbosa/url-shortener/internal/server/http/middleware"
 	http_routes "github.com/brunoibarbosa/url-shortener/internal/server/http/routes"
+	"github.com/joho/godotenv"
+	goredis "github.com/redis/go-redis/v9"
 )
 
 func main() {
-	log.Println("Starting URL Shortener API...")
+	logLevel := new(slog.LevelVar)
+	slog.SetDefault(logger.New(os.Stdout, logLevel))
+
+	slog.Info("Starting URL Shortener API")
 
 	// Config
-	log.Println("Loading application configuration...")
-	cfg := LoadAppConfig()
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML or TOML config file (env: CONFIG_FILE)")
+	printConfig := flag.Bool("print-config", false, "print the effective configuration with secrets redacted and exit")
+	flag.Parse()
+
+	slog.Info("Loading application configuration", "file", *configFile)
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		slog.Error("Failed to load .env file", "error", err)
+		os.Exit(1)
+	}
+	cfg, err := config.Load(config.Options{File: *configFile})
+	if *printConfig {
+		if writeErr := cfg.WriteYAML(os.Stdout); writeErr != nil {
+			slog.Error("Failed to print configuration", "error", writeErr)
+			os.Exit(1)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if err != nil {
+		for _, e := range unwrapJoined(err) {
+			slog.Error("Invalid configuration", "error", e)
+		}
+		os.Exit(1)
+	}
+	logLevel.Set(cfg.Log.Level)
+
+	// Tracing
+	slog.Info("Initializing tracing", "exporter", cfg.Tracing.Exporter)
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		ServiceName: cfg.Tracing.ServiceName,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		slog.Error("Failed to initialize tracing", "error", err)
+		os.Exit(1)
+	}
 
 	// Database
-	log.Println("Initializing database connections...")
-	postgres := pg.NewPostgres(cfg.Env.PostgresConn)
-	defer postgres.Pool.Close()
-
-	redisClient := redis.GetRedisClient(redis.RedisConfig{
-		RedisAddress:  cfg.Env.RedisAddress,
-		RedisPassword: cfg.Env.RedisPassword,
-		RedisDB:       cfg.Env.RedisDB,
+	slog.Info("Initializing database connections")
+	postgres := pg.NewPostgres(pg.PostgresConnection{
+		Host:            cfg.Postgres.Host,
+		User:            cfg.Postgres.User,
+		Password:        cfg.Postgres.Password,
+		Name:            cfg.Postgres.Name,
+		Port:            cfg.Postgres.Port,
+		ReplicaHost:     cfg.Postgres.ReplicaHost,
+		ReplicaPort:     cfg.Postgres.ReplicaPort,
+		MaxConns:        cfg.Postgres.MaxConns,
+		MinConns:        cfg.Postgres.MinConns,
+		MaxConnIdleTime: cfg.Postgres.MaxConnIdleTime,
 	})
-	defer redisClient.Close()
+
+	if cfg.Postgres.AutoMigrate {
+		slog.Info("Applying database migrations")
+		if err := migrate(postgres); err != nil {
+			slog.Error("Failed to apply database migrations", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	redisClient, redisErr := redis.NewRedis(cfg.Redis.Connection())
+	if redisClient == nil {
+		slog.Error("Unable to create Redis client", "error", redisErr)
+		os.Exit(1)
+	}
+
+	// Encryption
+	keyring, err := cfg.URL.Keyring(context.Background())
+	if err != nil {
+		slog.Error("Failed to build URL encryption keyring", "error", err)
+		os.Exit(1)
+	}
+	urlEncrypter := crypto.NewURLEncrypter(keyring)
+	blindIndexer, err := cfg.URL.BlindIndexer(context.Background())
+	if err != nil {
+		slog.Error("Failed to build URL blind indexer", "error", err)
+		os.Exit(1)
+	}
+
+	// Local URL cache, invalidated through Redis
+	urlLocalCache := memory_url_repo.NewURLLocalCache(cfg.URL.LocalCacheSize)
+
+	// Redis circuit breaker, shared by every Redis-backed dependency
+	redisBreaker := cfg.Redis.Breaker()
+	if redisErr != nil {
+		// Degrade right away instead of waiting for the first calls to time out.
+		redisBreaker.Trip()
+	}
+
+	// Short codes
+	shortCodeGenerator, shortCodePool := newShortCodeGenerator(cfg.URL, postgres, redisClient)
+
+	// Metrics
+	appMetrics := metrics.New()
+	appMetrics.RegisterPgxPool(postgres.Pool)
+	appMetrics.RegisterRedisPool(redisClient)
+	appMetrics.RegisterBreaker(redisBreaker)
+	appMetrics.RegisterKeyring("url", keyring)
+
+	// Webhooks: link events reach the queue through the outbox; clicks go
+	// through a buffer so redirects never wait on the insert.
+	clickPublisher := webhook.NewAsyncPublisher(pg_webhook_repo.NewWebhookDeliveryRepository(postgres.Router), appMetrics, cfg.Webhook.ClickBuffer)
 
 	// Translation
-	log.Println("Initializing i18n translations...")
+	slog.Info("Initializing i18n translations")
 	if err := i18n.Init(); err != nil {
-		log.Fatalf("Failed to initialize i18n: %v", err)
+		slog.Error("Failed to initialize i18n", "error", err)
+		os.Exit(1)
 	}
-	log.Println("i18n translations initialized successfully")
+	slog.Info("i18n translations initialized successfully")
 
 	// Router
-	log.Println("Setting up HTTP router and routes...")
+	slog.Info("Setting up HTTP router and routes")
 	router := http.NewRouter()
 	router.Use(
+		http_middleware.TracingMiddleware,
+		http_middleware.RequestIDMiddleware,
+		http_middleware.NewClientIPMiddleware(cfg.Server.TrustedProxies).Handler,
+		http_middleware.AccessLogMiddleware,
+		http_middleware.NewMetricsMiddleware(appMetrics).Handler,
 		http_middleware.LocaleMiddleware,
 		http_middleware.RecoverMiddleware,
 	)
-	http_routes.NewURLRoutes(router, postgres.Pool, redisClient, http_routes.URLRoutesConfig{
-		JWTSecret:                    cfg.Env.JWTSecret,
-		URLSecret:                    cfg.Env.URLSecret,
-		URLPersistExpirationDuration: cfg.Env.URLPersistExpirationDuration,
-		URLCacheExpirationDuration:   cfg.Env.URLCacheExpirationDuration,
+	http_routes.NewURLRoutes(router, postgres.Router, redisClient, http_routes.URLRoutesConfig{
+		JWTSecret:                    cfg.Auth.JWTSecret,
+		Encrypter:                    urlEncrypter,
+		BlindIndexer:                 blindIndexer,
+		URLPersistExpirationDuration: cfg.URL.PersistExpiration,
+		URLCacheExpirationDuration:   cfg.URL.CacheExpiration,
+		LocalCache:                   urlLocalCache,
+		LocalCacheExpiration:         cfg.URL.LocalCacheExpiration,
+		AnonymousRateLimit:           cfg.RateLimit.ShortenAnonymous,
+		AuthenticatedRateLimit:       cfg.RateLimit.ShortenAuthenticated,
+		ShortCodeLength:              cfg.URL.ShortCodeLength,
+		ShortCodeMaxRetries:          cfg.URL.ShortCodeMaxRetries,
+		ShortCodeGenerator:           shortCodeGenerator,
+		RedisBreaker:                 redisBreaker,
+		Metrics:                      appMetrics,
+		ClickPublisher:               clickPublisher,
+		IdempotencyTTL:               cfg.Idempotency.TTL,
+		IdempotencyLockTTL:           cfg.Idempotency.LockTTL,
+		IdempotencyMaxBodyBytes:      cfg.Idempotency.MaxBodyBytes,
+		IdempotencyFingerprintKey:    []byte(cfg.Idempotency.FingerprintKey),
+	})
+	http_routes.NewAuthRoutes(router, postgres.Router, redisClient, http_routes.AuthRoutesConfig{
+		JWTSecret:                 cfg.Auth.JWTSecret,
+		GoogleID:                  cfg.Auth.GoogleClientID,
+		GoogleSecret:              cfg.Auth.GoogleClientSecret,
+		ListenAddress:             cfg.Server.ListenAddress,
+		RefreshTokenDuration:      cfg.Auth.RefreshTokenDuration,
+		AccessTokenDuration:       cfg.Auth.AccessTokenDuration,
+		BcryptCost:                cfg.Auth.BcryptCost,
+		OAuthStateExpiration:      cfg.Auth.OAuthStateExpiration,
+		RateLimit:                 cfg.RateLimit.Auth,
+		RedisBreaker:              redisBreaker,
+		BlacklistFailurePolicy:    cfg.Redis.BlacklistFailurePolicy,
+		StateFailurePolicy:        cfg.Redis.StateFailurePolicy,
+		Metrics:                   appMetrics,
+		IdempotencyTTL:            cfg.Idempotency.TTL,
+		IdempotencyLockTTL:        cfg.Idempotency.LockTTL,
+		IdempotencyMaxBodyBytes:   cfg.Idempotency.MaxBodyBytes,
+		IdempotencyFingerprintKey: []byte(cfg.Idempotency.FingerprintKey),
 	})
-	http_routes.NewAuthRoutes(router, postgres.Pool, redisClient, http_routes.AuthRoutesConfig{
-		JWTSecret:            cfg.Env.JWTSecret,
-		GoogleID:             cfg.Env.GoogleID,
-		GoogleSecret:         cfg.Env.GoogleSecret,
-		ListenAddress:        cfg.Env.ListenAddress,
-		RefreshTokenDuration: cfg.Env.RefreshTokenDuration,
-		AccessTokenDuration:  cfg.Env.AccessTokenDuration,
+	http_routes.NewSessionRoutes(router, postgres.Router, http_routes.SessionRoutesConfig{
+		JWTSecret: cfg.Auth.JWTSecret,
 	})
-	http_routes.NewSessionRoutes(router, postgres.Pool, http_routes.SessionRoutesConfig{
-		JWTSecret: cfg.Env.JWTSecret,
+	http_routes.NewAuditRoutes(router, postgres.Router, http_routes.AuditRoutesConfig{
+		JWTSecret:    cfg.Auth.JWTSecret,
+		AdminUserIDs: cfg.Admin.UserIDs,
+	})
+	http_routes.NewWebhookRoutes(router, postgres.Router, http_routes.WebhookRoutesConfig{
+		JWTSecret:           cfg.Auth.JWTSecret,
+		MaxEndpointsPerUser: cfg.Webhook.MaxEndpointsPerUser,
+	})
+	healthHTTPHandler := http_routes.NewHealthRoutes(router, postgres.Router, redisClient, http_routes.HealthRoutesConfig{
+		CheckTimeout:  cfg.Server.HealthCheckTimeout,
+		ReplicaMaxLag: cfg.Postgres.ReplicaMaxLag,
+		RedisBreaker:  redisBreaker,
 	})
 
 	// Swagger - usa caminho absoluto para evitar problemas com diretório de trabalho
@@ -69,16 +249,175 @@ func main() {
 	http_routes.NewSwaggerRoutes(router, http_routes.SwaggerRoutesConfig{
 		SpecPath: swaggerSpecPath,
 	})
-	log.Println("Routes configured successfully")
+	slog.Info("Routes configured successfully")
+
+	// Admin server
+	serverTimeouts := http.ServerTimeouts{
+		Read:       cfg.Server.ReadTimeout,
+		ReadHeader: cfg.Server.ReadHeaderTimeout,
+		Write:      cfg.Server.WriteTimeout,
+		Idle:       cfg.Server.IdleTimeout,
+	}
+
+	slog.Info("Starting admin server", "address", cfg.Admin.ListenAddress)
+	adminServer := http.NewAdminServer(cfg.Admin.ListenAddress, http.AdminServerConfig{
+		MetricsHandler: appMetrics.Handler(),
+		Timeouts:       serverTimeouts,
+	})
 
 	// Server
-	log.Printf("Starting HTTP server on %s...", cfg.Env.ListenAddress)
-	server := http.NewServer(cfg.Env.ListenAddress, router)
-	log.Printf("Server is ready and listening on %s", cfg.Env.ListenAddress)
+	slog.Info("Starting HTTP server", "address", cfg.Server.ListenAddress)
+	server := http.NewServer(cfg.Server.ListenAddress, router, serverTimeouts)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 2)
+	go func() { serverErr <- adminServer.ListenAndServe() }()
+	go func() { serverErr <- server.ListenAndServe() }()
+	slog.Info("Server is ready and listening", "address", cfg.Server.ListenAddress)
+
+	reencryptDone := make(chan struct{})
+	if cfg.URL.ReencryptOnStartup {
+		reencryptHandler := url_command.NewReencryptURLsHandler(
+			pg_url_repo.NewURLRepository(postgres.Pool),
+			redis_url_repo.NewURLCacheRepository(redisClient),
+			urlEncrypter,
+		)
+		go func() {
+			defer close(reencryptDone)
+			reencryptURLs(ctx, reencryptHandler, cfg.URL)
+		}()
+	} else {
+		close(reencryptDone)
+	}
+
+	localCacheDone := make(chan struct{})
+	go func() {
+		defer close(localCacheDone)
+		redis_url_repo.NewURLCacheRepository(redisClient).ListenInvalidations(ctx, urlLocalCache)
+	}()
+
+	replicaMonitorDone := make(chan struct{})
+	go func() {
+		defer close(replicaMonitorDone)
+		postgres.Router.MonitorReplica(ctx, cfg.Postgres.ReplicaCheckInterval, cfg.Postgres.ReplicaMaxLag)
+	}()
+
+	schedulerDone := make(chan struct{})
+	if cfg.Scheduler.Enabled {
+		jobScheduler := newScheduler(cfg.Scheduler, cfg.Webhook, postgres, appMetrics)
+		go func() {
+			defer close(schedulerDone)
+			jobScheduler.Run(ctx)
+		}()
+	} else {
+		close(schedulerDone)
+	}
+
+	// The click publisher outlives ctx: it drains its buffer once the HTTP
+	// server has stopped producing clicks.
+	clickPublisherCtx, stopClickPublisher := context.WithCancel(context.Background())
+	clickPublisherDone := make(chan struct{})
+	go func() {
+		defer close(clickPublisherDone)
+		clickPublisher.Run(clickPublisherCtx)
+	}()
+
+	webhookDeliveryDone := make(chan struct{})
+	if cfg.Webhook.DeliveryEnabled {
+		deliverHandler := webhook_command.NewDeliverWebhooksHandler(
+			pg_webhook_repo.NewWebhookDeliveryRepository(postgres.Pool),
+			webhook.NewHTTPSender(cfg.Webhook.Timeout, cfg.Webhook.AllowPrivateNetworks),
+			appMetrics,
+		)
+		go func() {
+			defer close(webhookDeliveryDone)
+			deliverWebhooks(ctx, deliverHandler, cfg.Webhook)
+		}()
+	} else {
+		close(webhookDeliveryDone)
+	}
+
+	outboxRelayDone := make(chan struct{})
+	if cfg.Outbox.RelayEnabled {
+		relayHandler := outbox_command.NewRelayOutboxHandler(
+			pg_outbox_repo.NewOutboxRepository(postgres.Pool),
+			newOutboxSubscriptions(postgres, redisClient, redisBreaker),
+			appMetrics,
+		)
+		go func() {
+			defer close(outboxRelayDone)
+			relayOutbox(ctx, relayHandler, cfg.Outbox)
+		}()
+	} else {
+		close(outboxRelayDone)
+	}
+
+	shortCodePoolDone := make(chan struct{})
+	if shortCodePool != nil {
+		go func() {
+			defer close(shortCodePoolDone)
+			refillShortCodePool(ctx, shortCodePool, cfg.URL.ShortCodePoolRefillInterval)
+		}()
+	} else {
+		close(shortCodePoolDone)
+	}
+
+	exitCode := 0
+	select {
+	case err := <-serverErr:
+		slog.Error("Server failed", "error", err)
+		exitCode = 1
+	case <-ctx.Done():
+		slog.Info("Shutdown signal received")
+	}
+	stop()
+
+	// Shutdown: stop advertising readiness, give load balancers time to
+	// notice, drain in-flight requests, flush background work and only then
+	// close the connection pools the handlers depend on.
+	healthHTTPHandler.MarkShuttingDown()
+	if exitCode == 0 {
+		slog.Info("Waiting for load balancers to observe readiness change", "delay", cfg.Server.ShutdownDrainDelay)
+		time.Sleep(cfg.Server.ShutdownDrainDelay)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
 
-	if err := server.ListenAndServe(); err != nil {
-		log.Fatalf("Server failed to start: %v", err)
+	slog.Info("Draining HTTP connections")
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, stdhttp.ErrServerClosed) {
+		slog.Error("Failed to shut down HTTP server", "error", err)
+		exitCode = 1
 	}
+	if err := adminServer.Shutdown(shutdownCtx); err != nil && !errors.Is(err, stdhttp.ErrServerClosed) {
+		slog.Error("Failed to shut down admin server", "error", err)
+		exitCode = 1
+	}
+
+	slog.Info("Flushing background work")
+	stopClickPublisher()
+	<-clickPublisherDone
+	<-outboxRelayDone
+	<-webhookDeliveryDone
+	<-reencryptDone
+	<-shortCodePoolDone
+	<-localCacheDone
+	<-replicaMonitorDone
+	<-schedulerDone
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("Failed to flush traces", "error", err)
+	}
+
+	slog.Info("Closing database connections")
+	postgres.Close()
+	if err := redisClient.Close(); err != nil {
+		slog.Error("Failed to close Redis client", "error", err)
+	}
+
+	slog.Info("Shutdown complete")
+	os.Exit(exitCode)
 }
 
 // getProjectRoot retorna o caminho absoluto para a raiz do projeto
@@ -87,7 +426,8 @@ func getProjectRoot() string {
 	// Este arquivo está em cmd/url-shortener, então precisamos subir 2 níveis
 	executable, err := os.Getwd()
 	if err != nil {
-		log.Fatalf("Failed to get working directory: %v", err)
+		slog.Error("Failed to get working directory", "error", err)
+		os.Exit(1)
 	}
 
 	// Se estiver executando de cmd/url-shortener, volta 2 níveis
@@ -98,3 +438,229 @@ func getProjectRoot() string {
 	// Caso contrário, assume que já está na raiz
 	return executable
 }
+
+func migrate(postgres *pg.Postgres) error {
+	migrator, err := pg.NewMigrator(postgres.Pool)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	results, err := migrator.Up(context.Background())
+	for _, r := range results {
+		slog.Info("Applied migration", "version", r.Source.Version, "duration", r.Duration)
+	}
+	return err
+}
+
+// newShortCodeGenerator returns the configured generator and, for the pool
+// strategy, the pool that has to be refilled in the background.
+func newShortCodeGenerator(cfg config.URLConfig, postgres *pg.Postgres, redisClient goredis.UniversalClient) (url_domain.ShortCodeGenerator, *shortcode.PoolShortCodeGenerator) {
+	switch cfg.ShortCodeStrategy {
+	case shortcode.StrategyCounter:
+		var counter url_domain.ShortCodeCounterRepository = pg_shortcode_repo.NewShortCodeCounterRepository(postgres.Pool)
+		if cfg.ShortCodeCounterSource == shortcode.CounterSourceRedis {
+			counter = redis_shortcode_repo.NewShortCodeCounterRepository(redisClient)
+		}
+		if cfg.ShortCodeObfuscationKey == "" {
+			slog.Warn("Counter short codes are sequential; set SHORT_CODE_OBFUSCATION_KEY to make them unguessable")
+		}
+		return shortcode.NewCounterShortCodeGenerator(counter, cfg.ShortCodeCounterBlockSize, []byte(cfg.ShortCodeObfuscationKey)), nil
+	case shortcode.StrategyPool:
+		pool := shortcode.NewPoolShortCodeGenerator(pg_shortcode_repo.NewShortCodePoolRepository(postgres.Pool), shortcode.PoolConfig{
+			Length:       cfg.ShortCodeLength,
+			MinAvailable: cfg.ShortCodePoolMinAvailable,
+			RefillBatch:  cfg.ShortCodePoolRefillBatch,
+			Prefetch:     cfg.ShortCodePoolPrefetch,
+		})
+		return pool, pool
+	default:
+		return shortcode.NewRandomShortCodeGenerator(), nil
+	}
+}
+
+// refillShortCodePool keeps the pool above its minimum size until ctx is
+// cancelled. Every instance runs it; concurrent refills are harmless.
+func refillShortCodePool(ctx context.Context, pool *shortcode.PoolShortCodeGenerator, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		added, err := pool.Refill(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			slog.Warn("Failed to refill short code pool", "error", err)
+		case added > 0:
+			slog.Debug("Refilled short code pool", "added", added)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reencryptURLs moves stored URLs to the active encryption key. Rows already
+// on that key are skipped cheaply, so after a restart it resumes where it was.
+func reencryptURLs(ctx context.Context, handler *url_command.ReencryptURLsHandler, cfg config.URLConfig) {
+	slog.Info("Starting URL re-encryption", "batch_size", cfg.ReencryptBatchSize)
+
+	total, err := handler.Run(ctx, url_command.ReencryptURLsCommand{BatchSize: cfg.ReencryptBatchSize}, cfg.ReencryptPause, func(batch url_command.ReencryptURLsResult) {
+		for _, code := range batch.Failed {
+			slog.Warn("Failed to re-encrypt URL", "short_code", code)
+		}
+		slog.Debug("URL re-encryption progress", "last_short_code", batch.LastShortCode, "reencrypted", batch.Reencrypted)
+	})
+
+	attrs := []any{
+		"reencrypted", total.Reencrypted,
+		"current", total.Current,
+		"conflicts", total.Conflicts,
+		"failed", len(total.Failed),
+		"last_short_code", total.LastShortCode,
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		slog.Info("URL re-encryption interrupted", attrs...)
+	case err != nil:
+		slog.Error("URL re-encryption failed", append(attrs, "error", err)...)
+	default:
+		slog.Info("URL re-encryption finished", attrs...)
+	}
+}
+
+// deliverWebhooks sends due webhook deliveries until ctx is cancelled. A
+// full batch is followed by the next one right away; otherwise the queue is
+// polled again after the poll interval.
+func deliverWebhooks(ctx context.Context, handler *webhook_command.DeliverWebhooksHandler, cfg config.WebhookConfig) {
+	cmd := webhook_command.DeliverWebhooksCommand{
+		BatchSize:      cfg.BatchSize,
+		Concurrency:    cfg.Concurrency,
+		Lease:          cfg.DeliveryLease(),
+		MaxAttempts:    cfg.MaxAttempts,
+		RetryBaseDelay: cfg.RetryBaseDelay,
+		RetryMaxDelay:  cfg.RetryMaxDelay,
+	}
+
+	for {
+		claimed, err := handler.Handle(ctx, cmd)
+		if err != nil && ctx.Err() == nil {
+			slog.Warn("Failed to deliver webhooks", "error", err)
+		}
+		if claimed == cfg.BatchSize && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(cfg.PollInterval):
+		}
+	}
+}
+
+// relayOutbox hands outbox messages to their subscribers until ctx is
+// cancelled, polling like deliverWebhooks.
+func relayOutbox(ctx context.Context, handler *outbox_command.RelayOutboxHandler, cfg config.OutboxConfig) {
+	cmd := outbox_command.RelayOutboxCommand{
+		BatchSize:      cfg.BatchSize,
+		Lease:          cfg.Lease,
+		MaxAttempts:    cfg.MaxAttempts,
+		RetryBaseDelay: cfg.RetryBaseDelay,
+		RetryMaxDelay:  cfg.RetryMaxDelay,
+	}
+
+	for {
+		claimed, err := handler.Handle(ctx, cmd)
+		if err != nil && ctx.Err() == nil {
+			slog.Warn("Failed to relay outbox messages", "error", err)
+		}
+		if claimed == cfg.BatchSize && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(cfg.PollInterval):
+		}
+	}
+}
+
+// newOutboxSubscriptions lists who handles each outbox topic. Redis errors
+// are returned rather than tolerated, whatever the failure policies, so the
+// message is retried once Redis is back.
+func newOutboxSubscriptions(postgres *pg.Postgres, redisClient goredis.UniversalClient, redisBreaker *resilience.Breaker) outbox_domain.Subscriptions {
+	invalidateCache := url_subscriber.NewInvalidateCacheSubscriber(
+		resilient_repo.NewURLCacheRepository(redis_url_repo.NewURLCacheRepository(redisClient), redisBreaker),
+	)
+	publishWebhooks := webhook_subscriber.NewPublishURLEventSubscriber(pg_webhook_repo.NewWebhookDeliveryRepository(postgres.Pool))
+	blacklistToken := session_subscriber.NewBlacklistTokenSubscriber(
+		resilient_repo.NewBlacklistRepository(redis_session_repo.NewBlacklistRepository(redisClient), redisBreaker, resilience.FailClosed),
+	)
+
+	subs := outbox_domain.Subscriptions{}
+	subs.Subscribe(url_domain.TopicURLCreated, publishWebhooks)
+	subs.Subscribe(url_domain.TopicURLDeleted, invalidateCache)
+	subs.Subscribe(url_domain.TopicURLDeleted, publishWebhooks)
+	subs.Subscribe(url_domain.TopicURLExpired, invalidateCache)
+	subs.Subscribe(url_domain.TopicURLExpired, publishWebhooks)
+	subs.Subscribe(session_domain.TopicSessionRevoked, blacklistToken)
+	return subs
+}
+
+// newScheduler returns the scheduler of the cleanup jobs. OAuth states need
+// no job: Redis expires them.
+func newScheduler(cfg config.SchedulerConfig, webhookCfg config.WebhookConfig, postgres *pg.Postgres, appMetrics *metrics.Metrics) *scheduler.Scheduler {
+	purgeSessions := session_command.NewPurgeSessionsHandler(pg_session_repo.NewSessionRepository(postgres.Pool))
+	cleanupURLs := url_command.NewCleanupExpiredURLsHandler(
+		pg.NewTxManager(postgres.Pool),
+		pg_url_repo.NewURLRepository(postgres.Pool),
+		pg_outbox_repo.NewOutboxRepository(postgres.Pool),
+	)
+	purgeWebhookDeliveries := webhook_command.NewPurgeWebhookDeliveriesHandler(pg_webhook_repo.NewWebhookDeliveryRepository(postgres.Pool))
+
+	return scheduler.New(pg.NewAdvisoryLock(postgres.Pool, "url-shortener:scheduler"), appMetrics, cfg.ElectionInterval,
+		scheduler.Job{
+			Name:     "session_cleanup",
+			Interval: cfg.SessionCleanupInterval,
+			Run: func(ctx context.Context) (int64, error) {
+				return purgeSessions.Handle(ctx, session_command.PurgeSessionsCommand{
+					BatchSize: cfg.SessionCleanupBatchSize,
+					RetainFor: cfg.SessionRetention,
+				})
+			},
+		},
+		scheduler.Job{
+			Name:     "url_cleanup",
+			Interval: cfg.URLCleanupInterval,
+			Run: func(ctx context.Context) (int64, error) {
+				result, err := cleanupURLs.Handle(ctx, url_command.CleanupExpiredURLsCommand{
+					BatchSize:  cfg.URLCleanupBatchSize,
+					PurgeAfter: cfg.URLPurgeAfter,
+				})
+				return result.Expired + result.Purged, err
+			},
+		},
+		scheduler.Job{
+			Name:     "webhook_delivery_cleanup",
+			Interval: cfg.WebhookCleanupInterval,
+			Run: func(ctx context.Context) (int64, error) {
+				return purgeWebhookDeliveries.Handle(ctx, webhook_command.PurgeWebhookDeliveriesCommand{
+					BatchSize: cfg.WebhookCleanupBatchSize,
+					RetainFor: webhookCfg.DeliveryRetention,
+				})
+			},
+		},
+	)
+}
+
+// unwrapJoined splits an errors.Join result so each problem is logged on its own line.
+func unwrapJoined(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
@@ -0,0 +1,27 @@
+package query
+
+import (
+	"context"
+
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+	"github.com/google/uuid"
+)
+
+type ListAuditEventsHandler struct {
+	repo audit_domain.AuditQueryRepository
+}
+
+func NewListAuditEventsHandler(repo audit_domain.AuditQueryRepository) *ListAuditEventsHandler {
+	return &ListAuditEventsHandler{
+		repo: repo,
+	}
+}
+
+func (h *ListAuditEventsHandler) Handle(ctx context.Context, params audit_domain.ListEventsParams) ([]audit_domain.ListEventsDTO, uint64, error) {
+	return h.repo.List(ctx, params)
+}
+
+func (h *ListAuditEventsHandler) HandleForUser(ctx context.Context, userID uuid.UUID, params audit_domain.ListEventsParams) ([]audit_domain.ListEventsDTO, uint64, error) {
+	params.Filter.ActorID = &userID
+	return h.repo.List(ctx, params)
+}
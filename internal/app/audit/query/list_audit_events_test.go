@@ -0,0 +1,114 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/audit/query"
+	"github.com/brunoibarbosa/url-shortener/internal/domain"
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListAuditEventsHandler_Handle_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAuditQueryRepository(ctrl)
+
+	action := audit_domain.ActionLoginFailed
+	params := audit_domain.ListEventsParams{
+		Filter: audit_domain.ListEventsFilter{
+			Action: &action,
+		},
+		SortKind: domain.SortDesc,
+		Pagination: domain.Pagination{
+			Number: 1,
+			Size:   10,
+		},
+	}
+
+	expectedEvents := []audit_domain.ListEventsDTO{
+		{
+			ID:         1,
+			Action:     audit_domain.ActionLoginFailed,
+			TargetType: audit_domain.TargetUser,
+			TargetID:   "user@example.com",
+			IPAddress:  "192.168.1.1",
+			CreatedAt:  time.Now(),
+		},
+	}
+
+	mockRepo.EXPECT().List(ctx, params).Return(expectedEvents, uint64(1), nil)
+
+	handler := query.NewListAuditEventsHandler(mockRepo)
+
+	events, total, err := handler.Handle(ctx, params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedEvents, events)
+	assert.Equal(t, uint64(1), total)
+}
+
+func TestListAuditEventsHandler_HandleForUser_ScopesToActor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := uuid.New()
+	otherID := uuid.New()
+	mockRepo := mocks.NewMockAuditQueryRepository(ctrl)
+
+	params := audit_domain.ListEventsParams{
+		Filter: audit_domain.ListEventsFilter{
+			ActorID: &otherID,
+		},
+		Pagination: domain.Pagination{
+			Number: 1,
+			Size:   10,
+		},
+	}
+
+	mockRepo.EXPECT().List(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, p audit_domain.ListEventsParams) ([]audit_domain.ListEventsDTO, uint64, error) {
+			assert.Equal(t, &userID, p.Filter.ActorID)
+			assert.Equal(t, params.Pagination, p.Pagination)
+			return []audit_domain.ListEventsDTO{}, 0, nil
+		},
+	)
+
+	handler := query.NewListAuditEventsHandler(mockRepo)
+
+	events, total, err := handler.HandleForUser(ctx, userID, params)
+
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Equal(t, uint64(0), total)
+}
+
+func TestListAuditEventsHandler_Handle_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAuditQueryRepository(ctrl)
+	expectedError := errors.New("database error")
+
+	params := audit_domain.ListEventsParams{}
+
+	mockRepo.EXPECT().List(ctx, params).Return(nil, uint64(0), expectedError)
+
+	handler := query.NewListAuditEventsHandler(mockRepo)
+
+	events, total, err := handler.Handle(ctx, params)
+
+	assert.Equal(t, expectedError, err)
+	assert.Nil(t, events)
+	assert.Equal(t, uint64(0), total)
+}
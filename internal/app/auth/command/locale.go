@@ -0,0 +1,25 @@
+package command
+
+import (
+	"context"
+
+	user_domain "github.com/brunoibarbosa/url-shortener/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+// profileLocale is best effort: without it the access token carries no
+// locale and responses follow Accept-Language.
+func profileLocale(ctx context.Context, userRepo user_domain.UserRepository, userID uuid.UUID) string {
+	u, err := userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return ""
+	}
+	return localeOf(u)
+}
+
+func localeOf(u *user_domain.User) string {
+	if u == nil || u.Profile == nil || u.Profile.Locale == nil {
+		return ""
+	}
+	return *u.Profile.Locale
+}
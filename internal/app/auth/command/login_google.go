@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
 	bd_domain "github.com/brunoibarbosa/url-shortener/internal/domain/bd"
 	session_domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
 	user_domain "github.com/brunoibarbosa/url-shortener/internal/domain/user"
@@ -27,6 +28,8 @@ type LoginGoogleHandler struct {
 	tokenService         session_domain.TokenService
 	sessionEncrypter     session_domain.SessionEncrypter
 	stateService         session_domain.StateService
+	auditRecorder        audit_domain.AuditRecorder
+	metrics              session_domain.AuthMetrics
 	refreshTokenDuration time.Duration
 	accessTokenDuration  time.Duration
 }
@@ -41,6 +44,8 @@ func NewLoginGoogleHandler(
 	tokenService session_domain.TokenService,
 	sessionEncrypter session_domain.SessionEncrypter,
 	stateService session_domain.StateService,
+	auditRecorder audit_domain.AuditRecorder,
+	metrics session_domain.AuthMetrics,
 	refreshTokenDuration time.Duration,
 	accessTokenDuration time.Duration,
 ) *LoginGoogleHandler {
@@ -54,6 +59,8 @@ func NewLoginGoogleHandler(
 		tokenService,
 		sessionEncrypter,
 		stateService,
+		auditRecorder,
+		metrics,
 		refreshTokenDuration,
 		accessTokenDuration,
 	}
@@ -72,11 +79,13 @@ func (h *LoginGoogleHandler) Handle(ctx context.Context, cmd LoginGoogleCommand)
 
 	oauthUser, err := h.provider.ExchangeCode(ctx, cmd.Code)
 	if err != nil {
+		h.metrics.LoginFailed(user_domain.ProviderGoogle)
 		return "", "", err
 	}
 
 	var session *session_domain.Session
 	var refreshToken string
+	var locale string
 
 	err = h.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
 		existingProvider, err := h.providerRepo.Find(txCtx, user_domain.ProviderGoogle, oauthUser.ID)
@@ -119,6 +128,17 @@ func (h *LoginGoogleHandler) Handle(ctx context.Context, cmd LoginGoogleCommand)
 				return err
 			}
 
+			if err := h.auditRecorder.Record(txCtx, &audit_domain.Event{
+				ActorID:    &user.ID,
+				Action:     audit_domain.ActionProviderLinked,
+				TargetType: audit_domain.TargetProvider,
+				TargetID:   user_domain.ProviderGoogle,
+				IPAddress:  cmd.IPAddress,
+				UserAgent:  cmd.UserAgent,
+			}); err != nil {
+				return err
+			}
+
 			if oauthUser.Name != "" {
 				profile := &user_domain.UserProfile{
 					Name:      oauthUser.Name,
@@ -130,6 +150,8 @@ func (h *LoginGoogleHandler) Handle(ctx context.Context, cmd LoginGoogleCommand)
 			}
 		}
 
+		locale = localeOf(user)
+
 		refreshTokenObj := h.tokenService.GenerateRefreshToken()
 		refreshToken = refreshTokenObj.String()
 		refreshHash := h.sessionEncrypter.HashRefreshToken(refreshToken)
@@ -144,7 +166,18 @@ func (h *LoginGoogleHandler) Handle(ctx context.Context, cmd LoginGoogleCommand)
 			ExpiresAt:        &expiresAt,
 		}
 
-		return h.sessionRepo.Create(txCtx, session)
+		if err := h.sessionRepo.Create(txCtx, session); err != nil {
+			return err
+		}
+
+		return h.auditRecorder.Record(txCtx, &audit_domain.Event{
+			ActorID:    &user.ID,
+			Action:     audit_domain.ActionLogin,
+			TargetType: audit_domain.TargetSession,
+			TargetID:   session.ID.String(),
+			IPAddress:  cmd.IPAddress,
+			UserAgent:  cmd.UserAgent,
+		})
 	})
 
 	if err != nil {
@@ -155,10 +188,12 @@ func (h *LoginGoogleHandler) Handle(ctx context.Context, cmd LoginGoogleCommand)
 		UserID:    session.UserID,
 		SessionID: session.ID,
 		Duration:  h.accessTokenDuration,
+		Locale:    locale,
 	})
 	if err != nil {
 		return "", "", err
 	}
 
+	h.metrics.LoginSucceeded(user_domain.ProviderGoogle)
 	return accessToken, refreshToken, nil
 }
@@ -66,6 +66,12 @@ func TestLoginGoogleHandler_Handle_Success_NewUser(t *testing.T) {
 	mockSessionEncrypter.EXPECT().HashRefreshToken(refreshTokenUUID.String()).Return("hashed_refresh")
 	mockTokenService.EXPECT().GenerateAccessToken(gomock.Any()).Return("access_token", nil)
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockMetrics := mocks.NewMockAuthMetrics(ctrl)
+	mockMetrics.EXPECT().LoginSucceeded(gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().LoginFailed(gomock.Any()).AnyTimes()
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewLoginGoogleHandler(
 		mockTxManager,
 		mockProvider,
@@ -76,6 +82,8 @@ func TestLoginGoogleHandler_Handle_Success_NewUser(t *testing.T) {
 		mockTokenService,
 		mockSessionEncrypter,
 		mockStateService,
+		mockAuditRecorder,
+		mockMetrics,
 		24*time.Hour,
 		15*time.Minute,
 	)
@@ -146,6 +154,12 @@ func TestLoginGoogleHandler_Handle_Success_ExistingUser(t *testing.T) {
 	mockSessionEncrypter.EXPECT().HashRefreshToken(refreshTokenUUID.String()).Return("hashed_refresh")
 	mockTokenService.EXPECT().GenerateAccessToken(gomock.Any()).Return("access_token_2", nil)
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockMetrics := mocks.NewMockAuthMetrics(ctrl)
+	mockMetrics.EXPECT().LoginSucceeded(gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().LoginFailed(gomock.Any()).AnyTimes()
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewLoginGoogleHandler(
 		mockTxManager,
 		mockProvider,
@@ -156,6 +170,8 @@ func TestLoginGoogleHandler_Handle_Success_ExistingUser(t *testing.T) {
 		mockTokenService,
 		mockSessionEncrypter,
 		mockStateService,
+		mockAuditRecorder,
+		mockMetrics,
 		24*time.Hour,
 		15*time.Minute,
 	)
@@ -189,6 +205,12 @@ func TestLoginGoogleHandler_Handle_EmptyCode(t *testing.T) {
 		IPAddress: "192.168.1.1",
 	}
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockMetrics := mocks.NewMockAuthMetrics(ctrl)
+	mockMetrics.EXPECT().LoginSucceeded(gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().LoginFailed(gomock.Any()).AnyTimes()
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewLoginGoogleHandler(
 		mockTxManager,
 		mockProvider,
@@ -199,6 +221,8 @@ func TestLoginGoogleHandler_Handle_EmptyCode(t *testing.T) {
 		mockTokenService,
 		mockSessionEncrypter,
 		mockStateService,
+		mockAuditRecorder,
+		mockMetrics,
 		24*time.Hour,
 		15*time.Minute,
 	)
@@ -235,6 +259,12 @@ func TestLoginGoogleHandler_Handle_InvalidState(t *testing.T) {
 
 	mockStateService.EXPECT().ValidateState(ctx, "invalid_state").Return(session_domain.ErrInvalidState)
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockMetrics := mocks.NewMockAuthMetrics(ctrl)
+	mockMetrics.EXPECT().LoginSucceeded(gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().LoginFailed(gomock.Any()).AnyTimes()
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewLoginGoogleHandler(
 		mockTxManager,
 		mockProvider,
@@ -245,6 +275,8 @@ func TestLoginGoogleHandler_Handle_InvalidState(t *testing.T) {
 		mockTokenService,
 		mockSessionEncrypter,
 		mockStateService,
+		mockAuditRecorder,
+		mockMetrics,
 		24*time.Hour,
 		15*time.Minute,
 	)
@@ -283,6 +315,12 @@ func TestLoginGoogleHandler_Handle_ExchangeCodeError(t *testing.T) {
 	mockStateService.EXPECT().DeleteState(ctx, "valid_state").Return(nil)
 	mockProvider.EXPECT().ExchangeCode(ctx, "invalid_code").Return(nil, errors.New("oauth error"))
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockMetrics := mocks.NewMockAuthMetrics(ctrl)
+	mockMetrics.EXPECT().LoginSucceeded(gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().LoginFailed(gomock.Any()).AnyTimes()
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewLoginGoogleHandler(
 		mockTxManager,
 		mockProvider,
@@ -293,6 +331,8 @@ func TestLoginGoogleHandler_Handle_ExchangeCodeError(t *testing.T) {
 		mockTokenService,
 		mockSessionEncrypter,
 		mockStateService,
+		mockAuditRecorder,
+		mockMetrics,
 		24*time.Hour,
 		15*time.Minute,
 	)
@@ -338,6 +378,12 @@ func TestLoginGoogleHandler_Handle_TransactionError(t *testing.T) {
 	mockProvider.EXPECT().ExchangeCode(ctx, "valid_code").Return(oauthUser, nil)
 	mockTxManager.EXPECT().WithinTransaction(ctx, gomock.Any()).Return(errors.New("transaction error"))
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockMetrics := mocks.NewMockAuthMetrics(ctrl)
+	mockMetrics.EXPECT().LoginSucceeded(gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().LoginFailed(gomock.Any()).AnyTimes()
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewLoginGoogleHandler(
 		mockTxManager,
 		mockProvider,
@@ -348,6 +394,8 @@ func TestLoginGoogleHandler_Handle_TransactionError(t *testing.T) {
 		mockTokenService,
 		mockSessionEncrypter,
 		mockStateService,
+		mockAuditRecorder,
+		mockMetrics,
 		24*time.Hour,
 		15*time.Minute,
 	)
@@ -409,6 +457,12 @@ func TestLoginGoogleHandler_Handle_GenerateAccessTokenError(t *testing.T) {
 	mockSessionEncrypter.EXPECT().HashRefreshToken(refreshTokenUUID.String()).Return("hashed_refresh")
 	mockTokenService.EXPECT().GenerateAccessToken(gomock.Any()).Return("", session_domain.ErrTokenGenerate)
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockMetrics := mocks.NewMockAuthMetrics(ctrl)
+	mockMetrics.EXPECT().LoginSucceeded(gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().LoginFailed(gomock.Any()).AnyTimes()
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewLoginGoogleHandler(
 		mockTxManager,
 		mockProvider,
@@ -419,6 +473,8 @@ func TestLoginGoogleHandler_Handle_GenerateAccessTokenError(t *testing.T) {
 		mockTokenService,
 		mockSessionEncrypter,
 		mockStateService,
+		mockAuditRecorder,
+		mockMetrics,
 		24*time.Hour,
 		15*time.Minute,
 	)
@@ -480,6 +536,12 @@ func TestLoginGoogleHandler_Handle_NewUser_WithoutProfile(t *testing.T) {
 	mockSessionEncrypter.EXPECT().HashRefreshToken(refreshTokenUUID.String()).Return("hashed_refresh")
 	mockTokenService.EXPECT().GenerateAccessToken(gomock.Any()).Return("access_token_noname", nil)
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockMetrics := mocks.NewMockAuthMetrics(ctrl)
+	mockMetrics.EXPECT().LoginSucceeded(gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().LoginFailed(gomock.Any()).AnyTimes()
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewLoginGoogleHandler(
 		mockTxManager,
 		mockProvider,
@@ -490,6 +552,8 @@ func TestLoginGoogleHandler_Handle_NewUser_WithoutProfile(t *testing.T) {
 		mockTokenService,
 		mockSessionEncrypter,
 		mockStateService,
+		mockAuditRecorder,
+		mockMetrics,
 		24*time.Hour,
 		15*time.Minute,
 	)
@@ -555,6 +619,12 @@ func TestLoginGoogleHandler_Handle_ExistingUserByEmail(t *testing.T) {
 	mockSessionEncrypter.EXPECT().HashRefreshToken(refreshTokenUUID.String()).Return("hashed_refresh")
 	mockTokenService.EXPECT().GenerateAccessToken(gomock.Any()).Return("access_token_link", nil)
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockMetrics := mocks.NewMockAuthMetrics(ctrl)
+	mockMetrics.EXPECT().LoginSucceeded(gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().LoginFailed(gomock.Any()).AnyTimes()
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewLoginGoogleHandler(
 		mockTxManager,
 		mockProvider,
@@ -565,6 +635,8 @@ func TestLoginGoogleHandler_Handle_ExistingUserByEmail(t *testing.T) {
 		mockTokenService,
 		mockSessionEncrypter,
 		mockStateService,
+		mockAuditRecorder,
+		mockMetrics,
 		24*time.Hour,
 		15*time.Minute,
 	)
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"time"
 
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
 	bd_domain "github.com/brunoibarbosa/url-shortener/internal/domain/bd"
 	session_domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
 	user_domain "github.com/brunoibarbosa/url-shortener/internal/domain/user"
+	"github.com/google/uuid"
 )
 
 type LoginUserCommand struct {
@@ -20,10 +22,13 @@ type LoginUserCommand struct {
 type LoginUserHandler struct {
 	tx                   bd_domain.TransactionManager
 	providerRepo         user_domain.UserProviderRepository
+	userRepo             user_domain.UserRepository
 	sessionRepo          session_domain.SessionRepository
 	tokenService         session_domain.TokenService
 	passwordEncrypter    user_domain.UserPasswordEncrypter
 	sessionEncrypter     session_domain.SessionEncrypter
+	auditRecorder        audit_domain.AuditRecorder
+	metrics              session_domain.AuthMetrics
 	refreshTokenDuration time.Duration
 	accessTokenDuration  time.Duration
 }
@@ -31,20 +36,26 @@ type LoginUserHandler struct {
 func NewLoginUserHandler(
 	tx bd_domain.TransactionManager,
 	providerRepo user_domain.UserProviderRepository,
+	userRepo user_domain.UserRepository,
 	sessionRepo session_domain.SessionRepository,
 	tokenService session_domain.TokenService,
 	passwordEncrypter user_domain.UserPasswordEncrypter,
 	sessionEncrypter session_domain.SessionEncrypter,
+	auditRecorder audit_domain.AuditRecorder,
+	metrics session_domain.AuthMetrics,
 	refreshTokenDuration time.Duration,
 	accessTokenDuration time.Duration,
 ) *LoginUserHandler {
 	return &LoginUserHandler{
 		tx,
 		providerRepo,
+		userRepo,
 		sessionRepo,
 		tokenService,
 		passwordEncrypter,
 		sessionEncrypter,
+		auditRecorder,
+		metrics,
 		refreshTokenDuration,
 		accessTokenDuration,
 	}
@@ -59,6 +70,7 @@ func (h *LoginUserHandler) Handle(ctx context.Context, cmd LoginUserCommand) (st
 	if err != nil {
 		switch {
 		case errors.Is(err, user_domain.ErrNotFound):
+			h.recordFailure(ctx, cmd, nil)
 			return "", "", user_domain.ErrInvalidCredentials
 		default:
 			return "", "", err
@@ -66,10 +78,12 @@ func (h *LoginUserHandler) Handle(ctx context.Context, cmd LoginUserCommand) (st
 	}
 
 	if u == nil {
+		h.recordFailure(ctx, cmd, nil)
 		return "", "", user_domain.ErrInvalidCredentials
 	}
 
 	if !h.passwordEncrypter.CheckPassword(*u.PasswordHash, cmd.Password) {
+		h.recordFailure(ctx, cmd, &u.UserID)
 		return "", "", user_domain.ErrInvalidCredentials
 	}
 
@@ -90,7 +104,18 @@ func (h *LoginUserHandler) Handle(ctx context.Context, cmd LoginUserCommand) (st
 			IPAddress:        cmd.IPAddress,
 			ExpiresAt:        &expiresAt,
 		}
-		return h.sessionRepo.Create(txCtx, sess)
+		if err := h.sessionRepo.Create(txCtx, sess); err != nil {
+			return err
+		}
+
+		return h.auditRecorder.Record(txCtx, &audit_domain.Event{
+			ActorID:    &u.UserID,
+			Action:     audit_domain.ActionLogin,
+			TargetType: audit_domain.TargetSession,
+			TargetID:   sess.ID.String(),
+			IPAddress:  cmd.IPAddress,
+			UserAgent:  cmd.UserAgent,
+		})
 	})
 	if err != nil {
 		return "", "", err
@@ -100,10 +125,24 @@ func (h *LoginUserHandler) Handle(ctx context.Context, cmd LoginUserCommand) (st
 		UserID:    u.UserID,
 		SessionID: sess.ID,
 		Duration:  h.accessTokenDuration,
+		Locale:    profileLocale(ctx, h.userRepo, u.UserID),
 	})
 	if err != nil {
 		return "", "", err
 	}
 
+	h.metrics.LoginSucceeded(user_domain.ProviderPassword)
 	return accessToken, refreshToken, nil
 }
+
+func (h *LoginUserHandler) recordFailure(ctx context.Context, cmd LoginUserCommand, actorID *uuid.UUID) {
+	h.metrics.LoginFailed(user_domain.ProviderPassword)
+	_ = h.auditRecorder.Record(ctx, &audit_domain.Event{
+		ActorID:    actorID,
+		Action:     audit_domain.ActionLoginFailed,
+		TargetType: audit_domain.TargetUser,
+		TargetID:   cmd.Email,
+		IPAddress:  cmd.IPAddress,
+		UserAgent:  cmd.UserAgent,
+	})
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/brunoibarbosa/url-shortener/internal/app/auth/command"
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+	session_domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
 	user_domain "github.com/brunoibarbosa/url-shortener/internal/domain/user"
 	"github.com/brunoibarbosa/url-shortener/internal/mocks"
 	"github.com/google/uuid"
@@ -27,6 +29,7 @@ func TestLoginUserHandler_Handle_Success(t *testing.T) {
 
 	mockTx := mocks.NewMockTransactionManager(ctrl)
 	mockProviderRepo := mocks.NewMockUserProviderRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
 	mockTokenService := mocks.NewMockTokenService(ctrl)
 	mockPasswordEncrypter := mocks.NewMockUserPasswordEncrypter(ctrl)
@@ -51,15 +54,28 @@ func TestLoginUserHandler_Handle_Success(t *testing.T) {
 	mockTokenService.EXPECT().GenerateRefreshToken().Return(uuid.New())
 	mockSessionEncrypter.EXPECT().HashRefreshToken(gomock.Any()).Return("hashed_refresh_token")
 	mockSessionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
-	mockTokenService.EXPECT().GenerateAccessToken(gomock.Any()).Return(accessToken, nil)
+	locale := "pt"
+	mockUserRepo.EXPECT().GetByID(ctx, userID).Return(&user_domain.User{ID: userID, Profile: &user_domain.UserProfile{Locale: &locale}}, nil)
+	mockTokenService.EXPECT().GenerateAccessToken(gomock.Any()).DoAndReturn(func(params *session_domain.TokenParams) (string, error) {
+		assert.Equal(t, "pt", params.Locale)
+		return accessToken, nil
+	})
+
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockMetrics := mocks.NewMockAuthMetrics(ctrl)
+	mockMetrics.EXPECT().LoginSucceeded(user_domain.ProviderPassword).Times(1)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	handler := command.NewLoginUserHandler(
 		mockTx,
 		mockProviderRepo,
+		mockUserRepo,
 		mockSessionRepo,
 		mockTokenService,
 		mockPasswordEncrypter,
 		mockSessionEncrypter,
+		mockAuditRecorder,
+		mockMetrics,
 		24*time.Hour,
 		15*time.Minute,
 	)
@@ -86,18 +102,28 @@ func TestLoginUserHandler_Handle_EmptyCredentials(t *testing.T) {
 
 	mockTx := mocks.NewMockTransactionManager(ctrl)
 	mockProviderRepo := mocks.NewMockUserProviderRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
 	mockTokenService := mocks.NewMockTokenService(ctrl)
 	mockPasswordEncrypter := mocks.NewMockUserPasswordEncrypter(ctrl)
 	mockSessionEncrypter := mocks.NewMockSessionEncrypter(ctrl)
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockMetrics := mocks.NewMockAuthMetrics(ctrl)
+	mockMetrics.EXPECT().LoginSucceeded(gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().LoginFailed(gomock.Any()).AnyTimes()
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewLoginUserHandler(
 		mockTx,
 		mockProviderRepo,
+		mockUserRepo,
 		mockSessionRepo,
 		mockTokenService,
 		mockPasswordEncrypter,
 		mockSessionEncrypter,
+		mockAuditRecorder,
+		mockMetrics,
 		24*time.Hour,
 		15*time.Minute,
 	)
@@ -127,6 +153,7 @@ func TestLoginUserHandler_Handle_UserNotFound(t *testing.T) {
 
 	mockTx := mocks.NewMockTransactionManager(ctrl)
 	mockProviderRepo := mocks.NewMockUserProviderRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
 	mockTokenService := mocks.NewMockTokenService(ctrl)
 	mockPasswordEncrypter := mocks.NewMockUserPasswordEncrypter(ctrl)
@@ -134,13 +161,21 @@ func TestLoginUserHandler_Handle_UserNotFound(t *testing.T) {
 
 	mockProviderRepo.EXPECT().Find(ctx, user_domain.ProviderPassword, email).Return(nil, user_domain.ErrNotFound)
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockMetrics := mocks.NewMockAuthMetrics(ctrl)
+	mockMetrics.EXPECT().LoginFailed(user_domain.ProviderPassword).Times(1)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewLoginUserHandler(
 		mockTx,
 		mockProviderRepo,
+		mockUserRepo,
 		mockSessionRepo,
 		mockTokenService,
 		mockPasswordEncrypter,
 		mockSessionEncrypter,
+		mockAuditRecorder,
+		mockMetrics,
 		24*time.Hour,
 		15*time.Minute,
 	)
@@ -172,6 +207,7 @@ func TestLoginUserHandler_Handle_InvalidPassword(t *testing.T) {
 
 	mockTx := mocks.NewMockTransactionManager(ctrl)
 	mockProviderRepo := mocks.NewMockUserProviderRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
 	mockTokenService := mocks.NewMockTokenService(ctrl)
 	mockPasswordEncrypter := mocks.NewMockUserPasswordEncrypter(ctrl)
@@ -187,13 +223,21 @@ func TestLoginUserHandler_Handle_InvalidPassword(t *testing.T) {
 	mockProviderRepo.EXPECT().Find(ctx, user_domain.ProviderPassword, email).Return(provider, nil)
 	mockPasswordEncrypter.EXPECT().CheckPassword(passwordHash, password).Return(false)
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockMetrics := mocks.NewMockAuthMetrics(ctrl)
+	mockMetrics.EXPECT().LoginFailed(user_domain.ProviderPassword).Times(1)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewLoginUserHandler(
 		mockTx,
 		mockProviderRepo,
+		mockUserRepo,
 		mockSessionRepo,
 		mockTokenService,
 		mockPasswordEncrypter,
 		mockSessionEncrypter,
+		mockAuditRecorder,
+		mockMetrics,
 		24*time.Hour,
 		15*time.Minute,
 	)
@@ -225,6 +269,7 @@ passwordHash := "hashed_password"
 
 mockTx := mocks.NewMockTransactionManager(ctrl)
 mockProviderRepo := mocks.NewMockUserProviderRepository(ctrl)
+mockUserRepo := mocks.NewMockUserRepository(ctrl)
 mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
 mockTokenService := mocks.NewMockTokenService(ctrl)
 mockPasswordEncrypter := mocks.NewMockUserPasswordEncrypter(ctrl)
@@ -249,15 +294,25 @@ return fn(ctx)
 mockTokenService.EXPECT().GenerateRefreshToken().Return(uuid.New())
 mockSessionEncrypter.EXPECT().HashRefreshToken(gomock.Any()).Return("hashed_refresh_token")
 mockSessionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+mockUserRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(nil, user_domain.ErrNotFound)
 mockTokenService.EXPECT().GenerateAccessToken(gomock.Any()).Return("", errors.New("token generation failed"))
 
+mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+mockMetrics := mocks.NewMockAuthMetrics(ctrl)
+mockMetrics.EXPECT().LoginSucceeded(gomock.Any()).AnyTimes()
+mockMetrics.EXPECT().LoginFailed(gomock.Any()).AnyTimes()
+mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 handler := command.NewLoginUserHandler(
 mockTx,
 mockProviderRepo,
+mockUserRepo,
 mockSessionRepo,
 mockTokenService,
 mockPasswordEncrypter,
 mockSessionEncrypter,
+mockAuditRecorder,
+mockMetrics,
 24*time.Hour,
 15*time.Minute,
 )
@@ -276,3 +331,143 @@ assert.Equal(t, "token generation failed", err.Error())
 assert.Empty(t, access)
 assert.Empty(t, refresh)
 }
+
+func TestLoginUserHandler_Handle_RecordsFailedLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := uuid.New()
+	email := "user@example.com"
+	passwordHash := "hashed_password"
+
+	mockTx := mocks.NewMockTransactionManager(ctrl)
+	mockProviderRepo := mocks.NewMockUserProviderRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockTokenService := mocks.NewMockTokenService(ctrl)
+	mockPasswordEncrypter := mocks.NewMockUserPasswordEncrypter(ctrl)
+	mockSessionEncrypter := mocks.NewMockSessionEncrypter(ctrl)
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockMetrics := mocks.NewMockAuthMetrics(ctrl)
+	mockMetrics.EXPECT().LoginSucceeded(gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().LoginFailed(gomock.Any()).AnyTimes()
+
+	provider := &user_domain.UserProvider{
+		UserID:       userID,
+		Provider:     user_domain.ProviderPassword,
+		ProviderID:   email,
+		PasswordHash: &passwordHash,
+	}
+
+	mockProviderRepo.EXPECT().Find(ctx, user_domain.ProviderPassword, email).Return(provider, nil)
+	mockPasswordEncrypter.EXPECT().CheckPassword(passwordHash, "wrong").Return(false)
+	mockAuditRecorder.EXPECT().Record(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, e *audit_domain.Event) error {
+			assert.Equal(t, audit_domain.ActionLoginFailed, e.Action)
+			assert.Equal(t, &userID, e.ActorID)
+			assert.Equal(t, email, e.TargetID)
+			assert.Equal(t, "127.0.0.1", e.IPAddress)
+			assert.Equal(t, "Mozilla/5.0", e.UserAgent)
+			return nil
+		},
+	)
+
+	handler := command.NewLoginUserHandler(
+		mockTx,
+		mockProviderRepo,
+		mockUserRepo,
+		mockSessionRepo,
+		mockTokenService,
+		mockPasswordEncrypter,
+		mockSessionEncrypter,
+		mockAuditRecorder,
+		mockMetrics,
+		24*time.Hour,
+		15*time.Minute,
+	)
+
+	cmd := command.LoginUserCommand{
+		Email:     email,
+		Password:  "wrong",
+		UserAgent: "Mozilla/5.0",
+		IPAddress: "127.0.0.1",
+	}
+
+	_, _, err := handler.Handle(ctx, cmd)
+
+	assert.ErrorIs(t, err, user_domain.ErrInvalidCredentials)
+}
+
+func TestLoginUserHandler_Handle_AuditRecordErrorRollsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := uuid.New()
+	email := "user@example.com"
+	password := "password123"
+	passwordHash := "hashed_password"
+	expectedError := errors.New("audit error")
+
+	mockTx := mocks.NewMockTransactionManager(ctrl)
+	mockProviderRepo := mocks.NewMockUserProviderRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockTokenService := mocks.NewMockTokenService(ctrl)
+	mockPasswordEncrypter := mocks.NewMockUserPasswordEncrypter(ctrl)
+	mockSessionEncrypter := mocks.NewMockSessionEncrypter(ctrl)
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockMetrics := mocks.NewMockAuthMetrics(ctrl)
+	mockMetrics.EXPECT().LoginSucceeded(gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().LoginFailed(gomock.Any()).AnyTimes()
+
+	provider := &user_domain.UserProvider{
+		UserID:       userID,
+		Provider:     user_domain.ProviderPassword,
+		ProviderID:   email,
+		PasswordHash: &passwordHash,
+	}
+
+	mockProviderRepo.EXPECT().Find(ctx, user_domain.ProviderPassword, email).Return(provider, nil)
+	mockPasswordEncrypter.EXPECT().CheckPassword(passwordHash, password).Return(true)
+	mockTx.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+	mockTokenService.EXPECT().GenerateRefreshToken().Return(uuid.New())
+	mockSessionEncrypter.EXPECT().HashRefreshToken(gomock.Any()).Return("hashed_refresh_token")
+	mockSessionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, e *audit_domain.Event) error {
+			assert.Equal(t, audit_domain.ActionLogin, e.Action)
+			return expectedError
+		},
+	)
+
+	handler := command.NewLoginUserHandler(
+		mockTx,
+		mockProviderRepo,
+		mockUserRepo,
+		mockSessionRepo,
+		mockTokenService,
+		mockPasswordEncrypter,
+		mockSessionEncrypter,
+		mockAuditRecorder,
+		mockMetrics,
+		24*time.Hour,
+		15*time.Minute,
+	)
+
+	cmd := command.LoginUserCommand{
+		Email:    email,
+		Password: password,
+	}
+
+	access, refresh, err := handler.Handle(ctx, cmd)
+
+	assert.Equal(t, expectedError, err)
+	assert.Empty(t, access)
+	assert.Empty(t, refresh)
+}
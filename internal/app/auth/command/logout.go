@@ -3,30 +3,40 @@ package command
 import (
 	"context"
 	"errors"
-	"time"
 
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+	bd_domain "github.com/brunoibarbosa/url-shortener/internal/domain/bd"
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
 	session_domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
 )
 
 type LogoutCommand struct {
 	RefreshToken string
+	UserAgent    string
+	IPAddress    string
 }
 
 type LogoutHandler struct {
+	tx               bd_domain.TransactionManager
 	sessionRepo      session_domain.SessionRepository
-	blacklistRepo    session_domain.BlacklistRepository
+	outboxRepo       outbox_domain.OutboxRepository
 	sessionEncrypter session_domain.SessionEncrypter
+	auditRecorder    audit_domain.AuditRecorder
 }
 
 func NewLogoutHandler(
+	tx bd_domain.TransactionManager,
 	sessionRepo session_domain.SessionRepository,
-	blacklistRepo session_domain.BlacklistRepository,
+	outboxRepo outbox_domain.OutboxRepository,
 	sessionEncrypter session_domain.SessionEncrypter,
+	auditRecorder audit_domain.AuditRecorder,
 ) *LogoutHandler {
 	return &LogoutHandler{
+		tx,
 		sessionRepo,
-		blacklistRepo,
+		outboxRepo,
 		sessionEncrypter,
+		auditRecorder,
 	}
 }
 
@@ -51,28 +61,32 @@ func (h *LogoutHandler) Handle(ctx context.Context, cmd LogoutCommand) error {
 		return session_domain.ErrInvalidRefreshToken
 	}
 
-	if err := h.sessionRepo.Revoke(ctx, s.ID); err != nil {
-		return session_domain.ErrRevokeFailed
+	revoked, err := outbox_domain.NewMessage(session_domain.TopicSessionRevoked, session_domain.SessionRevokedEvent{
+		RefreshTokenHash: hashed,
+		ExpiresAt:        *s.ExpiresAt,
+	})
+	if err != nil {
+		return err
 	}
 
-	remainder := time.Until(*s.ExpiresAt)
-
-	const maxRetries = 3
-	var blErr error
-
-	if err := h.blacklistRepo.Revoke(ctx, hashed, remainder); err != nil {
-		for i := 0; i < maxRetries; i++ {
-			blErr = h.blacklistRepo.Revoke(ctx, hashed, remainder)
-			if blErr == nil {
-				break
-			}
-			time.Sleep(50 * time.Millisecond)
-		}
-
-		if blErr != nil {
-			return session_domain.ErrRevokeFailed
+	err = h.tx.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := h.sessionRepo.Revoke(txCtx, s.ID); err != nil {
+			return err
 		}
+		return h.outboxRepo.Append(txCtx, revoked)
+	})
+	if err != nil {
+		return session_domain.ErrRevokeFailed
 	}
 
+	_ = h.auditRecorder.Record(ctx, &audit_domain.Event{
+		ActorID:    &s.UserID,
+		Action:     audit_domain.ActionLogout,
+		TargetType: audit_domain.TargetSession,
+		TargetID:   s.ID.String(),
+		IPAddress:  cmd.IPAddress,
+		UserAgent:  cmd.UserAgent,
+	})
+
 	return nil
 }
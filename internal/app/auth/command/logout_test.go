@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/brunoibarbosa/url-shortener/internal/app/auth/command"
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
 	session_domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
 	"github.com/brunoibarbosa/url-shortener/internal/mocks"
 	"github.com/google/uuid"
@@ -26,7 +27,8 @@ func TestLogoutHandler_Handle_Success(t *testing.T) {
 	expiresAt := time.Now().Add(24 * time.Hour)
 
 	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
-	mockBlacklistRepo := mocks.NewMockBlacklistRepository(ctrl)
+	mockTx := mocks.NewMockTransactionManager(ctrl)
+	mockOutboxRepo := mocks.NewMockOutboxRepository(ctrl)
 	mockSessionEncrypter := mocks.NewMockSessionEncrypter(ctrl)
 
 	session := &session_domain.Session{
@@ -39,13 +41,29 @@ func TestLogoutHandler_Handle_Success(t *testing.T) {
 
 	mockSessionEncrypter.EXPECT().HashRefreshToken(refreshToken).Return(hashedToken)
 	mockSessionRepo.EXPECT().FindByRefreshToken(ctx, hashedToken).Return(session, nil)
+	mockTx.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		},
+	)
 	mockSessionRepo.EXPECT().Revoke(ctx, sessionID).Return(nil)
-	mockBlacklistRepo.EXPECT().Revoke(ctx, hashedToken, gomock.Any()).Return(nil)
+	mockOutboxRepo.EXPECT().Append(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, m *outbox_domain.Message) error {
+		var e session_domain.SessionRevokedEvent
+		assert.Equal(t, session_domain.TopicSessionRevoked, m.Topic)
+		assert.NoError(t, m.Decode(&e))
+		assert.Equal(t, hashedToken, e.RefreshTokenHash)
+		return nil
+	})
+
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	handler := command.NewLogoutHandler(
+		mockTx,
 		mockSessionRepo,
-		mockBlacklistRepo,
+		mockOutboxRepo,
 		mockSessionEncrypter,
+		mockAuditRecorder,
 	)
 
 	cmd := command.LogoutCommand{
@@ -66,16 +84,22 @@ func TestLogoutHandler_Handle_InvalidRefreshToken(t *testing.T) {
 	hashedToken := "hashed_invalid_token"
 
 	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
-	mockBlacklistRepo := mocks.NewMockBlacklistRepository(ctrl)
+	mockTx := mocks.NewMockTransactionManager(ctrl)
+	mockOutboxRepo := mocks.NewMockOutboxRepository(ctrl)
 	mockSessionEncrypter := mocks.NewMockSessionEncrypter(ctrl)
 
 	mockSessionEncrypter.EXPECT().HashRefreshToken(refreshToken).Return(hashedToken)
 	mockSessionRepo.EXPECT().FindByRefreshToken(ctx, hashedToken).Return(nil, session_domain.ErrNotFound)
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewLogoutHandler(
+		mockTx,
 		mockSessionRepo,
-		mockBlacklistRepo,
+		mockOutboxRepo,
 		mockSessionEncrypter,
+		mockAuditRecorder,
 	)
 
 	cmd := command.LogoutCommand{
@@ -100,7 +124,8 @@ func TestLogoutHandler_Handle_ExpiredSession(t *testing.T) {
 	expiresAt := time.Now().Add(-1 * time.Hour) // Expired
 
 	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
-	mockBlacklistRepo := mocks.NewMockBlacklistRepository(ctrl)
+	mockTx := mocks.NewMockTransactionManager(ctrl)
+	mockOutboxRepo := mocks.NewMockOutboxRepository(ctrl)
 	mockSessionEncrypter := mocks.NewMockSessionEncrypter(ctrl)
 
 	session := &session_domain.Session{
@@ -114,10 +139,15 @@ func TestLogoutHandler_Handle_ExpiredSession(t *testing.T) {
 	mockSessionEncrypter.EXPECT().HashRefreshToken(refreshToken).Return(hashedToken)
 	mockSessionRepo.EXPECT().FindByRefreshToken(ctx, hashedToken).Return(session, nil)
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewLogoutHandler(
+		mockTx,
 		mockSessionRepo,
-		mockBlacklistRepo,
+		mockOutboxRepo,
 		mockSessionEncrypter,
+		mockAuditRecorder,
 	)
 
 	cmd := command.LogoutCommand{
@@ -143,7 +173,8 @@ func TestLogoutHandler_Handle_RevokeSessionError(t *testing.T) {
 	expectedError := errors.New("revoke error")
 
 	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
-	mockBlacklistRepo := mocks.NewMockBlacklistRepository(ctrl)
+	mockTx := mocks.NewMockTransactionManager(ctrl)
+	mockOutboxRepo := mocks.NewMockOutboxRepository(ctrl)
 	mockSessionEncrypter := mocks.NewMockSessionEncrypter(ctrl)
 
 	session := &session_domain.Session{
@@ -156,12 +187,22 @@ func TestLogoutHandler_Handle_RevokeSessionError(t *testing.T) {
 
 	mockSessionEncrypter.EXPECT().HashRefreshToken(refreshToken).Return(hashedToken)
 	mockSessionRepo.EXPECT().FindByRefreshToken(ctx, hashedToken).Return(session, nil)
+	mockTx.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		},
+	)
 	mockSessionRepo.EXPECT().Revoke(ctx, sessionID).Return(expectedError)
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewLogoutHandler(
+		mockTx,
 		mockSessionRepo,
-		mockBlacklistRepo,
+		mockOutboxRepo,
 		mockSessionEncrypter,
+		mockAuditRecorder,
 	)
 
 	cmd := command.LogoutCommand{
@@ -174,7 +215,7 @@ func TestLogoutHandler_Handle_RevokeSessionError(t *testing.T) {
 	assert.Equal(t, session_domain.ErrRevokeFailed, err)
 }
 
-func TestLogoutHandler_Handle_BlacklistRetry(t *testing.T) {
+func TestLogoutHandler_Handle_OutboxError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -184,10 +225,11 @@ func TestLogoutHandler_Handle_BlacklistRetry(t *testing.T) {
 	sessionID := uuid.New()
 	userID := uuid.New()
 	expiresAt := time.Now().Add(24 * time.Hour)
-	blacklistError := errors.New("blacklist error")
+	outboxError := errors.New("outbox error")
 
 	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
-	mockBlacklistRepo := mocks.NewMockBlacklistRepository(ctrl)
+	mockTx := mocks.NewMockTransactionManager(ctrl)
+	mockOutboxRepo := mocks.NewMockOutboxRepository(ctrl)
 	mockSessionEncrypter := mocks.NewMockSessionEncrypter(ctrl)
 
 	session := &session_domain.Session{
@@ -200,16 +242,23 @@ func TestLogoutHandler_Handle_BlacklistRetry(t *testing.T) {
 
 	mockSessionEncrypter.EXPECT().HashRefreshToken(refreshToken).Return(hashedToken)
 	mockSessionRepo.EXPECT().FindByRefreshToken(ctx, hashedToken).Return(session, nil)
+	mockTx.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		},
+	)
 	mockSessionRepo.EXPECT().Revoke(ctx, sessionID).Return(nil)
-	
-	// First call fails, retries also fail
-	mockBlacklistRepo.EXPECT().Revoke(ctx, hashedToken, gomock.Any()).Return(blacklistError)
-	mockBlacklistRepo.EXPECT().Revoke(ctx, hashedToken, gomock.Any()).Return(blacklistError).Times(3)
+	mockOutboxRepo.EXPECT().Append(ctx, gomock.Any()).Return(outboxError)
+
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	handler := command.NewLogoutHandler(
+		mockTx,
 		mockSessionRepo,
-		mockBlacklistRepo,
+		mockOutboxRepo,
 		mockSessionEncrypter,
+		mockAuditRecorder,
 	)
 
 	cmd := command.LogoutCommand{
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"time"
 
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
 	bd_domain "github.com/brunoibarbosa/url-shortener/internal/domain/bd"
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
 	session_domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
+	user_domain "github.com/brunoibarbosa/url-shortener/internal/domain/user"
 )
 
 type RefreshTokenCommand struct {
@@ -18,9 +21,12 @@ type RefreshTokenCommand struct {
 type RefreshTokenHandler struct {
 	tx                   bd_domain.TransactionManager
 	sessionRepo          session_domain.SessionRepository
+	userRepo             user_domain.UserRepository
 	blacklistRepo        session_domain.BlacklistRepository
+	outboxRepo           outbox_domain.OutboxRepository
 	tokenService         session_domain.TokenService
 	sessionEncrypter     session_domain.SessionEncrypter
+	auditRecorder        audit_domain.AuditRecorder
 	refreshTokenDuration time.Duration
 	accessTokenDuration  time.Duration
 }
@@ -33,18 +39,24 @@ type RefreshTokenResponse struct {
 func NewRefreshTokenHandler(
 	tx bd_domain.TransactionManager,
 	sessionRepo session_domain.SessionRepository,
+	userRepo user_domain.UserRepository,
 	blacklistRepo session_domain.BlacklistRepository,
+	outboxRepo outbox_domain.OutboxRepository,
 	tokenService session_domain.TokenService,
 	sessionEncrypter session_domain.SessionEncrypter,
+	auditRecorder audit_domain.AuditRecorder,
 	refreshTokenDuration time.Duration,
 	accessTokenDuration time.Duration,
 ) *RefreshTokenHandler {
 	return &RefreshTokenHandler{
 		tx,
 		sessionRepo,
+		userRepo,
 		blacklistRepo,
+		outboxRepo,
 		tokenService,
 		sessionEncrypter,
+		auditRecorder,
 		refreshTokenDuration,
 		accessTokenDuration,
 	}
@@ -86,8 +98,14 @@ func (h *RefreshTokenHandler) Handle(ctx context.Context, cmd RefreshTokenComman
 			return err
 		}
 
-		remainder := time.Until(*s.ExpiresAt)
-		if err := h.blacklistRepo.Revoke(txCtx, hashed, remainder); err != nil {
+		revoked, err := outbox_domain.NewMessage(session_domain.TopicSessionRevoked, session_domain.SessionRevokedEvent{
+			RefreshTokenHash: hashed,
+			ExpiresAt:        *s.ExpiresAt,
+		})
+		if err != nil {
+			return err
+		}
+		if err := h.outboxRepo.Append(txCtx, revoked); err != nil {
 			return err
 		}
 
@@ -104,7 +122,18 @@ func (h *RefreshTokenHandler) Handle(ctx context.Context, cmd RefreshTokenComman
 			IPAddress:        cmd.IPAddress,
 			ExpiresAt:        &expiresAt,
 		}
-		return h.sessionRepo.Create(txCtx, sess)
+		if err := h.sessionRepo.Create(txCtx, sess); err != nil {
+			return err
+		}
+
+		return h.auditRecorder.Record(txCtx, &audit_domain.Event{
+			ActorID:    &s.UserID,
+			Action:     audit_domain.ActionRefresh,
+			TargetType: audit_domain.TargetSession,
+			TargetID:   sess.ID.String(),
+			IPAddress:  cmd.IPAddress,
+			UserAgent:  cmd.UserAgent,
+		})
 	})
 	if err != nil {
 		return RefreshTokenResponse{}, err
@@ -114,6 +143,7 @@ func (h *RefreshTokenHandler) Handle(ctx context.Context, cmd RefreshTokenComman
 		UserID:    s.UserID,
 		SessionID: sess.ID,
 		Duration:  h.accessTokenDuration,
+		Locale:    profileLocale(ctx, h.userRepo, s.UserID),
 	})
 	if err != nil {
 		return RefreshTokenResponse{}, err
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/brunoibarbosa/url-shortener/internal/app/auth/command"
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
 	session_domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
 	"github.com/brunoibarbosa/url-shortener/internal/mocks"
 	"github.com/google/uuid"
@@ -29,7 +30,9 @@ func TestRefreshTokenHandler_Handle_Success(t *testing.T) {
 
 	mockTx := mocks.NewMockTransactionManager(ctrl)
 	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockBlacklistRepo := mocks.NewMockBlacklistRepository(ctrl)
+	mockOutboxRepo := mocks.NewMockOutboxRepository(ctrl)
 	mockTokenService := mocks.NewMockTokenService(ctrl)
 	mockSessionEncrypter := mocks.NewMockSessionEncrypter(ctrl)
 
@@ -52,18 +55,36 @@ func TestRefreshTokenHandler_Handle_Success(t *testing.T) {
 	)
 
 	mockSessionRepo.EXPECT().Revoke(gomock.Any(), sessionID).Return(nil)
-	mockBlacklistRepo.EXPECT().Revoke(gomock.Any(), hashedOldToken, gomock.Any()).Return(nil)
+	mockOutboxRepo.EXPECT().Append(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *outbox_domain.Message) error {
+		var e session_domain.SessionRevokedEvent
+		assert.Equal(t, session_domain.TopicSessionRevoked, m.Topic)
+		assert.NoError(t, m.Decode(&e))
+		assert.Equal(t, hashedOldToken, e.RefreshTokenHash)
+		assert.True(t, expiresAt.Equal(e.ExpiresAt))
+		return nil
+	})
 	mockTokenService.EXPECT().GenerateRefreshToken().Return(uuid.New())
 	mockSessionEncrypter.EXPECT().HashRefreshToken(gomock.Any()).Return(hashedNewToken)
 	mockSessionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
-	mockTokenService.EXPECT().GenerateAccessToken(gomock.Any()).Return(accessToken, nil)
+	// A failed profile lookup only leaves the locale out of the token.
+	mockUserRepo.EXPECT().GetByID(gomock.Any(), userID).Return(nil, errors.New("db error"))
+	mockTokenService.EXPECT().GenerateAccessToken(gomock.Any()).DoAndReturn(func(params *session_domain.TokenParams) (string, error) {
+		assert.Empty(t, params.Locale)
+		return accessToken, nil
+	})
+
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	handler := command.NewRefreshTokenHandler(
 		mockTx,
 		mockSessionRepo,
+		mockUserRepo,
 		mockBlacklistRepo,
+		mockOutboxRepo,
 		mockTokenService,
 		mockSessionEncrypter,
+		mockAuditRecorder,
 		24*time.Hour,
 		15*time.Minute,
 	)
@@ -89,16 +110,24 @@ func TestRefreshTokenHandler_Handle_EmptyRefreshToken(t *testing.T) {
 
 	mockTx := mocks.NewMockTransactionManager(ctrl)
 	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockBlacklistRepo := mocks.NewMockBlacklistRepository(ctrl)
+	mockOutboxRepo := mocks.NewMockOutboxRepository(ctrl)
 	mockTokenService := mocks.NewMockTokenService(ctrl)
 	mockSessionEncrypter := mocks.NewMockSessionEncrypter(ctrl)
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewRefreshTokenHandler(
 		mockTx,
 		mockSessionRepo,
+		mockUserRepo,
 		mockBlacklistRepo,
+		mockOutboxRepo,
 		mockTokenService,
 		mockSessionEncrypter,
+		mockAuditRecorder,
 		24*time.Hour,
 		15*time.Minute,
 	)
@@ -127,19 +156,27 @@ func TestRefreshTokenHandler_Handle_SessionNotFound(t *testing.T) {
 
 	mockTx := mocks.NewMockTransactionManager(ctrl)
 	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockBlacklistRepo := mocks.NewMockBlacklistRepository(ctrl)
+	mockOutboxRepo := mocks.NewMockOutboxRepository(ctrl)
 	mockTokenService := mocks.NewMockTokenService(ctrl)
 	mockSessionEncrypter := mocks.NewMockSessionEncrypter(ctrl)
 
 	mockSessionEncrypter.EXPECT().HashRefreshToken(refreshToken).Return(hashedToken)
 	mockSessionRepo.EXPECT().FindByRefreshToken(ctx, hashedToken).Return(nil, session_domain.ErrNotFound)
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewRefreshTokenHandler(
 		mockTx,
 		mockSessionRepo,
+		mockUserRepo,
 		mockBlacklistRepo,
+		mockOutboxRepo,
 		mockTokenService,
 		mockSessionEncrypter,
+		mockAuditRecorder,
 		24*time.Hour,
 		15*time.Minute,
 	)
@@ -171,7 +208,9 @@ func TestRefreshTokenHandler_Handle_ExpiredSession(t *testing.T) {
 
 	mockTx := mocks.NewMockTransactionManager(ctrl)
 	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockBlacklistRepo := mocks.NewMockBlacklistRepository(ctrl)
+	mockOutboxRepo := mocks.NewMockOutboxRepository(ctrl)
 	mockTokenService := mocks.NewMockTokenService(ctrl)
 	mockSessionEncrypter := mocks.NewMockSessionEncrypter(ctrl)
 
@@ -186,12 +225,18 @@ func TestRefreshTokenHandler_Handle_ExpiredSession(t *testing.T) {
 	mockSessionEncrypter.EXPECT().HashRefreshToken(refreshToken).Return(hashedToken)
 	mockSessionRepo.EXPECT().FindByRefreshToken(ctx, hashedToken).Return(session, nil)
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewRefreshTokenHandler(
 		mockTx,
 		mockSessionRepo,
+		mockUserRepo,
 		mockBlacklistRepo,
+		mockOutboxRepo,
 		mockTokenService,
 		mockSessionEncrypter,
+		mockAuditRecorder,
 		24*time.Hour,
 		15*time.Minute,
 	)
@@ -223,7 +268,9 @@ func TestRefreshTokenHandler_Handle_RevokedToken(t *testing.T) {
 
 	mockTx := mocks.NewMockTransactionManager(ctrl)
 	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockBlacklistRepo := mocks.NewMockBlacklistRepository(ctrl)
+	mockOutboxRepo := mocks.NewMockOutboxRepository(ctrl)
 	mockTokenService := mocks.NewMockTokenService(ctrl)
 	mockSessionEncrypter := mocks.NewMockSessionEncrypter(ctrl)
 
@@ -239,12 +286,18 @@ func TestRefreshTokenHandler_Handle_RevokedToken(t *testing.T) {
 	mockSessionRepo.EXPECT().FindByRefreshToken(ctx, hashedToken).Return(session, nil)
 	mockBlacklistRepo.EXPECT().IsRevoked(ctx, hashedToken).Return(true, nil)
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewRefreshTokenHandler(
 		mockTx,
 		mockSessionRepo,
+		mockUserRepo,
 		mockBlacklistRepo,
+		mockOutboxRepo,
 		mockTokenService,
 		mockSessionEncrypter,
+		mockAuditRecorder,
 		24*time.Hour,
 		15*time.Minute,
 	)
@@ -277,7 +330,9 @@ func TestRefreshTokenHandler_Handle_TransactionError(t *testing.T) {
 
 	mockTx := mocks.NewMockTransactionManager(ctrl)
 	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockBlacklistRepo := mocks.NewMockBlacklistRepository(ctrl)
+	mockOutboxRepo := mocks.NewMockOutboxRepository(ctrl)
 	mockTokenService := mocks.NewMockTokenService(ctrl)
 	mockSessionEncrypter := mocks.NewMockSessionEncrypter(ctrl)
 
@@ -294,12 +349,18 @@ func TestRefreshTokenHandler_Handle_TransactionError(t *testing.T) {
 	mockBlacklistRepo.EXPECT().IsRevoked(ctx, hashedToken).Return(false, nil)
 	mockTx.EXPECT().WithinTransaction(ctx, gomock.Any()).Return(expectedError)
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	handler := command.NewRefreshTokenHandler(
 		mockTx,
 		mockSessionRepo,
+		mockUserRepo,
 		mockBlacklistRepo,
+		mockOutboxRepo,
 		mockTokenService,
 		mockSessionEncrypter,
+		mockAuditRecorder,
 		24*time.Hour,
 		15*time.Minute,
 	)
@@ -13,6 +13,7 @@ type RegisterUserCommand struct {
 	Email    string
 	Password string
 	Name     string
+	Locale   *string
 }
 
 type RegisterUserResponse struct {
@@ -84,7 +85,8 @@ func (h *RegisterUserHandler) Handle(ctx context.Context, cmd RegisterUserComman
 		}
 
 		pf = &user_domain.UserProfile{
-			Name: cmd.Name,
+			Name:   cmd.Name,
+			Locale: cmd.Locale,
 		}
 		if err := h.profileRepo.Create(txCtx, u.ID, pf); err != nil {
 			return err
@@ -103,6 +105,7 @@ func (h *RegisterUserHandler) Handle(ctx context.Context, cmd RegisterUserComman
 		Profile: user_domain.UserProfile{
 			Name:      pf.Name,
 			AvatarURL: pf.AvatarURL,
+			Locale:    pf.Locale,
 		},
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
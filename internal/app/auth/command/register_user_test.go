@@ -72,6 +72,54 @@ func TestRegisterUserHandler_Handle_Success(t *testing.T) {
 	assert.NotEqual(t, uuid.Nil, result.ID)
 }
 
+func TestRegisterUserHandler_Handle_StoresLocale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	locale := "pt"
+
+	mockTx := mocks.NewMockTransactionManager(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockProviderRepo := mocks.NewMockUserProviderRepository(ctrl)
+	mockProfileRepo := mocks.NewMockUserProfileRepository(ctrl)
+	mockPasswordEncrypter := mocks.NewMockUserPasswordEncrypter(ctrl)
+
+	mockPasswordEncrypter.EXPECT().HashPassword(gomock.Any()).Return("hashed_password", nil)
+	mockUserRepo.EXPECT().Exists(ctx, gomock.Any()).Return(false, nil)
+	mockTx.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+	mockUserRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	mockProviderRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockProfileRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, userID uuid.UUID, p *user_domain.UserProfile) error {
+			assert.Equal(t, &locale, p.Locale)
+			return nil
+		},
+	)
+
+	handler := command.NewRegisterUserHandler(
+		mockTx,
+		mockUserRepo,
+		mockProviderRepo,
+		mockProfileRepo,
+		mockPasswordEncrypter,
+	)
+
+	result, err := handler.Handle(ctx, command.RegisterUserCommand{
+		Email:    "locale@example.com",
+		Password: "password123",
+		Name:     "Maria",
+		Locale:   &locale,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &locale, result.Profile.Locale)
+}
+
 func TestRegisterUserHandler_Handle_EmailAlreadyExists(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,42 @@
+package command
+
+import (
+	"context"
+
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+	session_domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
+	"github.com/google/uuid"
+)
+
+type RevokeUserSessionsCommand struct {
+	UserID uuid.UUID
+}
+
+type RevokeUserSessionsHandler struct {
+	sessionRepo   session_domain.SessionRepository
+	auditRecorder audit_domain.AuditRecorder
+}
+
+func NewRevokeUserSessionsHandler(sessionRepo session_domain.SessionRepository, auditRecorder audit_domain.AuditRecorder) *RevokeUserSessionsHandler {
+	return &RevokeUserSessionsHandler{
+		sessionRepo,
+		auditRecorder,
+	}
+}
+
+// Handle revokes every active session of the user, so their refresh tokens
+// stop working. Access tokens already issued remain valid until they expire.
+func (h *RevokeUserSessionsHandler) Handle(ctx context.Context, cmd RevokeUserSessionsCommand) (int64, error) {
+	revoked, err := h.sessionRepo.RevokeAllByUserID(ctx, cmd.UserID)
+	if err != nil {
+		return 0, err
+	}
+
+	_ = h.auditRecorder.Record(ctx, &audit_domain.Event{
+		Action:     audit_domain.ActionSessionsRevoked,
+		TargetType: audit_domain.TargetUser,
+		TargetID:   cmd.UserID.String(),
+	})
+
+	return revoked, nil
+}
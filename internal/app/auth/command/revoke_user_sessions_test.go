@@ -0,0 +1,59 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/auth/command"
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRevokeUserSessionsHandler_Handle_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+
+	mockSessionRepo.EXPECT().RevokeAllByUserID(ctx, userID).Return(int64(3), nil)
+	mockAuditRecorder.EXPECT().Record(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, e *audit_domain.Event) error {
+		assert.Equal(t, audit_domain.ActionSessionsRevoked, e.Action)
+		assert.Equal(t, userID.String(), e.TargetID)
+		return nil
+	})
+
+	handler := command.NewRevokeUserSessionsHandler(mockSessionRepo, mockAuditRecorder)
+
+	revoked, err := handler.Handle(ctx, command.RevokeUserSessionsCommand{UserID: userID})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), revoked)
+}
+
+func TestRevokeUserSessionsHandler_Handle_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := uuid.New()
+	expectedError := errors.New("database error")
+
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+
+	mockSessionRepo.EXPECT().RevokeAllByUserID(ctx, userID).Return(int64(0), expectedError)
+
+	handler := command.NewRevokeUserSessionsHandler(mockSessionRepo, mockAuditRecorder)
+
+	_, err := handler.Handle(ctx, command.RevokeUserSessionsCommand{UserID: userID})
+
+	assert.Equal(t, expectedError, err)
+}
@@ -0,0 +1,93 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
+	"github.com/brunoibarbosa/url-shortener/pkg/util"
+)
+
+type RelayOutboxCommand struct {
+	BatchSize int
+	// Lease hides the claimed messages from other instances while they are
+	// relayed, so it has to outlast relaying the whole batch.
+	Lease time.Duration
+	// MaxAttempts failures move a message to the dead state.
+	MaxAttempts    int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+}
+
+// RelayOutboxHandler hands the outbox messages to the subscribers of their
+// topic. A message is only removed once every subscriber handled it; until
+// then it is retried, and subscribers that already succeeded see it again,
+// until it fails MaxAttempts times and is left in the dead state.
+type RelayOutboxHandler struct {
+	repo          domain.OutboxRepository
+	subscriptions domain.Subscriptions
+	metrics       domain.OutboxMetrics
+}
+
+func NewRelayOutboxHandler(repo domain.OutboxRepository, subscriptions domain.Subscriptions, metrics domain.OutboxMetrics) *RelayOutboxHandler {
+	return &RelayOutboxHandler{
+		repo:          repo,
+		subscriptions: subscriptions,
+		metrics:       metrics,
+	}
+}
+
+// Handle relays one batch, in the order the messages occurred, and returns
+// how many messages it claimed.
+func (h *RelayOutboxHandler) Handle(ctx context.Context, cmd RelayOutboxCommand) (int, error) {
+	messages, err := h.repo.ClaimDue(ctx, time.Now(), cmd.Lease, cmd.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for _, m := range messages {
+		if ctx.Err() != nil {
+			// The rest is relayed once its lease runs out.
+			break
+		}
+		if err := h.relay(ctx, cmd, m); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return len(messages), errors.Join(errs...)
+}
+
+func (h *RelayOutboxHandler) relay(ctx context.Context, cmd RelayOutboxCommand, m *domain.Message) error {
+	var handleErr error
+	for _, s := range h.subscriptions[m.Topic] {
+		if err := s.Handle(ctx, m); err != nil {
+			handleErr = errors.Join(handleErr, err)
+		}
+	}
+	if handleErr != nil && ctx.Err() != nil {
+		return nil
+	}
+
+	recordCtx := context.WithoutCancel(ctx)
+
+	if handleErr == nil {
+		h.metrics.MessageRelayed(m.Topic)
+		return h.repo.Delete(recordCtx, m.ID)
+	}
+
+	var next *time.Time
+	attempt := m.Attempts + 1
+	if attempt < cmd.MaxAttempts {
+		at := time.Now().Add(util.ExponentialBackoff(attempt, cmd.RetryBaseDelay, cmd.RetryMaxDelay))
+		next = &at
+	} else {
+		slog.ErrorContext(ctx, "Outbox message moved to the dead state", "id", m.ID, "topic", m.Topic, "attempts", attempt, "error", handleErr)
+	}
+
+	h.metrics.MessageFailed(m.Topic, next == nil)
+	return h.repo.MarkFailed(recordCtx, m.ID, next, handleErr.Error())
+}
@@ -0,0 +1,158 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/outbox/command"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var relayCommand = command.RelayOutboxCommand{
+	BatchSize:      10,
+	Lease:          time.Minute,
+	MaxAttempts:    5,
+	RetryBaseDelay: time.Second,
+	RetryMaxDelay:  time.Hour,
+}
+
+func TestRelayOutboxHandler_Handle_Outcomes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockOutboxRepository(ctrl)
+	mockMetrics := mocks.NewMockOutboxMetrics(ctrl)
+	cache := mocks.NewMockSubscriber(ctrl)
+	webhooks := mocks.NewMockSubscriber(ctrl)
+
+	deleted := &domain.Message{ID: uuid.New(), Topic: "url.deleted"}
+	failing := &domain.Message{ID: uuid.New(), Topic: "url.deleted", Attempts: 2}
+	unsubscribed := &domain.Message{ID: uuid.New(), Topic: "user.created"}
+
+	mockRepo.EXPECT().ClaimDue(ctx, gomock.Any(), time.Minute, 10).Return([]*domain.Message{deleted, failing, unsubscribed}, nil)
+
+	gomock.InOrder(
+		cache.EXPECT().Handle(ctx, deleted).Return(nil),
+		webhooks.EXPECT().Handle(ctx, deleted).Return(nil),
+		cache.EXPECT().Handle(ctx, failing).Return(errors.New("redis unavailable")),
+		webhooks.EXPECT().Handle(ctx, failing).Return(nil),
+	)
+
+	mockRepo.EXPECT().Delete(gomock.Any(), deleted.ID).Return(nil)
+	mockRepo.EXPECT().Delete(gomock.Any(), unsubscribed.ID).Return(nil)
+	mockRepo.EXPECT().MarkFailed(gomock.Any(), failing.ID, gomock.Any(), "redis unavailable").DoAndReturn(func(_ context.Context, _ uuid.UUID, next *time.Time, _ string) error {
+		// Third failed attempt: four times the base delay
+		if assert.NotNil(t, next) {
+			assert.WithinDuration(t, time.Now().Add(4*time.Second), *next, time.Second)
+		}
+		return nil
+	})
+
+	mockMetrics.EXPECT().MessageRelayed("url.deleted")
+	mockMetrics.EXPECT().MessageRelayed("user.created")
+	mockMetrics.EXPECT().MessageFailed("url.deleted", false)
+
+	subs := domain.Subscriptions{}
+	subs.Subscribe("url.deleted", cache)
+	subs.Subscribe("url.deleted", webhooks)
+	handler := command.NewRelayOutboxHandler(mockRepo, subs, mockMetrics)
+
+	claimed, err := handler.Handle(ctx, relayCommand)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, claimed)
+}
+
+func TestRelayOutboxHandler_Handle_DeadAfterMaxAttempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockOutboxRepository(ctrl)
+	mockMetrics := mocks.NewMockOutboxMetrics(ctrl)
+	subscriber := mocks.NewMockSubscriber(ctrl)
+
+	m := &domain.Message{ID: uuid.New(), Topic: "url.deleted", Attempts: 4}
+	mockRepo.EXPECT().ClaimDue(ctx, gomock.Any(), time.Minute, 10).Return([]*domain.Message{m}, nil)
+	subscriber.EXPECT().Handle(ctx, m).Return(errors.New("redis unavailable"))
+	mockRepo.EXPECT().MarkFailed(gomock.Any(), m.ID, nil, "redis unavailable").Return(nil)
+	mockMetrics.EXPECT().MessageFailed("url.deleted", true)
+
+	subs := domain.Subscriptions{}
+	subs.Subscribe("url.deleted", subscriber)
+	handler := command.NewRelayOutboxHandler(mockRepo, subs, mockMetrics)
+
+	_, err := handler.Handle(ctx, relayCommand)
+
+	assert.NoError(t, err)
+}
+
+func TestRelayOutboxHandler_Handle_ClaimError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockOutboxRepository(ctrl)
+
+	mockRepo.EXPECT().ClaimDue(ctx, gomock.Any(), time.Minute, 10).Return(nil, errors.New("db error"))
+
+	handler := command.NewRelayOutboxHandler(mockRepo, domain.Subscriptions{}, mocks.NewMockOutboxMetrics(ctrl))
+
+	claimed, err := handler.Handle(ctx, relayCommand)
+
+	assert.EqualError(t, err, "db error")
+	assert.Zero(t, claimed)
+}
+
+func TestRelayOutboxHandler_Handle_RecordError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockOutboxRepository(ctrl)
+	mockMetrics := mocks.NewMockOutboxMetrics(ctrl)
+
+	m := &domain.Message{ID: uuid.New(), Topic: "url.deleted"}
+	mockRepo.EXPECT().ClaimDue(ctx, gomock.Any(), time.Minute, 10).Return([]*domain.Message{m}, nil)
+	mockRepo.EXPECT().Delete(gomock.Any(), m.ID).Return(errors.New("db error"))
+	mockMetrics.EXPECT().MessageRelayed("url.deleted")
+
+	handler := command.NewRelayOutboxHandler(mockRepo, domain.Subscriptions{}, mockMetrics)
+
+	_, err := handler.Handle(ctx, relayCommand)
+
+	assert.EqualError(t, err, "db error")
+}
+
+func TestRelayOutboxHandler_Handle_InterruptedByShutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockRepo := mocks.NewMockOutboxRepository(ctrl)
+	subscriber := mocks.NewMockSubscriber(ctrl)
+
+	first := &domain.Message{ID: uuid.New(), Topic: "session.revoked"}
+	second := &domain.Message{ID: uuid.New(), Topic: "session.revoked"}
+	mockRepo.EXPECT().ClaimDue(ctx, gomock.Any(), time.Minute, 10).Return([]*domain.Message{first, second}, nil)
+	subscriber.EXPECT().Handle(ctx, first).DoAndReturn(func(ctx context.Context, _ *domain.Message) error {
+		cancel()
+		return ctx.Err()
+	})
+
+	subs := domain.Subscriptions{}
+	subs.Subscribe("session.revoked", subscriber)
+	handler := command.NewRelayOutboxHandler(mockRepo, subs, mocks.NewMockOutboxMetrics(ctrl))
+
+	claimed, err := handler.Handle(ctx, relayCommand)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, claimed)
+}
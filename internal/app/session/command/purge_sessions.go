@@ -0,0 +1,47 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
+)
+
+type PurgeSessionsCommand struct {
+	BatchSize int
+	// RetainFor keeps expired and revoked sessions around for a while
+	// after they stop being usable.
+	RetainFor time.Duration
+}
+
+// PurgeSessionsHandler deletes sessions that expired or were revoked more
+// than RetainFor ago, in batches of BatchSize.
+type PurgeSessionsHandler struct {
+	repo domain.SessionCleanupRepository
+}
+
+func NewPurgeSessionsHandler(repo domain.SessionCleanupRepository) *PurgeSessionsHandler {
+	return &PurgeSessionsHandler{
+		repo: repo,
+	}
+}
+
+func (h *PurgeSessionsHandler) Handle(ctx context.Context, cmd PurgeSessionsCommand) (int64, error) {
+	cutoff := time.Now().Add(-cmd.RetainFor)
+
+	var total int64
+	for {
+		deleted, err := h.repo.DeleteInactive(ctx, cutoff, cmd.BatchSize)
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+
+		if deleted < int64(cmd.BatchSize) {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/session/command"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPurgeSessionsHandler_Handle_Batches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockSessionCleanupRepository(ctrl)
+
+	before := time.Now().Add(-24 * time.Hour)
+	gomock.InOrder(
+		mockRepo.EXPECT().DeleteInactive(ctx, gomock.Any(), 10).DoAndReturn(func(_ context.Context, cutoff time.Time, _ int) (int64, error) {
+			assert.WithinDuration(t, before, cutoff, time.Second)
+			return 10, nil
+		}),
+		mockRepo.EXPECT().DeleteInactive(ctx, gomock.Any(), 10).Return(int64(4), nil),
+	)
+
+	handler := command.NewPurgeSessionsHandler(mockRepo)
+
+	deleted, err := handler.Handle(ctx, command.PurgeSessionsCommand{BatchSize: 10, RetainFor: 24 * time.Hour})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(14), deleted)
+}
+
+func TestPurgeSessionsHandler_Handle_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	expectedError := errors.New("database error")
+	mockRepo := mocks.NewMockSessionCleanupRepository(ctrl)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().DeleteInactive(ctx, gomock.Any(), 10).Return(int64(10), nil),
+		mockRepo.EXPECT().DeleteInactive(ctx, gomock.Any(), 10).Return(int64(0), expectedError),
+	)
+
+	handler := command.NewPurgeSessionsHandler(mockRepo)
+
+	deleted, err := handler.Handle(ctx, command.PurgeSessionsCommand{BatchSize: 10})
+
+	assert.ErrorIs(t, err, expectedError)
+	assert.Equal(t, int64(10), deleted)
+}
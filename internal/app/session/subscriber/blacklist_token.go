@@ -0,0 +1,34 @@
+package subscriber
+
+import (
+	"context"
+	"time"
+
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
+)
+
+// BlacklistTokenSubscriber blacklists revoked refresh tokens until they
+// would have expired.
+type BlacklistTokenSubscriber struct {
+	blacklistRepo domain.BlacklistRepository
+}
+
+func NewBlacklistTokenSubscriber(blacklistRepo domain.BlacklistRepository) *BlacklistTokenSubscriber {
+	return &BlacklistTokenSubscriber{
+		blacklistRepo: blacklistRepo,
+	}
+}
+
+func (s *BlacklistTokenSubscriber) Handle(ctx context.Context, m *outbox_domain.Message) error {
+	var e domain.SessionRevokedEvent
+	if err := m.Decode(&e); err != nil {
+		return err
+	}
+
+	remainder := time.Until(e.ExpiresAt)
+	if remainder <= 0 {
+		return nil
+	}
+	return s.blacklistRepo.Revoke(ctx, e.RefreshTokenHash, remainder)
+}
@@ -0,0 +1,50 @@
+package subscriber_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/session/subscriber"
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBlacklistTokenSubscriber_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockBlacklist := mocks.NewMockBlacklistRepository(ctrl)
+
+	m, err := outbox_domain.NewMessage(domain.TopicSessionRevoked, domain.SessionRevokedEvent{
+		RefreshTokenHash: "hashed",
+		ExpiresAt:        time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	mockBlacklist.EXPECT().Revoke(ctx, "hashed", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, expiresIn time.Duration) error {
+		assert.InDelta(t, time.Hour, expiresIn, float64(time.Minute))
+		return nil
+	})
+
+	assert.NoError(t, subscriber.NewBlacklistTokenSubscriber(mockBlacklist).Handle(ctx, m))
+}
+
+func TestBlacklistTokenSubscriber_Handle_AlreadyExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m, err := outbox_domain.NewMessage(domain.TopicSessionRevoked, domain.SessionRevokedEvent{
+		RefreshTokenHash: "hashed",
+		ExpiresAt:        time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	// The token is rejected as expired; there is nothing to blacklist.
+	assert.NoError(t, subscriber.NewBlacklistTokenSubscriber(mocks.NewMockBlacklistRepository(ctrl)).Handle(context.Background(), m))
+}
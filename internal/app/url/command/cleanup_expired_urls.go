@@ -0,0 +1,95 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	bd_domain "github.com/brunoibarbosa/url-shortener/internal/domain/bd"
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+)
+
+type CleanupExpiredURLsCommand struct {
+	BatchSize int
+	// PurgeAfter is how long deleted URLs are kept before their rows, and
+	// with them their short codes, are removed for good.
+	PurgeAfter time.Duration
+}
+
+type CleanupExpiredURLsResult struct {
+	Expired int64
+	Purged  int64
+}
+
+// CleanupExpiredURLsHandler soft-deletes expired URLs and purges URLs that
+// have been deleted for longer than PurgeAfter, in batches of BatchSize.
+type CleanupExpiredURLsHandler struct {
+	tx         bd_domain.TransactionManager
+	repo       domain.URLCleanupRepository
+	outboxRepo outbox_domain.OutboxRepository
+}
+
+func NewCleanupExpiredURLsHandler(tx bd_domain.TransactionManager, repo domain.URLCleanupRepository, outboxRepo outbox_domain.OutboxRepository) *CleanupExpiredURLsHandler {
+	return &CleanupExpiredURLsHandler{
+		tx:         tx,
+		repo:       repo,
+		outboxRepo: outboxRepo,
+	}
+}
+
+func (h *CleanupExpiredURLsHandler) Handle(ctx context.Context, cmd CleanupExpiredURLsCommand) (CleanupExpiredURLsResult, error) {
+	var result CleanupExpiredURLsResult
+	now := time.Now()
+
+	for {
+		var expired []*domain.URL
+		err := h.tx.WithinTransaction(ctx, func(txCtx context.Context) error {
+			var err error
+			expired, err = h.repo.SoftDeleteExpired(txCtx, now, cmd.BatchSize)
+			if err != nil {
+				return err
+			}
+			for _, u := range expired {
+				m, err := outbox_domain.NewMessage(domain.TopicURLExpired, domain.URLEvent{
+					ShortCode: u.ShortCode,
+					UserID:    u.UserID,
+					ExpiresAt: u.ExpiresAt,
+				})
+				if err != nil {
+					return err
+				}
+				if err := h.outboxRepo.Append(txCtx, m); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return result, err
+		}
+		result.Expired += int64(len(expired))
+
+		if len(expired) < cmd.BatchSize {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+	}
+
+	cutoff := now.Add(-cmd.PurgeAfter)
+	for {
+		purged, err := h.repo.PurgeDeleted(ctx, cutoff, cmd.BatchSize)
+		if err != nil {
+			return result, err
+		}
+		result.Purged += purged
+
+		if purged < int64(cmd.BatchSize) {
+			return result, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/url/command"
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCleanupExpiredURLsHandler_Handle_Batches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockURLCleanupRepository(ctrl)
+	mockOutbox := mocks.NewMockOutboxRepository(ctrl)
+
+	userID := uuid.New()
+	expiresAt := time.Now().Add(-time.Minute)
+
+	var now time.Time
+	gomock.InOrder(
+		mockRepo.EXPECT().SoftDeleteExpired(ctx, gomock.Any(), 2).DoAndReturn(func(_ context.Context, n time.Time, _ int) ([]*domain.URL, error) {
+			now = n
+			return []*domain.URL{
+				{ShortCode: "abc123", UserID: &userID, ExpiresAt: &expiresAt},
+				{ShortCode: "def456", ExpiresAt: &expiresAt},
+			}, nil
+		}),
+		mockRepo.EXPECT().SoftDeleteExpired(ctx, gomock.Any(), 2).Return([]*domain.URL{{ShortCode: "ghi789"}}, nil),
+		mockRepo.EXPECT().PurgeDeleted(ctx, gomock.Any(), 2).DoAndReturn(func(_ context.Context, cutoff time.Time, _ int) (int64, error) {
+			assert.Equal(t, now.Add(-time.Hour), cutoff)
+			return 2, nil
+		}),
+		mockRepo.EXPECT().PurgeDeleted(ctx, gomock.Any(), 2).Return(int64(0), nil),
+	)
+	var events []domain.URLEvent
+	mockOutbox.EXPECT().Append(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, m *outbox_domain.Message) error {
+		var e domain.URLEvent
+		assert.Equal(t, domain.TopicURLExpired, m.Topic)
+		assert.NoError(t, m.Decode(&e))
+		events = append(events, e)
+		return nil
+	}).Times(3)
+
+	handler := command.NewCleanupExpiredURLsHandler(newPassthroughTx(ctrl), mockRepo, mockOutbox)
+
+	result, err := handler.Handle(ctx, command.CleanupExpiredURLsCommand{BatchSize: 2, PurgeAfter: time.Hour})
+
+	assert.NoError(t, err)
+	assert.Equal(t, command.CleanupExpiredURLsResult{Expired: 3, Purged: 2}, result)
+	if assert.Len(t, events, 3) {
+		assert.Equal(t, "abc123", events[0].ShortCode)
+		assert.Equal(t, &userID, events[0].UserID)
+		assert.True(t, expiresAt.Equal(*events[0].ExpiresAt))
+		assert.Nil(t, events[1].UserID)
+		assert.Equal(t, "ghi789", events[2].ShortCode)
+	}
+}
+
+func TestCleanupExpiredURLsHandler_Handle_SoftDeleteError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	expectedError := errors.New("database error")
+	mockRepo := mocks.NewMockURLCleanupRepository(ctrl)
+
+	mockRepo.EXPECT().SoftDeleteExpired(ctx, gomock.Any(), 100).Return(nil, expectedError)
+
+	handler := command.NewCleanupExpiredURLsHandler(newPassthroughTx(ctrl), mockRepo, mocks.NewMockOutboxRepository(ctrl))
+
+	_, err := handler.Handle(ctx, command.CleanupExpiredURLsCommand{BatchSize: 100, PurgeAfter: time.Hour})
+
+	assert.ErrorIs(t, err, expectedError)
+}
+
+func TestCleanupExpiredURLsHandler_Handle_StopsWhenCancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockRepo := mocks.NewMockURLCleanupRepository(ctrl)
+
+	mockRepo.EXPECT().SoftDeleteExpired(ctx, gomock.Any(), 1).DoAndReturn(func(context.Context, time.Time, int) ([]*domain.URL, error) {
+		cancel()
+		return []*domain.URL{{ShortCode: "abc123"}}, nil
+	})
+
+	handler := command.NewCleanupExpiredURLsHandler(newPassthroughTx(ctrl), mockRepo, newNoopOutbox(ctrl))
+
+	result, err := handler.Handle(ctx, command.CleanupExpiredURLsCommand{BatchSize: 1, PurgeAfter: time.Hour})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int64(1), result.Expired)
+}
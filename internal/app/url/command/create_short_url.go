@@ -2,8 +2,12 @@ package command
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+	bd_domain "github.com/brunoibarbosa/url-shortener/internal/domain/bd"
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
 	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
 	"github.com/google/uuid"
 
@@ -15,88 +19,148 @@ type CreateShortURLCommand struct {
 	UserID      *uuid.UUID
 	Length      int
 	MaxRetries  int
+	// AllowDuplicate skips returning an existing code the user already owns
+	// for the same destination.
+	AllowDuplicate  bool
+	RedirectOptions domain.RedirectOptions
+	UserAgent       string
+	IPAddress       string
 }
 
 type CreateShortURLHandler struct {
+	tx                        bd_domain.TransactionManager
 	persistRepo               domain.URLRepository
 	cacheRepo                 domain.URLCacheRepository
 	encrypter                 domain.URLEncrypter
+	blindIndexer              domain.URLBlindIndexer
 	shortCodeGenerator        domain.ShortCodeGenerator
+	auditRecorder             audit_domain.AuditRecorder
+	outboxRepo                outbox_domain.OutboxRepository
+	metrics                   domain.URLMetrics
 	persistExpirationDuration time.Duration
 	cacheExpirationDuration   time.Duration
 }
 
 type CreateShortURLResult struct {
 	ShortCode string
+	// Existing is set when the user already had an active code for the
+	// destination and no new one was created.
+	Existing bool
 }
 
 func NewCreateShortURLHandler(
+	tx bd_domain.TransactionManager,
 	repo domain.URLRepository,
 	cache domain.URLCacheRepository,
 	encrypter domain.URLEncrypter,
+	blindIndexer domain.URLBlindIndexer,
 	shortCodeGenerator domain.ShortCodeGenerator,
+	auditRecorder audit_domain.AuditRecorder,
+	outboxRepo outbox_domain.OutboxRepository,
+	metrics domain.URLMetrics,
 	persistExpirationDuration time.Duration,
 	cacheExpirationDuration time.Duration,
 ) *CreateShortURLHandler {
 	return &CreateShortURLHandler{
+		tx:                        tx,
 		persistRepo:               repo,
 		cacheRepo:                 cache,
 		encrypter:                 encrypter,
+		blindIndexer:              blindIndexer,
 		shortCodeGenerator:        shortCodeGenerator,
+		auditRecorder:             auditRecorder,
+		outboxRepo:                outboxRepo,
+		metrics:                   metrics,
 		persistExpirationDuration: persistExpirationDuration,
 		cacheExpirationDuration:   cacheExpirationDuration,
 	}
 }
 
 func (h *CreateShortURLHandler) Handle(ctx context.Context, cmd CreateShortURLCommand) (CreateShortURLResult, error) {
-	for i := 0; i < cmd.MaxRetries; i++ {
-		shortCode, err := h.shortCodeGenerator.Generate(cmd.Length)
-		if err != nil {
-			return CreateShortURLResult{}, err
-		}
+	normalizedURL, err := domain.NormalizeURL(cmd.OriginalURL)
+	if err != nil {
+		return CreateShortURLResult{}, err
+	}
+	destinationIndex := h.blindIndexer.Index(normalizedURL)
 
-		existsRedis, err := h.cacheRepo.Exists(ctx, shortCode)
-		if err != nil {
-			return CreateShortURLResult{}, err
-		}
-		if existsRedis {
-			continue
-		}
+	if err := cmd.RedirectOptions.Validate(); err != nil {
+		return CreateShortURLResult{}, err
+	}
+	options := cmd.RedirectOptions.WithDefaults()
 
-		existsDB, err := h.persistRepo.Exists(ctx, shortCode)
-		if err != nil {
-			return CreateShortURLResult{}, err
+	if cmd.UserID != nil && !cmd.AllowDuplicate {
+		existing, err := h.persistRepo.FindActiveByDestination(ctx, *cmd.UserID, destinationIndex)
+		// A code redirecting differently is not a duplicate.
+		if err == nil && existing.RedirectOptions.WithDefaults() == options {
+			return CreateShortURLResult{ShortCode: existing.ShortCode, Existing: true}, nil
 		}
-		if existsDB {
-			continue
+		if err != nil && !errors.Is(err, domain.ErrURLNotFound) {
+			return CreateShortURLResult{}, err
 		}
+	}
+
+	encryptedUrl, err := h.encrypter.Encrypt(ctx, cmd.OriginalURL)
+	if err != nil {
+		return CreateShortURLResult{}, err
+	}
 
-		encryptedUrl, err := h.encrypter.Encrypt(cmd.OriginalURL)
+	// The unique constraint on short_code decides collisions: checking first
+	// would race with other instances picking the same code.
+	for i := 0; i < cmd.MaxRetries; i++ {
+		shortCode, err := h.shortCodeGenerator.Generate(ctx, cmd.Length)
 		if err != nil {
 			return CreateShortURLResult{}, err
 		}
 
 		expiresAt := time.Now().Add(h.persistExpirationDuration)
 		u := &domain.URL{
-			ShortCode:    shortCode,
-			EncryptedURL: encryptedUrl,
-			UserID:       cmd.UserID,
-			ExpiresAt:    &expiresAt,
+			ShortCode:        shortCode,
+			EncryptedURL:     encryptedUrl,
+			DestinationIndex: destinationIndex,
+			UserID:           cmd.UserID,
+			ExpiresAt:        &expiresAt,
+			RedirectOptions:  options,
 		}
 
-		cacheDuration := util.MinTimeDuration(h.cacheExpirationDuration, h.persistExpirationDuration)
-		err = h.cacheRepo.Save(ctx, u, cacheDuration)
+		created, err := outbox_domain.NewMessage(domain.TopicURLCreated, domain.URLEvent{
+			ShortCode: shortCode,
+			UserID:    cmd.UserID,
+			ExpiresAt: &expiresAt,
+		})
 		if err != nil {
 			return CreateShortURLResult{}, err
 		}
 
-		shortURL := CreateShortURLResult{ShortCode: shortCode}
-		if err := h.persistRepo.Save(ctx, u); err != nil {
-			_ = h.cacheRepo.Delete(ctx, shortCode)
+		err = h.tx.WithinTransaction(ctx, func(txCtx context.Context) error {
+			if err := h.persistRepo.Save(txCtx, u); err != nil {
+				return err
+			}
+			return h.outboxRepo.Append(txCtx, created)
+		})
+		if errors.Is(err, domain.ErrShortCodeTaken) {
+			h.metrics.ShortCodeCollision()
+			continue
+		}
+		if err != nil {
 			return CreateShortURLResult{}, err
 		}
 
-		return shortURL, nil
+		// Best effort: a missing entry is filled on the first redirect. It is
+		// warmed here rather than by an outbox subscriber so it can never land
+		// after the invalidation of a later delete.
+		cacheDuration := util.MinTimeDuration(h.cacheExpirationDuration, h.persistExpirationDuration)
+		_ = h.cacheRepo.Save(ctx, u, cacheDuration)
+
+		_ = h.auditRecorder.Record(ctx, &audit_domain.Event{
+			ActorID:    cmd.UserID,
+			Action:     audit_domain.ActionURLCreated,
+			TargetType: audit_domain.TargetURL,
+			TargetID:   shortCode,
+			IPAddress:  cmd.IPAddress,
+			UserAgent:  cmd.UserAgent,
+		})
+
+		return CreateShortURLResult{ShortCode: shortCode}, nil
 	}
 
 	return CreateShortURLResult{}, domain.ErrMaxRetries
@@ -7,7 +7,10 @@ import (
 	"time"
 
 	"github.com/brunoibarbosa/url-shortener/internal/app/url/command"
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
 	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -25,19 +28,29 @@ func TestCreateShortURLHandler_Handle_Success(t *testing.T) {
 	mockCache := mocks.NewMockURLCacheRepository(ctrl)
 	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
 	mockGenerator := mocks.NewMockShortCodeGenerator(ctrl)
+	mockIndexer := mocks.NewMockURLBlindIndexer(ctrl)
+	mockIndexer.EXPECT().Index(gomock.Any()).Return([]byte("index")).AnyTimes()
 
-	mockGenerator.EXPECT().Generate(6).Return(shortCode, nil)
-	mockCache.EXPECT().Exists(ctx, shortCode).Return(false, nil)
-	mockRepo.EXPECT().Exists(ctx, shortCode).Return(false, nil)
-	mockEncrypter.EXPECT().Encrypt(originalURL).Return(encryptedURL, nil)
+	mockGenerator.EXPECT().Generate(gomock.Any(), 6).Return(shortCode, nil)
+	mockEncrypter.EXPECT().Encrypt(gomock.Any(), originalURL).Return(encryptedURL, nil)
 	mockRepo.EXPECT().Save(ctx, gomock.Any()).Return(nil)
 	mockCache.EXPECT().Save(ctx, gomock.Any(), gomock.Any()).Return(nil)
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().ShortCodeCollision().AnyTimes()
+
 	handler := command.NewCreateShortURLHandler(
+		newPassthroughTx(ctrl),
 		mockRepo,
 		mockCache,
 		mockEncrypter,
+		mockIndexer,
 		mockGenerator,
+		mockAuditRecorder,
+		newNoopOutbox(ctrl),
+		mockMetrics,
 		24*time.Hour,
 		1*time.Hour,
 	)
@@ -69,23 +82,38 @@ func TestCreateShortURLHandler_Handle_CollisionRetry(t *testing.T) {
 	mockCache := mocks.NewMockURLCacheRepository(ctrl)
 	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
 	mockGenerator := mocks.NewMockShortCodeGenerator(ctrl)
+	mockIndexer := mocks.NewMockURLBlindIndexer(ctrl)
+	mockIndexer.EXPECT().Index(gomock.Any()).Return([]byte("index")).AnyTimes()
 
 	gomock.InOrder(
-		mockGenerator.EXPECT().Generate(6).Return(firstCode, nil),
-		mockGenerator.EXPECT().Generate(6).Return(secondCode, nil),
+		mockGenerator.EXPECT().Generate(gomock.Any(), 6).Return(firstCode, nil),
+		mockGenerator.EXPECT().Generate(gomock.Any(), 6).Return(secondCode, nil),
+	)
+	mockEncrypter.EXPECT().Encrypt(gomock.Any(), originalURL).Return(encryptedURL, nil)
+	gomock.InOrder(
+		mockRepo.EXPECT().Save(ctx, gomock.Any()).Return(domain.ErrShortCodeTaken),
+		mockRepo.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, u *domain.URL) error {
+			assert.Equal(t, secondCode, u.ShortCode)
+			return nil
+		}),
 	)
-	mockCache.EXPECT().Exists(ctx, firstCode).Return(true, nil)
-	mockCache.EXPECT().Exists(ctx, secondCode).Return(false, nil)
-	mockRepo.EXPECT().Exists(ctx, secondCode).Return(false, nil)
-	mockEncrypter.EXPECT().Encrypt(originalURL).Return(encryptedURL, nil)
-	mockRepo.EXPECT().Save(ctx, gomock.Any()).Return(nil)
 	mockCache.EXPECT().Save(ctx, gomock.Any(), gomock.Any()).Return(nil)
 
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().ShortCodeCollision().Times(1)
+
 	handler := command.NewCreateShortURLHandler(
+		newPassthroughTx(ctrl),
 		mockRepo,
 		mockCache,
 		mockEncrypter,
+		mockIndexer,
 		mockGenerator,
+		mockAuditRecorder,
+		newNoopOutbox(ctrl),
+		mockMetrics,
 		24*time.Hour,
 		1*time.Hour,
 	)
@@ -115,14 +143,27 @@ func TestCreateShortURLHandler_Handle_GeneratorError(t *testing.T) {
 	mockCache := mocks.NewMockURLCacheRepository(ctrl)
 	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
 	mockGenerator := mocks.NewMockShortCodeGenerator(ctrl)
+	mockIndexer := mocks.NewMockURLBlindIndexer(ctrl)
+	mockIndexer.EXPECT().Index(gomock.Any()).Return([]byte("index")).AnyTimes()
+
+	mockEncrypter.EXPECT().Encrypt(gomock.Any(), originalURL).Return("encrypted_url", nil)
+	mockGenerator.EXPECT().Generate(gomock.Any(), 6).Return("", expectedError)
 
-	mockGenerator.EXPECT().Generate(6).Return("", expectedError)
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().ShortCodeCollision().AnyTimes()
 
 	handler := command.NewCreateShortURLHandler(
+		newPassthroughTx(ctrl),
 		mockRepo,
 		mockCache,
 		mockEncrypter,
+		mockIndexer,
 		mockGenerator,
+		mockAuditRecorder,
+		newNoopOutbox(ctrl),
+		mockMetrics,
 		24*time.Hour,
 		1*time.Hour,
 	)
@@ -147,24 +188,32 @@ func TestCreateShortURLHandler_Handle_EncryptionError(t *testing.T) {
 
 	ctx := context.Background()
 	originalURL := "https://example.com"
-	shortCode := "abc123"
 	expectedError := errors.New("encryption error")
 
 	mockRepo := mocks.NewMockURLRepository(ctrl)
 	mockCache := mocks.NewMockURLCacheRepository(ctrl)
 	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
 	mockGenerator := mocks.NewMockShortCodeGenerator(ctrl)
+	mockIndexer := mocks.NewMockURLBlindIndexer(ctrl)
+	mockIndexer.EXPECT().Index(gomock.Any()).Return([]byte("index")).AnyTimes()
+
+	mockEncrypter.EXPECT().Encrypt(gomock.Any(), originalURL).Return("", expectedError)
 
-	mockGenerator.EXPECT().Generate(6).Return(shortCode, nil)
-	mockCache.EXPECT().Exists(ctx, shortCode).Return(false, nil)
-	mockRepo.EXPECT().Exists(ctx, shortCode).Return(false, nil)
-	mockEncrypter.EXPECT().Encrypt(originalURL).Return("", expectedError)
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().ShortCodeCollision().AnyTimes()
 
 	handler := command.NewCreateShortURLHandler(
+		newPassthroughTx(ctrl),
 		mockRepo,
 		mockCache,
 		mockEncrypter,
+		mockIndexer,
 		mockGenerator,
+		mockAuditRecorder,
+		newNoopOutbox(ctrl),
+		mockMetrics,
 		24*time.Hour,
 		1*time.Hour,
 	)
@@ -197,20 +246,28 @@ func TestCreateShortURLHandler_Handle_SaveError(t *testing.T) {
 	mockCache := mocks.NewMockURLCacheRepository(ctrl)
 	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
 	mockGenerator := mocks.NewMockShortCodeGenerator(ctrl)
+	mockIndexer := mocks.NewMockURLBlindIndexer(ctrl)
+	mockIndexer.EXPECT().Index(gomock.Any()).Return([]byte("index")).AnyTimes()
 
-	mockGenerator.EXPECT().Generate(6).Return(shortCode, nil)
-	mockCache.EXPECT().Exists(ctx, shortCode).Return(false, nil)
-	mockRepo.EXPECT().Exists(ctx, shortCode).Return(false, nil)
-	mockEncrypter.EXPECT().Encrypt(originalURL).Return(encryptedURL, nil)
-	mockCache.EXPECT().Save(ctx, gomock.Any(), gomock.Any()).Return(nil)
+	mockGenerator.EXPECT().Generate(gomock.Any(), 6).Return(shortCode, nil)
+	mockEncrypter.EXPECT().Encrypt(gomock.Any(), originalURL).Return(encryptedURL, nil)
 	mockRepo.EXPECT().Save(ctx, gomock.Any()).Return(expectedError)
-	mockCache.EXPECT().Delete(ctx, shortCode).Return(nil) // Cleanup on error
+
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().ShortCodeCollision().AnyTimes()
 
 	handler := command.NewCreateShortURLHandler(
+		newPassthroughTx(ctrl),
 		mockRepo,
 		mockCache,
 		mockEncrypter,
+		mockIndexer,
 		mockGenerator,
+		mockAuditRecorder,
+		newNoopOutbox(ctrl),
+		mockMetrics,
 		24*time.Hour,
 		1*time.Hour,
 	)
@@ -228,3 +285,329 @@ func TestCreateShortURLHandler_Handle_SaveError(t *testing.T) {
 	assert.Equal(t, expectedError, err)
 	assert.Empty(t, result.ShortCode)
 }
+
+func TestCreateShortURLHandler_Handle_CacheIsBestEffort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	originalURL := "https://example.com"
+	shortCode := "abc123"
+
+	mockRepo := mocks.NewMockURLRepository(ctrl)
+	mockCache := mocks.NewMockURLCacheRepository(ctrl)
+	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+	mockGenerator := mocks.NewMockShortCodeGenerator(ctrl)
+	mockIndexer := mocks.NewMockURLBlindIndexer(ctrl)
+	mockIndexer.EXPECT().Index(gomock.Any()).Return([]byte("index")).AnyTimes()
+
+	mockGenerator.EXPECT().Generate(gomock.Any(), 6).Return(shortCode, nil)
+	mockEncrypter.EXPECT().Encrypt(gomock.Any(), originalURL).Return("encrypted_url", nil)
+	gomock.InOrder(
+		mockRepo.EXPECT().Save(ctx, gomock.Any()).Return(nil),
+		mockCache.EXPECT().Save(ctx, gomock.Any(), gomock.Any()).Return(errors.New("redis down")),
+	)
+
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+
+	handler := command.NewCreateShortURLHandler(
+		newPassthroughTx(ctrl),
+		mockRepo,
+		mockCache,
+		mockEncrypter,
+		mockIndexer,
+		mockGenerator,
+		mockAuditRecorder,
+		newNoopOutbox(ctrl),
+		mockMetrics,
+		24*time.Hour,
+		1*time.Hour,
+	)
+
+	result, err := handler.Handle(ctx, command.CreateShortURLCommand{
+		OriginalURL: originalURL,
+		Length:      6,
+		MaxRetries:  10,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, shortCode, result.ShortCode)
+}
+
+func TestCreateShortURLHandler_Handle_MaxRetries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	originalURL := "https://example.com"
+
+	mockRepo := mocks.NewMockURLRepository(ctrl)
+	mockCache := mocks.NewMockURLCacheRepository(ctrl)
+	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+	mockGenerator := mocks.NewMockShortCodeGenerator(ctrl)
+	mockIndexer := mocks.NewMockURLBlindIndexer(ctrl)
+	mockIndexer.EXPECT().Index(gomock.Any()).Return([]byte("index")).AnyTimes()
+
+	mockEncrypter.EXPECT().Encrypt(gomock.Any(), originalURL).Return("encrypted_url", nil).Times(1)
+	mockGenerator.EXPECT().Generate(gomock.Any(), 6).Return("abc123", nil).Times(3)
+	mockRepo.EXPECT().Save(ctx, gomock.Any()).Return(domain.ErrShortCodeTaken).Times(3)
+
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().ShortCodeCollision().Times(3)
+
+	handler := command.NewCreateShortURLHandler(
+		newPassthroughTx(ctrl),
+		mockRepo,
+		mockCache,
+		mockEncrypter,
+		mockIndexer,
+		mockGenerator,
+		mockAuditRecorder,
+		newNoopOutbox(ctrl),
+		mockMetrics,
+		24*time.Hour,
+		1*time.Hour,
+	)
+
+	_, err := handler.Handle(ctx, command.CreateShortURLCommand{
+		OriginalURL: originalURL,
+		Length:      6,
+		MaxRetries:  3,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrMaxRetries)
+}
+
+func TestCreateShortURLHandler_Handle_Deduplication(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	originalURL := "HTTPS://Example.com:443/path/"
+	index := []byte("index")
+
+	newHandler := func(ctrl *gomock.Controller, repo *mocks.MockURLRepository, cache *mocks.MockURLCacheRepository, encrypter *mocks.MockURLEncrypter, generator *mocks.MockShortCodeGenerator) *command.CreateShortURLHandler {
+		mockIndexer := mocks.NewMockURLBlindIndexer(ctrl)
+		mockIndexer.EXPECT().Index("https://example.com/path").Return(index)
+		mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+		mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		mockMetrics := mocks.NewMockURLMetrics(ctrl)
+		mockMetrics.EXPECT().ShortCodeCollision().AnyTimes()
+
+		return command.NewCreateShortURLHandler(newPassthroughTx(ctrl), repo, cache, encrypter, mockIndexer, generator, mockAuditRecorder, newNoopOutbox(ctrl), mockMetrics, 24*time.Hour, time.Hour)
+	}
+
+	t.Run("should return the code the user already owns", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockURLRepository(ctrl)
+		mockRepo.EXPECT().FindActiveByDestination(ctx, userID, index).Return(&domain.URL{ShortCode: "abc123"}, nil)
+
+		handler := newHandler(ctrl, mockRepo, mocks.NewMockURLCacheRepository(ctrl), mocks.NewMockURLEncrypter(ctrl), mocks.NewMockShortCodeGenerator(ctrl))
+
+		result, err := handler.Handle(ctx, command.CreateShortURLCommand{OriginalURL: originalURL, UserID: &userID, Length: 6, MaxRetries: 10})
+
+		assert.NoError(t, err)
+		assert.Equal(t, command.CreateShortURLResult{ShortCode: "abc123", Existing: true}, result)
+	})
+
+	t.Run("should create a new code with the destination index when none exists", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockURLRepository(ctrl)
+		mockCache := mocks.NewMockURLCacheRepository(ctrl)
+		mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+		mockGenerator := mocks.NewMockShortCodeGenerator(ctrl)
+
+		mockRepo.EXPECT().FindActiveByDestination(ctx, userID, index).Return(nil, domain.ErrURLNotFound)
+		mockGenerator.EXPECT().Generate(gomock.Any(), 6).Return("xyz789", nil)
+		mockEncrypter.EXPECT().Encrypt(gomock.Any(), originalURL).Return("encrypted_url", nil)
+		mockCache.EXPECT().Save(ctx, gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, u *domain.URL) error {
+			assert.Equal(t, index, u.DestinationIndex)
+			return nil
+		})
+
+		handler := newHandler(ctrl, mockRepo, mockCache, mockEncrypter, mockGenerator)
+
+		result, err := handler.Handle(ctx, command.CreateShortURLCommand{OriginalURL: originalURL, UserID: &userID, Length: 6, MaxRetries: 10})
+
+		assert.NoError(t, err)
+		assert.Equal(t, command.CreateShortURLResult{ShortCode: "xyz789"}, result)
+	})
+
+	t.Run("should skip the lookup when duplicates are allowed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockURLRepository(ctrl)
+		mockCache := mocks.NewMockURLCacheRepository(ctrl)
+		mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+		mockGenerator := mocks.NewMockShortCodeGenerator(ctrl)
+
+		mockGenerator.EXPECT().Generate(gomock.Any(), 6).Return("xyz789", nil)
+		mockEncrypter.EXPECT().Encrypt(gomock.Any(), originalURL).Return("encrypted_url", nil)
+		mockCache.EXPECT().Save(ctx, gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().Save(ctx, gomock.Any()).Return(nil)
+
+		handler := newHandler(ctrl, mockRepo, mockCache, mockEncrypter, mockGenerator)
+
+		result, err := handler.Handle(ctx, command.CreateShortURLCommand{OriginalURL: originalURL, UserID: &userID, Length: 6, MaxRetries: 10, AllowDuplicate: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "xyz789", result.ShortCode)
+		assert.False(t, result.Existing)
+	})
+
+	t.Run("should create a new code when the owned one redirects differently", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockURLRepository(ctrl)
+		mockCache := mocks.NewMockURLCacheRepository(ctrl)
+		mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+		mockGenerator := mocks.NewMockShortCodeGenerator(ctrl)
+		options := domain.RedirectOptions{
+			RedirectType: domain.RedirectMovedPermanently,
+			ForwardQuery: true,
+			UTM:          domain.UTMParams{Source: "short"},
+		}
+
+		mockRepo.EXPECT().FindActiveByDestination(ctx, userID, index).Return(&domain.URL{ShortCode: "abc123"}, nil)
+		mockGenerator.EXPECT().Generate(gomock.Any(), 6).Return("xyz789", nil)
+		mockEncrypter.EXPECT().Encrypt(gomock.Any(), originalURL).Return("encrypted_url", nil)
+		mockCache.EXPECT().Save(ctx, gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, u *domain.URL) error {
+			assert.Equal(t, options, u.RedirectOptions)
+			return nil
+		})
+
+		handler := newHandler(ctrl, mockRepo, mockCache, mockEncrypter, mockGenerator)
+
+		result, err := handler.Handle(ctx, command.CreateShortURLCommand{OriginalURL: originalURL, UserID: &userID, Length: 6, MaxRetries: 10, RedirectOptions: options})
+
+		assert.NoError(t, err)
+		assert.Equal(t, command.CreateShortURLResult{ShortCode: "xyz789"}, result)
+	})
+
+	t.Run("should reject unsupported redirect types", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		handler := newHandler(ctrl, mocks.NewMockURLRepository(ctrl), mocks.NewMockURLCacheRepository(ctrl), mocks.NewMockURLEncrypter(ctrl), mocks.NewMockShortCodeGenerator(ctrl))
+
+		_, err := handler.Handle(ctx, command.CreateShortURLCommand{
+			OriginalURL:     originalURL,
+			UserID:          &userID,
+			Length:          6,
+			MaxRetries:      10,
+			RedirectOptions: domain.RedirectOptions{RedirectType: 303},
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidRedirectType)
+	})
+
+	t.Run("should return lookup errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockURLRepository(ctrl)
+		expectedError := errors.New("database error")
+		mockRepo.EXPECT().FindActiveByDestination(ctx, userID, index).Return(nil, expectedError)
+
+		handler := newHandler(ctrl, mockRepo, mocks.NewMockURLCacheRepository(ctrl), mocks.NewMockURLEncrypter(ctrl), mocks.NewMockShortCodeGenerator(ctrl))
+
+		_, err := handler.Handle(ctx, command.CreateShortURLCommand{OriginalURL: originalURL, UserID: &userID, Length: 6, MaxRetries: 10})
+
+		assert.Equal(t, expectedError, err)
+	})
+}
+
+func TestCreateShortURLHandler_Handle_WritesOutboxMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	txCtx := context.WithValue(ctx, txKey{}, "tx")
+	userID := uuid.New()
+
+	mockTx := mocks.NewMockTransactionManager(ctrl)
+	mockRepo := mocks.NewMockURLRepository(ctrl)
+	mockCache := mocks.NewMockURLCacheRepository(ctrl)
+	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+	mockGenerator := mocks.NewMockShortCodeGenerator(ctrl)
+	mockIndexer := mocks.NewMockURLBlindIndexer(ctrl)
+	mockIndexer.EXPECT().Index(gomock.Any()).Return([]byte("index"))
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil)
+	mockOutbox := mocks.NewMockOutboxRepository(ctrl)
+
+	mockRepo.EXPECT().FindActiveByDestination(ctx, userID, []byte("index")).Return(nil, domain.ErrURLNotFound)
+	mockEncrypter.EXPECT().Encrypt(gomock.Any(), "https://example.com").Return("encrypted_url", nil)
+	mockGenerator.EXPECT().Generate(gomock.Any(), 6).Return("abc123", nil)
+	mockTx.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(context.Context) error) error {
+		return fn(txCtx)
+	})
+	mockRepo.EXPECT().Save(txCtx, gomock.Any()).Return(nil)
+	mockOutbox.EXPECT().Append(txCtx, gomock.Any()).DoAndReturn(func(_ context.Context, m *outbox_domain.Message) error {
+		var e domain.URLEvent
+		assert.Equal(t, domain.TopicURLCreated, m.Topic)
+		assert.NoError(t, m.Decode(&e))
+		assert.Equal(t, "abc123", e.ShortCode)
+		assert.Equal(t, &userID, e.UserID)
+		assert.NotNil(t, e.ExpiresAt)
+		return nil
+	})
+	mockCache.EXPECT().Save(ctx, gomock.Any(), gomock.Any()).Return(nil)
+
+	handler := command.NewCreateShortURLHandler(mockTx, mockRepo, mockCache, mockEncrypter, mockIndexer, mockGenerator, mockAuditRecorder, mockOutbox, mocks.NewMockURLMetrics(ctrl), 24*time.Hour, time.Hour)
+
+	result, err := handler.Handle(ctx, command.CreateShortURLCommand{
+		OriginalURL: "https://example.com",
+		UserID:      &userID,
+		Length:      6,
+		MaxRetries:  3,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "abc123", result.ShortCode)
+}
+
+func TestCreateShortURLHandler_Handle_OutboxError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	expectedError := errors.New("outbox unavailable")
+
+	mockRepo := mocks.NewMockURLRepository(ctrl)
+	mockCache := mocks.NewMockURLCacheRepository(ctrl)
+	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+	mockGenerator := mocks.NewMockShortCodeGenerator(ctrl)
+	mockIndexer := mocks.NewMockURLBlindIndexer(ctrl)
+	mockIndexer.EXPECT().Index(gomock.Any()).Return([]byte("index"))
+	mockOutbox := mocks.NewMockOutboxRepository(ctrl)
+
+	mockEncrypter.EXPECT().Encrypt(gomock.Any(), "https://example.com").Return("encrypted_url", nil)
+	mockGenerator.EXPECT().Generate(gomock.Any(), 6).Return("abc123", nil)
+	mockRepo.EXPECT().Save(ctx, gomock.Any()).Return(nil)
+	mockOutbox.EXPECT().Append(ctx, gomock.Any()).Return(expectedError)
+
+	handler := command.NewCreateShortURLHandler(newPassthroughTx(ctrl), mockRepo, mockCache, mockEncrypter, mockIndexer, mockGenerator, mocks.NewMockAuditRecorder(ctrl), mockOutbox, mocks.NewMockURLMetrics(ctrl), 24*time.Hour, time.Hour)
+
+	_, err := handler.Handle(ctx, command.CreateShortURLCommand{
+		OriginalURL: "https://example.com",
+		Length:      6,
+		MaxRetries:  3,
+	})
+
+	assert.Equal(t, expectedError, err)
+}
+
+type txKey struct{}
+
+func newPassthroughTx(ctrl *gomock.Controller) *mocks.MockTransactionManager {
+	tx := mocks.NewMockTransactionManager(ctrl)
+	tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	}).AnyTimes()
+	return tx
+}
+
+func newNoopOutbox(ctrl *gomock.Controller) *mocks.MockOutboxRepository {
+	outbox := mocks.NewMockOutboxRepository(ctrl)
+	outbox.EXPECT().Append(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return outbox
+}
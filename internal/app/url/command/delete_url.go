@@ -3,34 +3,67 @@ package command
 import (
 	"context"
 
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+	bd_domain "github.com/brunoibarbosa/url-shortener/internal/domain/bd"
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
 	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
 	"github.com/google/uuid"
 )
 
 type DeleteURLCommand struct {
-	ID     uuid.UUID
-	UserID uuid.UUID
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	UserAgent string
+	IPAddress string
 }
 
 type DeleteURLHandler struct {
-	repo      domain.URLRepository
-	cacheRepo domain.URLCacheRepository
+	tx            bd_domain.TransactionManager
+	repo          domain.URLRepository
+	outboxRepo    outbox_domain.OutboxRepository
+	auditRecorder audit_domain.AuditRecorder
 }
 
-func NewDeleteURLHandler(repo domain.URLRepository, cacheRepo domain.URLCacheRepository) *DeleteURLHandler {
+func NewDeleteURLHandler(tx bd_domain.TransactionManager, repo domain.URLRepository, outboxRepo outbox_domain.OutboxRepository, auditRecorder audit_domain.AuditRecorder) *DeleteURLHandler {
 	return &DeleteURLHandler{
-		repo:      repo,
-		cacheRepo: cacheRepo,
+		tx:            tx,
+		repo:          repo,
+		outboxRepo:    outboxRepo,
+		auditRecorder: auditRecorder,
 	}
 }
 
 func (h *DeleteURLHandler) Handle(ctx context.Context, cmd DeleteURLCommand) error {
-	shortCode, err := h.repo.SoftDelete(ctx, cmd.ID, cmd.UserID)
+	var shortCode string
+	err := h.tx.WithinTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		shortCode, err = h.repo.SoftDelete(txCtx, cmd.ID, cmd.UserID)
+		if err != nil {
+			return err
+		}
+
+		deleted, err := outbox_domain.NewMessage(domain.TopicURLDeleted, domain.URLEvent{
+			ID:        &cmd.ID,
+			ShortCode: shortCode,
+			UserID:    &cmd.UserID,
+		})
+		if err != nil {
+			return err
+		}
+		return h.outboxRepo.Append(txCtx, deleted)
+	})
 	if err != nil {
 		return err
 	}
 
-	_ = h.cacheRepo.Delete(ctx, shortCode)
+	_ = h.auditRecorder.Record(ctx, &audit_domain.Event{
+		ActorID:    &cmd.UserID,
+		Action:     audit_domain.ActionURLDeleted,
+		TargetType: audit_domain.TargetURL,
+		TargetID:   shortCode,
+		IPAddress:  cmd.IPAddress,
+		UserAgent:  cmd.UserAgent,
+	})
 
 	return nil
 }
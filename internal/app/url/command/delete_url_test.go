@@ -6,6 +6,9 @@ import (
 	"testing"
 
 	"github.com/brunoibarbosa/url-shortener/internal/app/url/command"
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
 	"github.com/brunoibarbosa/url-shortener/internal/mocks"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -22,12 +25,13 @@ func TestDeleteURLHandler_Handle_Success(t *testing.T) {
 	shortCode := "abc123"
 
 	mockRepo := mocks.NewMockURLRepository(ctrl)
-	mockCache := mocks.NewMockURLCacheRepository(ctrl)
 
 	mockRepo.EXPECT().SoftDelete(ctx, urlID, userID).Return(shortCode, nil)
-	mockCache.EXPECT().Delete(ctx, shortCode).Return(nil)
 
-	handler := command.NewDeleteURLHandler(mockRepo, mockCache)
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	handler := command.NewDeleteURLHandler(newPassthroughTx(ctrl), mockRepo, newNoopOutbox(ctrl), mockAuditRecorder)
 
 	cmd := command.DeleteURLCommand{
 		ID:     urlID,
@@ -49,11 +53,13 @@ func TestDeleteURLHandler_Handle_SoftDeleteError(t *testing.T) {
 	expectedError := errors.New("soft delete error")
 
 	mockRepo := mocks.NewMockURLRepository(ctrl)
-	mockCache := mocks.NewMockURLCacheRepository(ctrl)
 
 	mockRepo.EXPECT().SoftDelete(ctx, urlID, userID).Return("", expectedError)
 
-	handler := command.NewDeleteURLHandler(mockRepo, mockCache)
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	handler := command.NewDeleteURLHandler(newPassthroughTx(ctrl), mockRepo, newNoopOutbox(ctrl), mockAuditRecorder)
 
 	cmd := command.DeleteURLCommand{
 		ID:     urlID,
@@ -66,7 +72,7 @@ func TestDeleteURLHandler_Handle_SoftDeleteError(t *testing.T) {
 	assert.Equal(t, expectedError, err)
 }
 
-func TestDeleteURLHandler_Handle_CacheDeleteFailure(t *testing.T) {
+func TestDeleteURLHandler_Handle_OutboxError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -74,15 +80,15 @@ func TestDeleteURLHandler_Handle_CacheDeleteFailure(t *testing.T) {
 	urlID := uuid.New()
 	userID := uuid.New()
 	shortCode := "abc123"
-	cacheError := errors.New("cache delete error")
+	outboxError := errors.New("outbox error")
 
 	mockRepo := mocks.NewMockURLRepository(ctrl)
-	mockCache := mocks.NewMockURLCacheRepository(ctrl)
+	mockOutbox := mocks.NewMockOutboxRepository(ctrl)
 
 	mockRepo.EXPECT().SoftDelete(ctx, urlID, userID).Return(shortCode, nil)
-	mockCache.EXPECT().Delete(ctx, shortCode).Return(cacheError)
+	mockOutbox.EXPECT().Append(ctx, gomock.Any()).Return(outboxError)
 
-	handler := command.NewDeleteURLHandler(mockRepo, mockCache)
+	handler := command.NewDeleteURLHandler(newPassthroughTx(ctrl), mockRepo, mockOutbox, mocks.NewMockAuditRecorder(ctrl))
 
 	cmd := command.DeleteURLCommand{
 		ID:     urlID,
@@ -91,8 +97,8 @@ func TestDeleteURLHandler_Handle_CacheDeleteFailure(t *testing.T) {
 
 	err := handler.Handle(ctx, cmd)
 
-	// Cache delete error is ignored (error silenciado com _)
-	assert.NoError(t, err)
+	// The deletion is rolled back with the message
+	assert.Equal(t, outboxError, err)
 }
 
 func TestDeleteURLHandler_Handle_WrongOwner(t *testing.T) {
@@ -105,11 +111,13 @@ func TestDeleteURLHandler_Handle_WrongOwner(t *testing.T) {
 	expectedError := errors.New("no rows affected")
 
 	mockRepo := mocks.NewMockURLRepository(ctrl)
-	mockCache := mocks.NewMockURLCacheRepository(ctrl)
 
 	mockRepo.EXPECT().SoftDelete(ctx, urlID, userID).Return("", expectedError)
 
-	handler := command.NewDeleteURLHandler(mockRepo, mockCache)
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+	mockAuditRecorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	handler := command.NewDeleteURLHandler(newPassthroughTx(ctrl), mockRepo, newNoopOutbox(ctrl), mockAuditRecorder)
 
 	cmd := command.DeleteURLCommand{
 		ID:     urlID,
@@ -121,3 +129,73 @@ func TestDeleteURLHandler_Handle_WrongOwner(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, expectedError, err)
 }
+
+func TestDeleteURLHandler_Handle_RecordsAuditEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	urlID := uuid.New()
+	userID := uuid.New()
+	shortCode := "abc123"
+
+	mockRepo := mocks.NewMockURLRepository(ctrl)
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+
+	mockRepo.EXPECT().SoftDelete(ctx, urlID, userID).Return(shortCode, nil)
+	mockAuditRecorder.EXPECT().Record(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, e *audit_domain.Event) error {
+			assert.Equal(t, audit_domain.ActionURLDeleted, e.Action)
+			assert.Equal(t, &userID, e.ActorID)
+			assert.Equal(t, audit_domain.TargetURL, e.TargetType)
+			assert.Equal(t, shortCode, e.TargetID)
+			return errors.New("audit error")
+		},
+	)
+
+	handler := command.NewDeleteURLHandler(newPassthroughTx(ctrl), mockRepo, newNoopOutbox(ctrl), mockAuditRecorder)
+
+	cmd := command.DeleteURLCommand{
+		ID:        urlID,
+		UserID:    userID,
+		IPAddress: "127.0.0.1",
+	}
+
+	err := handler.Handle(ctx, cmd)
+
+	assert.NoError(t, err)
+}
+
+func TestDeleteURLHandler_Handle_WritesOutboxMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	txCtx := context.WithValue(ctx, txKey{}, "tx")
+	urlID := uuid.New()
+	userID := uuid.New()
+
+	mockTx := mocks.NewMockTransactionManager(ctrl)
+	mockRepo := mocks.NewMockURLRepository(ctrl)
+	mockOutbox := mocks.NewMockOutboxRepository(ctrl)
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+
+	mockTx.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(context.Context) error) error {
+		return fn(txCtx)
+	})
+	mockRepo.EXPECT().SoftDelete(txCtx, urlID, userID).Return("abc123", nil)
+	mockOutbox.EXPECT().Append(txCtx, gomock.Any()).DoAndReturn(func(_ context.Context, m *outbox_domain.Message) error {
+		var e domain.URLEvent
+		assert.Equal(t, domain.TopicURLDeleted, m.Topic)
+		assert.NoError(t, m.Decode(&e))
+		assert.Equal(t, domain.URLEvent{ID: &urlID, ShortCode: "abc123", UserID: &userID}, e)
+		return nil
+	})
+	mockAuditRecorder.EXPECT().Record(ctx, gomock.Any()).Return(nil)
+
+	handler := command.NewDeleteURLHandler(mockTx, mockRepo, mockOutbox, mockAuditRecorder)
+
+	err := handler.Handle(ctx, command.DeleteURLCommand{ID: urlID, UserID: userID})
+
+	assert.NoError(t, err)
+}
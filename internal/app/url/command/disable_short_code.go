@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+	bd_domain "github.com/brunoibarbosa/url-shortener/internal/domain/bd"
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+)
+
+type DisableShortCodeCommand struct {
+	ShortCode string
+}
+
+// DisableShortCodeHandler deletes a URL on behalf of an admin. Like a delete
+// by the owner it publishes url.deleted, whose subscribers clear the cache
+// and notify the owner's webhooks.
+type DisableShortCodeHandler struct {
+	tx            bd_domain.TransactionManager
+	repo          domain.URLRepository
+	outboxRepo    outbox_domain.OutboxRepository
+	auditRecorder audit_domain.AuditRecorder
+}
+
+func NewDisableShortCodeHandler(tx bd_domain.TransactionManager, repo domain.URLRepository, outboxRepo outbox_domain.OutboxRepository, auditRecorder audit_domain.AuditRecorder) *DisableShortCodeHandler {
+	return &DisableShortCodeHandler{
+		tx:            tx,
+		repo:          repo,
+		outboxRepo:    outboxRepo,
+		auditRecorder: auditRecorder,
+	}
+}
+
+func (h *DisableShortCodeHandler) Handle(ctx context.Context, cmd DisableShortCodeCommand) error {
+	if cmd.ShortCode == "" {
+		return domain.ErrInvalidShortCode
+	}
+
+	err := h.tx.WithinTransaction(ctx, func(txCtx context.Context) error {
+		u, err := h.repo.DisableByShortCode(txCtx, cmd.ShortCode)
+		if err != nil {
+			return err
+		}
+
+		deleted, err := outbox_domain.NewMessage(domain.TopicURLDeleted, domain.URLEvent{
+			ShortCode: u.ShortCode,
+			UserID:    u.UserID,
+		})
+		if err != nil {
+			return err
+		}
+		return h.outboxRepo.Append(txCtx, deleted)
+	})
+	if err != nil {
+		return err
+	}
+
+	_ = h.auditRecorder.Record(ctx, &audit_domain.Event{
+		Action:     audit_domain.ActionURLDisabled,
+		TargetType: audit_domain.TargetURL,
+		TargetID:   cmd.ShortCode,
+	})
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/url/command"
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDisableShortCodeHandler_Handle_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	shortCode := "abc123"
+	ownerID := uuid.New()
+
+	mockRepo := mocks.NewMockURLRepository(ctrl)
+	mockOutbox := mocks.NewMockOutboxRepository(ctrl)
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+
+	mockRepo.EXPECT().DisableByShortCode(ctx, shortCode).Return(&domain.URL{ShortCode: shortCode, UserID: &ownerID}, nil)
+	mockOutbox.EXPECT().Append(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, m *outbox_domain.Message) error {
+		assert.Equal(t, domain.TopicURLDeleted, m.Topic)
+		var e domain.URLEvent
+		require.NoError(t, m.Decode(&e))
+		assert.Equal(t, shortCode, e.ShortCode)
+		assert.Equal(t, &ownerID, e.UserID)
+		return nil
+	})
+	mockAuditRecorder.EXPECT().Record(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, e *audit_domain.Event) error {
+		assert.Equal(t, audit_domain.ActionURLDisabled, e.Action)
+		assert.Equal(t, shortCode, e.TargetID)
+		return nil
+	})
+
+	handler := command.NewDisableShortCodeHandler(newPassthroughTx(ctrl), mockRepo, mockOutbox, mockAuditRecorder)
+
+	err := handler.Handle(ctx, command.DisableShortCodeCommand{ShortCode: shortCode})
+
+	assert.NoError(t, err)
+}
+
+func TestDisableShortCodeHandler_Handle_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockRepo := mocks.NewMockURLRepository(ctrl)
+	mockAuditRecorder := mocks.NewMockAuditRecorder(ctrl)
+
+	mockRepo.EXPECT().DisableByShortCode(ctx, "missing").Return(nil, domain.ErrURLNotFound)
+
+	handler := command.NewDisableShortCodeHandler(newPassthroughTx(ctrl), mockRepo, mocks.NewMockOutboxRepository(ctrl), mockAuditRecorder)
+
+	err := handler.Handle(ctx, command.DisableShortCodeCommand{ShortCode: "missing"})
+
+	assert.ErrorIs(t, err, domain.ErrURLNotFound)
+}
+
+func TestDisableShortCodeHandler_Handle_OutboxError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	expectedError := errors.New("outbox error")
+
+	mockRepo := mocks.NewMockURLRepository(ctrl)
+	mockOutbox := mocks.NewMockOutboxRepository(ctrl)
+
+	mockRepo.EXPECT().DisableByShortCode(ctx, "abc123").Return(&domain.URL{ShortCode: "abc123"}, nil)
+	mockOutbox.EXPECT().Append(ctx, gomock.Any()).Return(expectedError)
+
+	handler := command.NewDisableShortCodeHandler(newPassthroughTx(ctrl), mockRepo, mockOutbox, mocks.NewMockAuditRecorder(ctrl))
+
+	err := handler.Handle(ctx, command.DisableShortCodeCommand{ShortCode: "abc123"})
+
+	assert.ErrorIs(t, err, expectedError)
+}
+
+func TestDisableShortCodeHandler_Handle_EmptyShortCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := command.NewDisableShortCodeHandler(mocks.NewMockTransactionManager(ctrl), mocks.NewMockURLRepository(ctrl), mocks.NewMockOutboxRepository(ctrl), mocks.NewMockAuditRecorder(ctrl))
+
+	err := handler.Handle(context.Background(), command.DisableShortCodeCommand{})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidShortCode)
+}
@@ -0,0 +1,140 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	"github.com/brunoibarbosa/url-shortener/internal/validation"
+)
+
+type ReencryptURLsCommand struct {
+	// After continues the scan after this short code; empty starts from the beginning.
+	After     string
+	BatchSize int
+	DryRun    bool
+}
+
+type ReencryptURLsResult struct {
+	Reencrypted int
+	Current     int
+	// Conflicts counts rows changed concurrently; a later run picks them up.
+	Conflicts int
+	// Failed lists short codes that could not be decrypted to a valid URL.
+	Failed []string
+	// LastShortCode is the scan position to pass as After for the next batch.
+	LastShortCode string
+	Done          bool
+}
+
+// ReencryptURLsHandler moves stored URLs to the encrypter's active key, one
+// batch per call. Rows already on the active key are skipped without being
+// decrypted, so an interrupted run can resume from its last position or
+// simply start over.
+type ReencryptURLsHandler struct {
+	repo      domain.URLReencryptionRepository
+	cacheRepo domain.URLCacheRepository
+	encrypter domain.URLRotatingEncrypter
+}
+
+func NewReencryptURLsHandler(
+	repo domain.URLReencryptionRepository,
+	cacheRepo domain.URLCacheRepository,
+	encrypter domain.URLRotatingEncrypter,
+) *ReencryptURLsHandler {
+	return &ReencryptURLsHandler{
+		repo:      repo,
+		cacheRepo: cacheRepo,
+		encrypter: encrypter,
+	}
+}
+
+func (h *ReencryptURLsHandler) Handle(ctx context.Context, cmd ReencryptURLsCommand) (ReencryptURLsResult, error) {
+	result := ReencryptURLsResult{LastShortCode: cmd.After}
+
+	batch, err := h.repo.ListEncrypted(ctx, cmd.After, cmd.BatchSize)
+	if err != nil {
+		return result, err
+	}
+
+	for _, u := range batch {
+		if err := h.reencrypt(ctx, u, cmd.DryRun, &result); err != nil {
+			return result, err
+		}
+		result.LastShortCode = u.ShortCode
+	}
+
+	result.Done = len(batch) < cmd.BatchSize
+	return result, nil
+}
+
+// Run calls Handle until every row has been visited, passing each batch
+// result to progress and sleeping pause between batches. It returns the
+// totals; on error they cover the batches completed so far.
+func (h *ReencryptURLsHandler) Run(ctx context.Context, cmd ReencryptURLsCommand, pause time.Duration, progress func(ReencryptURLsResult)) (ReencryptURLsResult, error) {
+	total := ReencryptURLsResult{LastShortCode: cmd.After}
+
+	for {
+		batch, err := h.Handle(ctx, cmd)
+		if err != nil {
+			return total, err
+		}
+
+		total.Reencrypted += batch.Reencrypted
+		total.Current += batch.Current
+		total.Conflicts += batch.Conflicts
+		total.Failed = append(total.Failed, batch.Failed...)
+		total.LastShortCode = batch.LastShortCode
+		total.Done = batch.Done
+
+		if progress != nil {
+			progress(batch)
+		}
+		if batch.Done {
+			return total, nil
+		}
+		cmd.After = batch.LastShortCode
+
+		select {
+		case <-ctx.Done():
+			return total, ctx.Err()
+		case <-time.After(pause):
+		}
+	}
+}
+
+func (h *ReencryptURLsHandler) reencrypt(ctx context.Context, u *domain.URL, dryRun bool, result *ReencryptURLsResult) error {
+	if !h.encrypter.NeedsReencryption(u.EncryptedURL) {
+		result.Current++
+		return nil
+	}
+
+	original, err := h.encrypter.Decrypt(ctx, u.EncryptedURL)
+	if err != nil || validation.ValidateURL(original) != nil {
+		result.Failed = append(result.Failed, u.ShortCode)
+		return nil
+	}
+
+	if dryRun {
+		result.Reencrypted++
+		return nil
+	}
+
+	encrypted, err := h.encrypter.Encrypt(ctx, original)
+	if err != nil {
+		return err
+	}
+
+	updated, err := h.repo.UpdateEncryptedURL(ctx, u.ShortCode, u.EncryptedURL, encrypted)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		result.Conflicts++
+		return nil
+	}
+
+	_ = h.cacheRepo.Delete(ctx, u.ShortCode)
+	result.Reencrypted++
+	return nil
+}
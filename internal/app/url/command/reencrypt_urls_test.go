@@ -0,0 +1,199 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/url/command"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReencryptURLsHandler_Handle_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockRepo := mocks.NewMockURLReencryptionRepository(ctrl)
+	mockCache := mocks.NewMockURLCacheRepository(ctrl)
+	mockEncrypter := mocks.NewMockURLRotatingEncrypter(ctrl)
+
+	mockRepo.EXPECT().ListEncrypted(ctx, "", 3).Return([]*domain.URL{
+		{ShortCode: "aaa", EncryptedURL: "k1:a"},
+		{ShortCode: "bbb", EncryptedURL: "k2:b"},
+		{ShortCode: "ccc", EncryptedURL: "k1:c"},
+	}, nil)
+
+	mockEncrypter.EXPECT().NeedsReencryption("k1:a").Return(true)
+	mockEncrypter.EXPECT().NeedsReencryption("k2:b").Return(false)
+	mockEncrypter.EXPECT().NeedsReencryption("k1:c").Return(true)
+
+	mockEncrypter.EXPECT().Decrypt(ctx, "k1:a").Return("https://a.example.com", nil)
+	mockEncrypter.EXPECT().Decrypt(ctx, "k1:c").Return("", errors.New("authentication failed"))
+	mockEncrypter.EXPECT().Encrypt(ctx, "https://a.example.com").Return("k2:a", nil)
+
+	mockRepo.EXPECT().UpdateEncryptedURL(ctx, "aaa", "k1:a", "k2:a").Return(true, nil)
+	mockCache.EXPECT().Delete(ctx, "aaa").Return(nil)
+
+	handler := command.NewReencryptURLsHandler(mockRepo, mockCache, mockEncrypter)
+
+	result, err := handler.Handle(ctx, command.ReencryptURLsCommand{BatchSize: 3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Reencrypted)
+	assert.Equal(t, 1, result.Current)
+	assert.Equal(t, []string{"ccc"}, result.Failed)
+	assert.Equal(t, "ccc", result.LastShortCode)
+	assert.False(t, result.Done, "a full batch may be followed by more rows")
+}
+
+func TestReencryptURLsHandler_Handle_ResumesAfterCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockRepo := mocks.NewMockURLReencryptionRepository(ctrl)
+	mockCache := mocks.NewMockURLCacheRepository(ctrl)
+	mockEncrypter := mocks.NewMockURLRotatingEncrypter(ctrl)
+
+	mockRepo.EXPECT().ListEncrypted(ctx, "mmm", 10).Return([]*domain.URL{{ShortCode: "nnn", EncryptedURL: "k2:n"}}, nil)
+	mockEncrypter.EXPECT().NeedsReencryption("k2:n").Return(false)
+
+	handler := command.NewReencryptURLsHandler(mockRepo, mockCache, mockEncrypter)
+
+	result, err := handler.Handle(ctx, command.ReencryptURLsCommand{After: "mmm", BatchSize: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "nnn", result.LastShortCode)
+	assert.True(t, result.Done)
+}
+
+func TestReencryptURLsHandler_Handle_ConcurrentUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockRepo := mocks.NewMockURLReencryptionRepository(ctrl)
+	mockCache := mocks.NewMockURLCacheRepository(ctrl)
+	mockEncrypter := mocks.NewMockURLRotatingEncrypter(ctrl)
+
+	mockRepo.EXPECT().ListEncrypted(ctx, "", 10).Return([]*domain.URL{{ShortCode: "aaa", EncryptedURL: "k1:a"}}, nil)
+	mockEncrypter.EXPECT().NeedsReencryption("k1:a").Return(true)
+	mockEncrypter.EXPECT().Decrypt(ctx, "k1:a").Return("https://a.example.com", nil)
+	mockEncrypter.EXPECT().Encrypt(ctx, "https://a.example.com").Return("k2:a", nil)
+	mockRepo.EXPECT().UpdateEncryptedURL(ctx, "aaa", "k1:a", "k2:a").Return(false, nil)
+
+	handler := command.NewReencryptURLsHandler(mockRepo, mockCache, mockEncrypter)
+
+	result, err := handler.Handle(ctx, command.ReencryptURLsCommand{BatchSize: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Reencrypted)
+	assert.Equal(t, 1, result.Conflicts)
+}
+
+func TestReencryptURLsHandler_Handle_DryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockRepo := mocks.NewMockURLReencryptionRepository(ctrl)
+	mockCache := mocks.NewMockURLCacheRepository(ctrl)
+	mockEncrypter := mocks.NewMockURLRotatingEncrypter(ctrl)
+
+	mockRepo.EXPECT().ListEncrypted(ctx, "", 10).Return([]*domain.URL{{ShortCode: "aaa", EncryptedURL: "legacy"}}, nil)
+	mockEncrypter.EXPECT().NeedsReencryption("legacy").Return(true)
+	mockEncrypter.EXPECT().Decrypt(ctx, "legacy").Return("https://a.example.com", nil)
+
+	handler := command.NewReencryptURLsHandler(mockRepo, mockCache, mockEncrypter)
+
+	result, err := handler.Handle(ctx, command.ReencryptURLsCommand{BatchSize: 10, DryRun: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Reencrypted)
+	assert.Empty(t, result.Failed)
+}
+
+func TestReencryptURLsHandler_Handle_ListError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	expectedError := errors.New("database error")
+
+	mockRepo := mocks.NewMockURLReencryptionRepository(ctrl)
+	mockRepo.EXPECT().ListEncrypted(ctx, "", 10).Return(nil, expectedError)
+
+	handler := command.NewReencryptURLsHandler(mockRepo, mocks.NewMockURLCacheRepository(ctrl), mocks.NewMockURLRotatingEncrypter(ctrl))
+
+	_, err := handler.Handle(ctx, command.ReencryptURLsCommand{BatchSize: 10})
+
+	assert.Equal(t, expectedError, err)
+}
+
+func TestReencryptURLsHandler_Run_VisitsAllBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockRepo := mocks.NewMockURLReencryptionRepository(ctrl)
+	mockCache := mocks.NewMockURLCacheRepository(ctrl)
+	mockEncrypter := mocks.NewMockURLRotatingEncrypter(ctrl)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().ListEncrypted(ctx, "", 1).Return([]*domain.URL{{ShortCode: "aaa", EncryptedURL: "k1:a"}}, nil),
+		mockRepo.EXPECT().ListEncrypted(ctx, "aaa", 1).Return([]*domain.URL{{ShortCode: "bbb", EncryptedURL: "k2:b"}}, nil),
+		mockRepo.EXPECT().ListEncrypted(ctx, "bbb", 1).Return(nil, nil),
+	)
+	mockEncrypter.EXPECT().NeedsReencryption("k1:a").Return(true)
+	mockEncrypter.EXPECT().NeedsReencryption("k2:b").Return(false)
+	mockEncrypter.EXPECT().Decrypt(ctx, "k1:a").Return("https://a.example.com", nil)
+	mockEncrypter.EXPECT().Encrypt(ctx, "https://a.example.com").Return("k2:a", nil)
+	mockRepo.EXPECT().UpdateEncryptedURL(ctx, "aaa", "k1:a", "k2:a").Return(true, nil)
+	mockCache.EXPECT().Delete(ctx, "aaa").Return(nil)
+
+	handler := command.NewReencryptURLsHandler(mockRepo, mockCache, mockEncrypter)
+
+	batches := 0
+	total, err := handler.Run(ctx, command.ReencryptURLsCommand{BatchSize: 1}, 0, func(command.ReencryptURLsResult) {
+		batches++
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, batches)
+	assert.Equal(t, 1, total.Reencrypted)
+	assert.Equal(t, 1, total.Current)
+	assert.Equal(t, "bbb", total.LastShortCode)
+	assert.True(t, total.Done)
+}
+
+func TestReencryptURLsHandler_Run_StopsOnCancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mockRepo := mocks.NewMockURLReencryptionRepository(ctrl)
+	mockEncrypter := mocks.NewMockURLRotatingEncrypter(ctrl)
+
+	mockRepo.EXPECT().ListEncrypted(ctx, "", 1).Return([]*domain.URL{{ShortCode: "aaa", EncryptedURL: "k2:a"}}, nil)
+	mockEncrypter.EXPECT().NeedsReencryption("k2:a").Return(false)
+
+	handler := command.NewReencryptURLsHandler(mockRepo, mocks.NewMockURLCacheRepository(ctrl), mockEncrypter)
+
+	total, err := handler.Run(ctx, command.ReencryptURLsCommand{BatchSize: 1}, time.Hour, func(command.ReencryptURLsResult) {
+		cancel()
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, "aaa", total.LastShortCode)
+}
@@ -5,63 +5,127 @@ import (
 	"time"
 
 	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	webhook_domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
 	"github.com/brunoibarbosa/url-shortener/pkg/util"
+	"golang.org/x/sync/singleflight"
 )
 
 type GetOriginalURLQuery struct {
 	ShortCode string
+	// Path and RawQuery are the trailing path and query string of the
+	// request, forwarded when the URL allows it.
+	Path      string
+	RawQuery  string
+	Referer   string
+	UserAgent string
+}
+
+type GetOriginalURLResult struct {
+	Location string
+	Status   int
 }
 
 type GetOriginalURLHandler struct {
-	persistRepo             domain.URLRepository
-	cacheRepo               domain.URLCacheRepository
-	encrypter               domain.URLEncrypter
-	cacheExpirationDuration time.Duration
+	persistRepo                  domain.URLRepository
+	cacheRepo                    domain.URLCacheRepository
+	localCache                   domain.URLLocalCache
+	encrypter                    domain.URLEncrypter
+	metrics                      domain.URLMetrics
+	publisher                    webhook_domain.WebhookPublisher
+	cacheExpirationDuration      time.Duration
+	localCacheExpirationDuration time.Duration
+
+	// lookups coalesces concurrent misses for the same short code.
+	lookups singleflight.Group
 }
 
 func NewGetOriginalURLHandler(
 	repo domain.URLRepository,
 	cache domain.URLCacheRepository,
+	localCache domain.URLLocalCache,
 	encrypter domain.URLEncrypter,
+	metrics domain.URLMetrics,
+	publisher webhook_domain.WebhookPublisher,
 	cacheExpirationDuration time.Duration,
+	localCacheExpirationDuration time.Duration,
 ) *GetOriginalURLHandler {
 	return &GetOriginalURLHandler{
-		persistRepo:             repo,
-		cacheRepo:               cache,
-		encrypter:               encrypter,
-		cacheExpirationDuration: cacheExpirationDuration,
+		persistRepo:                  repo,
+		cacheRepo:                    cache,
+		localCache:                   localCache,
+		encrypter:                    encrypter,
+		metrics:                      metrics,
+		publisher:                    publisher,
+		cacheExpirationDuration:      cacheExpirationDuration,
+		localCacheExpirationDuration: localCacheExpirationDuration,
+	}
+}
+
+func (h *GetOriginalURLHandler) Handle(ctx context.Context, query GetOriginalURLQuery) (GetOriginalURLResult, error) {
+	redirect, err := h.resolve(ctx, query.ShortCode)
+	if err != nil {
+		return GetOriginalURLResult{}, err
+	}
+
+	location, err := redirect.Location(query.Path, query.RawQuery)
+	if err != nil {
+		return GetOriginalURLResult{}, err
+	}
+
+	_ = h.publisher.PublishToOwner(ctx, query.ShortCode, webhook_domain.NewEvent(webhook_domain.EventURLClicked, webhook_domain.ClickEventData{
+		ShortCode: query.ShortCode,
+		Referer:   query.Referer,
+		UserAgent: query.UserAgent,
+	}))
+
+	return GetOriginalURLResult{Location: location, Status: redirect.RedirectType.Status()}, nil
+}
+
+func (h *GetOriginalURLHandler) resolve(ctx context.Context, shortCode string) (domain.Redirect, error) {
+	if redirect, ok := h.localCache.Get(shortCode); ok {
+		h.metrics.LocalCacheHit()
+		return redirect, nil
 	}
+	h.metrics.LocalCacheMiss()
+
+	v, err, _ := h.lookups.Do(shortCode, func() (any, error) {
+		// Detached from the caller: other requests may be waiting on this lookup.
+		return h.lookup(context.WithoutCancel(ctx), shortCode)
+	})
+	if err != nil {
+		return domain.Redirect{}, err
+	}
+
+	return v.(domain.Redirect), nil
 }
 
-func (h *GetOriginalURLHandler) Handle(ctx context.Context, query GetOriginalURLQuery) (string, error) {
-	cachedUrl, err := h.cacheRepo.FindByShortCode(ctx, query.ShortCode)
+func (h *GetOriginalURLHandler) lookup(ctx context.Context, shortCode string) (domain.Redirect, error) {
+	cachedUrl, err := h.cacheRepo.FindByShortCode(ctx, shortCode)
 	if err != nil {
-		return "", err
+		return domain.Redirect{}, err
 	}
 	if cachedUrl != nil {
+		h.metrics.CacheHit()
 		if err := cachedUrl.CanBeAccessed(time.Now().UTC()); err != nil {
-			return "", err
+			return domain.Redirect{}, err
 		}
 
-		decryptedUrl, err := h.encrypter.Decrypt(cachedUrl.EncryptedURL)
-		if err != nil {
-			return "", err
-		}
-		return decryptedUrl, nil
+		return h.decrypt(ctx, cachedUrl)
 	}
+	h.metrics.CacheMiss()
 
-	url, err := h.persistRepo.FindByShortCode(ctx, query.ShortCode)
+	url, err := h.persistRepo.FindByShortCode(ctx, shortCode)
 
 	if err != nil {
-		return "", err
+		return domain.Redirect{}, err
 	}
 
 	if url == nil {
-		return "", domain.ErrURLNotFound
+		return domain.Redirect{}, domain.ErrURLNotFound
 	}
 
 	if err := url.CanBeAccessed(time.Now().UTC()); err != nil {
-		return "", err
+		return domain.Redirect{}, err
 	}
 
 	cacheDuration := h.cacheExpirationDuration
@@ -71,9 +135,23 @@ func (h *GetOriginalURLHandler) Handle(ctx context.Context, query GetOriginalURL
 	}
 	_ = h.cacheRepo.Save(ctx, url, cacheDuration)
 
-	decryptedUrl, err := h.encrypter.Decrypt(url.EncryptedURL)
+	return h.decrypt(ctx, url)
+}
+
+// decrypt returns the redirect of url and keeps it in the local cache,
+// never past the expiration of the URL when it is known.
+func (h *GetOriginalURLHandler) decrypt(ctx context.Context, url *domain.URL) (domain.Redirect, error) {
+	decryptedUrl, err := h.encrypter.Decrypt(ctx, url.EncryptedURL)
 	if err != nil {
-		return "", err
+		return domain.Redirect{}, err
+	}
+	redirect := domain.Redirect{Destination: decryptedUrl, RedirectOptions: url.RedirectOptions}
+
+	localCacheDuration := h.localCacheExpirationDuration
+	if url.ExpiresAt != nil {
+		localCacheDuration = util.MinTimeDuration(url.RemainingTTL(time.Now().UTC()), localCacheDuration)
 	}
-	return decryptedUrl, nil
+	h.localCache.Set(url.ShortCode, redirect, localCacheDuration)
+
+	return redirect, nil
 }
@@ -3,11 +3,13 @@ package query_test
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/brunoibarbosa/url-shortener/internal/app/url/query"
 	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	webhook_domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
 	"github.com/brunoibarbosa/url-shortener/internal/mocks"
 
 	"github.com/stretchr/testify/assert"
@@ -22,6 +24,12 @@ func TestGetOriginalURLHandler_Handle_CacheHit(t *testing.T) {
 	mockPersistRepo := mocks.NewMockURLRepository(ctrl)
 	mockCacheRepo := mocks.NewMockURLCacheRepository(ctrl)
 	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().LocalCacheMiss()
+	mockLocalCache := mocks.NewMockURLLocalCache(ctrl)
+	mockLocalCache.EXPECT().Get(gomock.Any()).Return(domain.Redirect{}, false)
+	mockLocalCache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().CacheHit().Times(1)
 
 	shortCode := "abc123"
 	encryptedURL := "encrypted_original_url"
@@ -34,21 +42,25 @@ func TestGetOriginalURLHandler_Handle_CacheHit(t *testing.T) {
 		DeletedAt:    nil,
 	}
 
-	mockCacheRepo.EXPECT().FindByShortCode(ctx, shortCode).Return(cachedURL, nil)
-	mockEncrypter.EXPECT().Decrypt(encryptedURL).Return(originalURL, nil)
+	mockCacheRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(cachedURL, nil)
+	mockEncrypter.EXPECT().Decrypt(gomock.Any(), encryptedURL).Return(originalURL, nil)
 
 	handler := query.NewGetOriginalURLHandler(
 		mockPersistRepo,
 		mockCacheRepo,
+		mockLocalCache,
 		mockEncrypter,
+		mockMetrics,
+		newNoopPublisher(ctrl),
 		1*time.Hour,
+		time.Minute,
 	)
 
 	q := query.GetOriginalURLQuery{ShortCode: shortCode}
 	result, err := handler.Handle(ctx, q)
 
 	assert.NoError(t, err)
-	assert.Equal(t, originalURL, result)
+	assert.Equal(t, originalURL, result.Location)
 }
 
 func TestGetOriginalURLHandler_Handle_CacheMiss(t *testing.T) {
@@ -59,6 +71,12 @@ func TestGetOriginalURLHandler_Handle_CacheMiss(t *testing.T) {
 	mockPersistRepo := mocks.NewMockURLRepository(ctrl)
 	mockCacheRepo := mocks.NewMockURLCacheRepository(ctrl)
 	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().LocalCacheMiss()
+	mockLocalCache := mocks.NewMockURLLocalCache(ctrl)
+	mockLocalCache.EXPECT().Get(gomock.Any()).Return(domain.Redirect{}, false)
+	mockLocalCache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().CacheMiss().Times(1)
 
 	shortCode := "xyz789"
 	encryptedURL := "encrypted_url"
@@ -71,23 +89,27 @@ func TestGetOriginalURLHandler_Handle_CacheMiss(t *testing.T) {
 		DeletedAt:    nil,
 	}
 
-	mockCacheRepo.EXPECT().FindByShortCode(ctx, shortCode).Return(nil, nil)
-	mockPersistRepo.EXPECT().FindByShortCode(ctx, shortCode).Return(url, nil)
-	mockCacheRepo.EXPECT().Save(ctx, url, 1*time.Hour).Return(nil)
-	mockEncrypter.EXPECT().Decrypt(encryptedURL).Return(originalURL, nil)
+	mockCacheRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(nil, nil)
+	mockPersistRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(url, nil)
+	mockCacheRepo.EXPECT().Save(gomock.Any(), url, 1*time.Hour).Return(nil)
+	mockEncrypter.EXPECT().Decrypt(gomock.Any(), encryptedURL).Return(originalURL, nil)
 
 	handler := query.NewGetOriginalURLHandler(
 		mockPersistRepo,
 		mockCacheRepo,
+		mockLocalCache,
 		mockEncrypter,
+		mockMetrics,
+		newNoopPublisher(ctrl),
 		1*time.Hour,
+		time.Minute,
 	)
 
 	q := query.GetOriginalURLQuery{ShortCode: shortCode}
 	result, err := handler.Handle(ctx, q)
 
 	assert.NoError(t, err)
-	assert.Equal(t, originalURL, result)
+	assert.Equal(t, originalURL, result.Location)
 }
 
 func TestGetOriginalURLHandler_Handle_URLNotFound(t *testing.T) {
@@ -98,17 +120,28 @@ func TestGetOriginalURLHandler_Handle_URLNotFound(t *testing.T) {
 	mockPersistRepo := mocks.NewMockURLRepository(ctrl)
 	mockCacheRepo := mocks.NewMockURLCacheRepository(ctrl)
 	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().LocalCacheMiss()
+	mockLocalCache := mocks.NewMockURLLocalCache(ctrl)
+	mockLocalCache.EXPECT().Get(gomock.Any()).Return(domain.Redirect{}, false)
+	mockLocalCache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().CacheHit().AnyTimes()
+	mockMetrics.EXPECT().CacheMiss().AnyTimes()
 
 	shortCode := "notfound"
 
-	mockCacheRepo.EXPECT().FindByShortCode(ctx, shortCode).Return(nil, nil)
-	mockPersistRepo.EXPECT().FindByShortCode(ctx, shortCode).Return(nil, nil)
+	mockCacheRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(nil, nil)
+	mockPersistRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(nil, nil)
 
 	handler := query.NewGetOriginalURLHandler(
 		mockPersistRepo,
 		mockCacheRepo,
+		mockLocalCache,
 		mockEncrypter,
+		mockMetrics,
+		newNoopPublisher(ctrl),
 		1*time.Hour,
+		time.Minute,
 	)
 
 	q := query.GetOriginalURLQuery{ShortCode: shortCode}
@@ -127,6 +160,13 @@ func TestGetOriginalURLHandler_Handle_ExpiredURL_Cache(t *testing.T) {
 	mockPersistRepo := mocks.NewMockURLRepository(ctrl)
 	mockCacheRepo := mocks.NewMockURLCacheRepository(ctrl)
 	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().LocalCacheMiss()
+	mockLocalCache := mocks.NewMockURLLocalCache(ctrl)
+	mockLocalCache.EXPECT().Get(gomock.Any()).Return(domain.Redirect{}, false)
+	mockLocalCache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().CacheHit().AnyTimes()
+	mockMetrics.EXPECT().CacheMiss().AnyTimes()
 
 	shortCode := "expired"
 	expiresAt := time.Now().Add(-1 * time.Hour)
@@ -138,13 +178,17 @@ func TestGetOriginalURLHandler_Handle_ExpiredURL_Cache(t *testing.T) {
 		DeletedAt:    nil,
 	}
 
-	mockCacheRepo.EXPECT().FindByShortCode(ctx, shortCode).Return(cachedURL, nil)
+	mockCacheRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(cachedURL, nil)
 
 	handler := query.NewGetOriginalURLHandler(
 		mockPersistRepo,
 		mockCacheRepo,
+		mockLocalCache,
 		mockEncrypter,
+		mockMetrics,
+		newNoopPublisher(ctrl),
 		1*time.Hour,
+		time.Minute,
 	)
 
 	q := query.GetOriginalURLQuery{ShortCode: shortCode}
@@ -163,6 +207,13 @@ func TestGetOriginalURLHandler_Handle_DeletedURL_Cache(t *testing.T) {
 	mockPersistRepo := mocks.NewMockURLRepository(ctrl)
 	mockCacheRepo := mocks.NewMockURLCacheRepository(ctrl)
 	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().LocalCacheMiss()
+	mockLocalCache := mocks.NewMockURLLocalCache(ctrl)
+	mockLocalCache.EXPECT().Get(gomock.Any()).Return(domain.Redirect{}, false)
+	mockLocalCache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().CacheHit().AnyTimes()
+	mockMetrics.EXPECT().CacheMiss().AnyTimes()
 
 	shortCode := "deleted"
 	deletedAt := time.Now()
@@ -174,13 +225,17 @@ func TestGetOriginalURLHandler_Handle_DeletedURL_Cache(t *testing.T) {
 		DeletedAt:    &deletedAt,
 	}
 
-	mockCacheRepo.EXPECT().FindByShortCode(ctx, shortCode).Return(cachedURL, nil)
+	mockCacheRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(cachedURL, nil)
 
 	handler := query.NewGetOriginalURLHandler(
 		mockPersistRepo,
 		mockCacheRepo,
+		mockLocalCache,
 		mockEncrypter,
+		mockMetrics,
+		newNoopPublisher(ctrl),
 		1*time.Hour,
+		time.Minute,
 	)
 
 	q := query.GetOriginalURLQuery{ShortCode: shortCode}
@@ -199,6 +254,13 @@ func TestGetOriginalURLHandler_Handle_ExpiredURL_Persist(t *testing.T) {
 	mockPersistRepo := mocks.NewMockURLRepository(ctrl)
 	mockCacheRepo := mocks.NewMockURLCacheRepository(ctrl)
 	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().LocalCacheMiss()
+	mockLocalCache := mocks.NewMockURLLocalCache(ctrl)
+	mockLocalCache.EXPECT().Get(gomock.Any()).Return(domain.Redirect{}, false)
+	mockLocalCache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().CacheHit().AnyTimes()
+	mockMetrics.EXPECT().CacheMiss().AnyTimes()
 
 	shortCode := "expired"
 	expiresAt := time.Now().Add(-1 * time.Hour)
@@ -210,14 +272,18 @@ func TestGetOriginalURLHandler_Handle_ExpiredURL_Persist(t *testing.T) {
 		DeletedAt:    nil,
 	}
 
-	mockCacheRepo.EXPECT().FindByShortCode(ctx, shortCode).Return(nil, nil)
-	mockPersistRepo.EXPECT().FindByShortCode(ctx, shortCode).Return(url, nil)
+	mockCacheRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(nil, nil)
+	mockPersistRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(url, nil)
 
 	handler := query.NewGetOriginalURLHandler(
 		mockPersistRepo,
 		mockCacheRepo,
+		mockLocalCache,
 		mockEncrypter,
+		mockMetrics,
+		newNoopPublisher(ctrl),
 		1*time.Hour,
+		time.Minute,
 	)
 
 	q := query.GetOriginalURLQuery{ShortCode: shortCode}
@@ -236,6 +302,13 @@ func TestGetOriginalURLHandler_Handle_CacheWithExpiringURL(t *testing.T) {
 	mockPersistRepo := mocks.NewMockURLRepository(ctrl)
 	mockCacheRepo := mocks.NewMockURLCacheRepository(ctrl)
 	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().LocalCacheMiss()
+	mockLocalCache := mocks.NewMockURLLocalCache(ctrl)
+	mockLocalCache.EXPECT().Get(gomock.Any()).Return(domain.Redirect{}, false)
+	mockLocalCache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().CacheHit().AnyTimes()
+	mockMetrics.EXPECT().CacheMiss().AnyTimes()
 
 	shortCode := "expiring"
 	encryptedURL := "encrypted"
@@ -249,24 +322,28 @@ func TestGetOriginalURLHandler_Handle_CacheWithExpiringURL(t *testing.T) {
 		DeletedAt:    nil,
 	}
 
-	mockCacheRepo.EXPECT().FindByShortCode(ctx, shortCode).Return(nil, nil)
-	mockPersistRepo.EXPECT().FindByShortCode(ctx, shortCode).Return(url, nil)
+	mockCacheRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(nil, nil)
+	mockPersistRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(url, nil)
 	// Should cache for 30 minutes (remaining TTL) instead of 1 hour
-	mockCacheRepo.EXPECT().Save(ctx, url, gomock.Any()).Return(nil)
-	mockEncrypter.EXPECT().Decrypt(encryptedURL).Return(originalURL, nil)
+	mockCacheRepo.EXPECT().Save(gomock.Any(), url, gomock.Any()).Return(nil)
+	mockEncrypter.EXPECT().Decrypt(gomock.Any(), encryptedURL).Return(originalURL, nil)
 
 	handler := query.NewGetOriginalURLHandler(
 		mockPersistRepo,
 		mockCacheRepo,
+		mockLocalCache,
 		mockEncrypter,
+		mockMetrics,
+		newNoopPublisher(ctrl),
 		1*time.Hour,
+		time.Minute,
 	)
 
 	q := query.GetOriginalURLQuery{ShortCode: shortCode}
 	result, err := handler.Handle(ctx, q)
 
 	assert.NoError(t, err)
-	assert.Equal(t, originalURL, result)
+	assert.Equal(t, originalURL, result.Location)
 }
 
 func TestGetOriginalURLHandler_Handle_CacheError(t *testing.T) {
@@ -277,16 +354,27 @@ func TestGetOriginalURLHandler_Handle_CacheError(t *testing.T) {
 	mockPersistRepo := mocks.NewMockURLRepository(ctrl)
 	mockCacheRepo := mocks.NewMockURLCacheRepository(ctrl)
 	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().LocalCacheMiss()
+	mockLocalCache := mocks.NewMockURLLocalCache(ctrl)
+	mockLocalCache.EXPECT().Get(gomock.Any()).Return(domain.Redirect{}, false)
+	mockLocalCache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().CacheHit().AnyTimes()
+	mockMetrics.EXPECT().CacheMiss().AnyTimes()
 
 	shortCode := "cache_error"
 
-	mockCacheRepo.EXPECT().FindByShortCode(ctx, shortCode).Return(nil, errors.New("cache error"))
+	mockCacheRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(nil, errors.New("cache error"))
 
 	handler := query.NewGetOriginalURLHandler(
 		mockPersistRepo,
 		mockCacheRepo,
+		mockLocalCache,
 		mockEncrypter,
+		mockMetrics,
+		newNoopPublisher(ctrl),
 		1*time.Hour,
+		time.Minute,
 	)
 
 	q := query.GetOriginalURLQuery{ShortCode: shortCode}
@@ -305,17 +393,28 @@ func TestGetOriginalURLHandler_Handle_PersistError(t *testing.T) {
 	mockPersistRepo := mocks.NewMockURLRepository(ctrl)
 	mockCacheRepo := mocks.NewMockURLCacheRepository(ctrl)
 	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().LocalCacheMiss()
+	mockLocalCache := mocks.NewMockURLLocalCache(ctrl)
+	mockLocalCache.EXPECT().Get(gomock.Any()).Return(domain.Redirect{}, false)
+	mockLocalCache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().CacheHit().AnyTimes()
+	mockMetrics.EXPECT().CacheMiss().AnyTimes()
 
 	shortCode := "persist_error"
 
-	mockCacheRepo.EXPECT().FindByShortCode(ctx, shortCode).Return(nil, nil)
-	mockPersistRepo.EXPECT().FindByShortCode(ctx, shortCode).Return(nil, errors.New("database error"))
+	mockCacheRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(nil, nil)
+	mockPersistRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(nil, errors.New("database error"))
 
 	handler := query.NewGetOriginalURLHandler(
 		mockPersistRepo,
 		mockCacheRepo,
+		mockLocalCache,
 		mockEncrypter,
+		mockMetrics,
+		newNoopPublisher(ctrl),
 		1*time.Hour,
+		time.Minute,
 	)
 
 	q := query.GetOriginalURLQuery{ShortCode: shortCode}
@@ -334,6 +433,13 @@ func TestGetOriginalURLHandler_Handle_DecryptError_Cache(t *testing.T) {
 	mockPersistRepo := mocks.NewMockURLRepository(ctrl)
 	mockCacheRepo := mocks.NewMockURLCacheRepository(ctrl)
 	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().LocalCacheMiss()
+	mockLocalCache := mocks.NewMockURLLocalCache(ctrl)
+	mockLocalCache.EXPECT().Get(gomock.Any()).Return(domain.Redirect{}, false)
+	mockLocalCache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().CacheHit().AnyTimes()
+	mockMetrics.EXPECT().CacheMiss().AnyTimes()
 
 	shortCode := "decrypt_error"
 	encryptedURL := "corrupted_encrypted"
@@ -345,14 +451,18 @@ func TestGetOriginalURLHandler_Handle_DecryptError_Cache(t *testing.T) {
 		DeletedAt:    nil,
 	}
 
-	mockCacheRepo.EXPECT().FindByShortCode(ctx, shortCode).Return(cachedURL, nil)
-	mockEncrypter.EXPECT().Decrypt(encryptedURL).Return("", errors.New("decryption failed"))
+	mockCacheRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(cachedURL, nil)
+	mockEncrypter.EXPECT().Decrypt(gomock.Any(), encryptedURL).Return("", errors.New("decryption failed"))
 
 	handler := query.NewGetOriginalURLHandler(
 		mockPersistRepo,
 		mockCacheRepo,
+		mockLocalCache,
 		mockEncrypter,
+		mockMetrics,
+		newNoopPublisher(ctrl),
 		1*time.Hour,
+		time.Minute,
 	)
 
 	q := query.GetOriginalURLQuery{ShortCode: shortCode}
@@ -371,6 +481,13 @@ func TestGetOriginalURLHandler_Handle_DecryptError_Persist(t *testing.T) {
 	mockPersistRepo := mocks.NewMockURLRepository(ctrl)
 	mockCacheRepo := mocks.NewMockURLCacheRepository(ctrl)
 	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().LocalCacheMiss()
+	mockLocalCache := mocks.NewMockURLLocalCache(ctrl)
+	mockLocalCache.EXPECT().Get(gomock.Any()).Return(domain.Redirect{}, false)
+	mockLocalCache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().CacheHit().AnyTimes()
+	mockMetrics.EXPECT().CacheMiss().AnyTimes()
 
 	shortCode := "decrypt_error"
 	encryptedURL := "corrupted"
@@ -382,16 +499,20 @@ func TestGetOriginalURLHandler_Handle_DecryptError_Persist(t *testing.T) {
 		DeletedAt:    nil,
 	}
 
-	mockCacheRepo.EXPECT().FindByShortCode(ctx, shortCode).Return(nil, nil)
-	mockPersistRepo.EXPECT().FindByShortCode(ctx, shortCode).Return(url, nil)
-	mockCacheRepo.EXPECT().Save(ctx, url, 1*time.Hour).Return(nil)
-	mockEncrypter.EXPECT().Decrypt(encryptedURL).Return("", errors.New("decryption failed"))
+	mockCacheRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(nil, nil)
+	mockPersistRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(url, nil)
+	mockCacheRepo.EXPECT().Save(gomock.Any(), url, 1*time.Hour).Return(nil)
+	mockEncrypter.EXPECT().Decrypt(gomock.Any(), encryptedURL).Return("", errors.New("decryption failed"))
 
 	handler := query.NewGetOriginalURLHandler(
 		mockPersistRepo,
 		mockCacheRepo,
+		mockLocalCache,
 		mockEncrypter,
+		mockMetrics,
+		newNoopPublisher(ctrl),
 		1*time.Hour,
+		time.Minute,
 	)
 
 	q := query.GetOriginalURLQuery{ShortCode: shortCode}
@@ -401,3 +522,252 @@ func TestGetOriginalURLHandler_Handle_DecryptError_Persist(t *testing.T) {
 	assert.Equal(t, "decryption failed", err.Error())
 	assert.Empty(t, result)
 }
+
+func TestGetOriginalURLHandler_Handle_LocalCacheHit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().LocalCacheHit().Times(1)
+	mockLocalCache := mocks.NewMockURLLocalCache(ctrl)
+	mockLocalCache.EXPECT().Get("hot123").Return(domain.Redirect{Destination: "https://example.com"}, true)
+
+	handler := query.NewGetOriginalURLHandler(
+		mocks.NewMockURLRepository(ctrl),
+		mocks.NewMockURLCacheRepository(ctrl),
+		mockLocalCache,
+		mocks.NewMockURLEncrypter(ctrl),
+		mockMetrics,
+		newNoopPublisher(ctrl),
+		1*time.Hour,
+		time.Minute,
+	)
+
+	result, err := handler.Handle(ctx, query.GetOriginalURLQuery{ShortCode: "hot123"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com", result.Location)
+}
+
+func TestGetOriginalURLHandler_Handle_StoresInLocalCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	shortCode := "expiring"
+	expiresAt := time.Now().Add(30 * time.Second)
+	url := &domain.URL{
+		ShortCode:    shortCode,
+		EncryptedURL: "encrypted",
+		ExpiresAt:    &expiresAt,
+	}
+
+	mockPersistRepo := mocks.NewMockURLRepository(ctrl)
+	mockCacheRepo := mocks.NewMockURLCacheRepository(ctrl)
+	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().LocalCacheMiss()
+	mockMetrics.EXPECT().CacheMiss()
+	mockLocalCache := mocks.NewMockURLLocalCache(ctrl)
+	mockLocalCache.EXPECT().Get(shortCode).Return(domain.Redirect{}, false)
+
+	mockCacheRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(nil, nil)
+	mockPersistRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(url, nil)
+	mockCacheRepo.EXPECT().Save(gomock.Any(), url, gomock.Any()).Return(nil)
+	mockEncrypter.EXPECT().Decrypt(gomock.Any(), "encrypted").Return("https://example.com", nil)
+	// Never kept past the expiration of the URL
+	mockLocalCache.EXPECT().Set(shortCode, domain.Redirect{Destination: "https://example.com"}, gomock.Any()).Do(func(_ string, _ domain.Redirect, ttl time.Duration) {
+		assert.LessOrEqual(t, ttl, 30*time.Second)
+		assert.Positive(t, ttl)
+	})
+
+	handler := query.NewGetOriginalURLHandler(
+		mockPersistRepo,
+		mockCacheRepo,
+		mockLocalCache,
+		mockEncrypter,
+		mockMetrics,
+		newNoopPublisher(ctrl),
+		1*time.Hour,
+		time.Minute,
+	)
+
+	result, err := handler.Handle(ctx, query.GetOriginalURLQuery{ShortCode: shortCode})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com", result.Location)
+}
+
+func TestGetOriginalURLHandler_Handle_CoalescesConcurrentMisses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const callers = 20
+	ctx := context.Background()
+	shortCode := "viral"
+	url := &domain.URL{ShortCode: shortCode, EncryptedURL: "encrypted"}
+
+	var misses sync.WaitGroup
+	misses.Add(callers)
+
+	mockPersistRepo := mocks.NewMockURLRepository(ctrl)
+	mockCacheRepo := mocks.NewMockURLCacheRepository(ctrl)
+	mockEncrypter := mocks.NewMockURLEncrypter(ctrl)
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().LocalCacheMiss().Do(misses.Done).Times(callers)
+	mockMetrics.EXPECT().CacheMiss().Times(1)
+	mockLocalCache := mocks.NewMockURLLocalCache(ctrl)
+	mockLocalCache.EXPECT().Get(shortCode).Return(domain.Redirect{}, false).Times(callers)
+	mockLocalCache.EXPECT().Set(shortCode, domain.Redirect{Destination: "https://example.com"}, time.Minute).Times(1)
+
+	mockCacheRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).DoAndReturn(func(context.Context, string) (*domain.URL, error) {
+		// Hold the lookup until every caller has missed the local cache
+		misses.Wait()
+		time.Sleep(20 * time.Millisecond)
+		return nil, nil
+	}).Times(1)
+	mockPersistRepo.EXPECT().FindByShortCode(gomock.Any(), shortCode).Return(url, nil).Times(1)
+	mockCacheRepo.EXPECT().Save(gomock.Any(), url, 1*time.Hour).Return(nil).Times(1)
+	mockEncrypter.EXPECT().Decrypt(gomock.Any(), "encrypted").Return("https://example.com", nil).Times(1)
+
+	handler := query.NewGetOriginalURLHandler(
+		mockPersistRepo,
+		mockCacheRepo,
+		mockLocalCache,
+		mockEncrypter,
+		mockMetrics,
+		newNoopPublisher(ctrl),
+		1*time.Hour,
+		time.Minute,
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := handler.Handle(ctx, query.GetOriginalURLQuery{ShortCode: shortCode})
+			assert.NoError(t, err)
+			assert.Equal(t, "https://example.com", result.Location)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestGetOriginalURLHandler_Handle_PublishesClick(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().LocalCacheHit()
+	mockLocalCache := mocks.NewMockURLLocalCache(ctrl)
+	mockLocalCache.EXPECT().Get("abc123").Return(domain.Redirect{Destination: "https://example.com"}, true)
+	mockPublisher := mocks.NewMockWebhookPublisher(ctrl)
+	mockPublisher.EXPECT().PublishToOwner(ctx, "abc123", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, e webhook_domain.Event) error {
+		assert.Equal(t, webhook_domain.EventURLClicked, e.Type)
+		assert.Equal(t, webhook_domain.ClickEventData{
+			ShortCode: "abc123",
+			Referer:   "https://news.example.com",
+			UserAgent: "Mozilla/5.0",
+		}, e.Data)
+		return nil
+	})
+
+	handler := query.NewGetOriginalURLHandler(
+		mocks.NewMockURLRepository(ctrl),
+		mocks.NewMockURLCacheRepository(ctrl),
+		mockLocalCache,
+		mocks.NewMockURLEncrypter(ctrl),
+		mockMetrics,
+		mockPublisher,
+		time.Hour,
+		time.Minute,
+	)
+
+	result, err := handler.Handle(ctx, query.GetOriginalURLQuery{
+		ShortCode: "abc123",
+		Referer:   "https://news.example.com",
+		UserAgent: "Mozilla/5.0",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com", result.Location)
+}
+
+func TestGetOriginalURLHandler_Handle_AppliesRedirectOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().LocalCacheHit()
+	mockLocalCache := mocks.NewMockURLLocalCache(ctrl)
+	mockLocalCache.EXPECT().Get("abc123").Return(domain.Redirect{
+		Destination: "https://example.com/docs",
+		RedirectOptions: domain.RedirectOptions{
+			RedirectType: domain.RedirectPermanentRedirect,
+			ForwardQuery: true,
+			ForwardPath:  true,
+			UTM:          domain.UTMParams{Source: "short"},
+		},
+	}, true)
+
+	handler := query.NewGetOriginalURLHandler(
+		mocks.NewMockURLRepository(ctrl),
+		mocks.NewMockURLCacheRepository(ctrl),
+		mockLocalCache,
+		mocks.NewMockURLEncrypter(ctrl),
+		mockMetrics,
+		newNoopPublisher(ctrl),
+		time.Hour,
+		time.Minute,
+	)
+
+	result, err := handler.Handle(ctx, query.GetOriginalURLQuery{
+		ShortCode: "abc123",
+		Path:      "guides/start",
+		RawQuery:  "ref=home",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/docs/guides/start?ref=home&utm_source=short", result.Location)
+	assert.Equal(t, 308, result.Status)
+}
+
+func TestGetOriginalURLHandler_Handle_InvalidForwardPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockMetrics := mocks.NewMockURLMetrics(ctrl)
+	mockMetrics.EXPECT().LocalCacheHit()
+	mockLocalCache := mocks.NewMockURLLocalCache(ctrl)
+	mockLocalCache.EXPECT().Get("abc123").Return(domain.Redirect{
+		Destination:     "https://example.com/docs",
+		RedirectOptions: domain.RedirectOptions{ForwardPath: true},
+	}, true)
+
+	// No click is published for a request that is not redirected
+	handler := query.NewGetOriginalURLHandler(
+		mocks.NewMockURLRepository(ctrl),
+		mocks.NewMockURLCacheRepository(ctrl),
+		mockLocalCache,
+		mocks.NewMockURLEncrypter(ctrl),
+		mockMetrics,
+		mocks.NewMockWebhookPublisher(ctrl),
+		time.Hour,
+		time.Minute,
+	)
+
+	_, err := handler.Handle(ctx, query.GetOriginalURLQuery{ShortCode: "abc123", Path: "../admin"})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidForwardPath)
+}
+
+func newNoopPublisher(ctrl *gomock.Controller) *mocks.MockWebhookPublisher {
+	publisher := mocks.NewMockWebhookPublisher(ctrl)
+	publisher.EXPECT().PublishToOwner(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return publisher
+}
@@ -0,0 +1,27 @@
+package subscriber
+
+import (
+	"context"
+
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+)
+
+// InvalidateCacheSubscriber removes deleted and expired URLs from the cache.
+type InvalidateCacheSubscriber struct {
+	cacheRepo domain.URLCacheRepository
+}
+
+func NewInvalidateCacheSubscriber(cacheRepo domain.URLCacheRepository) *InvalidateCacheSubscriber {
+	return &InvalidateCacheSubscriber{
+		cacheRepo: cacheRepo,
+	}
+}
+
+func (s *InvalidateCacheSubscriber) Handle(ctx context.Context, m *outbox_domain.Message) error {
+	var e domain.URLEvent
+	if err := m.Decode(&e); err != nil {
+		return err
+	}
+	return s.cacheRepo.Delete(ctx, e.ShortCode)
+}
@@ -0,0 +1,45 @@
+package subscriber_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/url/subscriber"
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInvalidateCacheSubscriber_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCache := mocks.NewMockURLCacheRepository(ctrl)
+
+	m, err := outbox_domain.NewMessage(domain.TopicURLDeleted, domain.URLEvent{ShortCode: "abc123"})
+	require.NoError(t, err)
+
+	mockCache.EXPECT().Delete(ctx, "abc123").Return(nil)
+
+	assert.NoError(t, subscriber.NewInvalidateCacheSubscriber(mockCache).Handle(ctx, m))
+}
+
+func TestInvalidateCacheSubscriber_Handle_CacheError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCache := mocks.NewMockURLCacheRepository(ctrl)
+
+	m, err := outbox_domain.NewMessage(domain.TopicURLExpired, domain.URLEvent{ShortCode: "abc123"})
+	require.NoError(t, err)
+
+	mockCache.EXPECT().Delete(ctx, "abc123").Return(errors.New("redis unavailable"))
+
+	assert.EqualError(t, subscriber.NewInvalidateCacheSubscriber(mockCache).Handle(ctx, m), "redis unavailable")
+}
@@ -0,0 +1,71 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/google/uuid"
+)
+
+type CreateWebhookEndpointCommand struct {
+	UserID uuid.UUID
+	URL    string
+	Events []domain.EventType
+}
+
+type CreateWebhookEndpointHandler struct {
+	repo                domain.WebhookEndpointRepository
+	maxEndpointsPerUser int
+}
+
+func NewCreateWebhookEndpointHandler(repo domain.WebhookEndpointRepository, maxEndpointsPerUser int) *CreateWebhookEndpointHandler {
+	return &CreateWebhookEndpointHandler{
+		repo:                repo,
+		maxEndpointsPerUser: maxEndpointsPerUser,
+	}
+}
+
+// Handle registers the endpoint with a new signing secret, which is only
+// returned here.
+func (h *CreateWebhookEndpointHandler) Handle(ctx context.Context, cmd CreateWebhookEndpointCommand) (*domain.Endpoint, error) {
+	if err := domain.ValidateEndpointURL(cmd.URL); err != nil {
+		return nil, err
+	}
+	if len(cmd.Events) == 0 {
+		return nil, domain.ErrNoEventTypes
+	}
+	for _, eventType := range cmd.Events {
+		if !eventType.IsSubscribable() {
+			return nil, domain.ErrUnknownEventType
+		}
+	}
+
+	count, err := h.repo.CountByUserID(ctx, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= h.maxEndpointsPerUser {
+		return nil, domain.ErrTooManyEndpoints
+	}
+
+	secret, err := domain.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	events := slices.Clone(cmd.Events)
+	slices.Sort(events)
+
+	endpoint := &domain.Endpoint{
+		UserID: cmd.UserID,
+		URL:    cmd.URL,
+		Secret: secret,
+		Events: slices.Compact(events),
+	}
+	if err := h.repo.Create(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
+}
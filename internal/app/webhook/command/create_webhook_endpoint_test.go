@@ -0,0 +1,121 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/webhook/command"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateWebhookEndpointHandler_Handle_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := uuid.New()
+	mockRepo := mocks.NewMockWebhookEndpointRepository(ctrl)
+
+	mockRepo.EXPECT().CountByUserID(ctx, userID).Return(2, nil)
+	mockRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, e *domain.Endpoint) error {
+		e.ID = uuid.New()
+		return nil
+	})
+
+	handler := command.NewCreateWebhookEndpointHandler(mockRepo, 3)
+
+	endpoint, err := handler.Handle(ctx, command.CreateWebhookEndpointCommand{
+		UserID: userID,
+		URL:    "https://hooks.example.com/shortener",
+		Events: []domain.EventType{domain.EventURLDeleted, domain.EventURLCreated, domain.EventURLDeleted},
+	})
+
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, endpoint.ID)
+	assert.Equal(t, userID, endpoint.UserID)
+	assert.Equal(t, "https://hooks.example.com/shortener", endpoint.URL)
+	assert.True(t, strings.HasPrefix(endpoint.Secret, "whsec_"))
+	assert.Equal(t, []domain.EventType{domain.EventURLCreated, domain.EventURLDeleted}, endpoint.Events)
+}
+
+func TestCreateWebhookEndpointHandler_Handle_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		events  []domain.EventType
+		wantErr error
+	}{
+		{"invalid url", "ftp://example.com", []domain.EventType{domain.EventURLCreated}, domain.ErrInvalidEndpointURL},
+		{"no events", "https://example.com", nil, domain.ErrNoEventTypes},
+		{"unknown event", "https://example.com", []domain.EventType{"url.renamed"}, domain.ErrUnknownEventType},
+		{"test event", "https://example.com", []domain.EventType{domain.EventTest}, domain.ErrUnknownEventType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := command.NewCreateWebhookEndpointHandler(mocks.NewMockWebhookEndpointRepository(ctrl), 10)
+
+			_, err := handler.Handle(context.Background(), command.CreateWebhookEndpointCommand{
+				UserID: uuid.New(),
+				URL:    tt.url,
+				Events: tt.events,
+			})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCreateWebhookEndpointHandler_Handle_TooManyEndpoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := uuid.New()
+	mockRepo := mocks.NewMockWebhookEndpointRepository(ctrl)
+
+	mockRepo.EXPECT().CountByUserID(ctx, userID).Return(3, nil)
+
+	handler := command.NewCreateWebhookEndpointHandler(mockRepo, 3)
+
+	_, err := handler.Handle(ctx, command.CreateWebhookEndpointCommand{
+		UserID: userID,
+		URL:    "https://example.com",
+		Events: []domain.EventType{domain.EventURLClicked},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrTooManyEndpoints)
+}
+
+func TestCreateWebhookEndpointHandler_Handle_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	expectedError := errors.New("database error")
+	mockRepo := mocks.NewMockWebhookEndpointRepository(ctrl)
+
+	mockRepo.EXPECT().CountByUserID(ctx, gomock.Any()).Return(0, nil)
+	mockRepo.EXPECT().Create(ctx, gomock.Any()).Return(expectedError)
+
+	handler := command.NewCreateWebhookEndpointHandler(mockRepo, 3)
+
+	endpoint, err := handler.Handle(ctx, command.CreateWebhookEndpointCommand{
+		UserID: uuid.New(),
+		URL:    "https://example.com",
+		Events: []domain.EventType{domain.EventURLClicked},
+	})
+
+	assert.ErrorIs(t, err, expectedError)
+	assert.Nil(t, endpoint)
+}
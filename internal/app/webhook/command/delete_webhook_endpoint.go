@@ -0,0 +1,29 @@
+package command
+
+import (
+	"context"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/google/uuid"
+)
+
+type DeleteWebhookEndpointCommand struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+type DeleteWebhookEndpointHandler struct {
+	repo domain.WebhookEndpointRepository
+}
+
+func NewDeleteWebhookEndpointHandler(repo domain.WebhookEndpointRepository) *DeleteWebhookEndpointHandler {
+	return &DeleteWebhookEndpointHandler{
+		repo: repo,
+	}
+}
+
+// Handle removes the endpoint together with its pending deliveries and
+// delivery log.
+func (h *DeleteWebhookEndpointHandler) Handle(ctx context.Context, cmd DeleteWebhookEndpointCommand) error {
+	return h.repo.Delete(ctx, cmd.ID, cmd.UserID)
+}
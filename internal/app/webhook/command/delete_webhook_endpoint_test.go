@@ -0,0 +1,31 @@
+package command_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/webhook/command"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDeleteWebhookEndpointHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	id := uuid.New()
+	userID := uuid.New()
+	mockRepo := mocks.NewMockWebhookEndpointRepository(ctrl)
+
+	mockRepo.EXPECT().Delete(ctx, id, userID).Return(domain.ErrEndpointNotFound)
+
+	handler := command.NewDeleteWebhookEndpointHandler(mockRepo)
+
+	err := handler.Handle(ctx, command.DeleteWebhookEndpointCommand{ID: id, UserID: userID})
+
+	assert.ErrorIs(t, err, domain.ErrEndpointNotFound)
+}
@@ -0,0 +1,107 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/brunoibarbosa/url-shortener/pkg/util"
+)
+
+type DeliverWebhooksCommand struct {
+	BatchSize   int
+	Concurrency int
+	// Lease hides the claimed deliveries from other instances while they
+	// are sent, so it has to outlast sending the whole batch.
+	Lease time.Duration
+	// MaxAttempts is how many failed attempts move a delivery to the
+	// dead-letter state.
+	MaxAttempts    int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+}
+
+// DeliverWebhooksHandler sends the deliveries that are due. Every instance
+// can run it: claimed deliveries are skipped by the others.
+type DeliverWebhooksHandler struct {
+	repo    domain.WebhookDeliveryRepository
+	sender  domain.WebhookSender
+	metrics domain.WebhookMetrics
+}
+
+func NewDeliverWebhooksHandler(repo domain.WebhookDeliveryRepository, sender domain.WebhookSender, metrics domain.WebhookMetrics) *DeliverWebhooksHandler {
+	return &DeliverWebhooksHandler{
+		repo:    repo,
+		sender:  sender,
+		metrics: metrics,
+	}
+}
+
+// Handle sends one batch and returns how many deliveries it claimed.
+func (h *DeliverWebhooksHandler) Handle(ctx context.Context, cmd DeliverWebhooksCommand) (int, error) {
+	due, err := h.repo.ClaimDue(ctx, time.Now(), cmd.Lease, cmd.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	slots := make(chan struct{}, max(cmd.Concurrency, 1))
+	for _, d := range due {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			if err := h.deliver(ctx, cmd, d); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return len(due), errors.Join(errs...)
+}
+
+func (h *DeliverWebhooksHandler) deliver(ctx context.Context, cmd DeliverWebhooksCommand, d *domain.DueDelivery) error {
+	status, sendErr := h.sender.Send(ctx, d)
+	if sendErr != nil && ctx.Err() != nil {
+		// Interrupted by shutdown: the delivery is sent again once its
+		// lease runs out.
+		return nil
+	}
+
+	result := domain.AttemptResult{
+		AttemptedAt:    time.Now(),
+		ResponseStatus: status,
+	}
+	// The attempt happened, so it is recorded even if ctx is cancelled now.
+	recordCtx := context.WithoutCancel(ctx)
+
+	if sendErr == nil {
+		h.metrics.DeliverySucceeded()
+		return h.repo.MarkSucceeded(recordCtx, d.ID, result)
+	}
+
+	result.Error = sendErr.Error()
+	attempt := d.Attempts + 1
+
+	var nextAttemptAt *time.Time
+	if attempt < cmd.MaxAttempts {
+		next := result.AttemptedAt.Add(util.ExponentialBackoff(attempt, cmd.RetryBaseDelay, cmd.RetryMaxDelay))
+		nextAttemptAt = &next
+	}
+	h.metrics.DeliveryFailed(nextAttemptAt == nil)
+
+	return h.repo.MarkFailed(recordCtx, d.ID, result, nextAttemptAt)
+}
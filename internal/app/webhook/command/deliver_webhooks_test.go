@@ -0,0 +1,143 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/webhook/command"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var deliverCommand = command.DeliverWebhooksCommand{
+	BatchSize:      10,
+	Concurrency:    2,
+	Lease:          time.Minute,
+	MaxAttempts:    3,
+	RetryBaseDelay: 30 * time.Second,
+	RetryMaxDelay:  time.Hour,
+}
+
+func TestDeliverWebhooksHandler_Handle_Outcomes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+	mockSender := mocks.NewMockWebhookSender(ctrl)
+	mockMetrics := mocks.NewMockWebhookMetrics(ctrl)
+
+	ok := &domain.DueDelivery{Delivery: domain.Delivery{ID: uuid.New()}}
+	retried := &domain.DueDelivery{Delivery: domain.Delivery{ID: uuid.New(), Attempts: 1}}
+	dead := &domain.DueDelivery{Delivery: domain.Delivery{ID: uuid.New(), Attempts: 2}}
+
+	mockRepo.EXPECT().ClaimDue(ctx, gomock.Any(), time.Minute, 10).Return([]*domain.DueDelivery{ok, retried, dead}, nil)
+
+	status200, status500 := 200, 500
+	mockSender.EXPECT().Send(ctx, ok).Return(&status200, nil)
+	mockSender.EXPECT().Send(ctx, retried).Return(&status500, domain.ErrUnexpectedStatus)
+	mockSender.EXPECT().Send(ctx, dead).Return(nil, errors.New("connection refused"))
+
+	mockRepo.EXPECT().MarkSucceeded(gomock.Any(), ok.ID, gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, r domain.AttemptResult) error {
+		assert.Equal(t, &status200, r.ResponseStatus)
+		assert.Empty(t, r.Error)
+		return nil
+	})
+	mockRepo.EXPECT().MarkFailed(gomock.Any(), retried.ID, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, r domain.AttemptResult, next *time.Time) error {
+		assert.Equal(t, &status500, r.ResponseStatus)
+		assert.Equal(t, domain.ErrUnexpectedStatus.Error(), r.Error)
+		// Second failed attempt: twice the base delay
+		require.NotNil(t, next)
+		assert.Equal(t, time.Minute, next.Sub(r.AttemptedAt))
+		return nil
+	})
+	mockRepo.EXPECT().MarkFailed(gomock.Any(), dead.ID, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, r domain.AttemptResult, next *time.Time) error {
+		assert.Nil(t, r.ResponseStatus)
+		assert.Equal(t, "connection refused", r.Error)
+		assert.Nil(t, next)
+		return nil
+	})
+
+	mockMetrics.EXPECT().DeliverySucceeded()
+	mockMetrics.EXPECT().DeliveryFailed(false)
+	mockMetrics.EXPECT().DeliveryFailed(true)
+
+	handler := command.NewDeliverWebhooksHandler(mockRepo, mockSender, mockMetrics)
+
+	claimed, err := handler.Handle(ctx, deliverCommand)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, claimed)
+}
+
+func TestDeliverWebhooksHandler_Handle_ClaimError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	expectedError := errors.New("database error")
+	mockRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+
+	mockRepo.EXPECT().ClaimDue(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, expectedError)
+
+	handler := command.NewDeliverWebhooksHandler(mockRepo, mocks.NewMockWebhookSender(ctrl), mocks.NewMockWebhookMetrics(ctrl))
+
+	claimed, err := handler.Handle(ctx, deliverCommand)
+
+	assert.ErrorIs(t, err, expectedError)
+	assert.Zero(t, claimed)
+}
+
+func TestDeliverWebhooksHandler_Handle_RecordError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	expectedError := errors.New("database error")
+	mockRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+	mockSender := mocks.NewMockWebhookSender(ctrl)
+	mockMetrics := mocks.NewMockWebhookMetrics(ctrl)
+
+	due := &domain.DueDelivery{Delivery: domain.Delivery{ID: uuid.New()}}
+	mockRepo.EXPECT().ClaimDue(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return([]*domain.DueDelivery{due}, nil)
+	mockSender.EXPECT().Send(ctx, due).Return(nil, nil)
+	mockMetrics.EXPECT().DeliverySucceeded()
+	mockRepo.EXPECT().MarkSucceeded(gomock.Any(), due.ID, gomock.Any()).Return(expectedError)
+
+	handler := command.NewDeliverWebhooksHandler(mockRepo, mockSender, mockMetrics)
+
+	_, err := handler.Handle(ctx, deliverCommand)
+
+	assert.ErrorIs(t, err, expectedError)
+}
+
+func TestDeliverWebhooksHandler_Handle_InterruptedByShutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+	mockSender := mocks.NewMockWebhookSender(ctrl)
+
+	due := &domain.DueDelivery{Delivery: domain.Delivery{ID: uuid.New()}}
+	mockRepo.EXPECT().ClaimDue(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return([]*domain.DueDelivery{due}, nil)
+	mockSender.EXPECT().Send(ctx, due).DoAndReturn(func(context.Context, *domain.DueDelivery) (*int, error) {
+		cancel()
+		return nil, context.Canceled
+	})
+
+	// Neither recorded nor counted: the delivery is picked up again once
+	// its lease expires.
+	handler := command.NewDeliverWebhooksHandler(mockRepo, mockSender, mocks.NewMockWebhookMetrics(ctrl))
+
+	claimed, err := handler.Handle(ctx, deliverCommand)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, claimed)
+}
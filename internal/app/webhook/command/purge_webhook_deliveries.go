@@ -0,0 +1,46 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+)
+
+type PurgeWebhookDeliveriesCommand struct {
+	BatchSize int
+	// RetainFor keeps finished deliveries in the delivery log for a while.
+	RetainFor time.Duration
+}
+
+// PurgeWebhookDeliveriesHandler deletes succeeded and dead deliveries
+// created more than RetainFor ago, in batches of BatchSize.
+type PurgeWebhookDeliveriesHandler struct {
+	repo domain.WebhookDeliveryRepository
+}
+
+func NewPurgeWebhookDeliveriesHandler(repo domain.WebhookDeliveryRepository) *PurgeWebhookDeliveriesHandler {
+	return &PurgeWebhookDeliveriesHandler{
+		repo: repo,
+	}
+}
+
+func (h *PurgeWebhookDeliveriesHandler) Handle(ctx context.Context, cmd PurgeWebhookDeliveriesCommand) (int64, error) {
+	cutoff := time.Now().Add(-cmd.RetainFor)
+
+	var total int64
+	for {
+		deleted, err := h.repo.DeleteFinished(ctx, cutoff, cmd.BatchSize)
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+
+		if deleted < int64(cmd.BatchSize) {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package command_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/webhook/command"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPurgeWebhookDeliveriesHandler_Handle_Batches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+
+	before := time.Now().Add(-7 * 24 * time.Hour)
+	gomock.InOrder(
+		mockRepo.EXPECT().DeleteFinished(ctx, gomock.Any(), 10).DoAndReturn(func(_ context.Context, cutoff time.Time, _ int) (int64, error) {
+			assert.WithinDuration(t, before, cutoff, time.Second)
+			return 10, nil
+		}),
+		mockRepo.EXPECT().DeleteFinished(ctx, gomock.Any(), 10).Return(int64(3), nil),
+	)
+
+	handler := command.NewPurgeWebhookDeliveriesHandler(mockRepo)
+
+	deleted, err := handler.Handle(ctx, command.PurgeWebhookDeliveriesCommand{BatchSize: 10, RetainFor: 7 * 24 * time.Hour})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(13), deleted)
+}
@@ -0,0 +1,31 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/google/uuid"
+)
+
+type RetryWebhookDeliveryCommand struct {
+	ID         uuid.UUID
+	EndpointID uuid.UUID
+	UserID     uuid.UUID
+}
+
+type RetryWebhookDeliveryHandler struct {
+	repo domain.WebhookDeliveryRepository
+}
+
+func NewRetryWebhookDeliveryHandler(repo domain.WebhookDeliveryRepository) *RetryWebhookDeliveryHandler {
+	return &RetryWebhookDeliveryHandler{
+		repo: repo,
+	}
+}
+
+// Handle takes a delivery out of the dead-letter state and queues it for
+// immediate delivery with a fresh set of attempts.
+func (h *RetryWebhookDeliveryHandler) Handle(ctx context.Context, cmd RetryWebhookDeliveryCommand) error {
+	return h.repo.Retry(ctx, cmd.ID, cmd.EndpointID, cmd.UserID, time.Now())
+}
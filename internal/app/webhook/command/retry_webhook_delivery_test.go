@@ -0,0 +1,32 @@
+package command_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/webhook/command"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRetryWebhookDeliveryHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	id := uuid.New()
+	endpointID := uuid.New()
+	userID := uuid.New()
+	mockRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+
+	mockRepo.EXPECT().Retry(ctx, id, endpointID, userID, gomock.Any()).Return(domain.ErrDeliveryNotRetryable)
+
+	handler := command.NewRetryWebhookDeliveryHandler(mockRepo)
+
+	err := handler.Handle(ctx, command.RetryWebhookDeliveryCommand{ID: id, EndpointID: endpointID, UserID: userID})
+
+	assert.ErrorIs(t, err, domain.ErrDeliveryNotRetryable)
+}
@@ -0,0 +1,38 @@
+package command
+
+import (
+	"context"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/google/uuid"
+)
+
+type SendTestWebhookCommand struct {
+	EndpointID uuid.UUID
+	UserID     uuid.UUID
+}
+
+type SendTestWebhookHandler struct {
+	endpointRepo domain.WebhookEndpointRepository
+	deliveryRepo domain.WebhookDeliveryRepository
+}
+
+func NewSendTestWebhookHandler(endpointRepo domain.WebhookEndpointRepository, deliveryRepo domain.WebhookDeliveryRepository) *SendTestWebhookHandler {
+	return &SendTestWebhookHandler{
+		endpointRepo: endpointRepo,
+		deliveryRepo: deliveryRepo,
+	}
+}
+
+// Handle queues a webhook.test event for the endpoint. It goes through the
+// same queue, signing and retries as real events.
+func (h *SendTestWebhookHandler) Handle(ctx context.Context, cmd SendTestWebhookCommand) (*domain.Delivery, error) {
+	endpoint, err := h.endpointRepo.FindByID(ctx, cmd.EndpointID, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.deliveryRepo.Enqueue(ctx, endpoint.ID, domain.NewEvent(domain.EventTest, map[string]string{
+		"endpointId": endpoint.ID.String(),
+	}))
+}
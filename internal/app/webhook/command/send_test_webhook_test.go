@@ -0,0 +1,54 @@
+package command_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/webhook/command"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSendTestWebhookHandler_Handle_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	endpointID := uuid.New()
+	userID := uuid.New()
+	mockEndpoints := mocks.NewMockWebhookEndpointRepository(ctrl)
+	mockDeliveries := mocks.NewMockWebhookDeliveryRepository(ctrl)
+
+	delivery := &domain.Delivery{ID: uuid.New(), EndpointID: endpointID, Status: domain.DeliveryPending}
+	mockEndpoints.EXPECT().FindByID(ctx, endpointID, userID).Return(&domain.Endpoint{ID: endpointID, UserID: userID}, nil)
+	mockDeliveries.EXPECT().Enqueue(ctx, endpointID, gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, e domain.Event) (*domain.Delivery, error) {
+		assert.Equal(t, domain.EventTest, e.Type)
+		return delivery, nil
+	})
+
+	handler := command.NewSendTestWebhookHandler(mockEndpoints, mockDeliveries)
+
+	result, err := handler.Handle(ctx, command.SendTestWebhookCommand{EndpointID: endpointID, UserID: userID})
+
+	assert.NoError(t, err)
+	assert.Equal(t, delivery, result)
+}
+
+func TestSendTestWebhookHandler_Handle_EndpointNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockEndpoints := mocks.NewMockWebhookEndpointRepository(ctrl)
+
+	mockEndpoints.EXPECT().FindByID(ctx, gomock.Any(), gomock.Any()).Return(nil, domain.ErrEndpointNotFound)
+
+	handler := command.NewSendTestWebhookHandler(mockEndpoints, mocks.NewMockWebhookDeliveryRepository(ctrl))
+
+	_, err := handler.Handle(ctx, command.SendTestWebhookCommand{EndpointID: uuid.New(), UserID: uuid.New()})
+
+	assert.ErrorIs(t, err, domain.ErrEndpointNotFound)
+}
@@ -0,0 +1,29 @@
+package query
+
+import (
+	"context"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+)
+
+type ListWebhookDeliveriesHandler struct {
+	endpointRepo domain.WebhookEndpointRepository
+	queryRepo    domain.WebhookDeliveryQueryRepository
+}
+
+func NewListWebhookDeliveriesHandler(endpointRepo domain.WebhookEndpointRepository, queryRepo domain.WebhookDeliveryQueryRepository) *ListWebhookDeliveriesHandler {
+	return &ListWebhookDeliveriesHandler{
+		endpointRepo: endpointRepo,
+		queryRepo:    queryRepo,
+	}
+}
+
+// Handle returns the delivery log of an endpoint of params.UserID, or
+// ErrEndpointNotFound.
+func (h *ListWebhookDeliveriesHandler) Handle(ctx context.Context, params domain.ListDeliveriesParams) ([]domain.Delivery, uint64, error) {
+	if _, err := h.endpointRepo.FindByID(ctx, params.EndpointID, params.UserID); err != nil {
+		return nil, 0, err
+	}
+
+	return h.queryRepo.List(ctx, params)
+}
@@ -0,0 +1,59 @@
+package query_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/webhook/query"
+	"github.com/brunoibarbosa/url-shortener/internal/domain"
+	webhook_domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListWebhookDeliveriesHandler_Handle_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	endpointID := uuid.New()
+	userID := uuid.New()
+	mockEndpoints := mocks.NewMockWebhookEndpointRepository(ctrl)
+	mockQuery := mocks.NewMockWebhookDeliveryQueryRepository(ctrl)
+
+	params := webhook_domain.ListDeliveriesParams{
+		EndpointID: endpointID,
+		UserID:     userID,
+		Pagination: domain.Pagination{Number: 1, Size: 10},
+	}
+	expected := []webhook_domain.Delivery{{ID: uuid.New(), EndpointID: endpointID, Status: webhook_domain.DeliveryDead}}
+
+	mockEndpoints.EXPECT().FindByID(ctx, endpointID, userID).Return(&webhook_domain.Endpoint{ID: endpointID}, nil)
+	mockQuery.EXPECT().List(ctx, params).Return(expected, uint64(1), nil)
+
+	handler := query.NewListWebhookDeliveriesHandler(mockEndpoints, mockQuery)
+
+	deliveries, total, err := handler.Handle(ctx, params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, deliveries)
+	assert.Equal(t, uint64(1), total)
+}
+
+func TestListWebhookDeliveriesHandler_Handle_EndpointNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockEndpoints := mocks.NewMockWebhookEndpointRepository(ctrl)
+
+	mockEndpoints.EXPECT().FindByID(ctx, gomock.Any(), gomock.Any()).Return(nil, webhook_domain.ErrEndpointNotFound)
+
+	handler := query.NewListWebhookDeliveriesHandler(mockEndpoints, mocks.NewMockWebhookDeliveryQueryRepository(ctrl))
+
+	_, _, err := handler.Handle(ctx, webhook_domain.ListDeliveriesParams{EndpointID: uuid.New(), UserID: uuid.New()})
+
+	assert.ErrorIs(t, err, webhook_domain.ErrEndpointNotFound)
+}
@@ -0,0 +1,22 @@
+package query
+
+import (
+	"context"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/google/uuid"
+)
+
+type ListWebhookEndpointsHandler struct {
+	repo domain.WebhookEndpointRepository
+}
+
+func NewListWebhookEndpointsHandler(repo domain.WebhookEndpointRepository) *ListWebhookEndpointsHandler {
+	return &ListWebhookEndpointsHandler{
+		repo: repo,
+	}
+}
+
+func (h *ListWebhookEndpointsHandler) Handle(ctx context.Context, userID uuid.UUID) ([]domain.Endpoint, error) {
+	return h.repo.ListByUserID(ctx, userID)
+}
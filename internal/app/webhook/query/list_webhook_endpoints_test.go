@@ -0,0 +1,51 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/webhook/query"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListWebhookEndpointsHandler_Handle_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := uuid.New()
+	mockRepo := mocks.NewMockWebhookEndpointRepository(ctrl)
+
+	expected := []domain.Endpoint{{ID: uuid.New(), UserID: userID, URL: "https://example.com"}}
+	mockRepo.EXPECT().ListByUserID(ctx, userID).Return(expected, nil)
+
+	handler := query.NewListWebhookEndpointsHandler(mockRepo)
+
+	endpoints, err := handler.Handle(ctx, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, endpoints)
+}
+
+func TestListWebhookEndpointsHandler_Handle_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	expectedError := errors.New("database error")
+	mockRepo := mocks.NewMockWebhookEndpointRepository(ctrl)
+
+	mockRepo.EXPECT().ListByUserID(ctx, gomock.Any()).Return(nil, expectedError)
+
+	handler := query.NewListWebhookEndpointsHandler(mockRepo)
+
+	endpoints, err := handler.Handle(ctx, uuid.New())
+
+	assert.ErrorIs(t, err, expectedError)
+	assert.Nil(t, endpoints)
+}
@@ -0,0 +1,54 @@
+package subscriber
+
+import (
+	"context"
+
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
+	url_domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+)
+
+var urlEventTypes = map[string]domain.EventType{
+	url_domain.TopicURLCreated: domain.EventURLCreated,
+	url_domain.TopicURLDeleted: domain.EventURLDeleted,
+	url_domain.TopicURLExpired: domain.EventURLExpired,
+}
+
+// PublishURLEventSubscriber turns URL events into webhook events for the
+// owner of the URL. The webhook event keeps the id of the outbox message,
+// so relaying it again does not queue a second delivery.
+type PublishURLEventSubscriber struct {
+	publisher domain.WebhookPublisher
+}
+
+func NewPublishURLEventSubscriber(publisher domain.WebhookPublisher) *PublishURLEventSubscriber {
+	return &PublishURLEventSubscriber{
+		publisher: publisher,
+	}
+}
+
+func (s *PublishURLEventSubscriber) Handle(ctx context.Context, m *outbox_domain.Message) error {
+	eventType, ok := urlEventTypes[m.Topic]
+	if !ok {
+		return nil
+	}
+
+	var e url_domain.URLEvent
+	if err := m.Decode(&e); err != nil {
+		return err
+	}
+	if e.UserID == nil {
+		return nil
+	}
+
+	return s.publisher.Publish(ctx, *e.UserID, domain.Event{
+		ID:         m.ID,
+		Type:       eventType,
+		OccurredAt: m.OccurredAt,
+		Data: domain.URLEventData{
+			ID:        e.ID,
+			ShortCode: e.ShortCode,
+			ExpiresAt: e.ExpiresAt,
+		},
+	})
+}
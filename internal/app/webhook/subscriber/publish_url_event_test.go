@@ -0,0 +1,56 @@
+package subscriber_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/webhook/subscriber"
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
+	url_domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPublishURLEventSubscriber_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockPublisher := mocks.NewMockWebhookPublisher(ctrl)
+
+	userID := uuid.New()
+	expiresAt := time.Now().Add(time.Hour).UTC()
+	m, err := outbox_domain.NewMessage(url_domain.TopicURLCreated, url_domain.URLEvent{
+		ShortCode: "abc123",
+		UserID:    &userID,
+		ExpiresAt: &expiresAt,
+	})
+	require.NoError(t, err)
+
+	mockPublisher.EXPECT().Publish(ctx, userID, gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, e domain.Event) error {
+		assert.Equal(t, m.ID, e.ID)
+		assert.Equal(t, domain.EventURLCreated, e.Type)
+		assert.Equal(t, m.OccurredAt, e.OccurredAt)
+		data := e.Data.(domain.URLEventData)
+		assert.Equal(t, "abc123", data.ShortCode)
+		assert.True(t, expiresAt.Equal(*data.ExpiresAt))
+		return nil
+	})
+
+	assert.NoError(t, subscriber.NewPublishURLEventSubscriber(mockPublisher).Handle(ctx, m))
+}
+
+func TestPublishURLEventSubscriber_Handle_AnonymousURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m, err := outbox_domain.NewMessage(url_domain.TopicURLExpired, url_domain.URLEvent{ShortCode: "abc123"})
+	require.NoError(t, err)
+
+	assert.NoError(t, subscriber.NewPublishURLEventSubscriber(mocks.NewMockWebhookPublisher(ctrl)).Handle(context.Background(), m))
+}
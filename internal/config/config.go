@@ -0,0 +1,483 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/domain/ratelimit"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/redis"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/resilience"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/crypto"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/shortcode"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Config is the application configuration. Values are layered in order:
+// Default(), an optional YAML/TOML file and finally environment variables
+// (named by the env tag). Fields tagged secret are redacted when printed.
+type Config struct {
+	Server      ServerConfig      `yaml:"server" toml:"server"`
+	Admin       AdminConfig       `yaml:"admin" toml:"admin"`
+	Postgres    PostgresConfig    `yaml:"postgres" toml:"postgres"`
+	Redis       RedisConfig       `yaml:"redis" toml:"redis"`
+	Auth        AuthConfig        `yaml:"auth" toml:"auth"`
+	URL         URLConfig         `yaml:"url" toml:"url"`
+	RateLimit   RateLimitConfig   `yaml:"rateLimit" toml:"rateLimit"`
+	Scheduler   SchedulerConfig   `yaml:"scheduler" toml:"scheduler"`
+	Webhook     WebhookConfig     `yaml:"webhook" toml:"webhook"`
+	Outbox      OutboxConfig      `yaml:"outbox" toml:"outbox"`
+	Idempotency IdempotencyConfig `yaml:"idempotency" toml:"idempotency"`
+	Log         LogConfig         `yaml:"log" toml:"log"`
+	Tracing     TracingConfig     `yaml:"tracing" toml:"tracing"`
+}
+
+type ServerConfig struct {
+	ListenAddress      string        `yaml:"listenAddress" toml:"listenAddress" env:"LISTEN_ADDRESS"`
+	ReadTimeout        time.Duration `yaml:"readTimeout" toml:"readTimeout" env:"SERVER_READ_TIMEOUT"`
+	ReadHeaderTimeout  time.Duration `yaml:"readHeaderTimeout" toml:"readHeaderTimeout" env:"SERVER_READ_HEADER_TIMEOUT"`
+	WriteTimeout       time.Duration `yaml:"writeTimeout" toml:"writeTimeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout        time.Duration `yaml:"idleTimeout" toml:"idleTimeout" env:"SERVER_IDLE_TIMEOUT"`
+	HealthCheckTimeout time.Duration `yaml:"healthCheckTimeout" toml:"healthCheckTimeout" env:"HEALTH_CHECK_TIMEOUT"`
+	ShutdownDrainDelay time.Duration `yaml:"shutdownDrainDelay" toml:"shutdownDrainDelay" env:"SHUTDOWN_DRAIN_DELAY"`
+	ShutdownTimeout    time.Duration `yaml:"shutdownTimeout" toml:"shutdownTimeout" env:"SHUTDOWN_TIMEOUT"`
+	// TrustedProxies are the CIDRs of the load balancers whose Forwarded and
+	// X-Forwarded-For headers identify the client. Empty trusts no one.
+	TrustedProxies []netip.Prefix `yaml:"trustedProxies" toml:"trustedProxies" env:"TRUSTED_PROXIES"`
+}
+
+type AdminConfig struct {
+	ListenAddress string      `yaml:"listenAddress" toml:"listenAddress" env:"ADMIN_LISTEN_ADDRESS"`
+	UserIDs       []uuid.UUID `yaml:"userIds" toml:"userIds" env:"ADMIN_USER_IDS"`
+}
+
+type PostgresConfig struct {
+	Host            string        `yaml:"host" toml:"host" env:"DB_HOST"`
+	Port            int           `yaml:"port" toml:"port" env:"DB_PORT"`
+	User            string        `yaml:"user" toml:"user" env:"DB_USER"`
+	Password        string        `yaml:"password" toml:"password" env:"DB_PASSWORD" secret:"true"`
+	Name            string        `yaml:"name" toml:"name" env:"DB_NAME"`
+	MaxConns        int32         `yaml:"maxConns" toml:"maxConns" env:"DB_MAX_CONNS"`
+	MinConns        int32         `yaml:"minConns" toml:"minConns" env:"DB_MIN_CONNS"`
+	MaxConnIdleTime time.Duration `yaml:"maxConnIdleTime" toml:"maxConnIdleTime" env:"DB_MAX_CONN_IDLE_TIME"`
+	AutoMigrate     bool          `yaml:"autoMigrate" toml:"autoMigrate" env:"DB_AUTO_MIGRATE"`
+	// ReplicaHost enables read-replica routing. Reads fall back to the
+	// primary while the replica lags more than ReplicaMaxLag.
+	ReplicaHost          string        `yaml:"replicaHost" toml:"replicaHost" env:"DB_REPLICA_HOST"`
+	ReplicaPort          int           `yaml:"replicaPort" toml:"replicaPort" env:"DB_REPLICA_PORT"`
+	ReplicaMaxLag        time.Duration `yaml:"replicaMaxLag" toml:"replicaMaxLag" env:"DB_REPLICA_MAX_LAG"`
+	ReplicaCheckInterval time.Duration `yaml:"replicaCheckInterval" toml:"replicaCheckInterval" env:"DB_REPLICA_CHECK_INTERVAL"`
+}
+
+type RedisConfig struct {
+	// Mode is standalone, sentinel or cluster. Address is used in standalone
+	// mode; Addresses lists the Sentinels or the cluster seed nodes.
+	Mode             string         `yaml:"mode" toml:"mode" env:"REDIS_MODE"`
+	Address          string         `yaml:"address" toml:"address" env:"REDIS_ADDRESS"`
+	Addresses        []string       `yaml:"addresses" toml:"addresses" env:"REDIS_ADDRESSES"`
+	MasterName       string         `yaml:"masterName" toml:"masterName" env:"REDIS_MASTER_NAME"`
+	Username         string         `yaml:"username" toml:"username" env:"REDIS_USERNAME"`
+	Password         string         `yaml:"password" toml:"password" env:"REDIS_PASSWORD" secret:"true"`
+	SentinelPassword string         `yaml:"sentinelPassword" toml:"sentinelPassword" env:"REDIS_SENTINEL_PASSWORD" secret:"true"`
+	DB               int            `yaml:"db" toml:"db" env:"REDIS_DB"`
+	TLS              RedisTLSConfig `yaml:"tls" toml:"tls"`
+	// OperationTimeout bounds each cache, blacklist, state and rate limit
+	// call; consecutive failures open the circuit breaker for
+	// BreakerOpenDuration.
+	OperationTimeout        time.Duration `yaml:"operationTimeout" toml:"operationTimeout" env:"REDIS_OPERATION_TIMEOUT"`
+	BreakerFailureThreshold int           `yaml:"breakerFailureThreshold" toml:"breakerFailureThreshold" env:"REDIS_BREAKER_FAILURE_THRESHOLD"`
+	BreakerOpenDuration     time.Duration `yaml:"breakerOpenDuration" toml:"breakerOpenDuration" env:"REDIS_BREAKER_OPEN_DURATION"`
+	// BlacklistFailurePolicy and StateFailurePolicy decide whether refresh
+	// token revocation checks and OAuth state checks pass (open) or fail
+	// (closed) while Redis is unavailable.
+	BlacklistFailurePolicy resilience.FailurePolicy `yaml:"blacklistFailurePolicy" toml:"blacklistFailurePolicy" env:"REDIS_BLACKLIST_FAILURE_POLICY"`
+	StateFailurePolicy     resilience.FailurePolicy `yaml:"stateFailurePolicy" toml:"stateFailurePolicy" env:"REDIS_STATE_FAILURE_POLICY"`
+}
+
+type RedisTLSConfig struct {
+	Enabled            bool   `yaml:"enabled" toml:"enabled" env:"REDIS_TLS_ENABLED"`
+	CAFile             string `yaml:"caFile" toml:"caFile" env:"REDIS_TLS_CA_FILE"`
+	CertFile           string `yaml:"certFile" toml:"certFile" env:"REDIS_TLS_CERT_FILE"`
+	KeyFile            string `yaml:"keyFile" toml:"keyFile" env:"REDIS_TLS_KEY_FILE"`
+	ServerName         string `yaml:"serverName" toml:"serverName" env:"REDIS_TLS_SERVER_NAME"`
+	InsecureSkipVerify bool   `yaml:"insecureSkipVerify" toml:"insecureSkipVerify" env:"REDIS_TLS_INSECURE_SKIP_VERIFY"`
+}
+
+// Connection returns the client settings for the configured mode.
+func (c RedisConfig) Connection() redis.RedisConfig {
+	addresses := c.Addresses
+	if c.Mode == redis.ModeStandalone {
+		addresses = []string{c.Address}
+	}
+
+	return redis.RedisConfig{
+		Mode:             c.Mode,
+		Addresses:        addresses,
+		MasterName:       c.MasterName,
+		Username:         c.Username,
+		Password:         c.Password,
+		SentinelPassword: c.SentinelPassword,
+		DB:               c.DB,
+		TLS: redis.RedisTLSConfig{
+			Enabled:            c.TLS.Enabled,
+			CAFile:             c.TLS.CAFile,
+			CertFile:           c.TLS.CertFile,
+			KeyFile:            c.TLS.KeyFile,
+			ServerName:         c.TLS.ServerName,
+			InsecureSkipVerify: c.TLS.InsecureSkipVerify,
+		},
+	}
+}
+
+func (c RedisConfig) Breaker() *resilience.Breaker {
+	return resilience.NewBreaker(resilience.BreakerConfig{
+		Name:             "redis",
+		Timeout:          c.OperationTimeout,
+		FailureThreshold: c.BreakerFailureThreshold,
+		OpenDuration:     c.BreakerOpenDuration,
+	})
+}
+
+type AuthConfig struct {
+	JWTSecret            string        `yaml:"jwtSecret" toml:"jwtSecret" env:"JWT_SECRET" secret:"true"`
+	GoogleClientID       string        `yaml:"googleClientId" toml:"googleClientId" env:"GOOGLE_CLIENT_ID"`
+	GoogleClientSecret   string        `yaml:"googleClientSecret" toml:"googleClientSecret" env:"GOOGLE_CLIENT_SECRET" secret:"true"`
+	AccessTokenDuration  time.Duration `yaml:"accessTokenDuration" toml:"accessTokenDuration" env:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration time.Duration `yaml:"refreshTokenDuration" toml:"refreshTokenDuration" env:"REFRESH_TOKEN_DURATION"`
+	BcryptCost           int           `yaml:"bcryptCost" toml:"bcryptCost" env:"BCRYPT_COST"`
+	OAuthStateExpiration time.Duration `yaml:"oauthStateExpiration" toml:"oauthStateExpiration" env:"OAUTH_STATE_EXPIRATION"`
+}
+
+type URLConfig struct {
+	// EncryptionKeys are "<id>:<wrapped>" data keys wrapped by KeyProvider;
+	// new values are sealed with ActiveKeyID and older keys stay readable
+	// until rotated out.
+	EncryptionKeys []string `yaml:"encryptionKeys" toml:"encryptionKeys" env:"URL_ENCRYPTION_KEYS" secret:"true"`
+	ActiveKeyID    string   `yaml:"activeKeyId" toml:"activeKeyId" env:"URL_ENCRYPTION_ACTIVE_KEY"`
+	// BlindIndexKey is a "<id>:<wrapped>" key, wrapped like EncryptionKeys,
+	// for the HMAC that lets a user's duplicate destinations be found.
+	BlindIndexKey   string        `yaml:"blindIndexKey" toml:"blindIndexKey" env:"URL_BLIND_INDEX_KEY" secret:"true"`
+	KeyProvider     string        `yaml:"keyProvider" toml:"keyProvider" env:"URL_KEY_PROVIDER"`
+	MasterKeyFile   string        `yaml:"masterKeyFile" toml:"masterKeyFile" env:"URL_MASTER_KEY_FILE"`
+	DataKeyCacheTTL time.Duration `yaml:"dataKeyCacheTtl" toml:"dataKeyCacheTtl" env:"URL_DATA_KEY_CACHE_TTL"`
+	// DataKeyStaleGrace keeps serving a cached data key past its TTL while
+	// the key provider fails to unwrap it again; zero disables it.
+	DataKeyStaleGrace time.Duration `yaml:"dataKeyStaleGrace" toml:"dataKeyStaleGrace" env:"URL_DATA_KEY_STALE_GRACE"`
+	Vault             VaultConfig   `yaml:"vault" toml:"vault"`
+	// Secret is the pre-keyring AES-CTR key, only needed to read old rows.
+	Secret            string        `yaml:"secret" toml:"secret" env:"URL_SECRET" secret:"true"`
+	PersistExpiration time.Duration `yaml:"persistExpiration" toml:"persistExpiration" env:"URL_PERSIST_EXPIRATION_DURATION"`
+	CacheExpiration   time.Duration `yaml:"cacheExpiration" toml:"cacheExpiration" env:"URL_CACHE_EXPIRATION_DURATION"`
+	// LocalCacheSize bounds the in-process cache of hot links; 0 disables it.
+	LocalCacheSize       int           `yaml:"localCacheSize" toml:"localCacheSize" env:"URL_LOCAL_CACHE_SIZE"`
+	LocalCacheExpiration time.Duration `yaml:"localCacheExpiration" toml:"localCacheExpiration" env:"URL_LOCAL_CACHE_EXPIRATION_DURATION"`
+	ShortCodeLength      int           `yaml:"shortCodeLength" toml:"shortCodeLength" env:"SHORT_CODE_LENGTH"`
+	ShortCodeMaxRetries  int           `yaml:"shortCodeMaxRetries" toml:"shortCodeMaxRetries" env:"SHORT_CODE_MAX_RETRIES"`
+	// ShortCodeStrategy is random, counter or pool.
+	ShortCodeStrategy         string `yaml:"shortCodeStrategy" toml:"shortCodeStrategy" env:"SHORT_CODE_STRATEGY"`
+	ShortCodeCounterSource    string `yaml:"shortCodeCounterSource" toml:"shortCodeCounterSource" env:"SHORT_CODE_COUNTER_SOURCE"`
+	ShortCodeCounterBlockSize int64  `yaml:"shortCodeCounterBlockSize" toml:"shortCodeCounterBlockSize" env:"SHORT_CODE_COUNTER_BLOCK_SIZE"`
+	// ShortCodeObfuscationKey makes counter codes non-sequential; it must not
+	// change once codes have been issued.
+	ShortCodeObfuscationKey     string        `yaml:"shortCodeObfuscationKey" toml:"shortCodeObfuscationKey" env:"SHORT_CODE_OBFUSCATION_KEY" secret:"true"`
+	ShortCodePoolMinAvailable   int64         `yaml:"shortCodePoolMinAvailable" toml:"shortCodePoolMinAvailable" env:"SHORT_CODE_POOL_MIN_AVAILABLE"`
+	ShortCodePoolRefillBatch    int           `yaml:"shortCodePoolRefillBatch" toml:"shortCodePoolRefillBatch" env:"SHORT_CODE_POOL_REFILL_BATCH"`
+	ShortCodePoolRefillInterval time.Duration `yaml:"shortCodePoolRefillInterval" toml:"shortCodePoolRefillInterval" env:"SHORT_CODE_POOL_REFILL_INTERVAL"`
+	ShortCodePoolPrefetch       int           `yaml:"shortCodePoolPrefetch" toml:"shortCodePoolPrefetch" env:"SHORT_CODE_POOL_PREFETCH"`
+	ReencryptOnStartup          bool          `yaml:"reencryptOnStartup" toml:"reencryptOnStartup" env:"URL_REENCRYPT_ON_STARTUP"`
+	ReencryptBatchSize          int           `yaml:"reencryptBatchSize" toml:"reencryptBatchSize" env:"URL_REENCRYPT_BATCH_SIZE"`
+	ReencryptPause              time.Duration `yaml:"reencryptPause" toml:"reencryptPause" env:"URL_REENCRYPT_PAUSE"`
+}
+
+type VaultConfig struct {
+	Address      string        `yaml:"address" toml:"address" env:"VAULT_ADDR"`
+	Token        string        `yaml:"token" toml:"token" env:"VAULT_TOKEN" secret:"true"`
+	Namespace    string        `yaml:"namespace" toml:"namespace" env:"VAULT_NAMESPACE"`
+	TransitMount string        `yaml:"transitMount" toml:"transitMount" env:"VAULT_TRANSIT_MOUNT"`
+	TransitKey   string        `yaml:"transitKey" toml:"transitKey" env:"VAULT_TRANSIT_KEY"`
+	Timeout      time.Duration `yaml:"timeout" toml:"timeout" env:"VAULT_TIMEOUT"`
+}
+
+type RateLimitConfig struct {
+	ShortenAnonymous     ratelimit.Limit `yaml:"shortenAnonymous" toml:"shortenAnonymous" env:"RATE_LIMIT_SHORTEN_ANONYMOUS"`
+	ShortenAuthenticated ratelimit.Limit `yaml:"shortenAuthenticated" toml:"shortenAuthenticated" env:"RATE_LIMIT_SHORTEN_AUTHENTICATED"`
+	Auth                 ratelimit.Limit `yaml:"auth" toml:"auth" env:"RATE_LIMIT_AUTH"`
+}
+
+// SchedulerConfig controls the cleanup jobs. Every instance competes for a
+// Postgres advisory lock and only the holder runs them.
+type SchedulerConfig struct {
+	Enabled          bool          `yaml:"enabled" toml:"enabled" env:"SCHEDULER_ENABLED"`
+	ElectionInterval time.Duration `yaml:"electionInterval" toml:"electionInterval" env:"SCHEDULER_ELECTION_INTERVAL"`
+	// Sessions are deleted SessionRetention after they expire or are revoked.
+	SessionCleanupInterval  time.Duration `yaml:"sessionCleanupInterval" toml:"sessionCleanupInterval" env:"SESSION_CLEANUP_INTERVAL"`
+	SessionCleanupBatchSize int           `yaml:"sessionCleanupBatchSize" toml:"sessionCleanupBatchSize" env:"SESSION_CLEANUP_BATCH_SIZE"`
+	SessionRetention        time.Duration `yaml:"sessionRetention" toml:"sessionRetention" env:"SESSION_CLEANUP_RETENTION"`
+	// Expired URLs are soft-deleted, and deleted URLs are purged
+	// URLPurgeAfter later. Purged short codes are kept in
+	// retired_short_codes and never issued again.
+	URLCleanupInterval  time.Duration `yaml:"urlCleanupInterval" toml:"urlCleanupInterval" env:"URL_CLEANUP_INTERVAL"`
+	URLCleanupBatchSize int           `yaml:"urlCleanupBatchSize" toml:"urlCleanupBatchSize" env:"URL_CLEANUP_BATCH_SIZE"`
+	URLPurgeAfter       time.Duration `yaml:"urlPurgeAfter" toml:"urlPurgeAfter" env:"URL_PURGE_AFTER"`
+	// Finished webhook deliveries are deleted Webhook.DeliveryRetention
+	// after they were created.
+	WebhookCleanupInterval  time.Duration `yaml:"webhookCleanupInterval" toml:"webhookCleanupInterval" env:"WEBHOOK_CLEANUP_INTERVAL"`
+	WebhookCleanupBatchSize int           `yaml:"webhookCleanupBatchSize" toml:"webhookCleanupBatchSize" env:"WEBHOOK_CLEANUP_BATCH_SIZE"`
+}
+
+// WebhookConfig controls outgoing webhooks. Every instance polls the
+// delivery queue; claimed deliveries are skipped by the others.
+type WebhookConfig struct {
+	DeliveryEnabled     bool          `yaml:"deliveryEnabled" toml:"deliveryEnabled" env:"WEBHOOK_DELIVERY_ENABLED"`
+	MaxEndpointsPerUser int           `yaml:"maxEndpointsPerUser" toml:"maxEndpointsPerUser" env:"WEBHOOK_MAX_ENDPOINTS_PER_USER"`
+	Timeout             time.Duration `yaml:"timeout" toml:"timeout" env:"WEBHOOK_TIMEOUT"`
+	PollInterval        time.Duration `yaml:"pollInterval" toml:"pollInterval" env:"WEBHOOK_POLL_INTERVAL"`
+	BatchSize           int           `yaml:"batchSize" toml:"batchSize" env:"WEBHOOK_BATCH_SIZE"`
+	Concurrency         int           `yaml:"concurrency" toml:"concurrency" env:"WEBHOOK_CONCURRENCY"`
+	// A delivery moves to the dead-letter state after MaxAttempts failures.
+	MaxAttempts    int           `yaml:"maxAttempts" toml:"maxAttempts" env:"WEBHOOK_MAX_ATTEMPTS"`
+	RetryBaseDelay time.Duration `yaml:"retryBaseDelay" toml:"retryBaseDelay" env:"WEBHOOK_RETRY_BASE_DELAY"`
+	RetryMaxDelay  time.Duration `yaml:"retryMaxDelay" toml:"retryMaxDelay" env:"WEBHOOK_RETRY_MAX_DELAY"`
+	// ClickBuffer is how many url.clicked events wait to be queued before
+	// new ones are dropped.
+	ClickBuffer int `yaml:"clickBuffer" toml:"clickBuffer" env:"WEBHOOK_CLICK_BUFFER"`
+	// AllowPrivateNetworks lets endpoints resolve to loopback, private and
+	// link-local addresses. Only meant for development.
+	AllowPrivateNetworks bool          `yaml:"allowPrivateNetworks" toml:"allowPrivateNetworks" env:"WEBHOOK_ALLOW_PRIVATE_NETWORKS"`
+	DeliveryRetention    time.Duration `yaml:"deliveryRetention" toml:"deliveryRetention" env:"WEBHOOK_DELIVERY_RETENTION"`
+}
+
+// DeliveryLease is how long a claimed batch is hidden from other
+// instances: enough to send it with every request timing out.
+func (c WebhookConfig) DeliveryLease() time.Duration {
+	waves := (c.BatchSize + c.Concurrency - 1) / max(c.Concurrency, 1)
+	return time.Duration(waves+1) * c.Timeout
+}
+
+// OutboxConfig controls the relay of the outbox, the domain events written
+// in the same transaction as the change that caused them. Every instance
+// can relay; claimed messages are skipped by the others.
+type OutboxConfig struct {
+	RelayEnabled bool          `yaml:"relayEnabled" toml:"relayEnabled" env:"OUTBOX_RELAY_ENABLED"`
+	PollInterval time.Duration `yaml:"pollInterval" toml:"pollInterval" env:"OUTBOX_POLL_INTERVAL"`
+	BatchSize    int           `yaml:"batchSize" toml:"batchSize" env:"OUTBOX_BATCH_SIZE"`
+	// Lease hides a claimed batch from the other instances; it has to
+	// outlast handing the whole batch to the subscribers.
+	Lease time.Duration `yaml:"lease" toml:"lease" env:"OUTBOX_LEASE"`
+	// MaxAttempts failures leave a message in the dead state, kept in the
+	// table but no longer relayed.
+	MaxAttempts    int           `yaml:"maxAttempts" toml:"maxAttempts" env:"OUTBOX_MAX_ATTEMPTS"`
+	RetryBaseDelay time.Duration `yaml:"retryBaseDelay" toml:"retryBaseDelay" env:"OUTBOX_RETRY_BASE_DELAY"`
+	RetryMaxDelay  time.Duration `yaml:"retryMaxDelay" toml:"retryMaxDelay" env:"OUTBOX_RETRY_MAX_DELAY"`
+}
+
+// IdempotencyConfig controls the Idempotency-Key support of the create
+// endpoints.
+type IdempotencyConfig struct {
+	// TTL is how long a response is replayed for retries with the same key.
+	TTL time.Duration `yaml:"ttl" toml:"ttl" env:"IDEMPOTENCY_TTL"`
+	// LockTTL frees the key of a request that never finished, e.g. because
+	// the instance running it died.
+	LockTTL time.Duration `yaml:"lockTtl" toml:"lockTtl" env:"IDEMPOTENCY_LOCK_TTL"`
+	// MaxBodyBytes bounds the request body read to fingerprint a request;
+	// larger bodies are rejected with 413.
+	MaxBodyBytes int64 `yaml:"maxBodyBytes" toml:"maxBodyBytes" env:"IDEMPOTENCY_MAX_BODY_BYTES"`
+	// FingerprintKey keys the HMAC of stored request fingerprints. Changing
+	// it makes retries of in-flight keys fail with 422 until they expire.
+	FingerprintKey string `yaml:"fingerprintKey" toml:"fingerprintKey" env:"IDEMPOTENCY_FINGERPRINT_KEY" secret:"true"`
+}
+
+type LogConfig struct {
+	Level slog.Level `yaml:"level" toml:"level" env:"LOG_LEVEL"`
+}
+
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter" toml:"exporter" env:"TRACING_EXPORTER"`
+	ServiceName string  `yaml:"serviceName" toml:"serviceName" env:"TRACING_SERVICE_NAME"`
+	SampleRatio float64 `yaml:"sampleRatio" toml:"sampleRatio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			ListenAddress:      "0.0.0.0:8080",
+			ReadTimeout:        10 * time.Second,
+			ReadHeaderTimeout:  5 * time.Second,
+			WriteTimeout:       30 * time.Second,
+			IdleTimeout:        time.Minute,
+			HealthCheckTimeout: 2 * time.Second,
+			ShutdownDrainDelay: 5 * time.Second,
+			ShutdownTimeout:    30 * time.Second,
+		},
+		Admin: AdminConfig{
+			ListenAddress: "0.0.0.0:9090",
+		},
+		Postgres: PostgresConfig{
+			Port:                 5432,
+			MaxConns:             10,
+			MinConns:             2,
+			MaxConnIdleTime:      6 * time.Minute,
+			ReplicaPort:          5432,
+			ReplicaMaxLag:        10 * time.Second,
+			ReplicaCheckInterval: 5 * time.Second,
+		},
+		Redis: RedisConfig{
+			Mode:                    redis.ModeStandalone,
+			Address:                 "localhost:6379",
+			OperationTimeout:        500 * time.Millisecond,
+			BreakerFailureThreshold: 5,
+			BreakerOpenDuration:     10 * time.Second,
+			BlacklistFailurePolicy:  resilience.FailOpen,
+			StateFailurePolicy:      resilience.FailClosed,
+		},
+		Auth: AuthConfig{
+			AccessTokenDuration:  15 * time.Minute,
+			RefreshTokenDuration: 720 * time.Hour,
+			BcryptCost:           bcrypt.DefaultCost,
+			OAuthStateExpiration: 2 * time.Minute,
+		},
+		URL: URLConfig{
+			PersistExpiration:           24 * time.Hour,
+			CacheExpiration:             time.Hour,
+			LocalCacheSize:              10000,
+			LocalCacheExpiration:        time.Minute,
+			ShortCodeLength:             6,
+			ShortCodeMaxRetries:         10,
+			ShortCodeStrategy:           shortcode.StrategyRandom,
+			ShortCodeCounterSource:      shortcode.CounterSourcePostgres,
+			ShortCodeCounterBlockSize:   100,
+			ShortCodePoolMinAvailable:   10000,
+			ShortCodePoolRefillBatch:    1000,
+			ShortCodePoolRefillInterval: 30 * time.Second,
+			ShortCodePoolPrefetch:       50,
+			KeyProvider:                 crypto.KeyProviderLocal,
+			DataKeyCacheTTL:             time.Hour,
+			DataKeyStaleGrace:           time.Hour,
+			ReencryptBatchSize:          500,
+			ReencryptPause:              100 * time.Millisecond,
+			Vault: VaultConfig{
+				TransitMount: "transit",
+				Timeout:      5 * time.Second,
+			},
+		},
+		RateLimit: RateLimitConfig{
+			ShortenAnonymous:     ratelimit.Limit{Requests: 10, Period: time.Minute},
+			ShortenAuthenticated: ratelimit.Limit{Requests: 60, Period: time.Minute},
+			Auth:                 ratelimit.Limit{Requests: 10, Period: time.Minute},
+		},
+		Scheduler: SchedulerConfig{
+			Enabled:                 true,
+			ElectionInterval:        15 * time.Second,
+			SessionCleanupInterval:  time.Hour,
+			SessionCleanupBatchSize: 1000,
+			SessionRetention:        24 * time.Hour,
+			URLCleanupInterval:      time.Hour,
+			URLCleanupBatchSize:     1000,
+			URLPurgeAfter:           30 * 24 * time.Hour,
+			WebhookCleanupInterval:  time.Hour,
+			WebhookCleanupBatchSize: 1000,
+		},
+		Webhook: WebhookConfig{
+			DeliveryEnabled:     true,
+			MaxEndpointsPerUser: 10,
+			Timeout:             10 * time.Second,
+			PollInterval:        5 * time.Second,
+			BatchSize:           50,
+			Concurrency:         8,
+			MaxAttempts:         8,
+			RetryBaseDelay:      30 * time.Second,
+			RetryMaxDelay:       6 * time.Hour,
+			ClickBuffer:         1024,
+			DeliveryRetention:   7 * 24 * time.Hour,
+		},
+		Outbox: OutboxConfig{
+			RelayEnabled:   true,
+			PollInterval:   time.Second,
+			BatchSize:      100,
+			Lease:          30 * time.Second,
+			MaxAttempts:    30,
+			RetryBaseDelay: time.Second,
+			RetryMaxDelay:  5 * time.Minute,
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:          24 * time.Hour,
+			LockTTL:      time.Minute,
+			MaxBodyBytes: 1 << 20,
+		},
+		Log: LogConfig{
+			Level: slog.LevelInfo,
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			ServiceName: "url-shortener",
+			SampleRatio: 1,
+		},
+	}
+}
+
+// NewKeyProvider returns the configured provider for wrapping data keys.
+func (c URLConfig) NewKeyProvider() (crypto.KeyProvider, error) {
+	switch c.KeyProvider {
+	case crypto.KeyProviderLocal:
+		return crypto.NewLocalKeyProvider(c.MasterKeyFile)
+	case crypto.KeyProviderVault:
+		return crypto.NewVaultTransitKeyProvider(crypto.VaultTransitConfig{
+			Address:   c.Vault.Address,
+			Token:     c.Vault.Token,
+			Namespace: c.Vault.Namespace,
+			Mount:     c.Vault.TransitMount,
+			KeyName:   c.Vault.TransitKey,
+			Timeout:   c.Vault.Timeout,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown key provider %q", c.KeyProvider)
+	}
+}
+
+// Keyring builds the URL encryption keyring and unwraps the active key, so
+// an unreachable or misconfigured key provider fails at startup.
+func (c URLConfig) Keyring(ctx context.Context) (*crypto.Keyring, error) {
+	keys, err := crypto.ParseWrappedKeys(c.EncryptionKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := c.NewKeyProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	keyring, err := crypto.NewEnvelopeKeyring(provider, c.ActiveKeyID, keys, c.DataKeyCacheTTL, c.DataKeyStaleGrace, c.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := keyring.Preload(ctx); err != nil {
+		return nil, err
+	}
+
+	return keyring, nil
+}
+
+// BlindIndexer unwraps the blind index key and returns the indexer using it.
+func (c URLConfig) BlindIndexer(ctx context.Context) (*crypto.URLBlindIndexer, error) {
+	key, err := crypto.ParseWrappedKey(c.BlindIndexKey)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := c.NewKeyProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := provider.UnwrapKey(ctx, key.Wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("unwrap blind index key %q: %w", key.ID, err)
+	}
+
+	return crypto.NewURLBlindIndexer(secret)
+}
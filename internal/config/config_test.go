@@ -0,0 +1,604 @@
+package config_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/config"
+	"github.com/brunoibarbosa/url-shortener/internal/domain/ratelimit"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/redis"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/resilience"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lookupFrom(env map[string]string) config.LookupEnvFunc {
+	return func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+}
+
+// Validation only parses wrapped keys; unwrapping happens in URLConfig.Keyring.
+const testEncryptionKey = "k1:YWJjZGVmZ2hpamtsbW5vcHFyc3R1dnd4eXowMTIzNDU="
+
+func requiredEnv() map[string]string {
+	return map[string]string{
+		"URL_ENCRYPTION_KEYS":         testEncryptionKey,
+		"URL_ENCRYPTION_ACTIVE_KEY":   "k1",
+		"URL_MASTER_KEY_FILE":         "/etc/url-shortener/master.keys",
+		"URL_BLIND_INDEX_KEY":         "bidx:wrapped",
+		"URL_SECRET":                  "12345678901234567890123456789012",
+		"JWT_SECRET":                  "jwt-secret",
+		"IDEMPOTENCY_FINGERPRINT_KEY": "fingerprint-key",
+		"GOOGLE_CLIENT_ID":            "google-id",
+		"GOOGLE_CLIENT_SECRET":        "google-secret",
+		"DB_HOST":                     "localhost",
+		"DB_USER":                     "user",
+		"DB_PASSWORD":                 "password",
+		"DB_NAME":                     "url_shortener",
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_DefaultsWithRequiredEnv(t *testing.T) {
+	cfg, err := config.Load(config.Options{LookupEnv: lookupFrom(requiredEnv())})
+
+	require.NoError(t, err)
+	assert.Equal(t, "0.0.0.0:8080", cfg.Server.ListenAddress)
+	assert.Equal(t, 5432, cfg.Postgres.Port)
+	assert.Equal(t, int32(10), cfg.Postgres.MaxConns)
+	assert.Equal(t, 6, cfg.URL.ShortCodeLength)
+	assert.Equal(t, 10, cfg.URL.ShortCodeMaxRetries)
+	assert.Equal(t, 2*time.Minute, cfg.Auth.OAuthStateExpiration)
+	assert.Equal(t, slog.LevelInfo, cfg.Log.Level)
+	assert.Equal(t, 500, cfg.URL.ReencryptBatchSize)
+	assert.Equal(t, "random", cfg.URL.ShortCodeStrategy)
+	assert.Equal(t, 10000, cfg.URL.LocalCacheSize)
+	assert.Equal(t, time.Minute, cfg.URL.LocalCacheExpiration)
+	assert.Equal(t, 500*time.Millisecond, cfg.Redis.OperationTimeout)
+	assert.Equal(t, resilience.FailOpen, cfg.Redis.BlacklistFailurePolicy)
+	assert.Equal(t, resilience.FailClosed, cfg.Redis.StateFailurePolicy)
+}
+
+func TestLoad_PostgresReplica(t *testing.T) {
+	env := requiredEnv()
+	env["DB_REPLICA_HOST"] = "replica"
+	env["DB_REPLICA_MAX_LAG"] = "0s"
+
+	_, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DB_REPLICA_MAX_LAG")
+
+	env["DB_REPLICA_MAX_LAG"] = "2s"
+
+	cfg, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.NoError(t, err)
+	assert.Equal(t, "replica", cfg.Postgres.ReplicaHost)
+	assert.Equal(t, 5432, cfg.Postgres.ReplicaPort)
+	assert.Equal(t, 2*time.Second, cfg.Postgres.ReplicaMaxLag)
+}
+
+func TestLoad_Scheduler(t *testing.T) {
+	env := requiredEnv()
+
+	cfg, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.NoError(t, err)
+	assert.True(t, cfg.Scheduler.Enabled)
+	assert.Equal(t, time.Hour, cfg.Scheduler.SessionCleanupInterval)
+	assert.Equal(t, 1000, cfg.Scheduler.URLCleanupBatchSize)
+	assert.Equal(t, 720*time.Hour, cfg.Scheduler.URLPurgeAfter)
+
+	env["URL_CLEANUP_BATCH_SIZE"] = "0"
+
+	_, err = config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "URL_CLEANUP_BATCH_SIZE")
+
+	// Settings of a disabled scheduler are not validated
+	env["SCHEDULER_ENABLED"] = "false"
+
+	cfg, err = config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.NoError(t, err)
+	assert.False(t, cfg.Scheduler.Enabled)
+}
+
+func TestLoad_Webhook(t *testing.T) {
+	env := requiredEnv()
+
+	cfg, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.NoError(t, err)
+	assert.True(t, cfg.Webhook.DeliveryEnabled)
+	assert.False(t, cfg.Webhook.AllowPrivateNetworks)
+	assert.Equal(t, 8, cfg.Webhook.MaxAttempts)
+	assert.Equal(t, 6*time.Hour, cfg.Webhook.RetryMaxDelay)
+	// 50 deliveries, 8 at a time: 7 waves plus one timeout of slack
+	assert.Equal(t, 80*time.Second, cfg.Webhook.DeliveryLease())
+
+	env["WEBHOOK_RETRY_BASE_DELAY"] = "1h"
+	env["WEBHOOK_RETRY_MAX_DELAY"] = "1m"
+
+	_, err = config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "WEBHOOK_RETRY_MAX_DELAY")
+
+	// Delivery settings are not validated on instances that do not deliver
+	env["WEBHOOK_DELIVERY_ENABLED"] = "false"
+
+	cfg, err = config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.NoError(t, err)
+	assert.False(t, cfg.Webhook.DeliveryEnabled)
+}
+
+func TestLoad_Outbox(t *testing.T) {
+	env := requiredEnv()
+
+	cfg, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.NoError(t, err)
+	assert.True(t, cfg.Outbox.RelayEnabled)
+	assert.Equal(t, time.Second, cfg.Outbox.PollInterval)
+	assert.Equal(t, 100, cfg.Outbox.BatchSize)
+	assert.Equal(t, 30, cfg.Outbox.MaxAttempts)
+
+	env["OUTBOX_MAX_ATTEMPTS"] = "0"
+
+	_, err = config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "OUTBOX_MAX_ATTEMPTS")
+
+	env["OUTBOX_MAX_ATTEMPTS"] = "30"
+
+	env["OUTBOX_LEASE"] = "0s"
+
+	_, err = config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "OUTBOX_LEASE")
+
+	env["OUTBOX_RELAY_ENABLED"] = "false"
+
+	cfg, err = config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.NoError(t, err)
+	assert.False(t, cfg.Outbox.RelayEnabled)
+}
+
+func TestLoad_Idempotency(t *testing.T) {
+	env := requiredEnv()
+
+	cfg, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, cfg.Idempotency.TTL)
+	assert.Equal(t, time.Minute, cfg.Idempotency.LockTTL)
+	assert.Equal(t, int64(1<<20), cfg.Idempotency.MaxBodyBytes)
+	assert.Equal(t, "fingerprint-key", cfg.Idempotency.FingerprintKey)
+
+	env["IDEMPOTENCY_TTL"] = "48h"
+
+	cfg, err = config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.NoError(t, err)
+	assert.Equal(t, 48*time.Hour, cfg.Idempotency.TTL)
+
+	env["IDEMPOTENCY_LOCK_TTL"] = "0s"
+
+	_, err = config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "IDEMPOTENCY_LOCK_TTL")
+
+	env["IDEMPOTENCY_LOCK_TTL"] = "1m"
+	env["IDEMPOTENCY_MAX_BODY_BYTES"] = "0"
+
+	_, err = config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "IDEMPOTENCY_MAX_BODY_BYTES")
+}
+
+func TestLoad_TrustedProxies(t *testing.T) {
+	env := requiredEnv()
+
+	cfg, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Server.TrustedProxies)
+
+	env["TRUSTED_PROXIES"] = "10.0.0.0/8, 2001:db8::/32"
+
+	cfg, err = config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("2001:db8::/32")}, cfg.Server.TrustedProxies)
+
+	env["TRUSTED_PROXIES"] = "10.0.0.1"
+
+	_, err = config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "TRUSTED_PROXIES")
+}
+
+func TestLoad_RedisModes(t *testing.T) {
+	t.Run("should use the single address in standalone mode", func(t *testing.T) {
+		cfg, err := config.Load(config.Options{LookupEnv: lookupFrom(requiredEnv())})
+
+		require.NoError(t, err)
+		conn := cfg.Redis.Connection()
+		assert.Equal(t, redis.ModeStandalone, conn.Mode)
+		assert.Equal(t, []string{"localhost:6379"}, conn.Addresses)
+	})
+
+	t.Run("should accept sentinel addresses", func(t *testing.T) {
+		env := requiredEnv()
+		env["REDIS_MODE"] = "sentinel"
+		env["REDIS_ADDRESSES"] = "sentinel-1:26379,sentinel-2:26379"
+		env["REDIS_MASTER_NAME"] = "mymaster"
+		env["REDIS_TLS_ENABLED"] = "true"
+
+		cfg, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+		require.NoError(t, err)
+		conn := cfg.Redis.Connection()
+		assert.Equal(t, []string{"sentinel-1:26379", "sentinel-2:26379"}, conn.Addresses)
+		assert.Equal(t, "mymaster", conn.MasterName)
+		assert.True(t, conn.TLS.Enabled)
+	})
+
+	t.Run("should require a master name in sentinel mode", func(t *testing.T) {
+		env := requiredEnv()
+		env["REDIS_MODE"] = "sentinel"
+
+		_, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "REDIS_ADDRESSES")
+		assert.Contains(t, err.Error(), "REDIS_MASTER_NAME")
+	})
+
+	t.Run("should reject a database other than 0 in cluster mode", func(t *testing.T) {
+		env := requiredEnv()
+		env["REDIS_MODE"] = "cluster"
+		env["REDIS_ADDRESSES"] = "node-1:6379"
+		env["REDIS_DB"] = "2"
+
+		_, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "REDIS_DB")
+	})
+
+	t.Run("should reject an unknown mode", func(t *testing.T) {
+		env := requiredEnv()
+		env["REDIS_MODE"] = "ring"
+
+		_, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "REDIS_MODE")
+	})
+}
+
+func TestLoad_RedisFailurePolicies(t *testing.T) {
+	t.Run("should parse policies from env", func(t *testing.T) {
+		env := requiredEnv()
+		env["REDIS_BLACKLIST_FAILURE_POLICY"] = "closed"
+		env["REDIS_STATE_FAILURE_POLICY"] = "open"
+
+		cfg, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+		require.NoError(t, err)
+		assert.Equal(t, resilience.FailClosed, cfg.Redis.BlacklistFailurePolicy)
+		assert.Equal(t, resilience.FailOpen, cfg.Redis.StateFailurePolicy)
+	})
+
+	t.Run("should reject an unknown policy", func(t *testing.T) {
+		env := requiredEnv()
+		env["REDIS_STATE_FAILURE_POLICY"] = "ignore"
+
+		_, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "REDIS_STATE_FAILURE_POLICY")
+	})
+
+	t.Run("should reject a non-positive breaker threshold", func(t *testing.T) {
+		env := requiredEnv()
+		env["REDIS_BREAKER_FAILURE_THRESHOLD"] = "0"
+
+		_, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "REDIS_BREAKER_FAILURE_THRESHOLD")
+	})
+}
+
+func TestLoad_ShortCodeStrategy(t *testing.T) {
+	t.Run("should accept the counter strategy", func(t *testing.T) {
+		env := requiredEnv()
+		env["SHORT_CODE_STRATEGY"] = "counter"
+		env["SHORT_CODE_COUNTER_SOURCE"] = "redis"
+
+		cfg, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+		require.NoError(t, err)
+		assert.Equal(t, "redis", cfg.URL.ShortCodeCounterSource)
+		assert.Equal(t, int64(100), cfg.URL.ShortCodeCounterBlockSize)
+	})
+
+	t.Run("should limit the code length of the counter strategy", func(t *testing.T) {
+		env := requiredEnv()
+		env["SHORT_CODE_STRATEGY"] = "counter"
+		env["SHORT_CODE_LENGTH"] = "12"
+		env["SHORT_CODE_COUNTER_SOURCE"] = "memcached"
+
+		_, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SHORT_CODE_LENGTH")
+		assert.Contains(t, err.Error(), "SHORT_CODE_COUNTER_SOURCE")
+	})
+
+	t.Run("should reject an unknown strategy", func(t *testing.T) {
+		env := requiredEnv()
+		env["SHORT_CODE_STRATEGY"] = "sequential"
+
+		_, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SHORT_CODE_STRATEGY")
+	})
+}
+
+func TestLoad_EncryptionKeys(t *testing.T) {
+	t.Run("should build a keyring from keys wrapped by the local provider", func(t *testing.T) {
+		masterKey, err := crypto.GenerateKey("master")
+		require.NoError(t, err)
+		masterKeyFile := writeFile(t, "master.keys", "# master keys\n"+masterKey+"\n")
+		provider, err := crypto.NewLocalKeyProvider(masterKeyFile)
+		require.NoError(t, err)
+		dataKey, err := crypto.NewDataKey(context.Background(), provider, "k2")
+		require.NoError(t, err)
+
+		env := requiredEnv()
+		env["URL_ENCRYPTION_KEYS"] = dataKey
+		env["URL_ENCRYPTION_ACTIVE_KEY"] = "k2"
+		env["URL_MASTER_KEY_FILE"] = masterKeyFile
+		cfg, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+		require.NoError(t, err)
+
+		keyring, err := cfg.URL.Keyring(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, "k2", keyring.ActiveKeyID())
+	})
+
+	t.Run("should build the blind indexer from a wrapped key", func(t *testing.T) {
+		masterKey, err := crypto.GenerateKey("master")
+		require.NoError(t, err)
+		masterKeyFile := writeFile(t, "master.keys", masterKey)
+		provider, err := crypto.NewLocalKeyProvider(masterKeyFile)
+		require.NoError(t, err)
+		blindIndexKey, err := crypto.NewDataKey(context.Background(), provider, "bidx")
+		require.NoError(t, err)
+
+		env := requiredEnv()
+		env["URL_BLIND_INDEX_KEY"] = blindIndexKey
+		env["URL_MASTER_KEY_FILE"] = masterKeyFile
+		cfg, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+		require.NoError(t, err)
+
+		indexer, err := cfg.URL.BlindIndexer(context.Background())
+
+		require.NoError(t, err)
+		assert.Len(t, indexer.Index("https://example.com"), 32)
+	})
+
+	t.Run("should fail when the data key cannot be unwrapped", func(t *testing.T) {
+		masterKey, err := crypto.GenerateKey("master")
+		require.NoError(t, err)
+
+		env := requiredEnv()
+		env["URL_MASTER_KEY_FILE"] = writeFile(t, "master.keys", masterKey)
+		cfg, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+		require.NoError(t, err)
+
+		_, err = cfg.URL.Keyring(context.Background())
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should require the settings of the selected key provider", func(t *testing.T) {
+		env := requiredEnv()
+		env["URL_KEY_PROVIDER"] = "vault"
+
+		_, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "VAULT_ADDR: is required")
+		assert.Contains(t, err.Error(), "VAULT_TRANSIT_KEY: is required")
+	})
+
+	t.Run("should reject an active key that is not configured", func(t *testing.T) {
+		env := requiredEnv()
+		env["URL_ENCRYPTION_ACTIVE_KEY"] = "k2"
+
+		_, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "URL_ENCRYPTION_ACTIVE_KEY")
+	})
+
+	t.Run("should reject malformed keys", func(t *testing.T) {
+		env := requiredEnv()
+		env["URL_ENCRYPTION_KEYS"] = "k1"
+
+		_, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "URL_ENCRYPTION_KEYS")
+	})
+}
+
+func TestLoad_EnvOverridesTypedValues(t *testing.T) {
+	adminID := uuid.New()
+	env := requiredEnv()
+	env["DB_PORT"] = "6543"
+	env["DB_MAX_CONNS"] = "20"
+	env["URL_CACHE_EXPIRATION_DURATION"] = "30m"
+	env["RATE_LIMIT_AUTH"] = "5/1m"
+	env["LOG_LEVEL"] = "debug"
+	env["TRACING_SAMPLE_RATIO"] = "0.25"
+	env["ADMIN_USER_IDS"] = adminID.String() + ", "
+
+	cfg, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.NoError(t, err)
+	assert.Equal(t, 6543, cfg.Postgres.Port)
+	assert.Equal(t, int32(20), cfg.Postgres.MaxConns)
+	assert.Equal(t, 30*time.Minute, cfg.URL.CacheExpiration)
+	assert.Equal(t, ratelimit.Limit{Requests: 5, Period: time.Minute}, cfg.RateLimit.Auth)
+	assert.Equal(t, slog.LevelDebug, cfg.Log.Level)
+	assert.Equal(t, 0.25, cfg.Tracing.SampleRatio)
+	assert.Equal(t, []uuid.UUID{adminID}, cfg.Admin.UserIDs)
+}
+
+func TestLoad_YAMLFile(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+server:
+  listenAddress: 127.0.0.1:8000
+  writeTimeout: 45s
+url:
+  shortCodeLength: 8
+rateLimit:
+  shortenAnonymous: 3/1s
+`)
+
+	cfg, err := config.Load(config.Options{File: path, LookupEnv: lookupFrom(requiredEnv())})
+
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:8000", cfg.Server.ListenAddress)
+	assert.Equal(t, 45*time.Second, cfg.Server.WriteTimeout)
+	assert.Equal(t, 8, cfg.URL.ShortCodeLength)
+	assert.Equal(t, ratelimit.Limit{Requests: 3, Period: time.Second}, cfg.RateLimit.ShortenAnonymous)
+	assert.Equal(t, 10*time.Second, cfg.Server.ReadTimeout, "unset keys keep their defaults")
+}
+
+func TestLoad_TOMLFile(t *testing.T) {
+	path := writeFile(t, "config.toml", `
+[postgres]
+maxConns = 25
+maxConnIdleTime = "10m"
+
+[auth]
+bcryptCost = 12
+`)
+
+	cfg, err := config.Load(config.Options{File: path, LookupEnv: lookupFrom(requiredEnv())})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(25), cfg.Postgres.MaxConns)
+	assert.Equal(t, 10*time.Minute, cfg.Postgres.MaxConnIdleTime)
+	assert.Equal(t, 12, cfg.Auth.BcryptCost)
+}
+
+func TestLoad_EnvOverridesFile(t *testing.T) {
+	path := writeFile(t, "config.yaml", "url:\n  shortCodeLength: 8\n")
+	env := requiredEnv()
+	env["SHORT_CODE_LENGTH"] = "10"
+
+	cfg, err := config.Load(config.Options{File: path, LookupEnv: lookupFrom(env)})
+
+	require.NoError(t, err)
+	assert.Equal(t, 10, cfg.URL.ShortCodeLength)
+}
+
+func TestLoad_FileErrors(t *testing.T) {
+	t.Run("should reject unknown keys", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", "url:\n  shortCodeLenght: 8\n")
+
+		_, err := config.Load(config.Options{File: path, LookupEnv: lookupFrom(requiredEnv())})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject unsupported formats", func(t *testing.T) {
+		path := writeFile(t, "config.json", "{}")
+
+		_, err := config.Load(config.Options{File: path, LookupEnv: lookupFrom(requiredEnv())})
+
+		assert.ErrorIs(t, err, config.ErrUnsupportedFileFormat)
+	})
+}
+
+func TestLoad_ReportsAllErrors(t *testing.T) {
+	env := map[string]string{
+		"URL_SECRET":   "too-short",
+		"DB_PORT":      "not-a-number",
+		"LOG_LEVEL":    "verbose",
+		"BCRYPT_COST":  "99",
+		"DB_MIN_CONNS": "50",
+	}
+
+	_, err := config.Load(config.Options{LookupEnv: lookupFrom(env)})
+
+	require.Error(t, err)
+	msg := err.Error()
+	for _, key := range []string{
+		"URL_SECRET: must be exactly 16, 24 or 32 bytes",
+		"DB_PORT: invalid value",
+		"LOG_LEVEL: invalid value",
+		"BCRYPT_COST: must be between",
+		"DB_MIN_CONNS: must be between",
+		"JWT_SECRET: is required",
+		"DB_HOST: is required",
+	} {
+		assert.Contains(t, msg, key)
+	}
+}
+
+func TestConfig_WriteYAMLRedactsSecrets(t *testing.T) {
+	cfg, err := config.Load(config.Options{LookupEnv: lookupFrom(requiredEnv())})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, cfg.WriteYAML(&buf))
+
+	out := buf.String()
+	assert.NotContains(t, out, "12345678901234567890123456789012")
+	assert.NotContains(t, out, "jwt-secret")
+	assert.NotContains(t, out, "fingerprint-key")
+	assert.NotContains(t, out, "YWJjZGVmZ2hpamtsbW5vcHFyc3R1dnd4eXowMTIzNDU=")
+	assert.NotContains(t, out, "google-secret")
+	assert.NotContains(t, out, "password: password")
+	assert.Contains(t, out, "********")
+	assert.Contains(t, out, "googleClientId: google-id")
+	assert.Contains(t, out, "shortenAnonymous: 10/1m0s")
+
+	assert.Equal(t, "jwt-secret", cfg.Auth.JWTSecret, "redaction must not modify the original")
+	assert.Equal(t, []string{testEncryptionKey}, cfg.URL.EncryptionKeys, "redaction must not modify the original")
+}
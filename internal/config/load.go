@@ -0,0 +1,164 @@
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrUnsupportedFileFormat = errors.New("unsupported config file format, expected .yaml, .yml or .toml")
+
+type LookupEnvFunc func(key string) (string, bool)
+
+type Options struct {
+	// File is an optional YAML or TOML file applied on top of the defaults.
+	File string
+	// LookupEnv resolves environment variables; defaults to os.LookupEnv.
+	LookupEnv LookupEnvFunc
+}
+
+// Load builds the configuration and validates it. Every problem found is
+// reported in the returned error, joined with errors.Join; the partially
+// loaded config is still returned so callers can inspect it.
+func Load(opts Options) (*Config, error) {
+	cfg := Default()
+
+	if opts.File != "" {
+		if err := loadFile(opts.File, cfg); err != nil {
+			return cfg, fmt.Errorf("config file %s: %w", opts.File, err)
+		}
+	}
+
+	lookup := opts.LookupEnv
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+
+	errs := applyEnv(reflect.ValueOf(cfg).Elem(), lookup)
+	errs = append(errs, cfg.validate()...)
+
+	return cfg, errors.Join(errs...)
+}
+
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		return dec.Decode(cfg)
+	case ".toml":
+		md, err := toml.Decode(string(data), cfg)
+		if err != nil {
+			return err
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("unknown keys: %v", undecoded)
+		}
+		return nil
+	default:
+		return ErrUnsupportedFileFormat
+	}
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func applyEnv(v reflect.Value, lookup LookupEnvFunc) []error {
+	var errs []error
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+
+		key, ok := field.Tag.Lookup("env")
+		if !ok {
+			if fv.Kind() == reflect.Struct {
+				errs = append(errs, applyEnv(fv, lookup)...)
+			}
+			continue
+		}
+
+		raw, ok := lookup(key)
+		if !ok || strings.TrimSpace(raw) == "" {
+			continue
+		}
+
+		if err := setValue(fv, strings.TrimSpace(raw)); err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid value %q: %w", key, raw, err))
+		}
+	}
+
+	return errs
+}
+
+func setValue(v reflect.Value, raw string) error {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(raw))
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return errors.New("expected an integer")
+		}
+		v.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return errors.New("expected a number")
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return errors.New("expected a boolean")
+		}
+		v.SetBool(b)
+	case reflect.Slice:
+		var parts []string
+		for _, p := range strings.Split(raw, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				parts = append(parts, p)
+			}
+		}
+
+		slice := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, p := range parts {
+			if err := setValue(slice.Index(i), p); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	default:
+		return fmt.Errorf("unsupported config type %s", v.Type())
+	}
+
+	return nil
+}
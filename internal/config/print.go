@@ -0,0 +1,46 @@
+package config
+
+import (
+	"io"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "********"
+
+// Redacted returns a copy of the config with every non-empty secret replaced.
+func (c *Config) Redacted() *Config {
+	cp := *c
+	redact(reflect.ValueOf(&cp).Elem())
+	return &cp
+}
+
+func (c *Config) WriteYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c.Redacted()); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func redact(v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		fv := v.Field(i)
+		switch {
+		case fv.Kind() == reflect.Struct && t.Field(i).Tag.Get("env") == "":
+			redact(fv)
+		case t.Field(i).Tag.Get("secret") == "true" && fv.Kind() == reflect.String && fv.String() != "":
+			fv.SetString(redacted)
+		case t.Field(i).Tag.Get("secret") == "true" && fv.Kind() == reflect.Slice && fv.Len() > 0:
+			// Copy before writing so the original config keeps its values.
+			masked := reflect.MakeSlice(fv.Type(), fv.Len(), fv.Len())
+			for j := 0; j < fv.Len(); j++ {
+				masked.Index(j).SetString(redacted)
+			}
+			fv.Set(masked)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/redis"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/crypto"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/shortcode"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/tracing"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type validator struct {
+	errs []error
+}
+
+func (v *validator) check(ok bool, key, format string, args ...any) {
+	if !ok {
+		v.errs = append(v.errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+}
+
+func (v *validator) required(value, key string) {
+	v.check(value != "", key, "is required")
+}
+
+func (v *validator) positive(d time.Duration, key string) {
+	v.check(d > 0, key, "must be a positive duration (got %s)", d)
+}
+
+func (v *validator) address(addr, key string) {
+	if addr == "" {
+		v.required(addr, key)
+		return
+	}
+	_, _, err := net.SplitHostPort(addr)
+	v.check(err == nil, key, "must be a host:port address (got %q)", addr)
+}
+
+func (c *Config) validate() []error {
+	v := &validator{}
+
+	v.address(c.Server.ListenAddress, "LISTEN_ADDRESS")
+	v.positive(c.Server.ReadTimeout, "SERVER_READ_TIMEOUT")
+	v.positive(c.Server.ReadHeaderTimeout, "SERVER_READ_HEADER_TIMEOUT")
+	v.positive(c.Server.WriteTimeout, "SERVER_WRITE_TIMEOUT")
+	v.positive(c.Server.IdleTimeout, "SERVER_IDLE_TIMEOUT")
+	v.positive(c.Server.HealthCheckTimeout, "HEALTH_CHECK_TIMEOUT")
+	v.check(c.Server.ShutdownDrainDelay >= 0, "SHUTDOWN_DRAIN_DELAY", "must not be negative")
+	v.positive(c.Server.ShutdownTimeout, "SHUTDOWN_TIMEOUT")
+
+	v.address(c.Admin.ListenAddress, "ADMIN_LISTEN_ADDRESS")
+	v.check(c.Admin.ListenAddress != c.Server.ListenAddress, "ADMIN_LISTEN_ADDRESS", "must differ from LISTEN_ADDRESS")
+
+	v.required(c.Postgres.Host, "DB_HOST")
+	v.check(c.Postgres.Port > 0 && c.Postgres.Port <= 65535, "DB_PORT", "must be between 1 and 65535 (got %d)", c.Postgres.Port)
+	v.required(c.Postgres.User, "DB_USER")
+	v.required(c.Postgres.Password, "DB_PASSWORD")
+	v.required(c.Postgres.Name, "DB_NAME")
+	v.check(c.Postgres.MaxConns > 0, "DB_MAX_CONNS", "must be positive (got %d)", c.Postgres.MaxConns)
+	v.check(c.Postgres.MinConns >= 0 && c.Postgres.MinConns <= c.Postgres.MaxConns, "DB_MIN_CONNS", "must be between 0 and DB_MAX_CONNS (got %d)", c.Postgres.MinConns)
+	v.positive(c.Postgres.MaxConnIdleTime, "DB_MAX_CONN_IDLE_TIME")
+	if c.Postgres.ReplicaHost != "" {
+		v.check(c.Postgres.ReplicaPort > 0 && c.Postgres.ReplicaPort <= 65535, "DB_REPLICA_PORT", "must be between 1 and 65535 (got %d)", c.Postgres.ReplicaPort)
+		v.positive(c.Postgres.ReplicaMaxLag, "DB_REPLICA_MAX_LAG")
+		v.positive(c.Postgres.ReplicaCheckInterval, "DB_REPLICA_CHECK_INTERVAL")
+	}
+
+	switch c.Redis.Mode {
+	case redis.ModeStandalone:
+		v.address(c.Redis.Address, "REDIS_ADDRESS")
+	case redis.ModeSentinel, redis.ModeCluster:
+		v.check(len(c.Redis.Addresses) > 0, "REDIS_ADDRESSES", "is required in %s mode", c.Redis.Mode)
+		for _, addr := range c.Redis.Addresses {
+			v.address(addr, "REDIS_ADDRESSES")
+		}
+		if c.Redis.Mode == redis.ModeSentinel {
+			v.required(c.Redis.MasterName, "REDIS_MASTER_NAME")
+		} else {
+			v.check(c.Redis.DB == 0, "REDIS_DB", "must be 0 in cluster mode (got %d)", c.Redis.DB)
+		}
+	default:
+		v.check(false, "REDIS_MODE", "must be one of standalone, sentinel or cluster (got %q)", c.Redis.Mode)
+	}
+	v.check(c.Redis.DB >= 0, "REDIS_DB", "must not be negative (got %d)", c.Redis.DB)
+	v.check((c.Redis.TLS.CertFile == "") == (c.Redis.TLS.KeyFile == ""), "REDIS_TLS_KEY_FILE", "must be set together with REDIS_TLS_CERT_FILE")
+	v.positive(c.Redis.OperationTimeout, "REDIS_OPERATION_TIMEOUT")
+	v.check(c.Redis.BreakerFailureThreshold > 0, "REDIS_BREAKER_FAILURE_THRESHOLD", "must be positive (got %d)", c.Redis.BreakerFailureThreshold)
+	v.positive(c.Redis.BreakerOpenDuration, "REDIS_BREAKER_OPEN_DURATION")
+
+	v.required(c.Auth.JWTSecret, "JWT_SECRET")
+	v.required(c.Auth.GoogleClientID, "GOOGLE_CLIENT_ID")
+	v.required(c.Auth.GoogleClientSecret, "GOOGLE_CLIENT_SECRET")
+	v.positive(c.Auth.AccessTokenDuration, "ACCESS_TOKEN_DURATION")
+	v.positive(c.Auth.RefreshTokenDuration, "REFRESH_TOKEN_DURATION")
+	v.check(c.Auth.BcryptCost >= bcrypt.MinCost && c.Auth.BcryptCost <= bcrypt.MaxCost, "BCRYPT_COST", "must be between %d and %d (got %d)", bcrypt.MinCost, bcrypt.MaxCost, c.Auth.BcryptCost)
+	v.positive(c.Auth.OAuthStateExpiration, "OAUTH_STATE_EXPIRATION")
+
+	if n := len(c.URL.Secret); n > 0 {
+		v.check(n == 16 || n == 24 || n == 32, "URL_SECRET", "must be exactly 16, 24 or 32 bytes for AES (got %d)", n)
+	}
+	if len(c.URL.EncryptionKeys) == 0 {
+		v.check(false, "URL_ENCRYPTION_KEYS", "is required")
+	} else if keys, err := crypto.ParseWrappedKeys(c.URL.EncryptionKeys); err != nil {
+		v.check(false, "URL_ENCRYPTION_KEYS", "%v", err)
+	} else if _, err := crypto.NewEnvelopeKeyring(nil, c.URL.ActiveKeyID, keys, 0, 0, ""); err != nil {
+		v.check(false, "URL_ENCRYPTION_ACTIVE_KEY", "%v", err)
+	}
+	if c.URL.BlindIndexKey == "" {
+		v.required(c.URL.BlindIndexKey, "URL_BLIND_INDEX_KEY")
+	} else if _, err := crypto.ParseWrappedKey(c.URL.BlindIndexKey); err != nil {
+		v.check(false, "URL_BLIND_INDEX_KEY", "%v", err)
+	}
+	switch c.URL.KeyProvider {
+	case crypto.KeyProviderLocal:
+		v.required(c.URL.MasterKeyFile, "URL_MASTER_KEY_FILE")
+	case crypto.KeyProviderVault:
+		v.required(c.URL.Vault.Address, "VAULT_ADDR")
+		v.required(c.URL.Vault.Token, "VAULT_TOKEN")
+		v.required(c.URL.Vault.TransitMount, "VAULT_TRANSIT_MOUNT")
+		v.required(c.URL.Vault.TransitKey, "VAULT_TRANSIT_KEY")
+		v.positive(c.URL.Vault.Timeout, "VAULT_TIMEOUT")
+	default:
+		v.check(false, "URL_KEY_PROVIDER", "must be one of local or vault (got %q)", c.URL.KeyProvider)
+	}
+	v.positive(c.URL.DataKeyCacheTTL, "URL_DATA_KEY_CACHE_TTL")
+	v.check(c.URL.DataKeyStaleGrace >= 0, "URL_DATA_KEY_STALE_GRACE", "must not be negative")
+	v.positive(c.URL.PersistExpiration, "URL_PERSIST_EXPIRATION_DURATION")
+	v.positive(c.URL.CacheExpiration, "URL_CACHE_EXPIRATION_DURATION")
+	v.check(c.URL.LocalCacheSize >= 0, "URL_LOCAL_CACHE_SIZE", "must not be negative (got %d)", c.URL.LocalCacheSize)
+	v.positive(c.URL.LocalCacheExpiration, "URL_LOCAL_CACHE_EXPIRATION_DURATION")
+	v.check(c.URL.ShortCodeLength >= 4 && c.URL.ShortCodeLength <= 32, "SHORT_CODE_LENGTH", "must be between 4 and 32 (got %d)", c.URL.ShortCodeLength)
+	v.check(c.URL.ShortCodeMaxRetries > 0, "SHORT_CODE_MAX_RETRIES", "must be positive (got %d)", c.URL.ShortCodeMaxRetries)
+	switch c.URL.ShortCodeStrategy {
+	case shortcode.StrategyRandom:
+	case shortcode.StrategyCounter:
+		v.check(c.URL.ShortCodeLength <= shortcode.MaxCounterLength, "SHORT_CODE_LENGTH", "must be at most %d with the counter strategy (got %d)", shortcode.MaxCounterLength, c.URL.ShortCodeLength)
+		v.check(c.URL.ShortCodeCounterSource == shortcode.CounterSourcePostgres || c.URL.ShortCodeCounterSource == shortcode.CounterSourceRedis,
+			"SHORT_CODE_COUNTER_SOURCE", "must be one of postgres or redis (got %q)", c.URL.ShortCodeCounterSource)
+		v.check(c.URL.ShortCodeCounterBlockSize > 0, "SHORT_CODE_COUNTER_BLOCK_SIZE", "must be positive (got %d)", c.URL.ShortCodeCounterBlockSize)
+	case shortcode.StrategyPool:
+		v.check(c.URL.ShortCodePoolMinAvailable > 0, "SHORT_CODE_POOL_MIN_AVAILABLE", "must be positive (got %d)", c.URL.ShortCodePoolMinAvailable)
+		v.check(c.URL.ShortCodePoolRefillBatch > 0, "SHORT_CODE_POOL_REFILL_BATCH", "must be positive (got %d)", c.URL.ShortCodePoolRefillBatch)
+		v.positive(c.URL.ShortCodePoolRefillInterval, "SHORT_CODE_POOL_REFILL_INTERVAL")
+		v.check(c.URL.ShortCodePoolPrefetch > 0, "SHORT_CODE_POOL_PREFETCH", "must be positive (got %d)", c.URL.ShortCodePoolPrefetch)
+	default:
+		v.check(false, "SHORT_CODE_STRATEGY", "must be one of random, counter or pool (got %q)", c.URL.ShortCodeStrategy)
+	}
+	v.check(c.URL.ReencryptBatchSize > 0, "URL_REENCRYPT_BATCH_SIZE", "must be positive (got %d)", c.URL.ReencryptBatchSize)
+	v.check(c.URL.ReencryptPause >= 0, "URL_REENCRYPT_PAUSE", "must not be negative")
+
+	v.check(!c.RateLimit.ShortenAnonymous.IsZero(), "RATE_LIMIT_SHORTEN_ANONYMOUS", "is required")
+	v.check(!c.RateLimit.ShortenAuthenticated.IsZero(), "RATE_LIMIT_SHORTEN_AUTHENTICATED", "is required")
+	v.check(!c.RateLimit.Auth.IsZero(), "RATE_LIMIT_AUTH", "is required")
+
+	if c.Scheduler.Enabled {
+		v.positive(c.Scheduler.ElectionInterval, "SCHEDULER_ELECTION_INTERVAL")
+		v.positive(c.Scheduler.SessionCleanupInterval, "SESSION_CLEANUP_INTERVAL")
+		v.check(c.Scheduler.SessionCleanupBatchSize > 0, "SESSION_CLEANUP_BATCH_SIZE", "must be positive (got %d)", c.Scheduler.SessionCleanupBatchSize)
+		v.check(c.Scheduler.SessionRetention >= 0, "SESSION_CLEANUP_RETENTION", "must not be negative")
+		v.positive(c.Scheduler.URLCleanupInterval, "URL_CLEANUP_INTERVAL")
+		v.check(c.Scheduler.URLCleanupBatchSize > 0, "URL_CLEANUP_BATCH_SIZE", "must be positive (got %d)", c.Scheduler.URLCleanupBatchSize)
+		v.check(c.Scheduler.URLPurgeAfter >= 0, "URL_PURGE_AFTER", "must not be negative")
+		v.positive(c.Scheduler.WebhookCleanupInterval, "WEBHOOK_CLEANUP_INTERVAL")
+		v.check(c.Scheduler.WebhookCleanupBatchSize > 0, "WEBHOOK_CLEANUP_BATCH_SIZE", "must be positive (got %d)", c.Scheduler.WebhookCleanupBatchSize)
+	}
+
+	v.check(c.Webhook.MaxEndpointsPerUser > 0, "WEBHOOK_MAX_ENDPOINTS_PER_USER", "must be positive (got %d)", c.Webhook.MaxEndpointsPerUser)
+	v.check(c.Webhook.ClickBuffer > 0, "WEBHOOK_CLICK_BUFFER", "must be positive (got %d)", c.Webhook.ClickBuffer)
+	v.check(c.Webhook.DeliveryRetention >= 0, "WEBHOOK_DELIVERY_RETENTION", "must not be negative")
+	if c.Webhook.DeliveryEnabled {
+		v.positive(c.Webhook.Timeout, "WEBHOOK_TIMEOUT")
+		v.positive(c.Webhook.PollInterval, "WEBHOOK_POLL_INTERVAL")
+		v.check(c.Webhook.BatchSize > 0, "WEBHOOK_BATCH_SIZE", "must be positive (got %d)", c.Webhook.BatchSize)
+		v.check(c.Webhook.Concurrency > 0, "WEBHOOK_CONCURRENCY", "must be positive (got %d)", c.Webhook.Concurrency)
+		v.check(c.Webhook.MaxAttempts > 0, "WEBHOOK_MAX_ATTEMPTS", "must be positive (got %d)", c.Webhook.MaxAttempts)
+		v.positive(c.Webhook.RetryBaseDelay, "WEBHOOK_RETRY_BASE_DELAY")
+		v.check(c.Webhook.RetryMaxDelay >= c.Webhook.RetryBaseDelay, "WEBHOOK_RETRY_MAX_DELAY", "must not be less than WEBHOOK_RETRY_BASE_DELAY")
+	}
+
+	if c.Outbox.RelayEnabled {
+		v.positive(c.Outbox.PollInterval, "OUTBOX_POLL_INTERVAL")
+		v.check(c.Outbox.BatchSize > 0, "OUTBOX_BATCH_SIZE", "must be positive (got %d)", c.Outbox.BatchSize)
+		v.positive(c.Outbox.Lease, "OUTBOX_LEASE")
+		v.check(c.Outbox.MaxAttempts > 0, "OUTBOX_MAX_ATTEMPTS", "must be positive (got %d)", c.Outbox.MaxAttempts)
+		v.positive(c.Outbox.RetryBaseDelay, "OUTBOX_RETRY_BASE_DELAY")
+		v.check(c.Outbox.RetryMaxDelay >= c.Outbox.RetryBaseDelay, "OUTBOX_RETRY_MAX_DELAY", "must not be less than OUTBOX_RETRY_BASE_DELAY")
+	}
+
+	v.positive(c.Idempotency.TTL, "IDEMPOTENCY_TTL")
+	v.positive(c.Idempotency.LockTTL, "IDEMPOTENCY_LOCK_TTL")
+	v.required(c.Idempotency.FingerprintKey, "IDEMPOTENCY_FINGERPRINT_KEY")
+	v.check(c.Idempotency.MaxBodyBytes > 0, "IDEMPOTENCY_MAX_BODY_BYTES", "must be positive (got %d)", c.Idempotency.MaxBodyBytes)
+
+	switch c.Tracing.Exporter {
+	case tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP:
+	default:
+		v.check(false, "TRACING_EXPORTER", "must be one of none, stdout or otlp (got %q)", c.Tracing.Exporter)
+	}
+	v.check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "TRACING_SAMPLE_RATIO", "must be between 0 and 1 (got %g)", c.Tracing.SampleRatio)
+	if c.Tracing.Exporter != tracing.ExporterNone {
+		v.required(c.Tracing.ServiceName, "TRACING_SERVICE_NAME")
+	}
+
+	return v.errs
+}
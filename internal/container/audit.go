@@ -0,0 +1,29 @@
+package container
+
+import (
+	"github.com/brunoibarbosa/url-shortener/internal/app/audit/query"
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+)
+
+type AuditHandlerFactory struct {
+	listEventsRepo audit_domain.AuditQueryRepository
+
+	listHandler *query.ListAuditEventsHandler
+}
+
+type AuditFactoryDependencies struct {
+	ListEventsRepo audit_domain.AuditQueryRepository
+}
+
+func NewAuditHandlerFactory(deps AuditFactoryDependencies) *AuditHandlerFactory {
+	return &AuditHandlerFactory{
+		listEventsRepo: deps.ListEventsRepo,
+	}
+}
+
+func (f *AuditHandlerFactory) ListAuditEventsHandler() *query.ListAuditEventsHandler {
+	if f.listHandler == nil {
+		f.listHandler = query.NewListAuditEventsHandler(f.listEventsRepo)
+	}
+	return f.listHandler
+}
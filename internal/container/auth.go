@@ -4,7 +4,9 @@ import (
 	"time"
 
 	"github.com/brunoibarbosa/url-shortener/internal/app/auth/command"
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
 	bd_domain "github.com/brunoibarbosa/url-shortener/internal/domain/bd"
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
 	session_domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
 	user_domain "github.com/brunoibarbosa/url-shortener/internal/domain/user"
 )
@@ -16,11 +18,14 @@ type AuthHandlerFactory struct {
 	profileRepo          user_domain.UserProfileRepository
 	sessionRepo          session_domain.SessionRepository
 	blacklistRepo        session_domain.BlacklistRepository
+	outboxRepo           outbox_domain.OutboxRepository
 	stateService         session_domain.StateService
 	oauthProvider        session_domain.OAuthProvider
 	tokenService         session_domain.TokenService
 	passwordEncrypter    user_domain.UserPasswordEncrypter
 	sessionEncrypter     session_domain.SessionEncrypter
+	auditRecorder        audit_domain.AuditRecorder
+	metrics              session_domain.AuthMetrics
 	refreshTokenDuration time.Duration
 	accessTokenDuration  time.Duration
 
@@ -39,11 +44,14 @@ type AuthFactoryDependencies struct {
 	ProfileRepo          user_domain.UserProfileRepository
 	SessionRepo          session_domain.SessionRepository
 	BlacklistRepo        session_domain.BlacklistRepository
+	OutboxRepo           outbox_domain.OutboxRepository
 	StateService         session_domain.StateService
 	OAuthProvider        session_domain.OAuthProvider
 	TokenService         session_domain.TokenService
 	PasswordEncrypter    user_domain.UserPasswordEncrypter
 	SessionEncrypter     session_domain.SessionEncrypter
+	AuditRecorder        audit_domain.AuditRecorder
+	Metrics              session_domain.AuthMetrics
 	RefreshTokenDuration time.Duration
 	AccessTokenDuration  time.Duration
 }
@@ -56,11 +64,14 @@ func NewAuthHandlerFactory(deps AuthFactoryDependencies) *AuthHandlerFactory {
 		profileRepo:          deps.ProfileRepo,
 		sessionRepo:          deps.SessionRepo,
 		blacklistRepo:        deps.BlacklistRepo,
+		outboxRepo:           deps.OutboxRepo,
 		stateService:         deps.StateService,
 		oauthProvider:        deps.OAuthProvider,
 		tokenService:         deps.TokenService,
 		passwordEncrypter:    deps.PasswordEncrypter,
 		sessionEncrypter:     deps.SessionEncrypter,
+		auditRecorder:        deps.AuditRecorder,
+		metrics:              deps.Metrics,
 		refreshTokenDuration: deps.RefreshTokenDuration,
 		accessTokenDuration:  deps.AccessTokenDuration,
 	}
@@ -84,10 +95,13 @@ func (f *AuthHandlerFactory) LoginUserHandler() *command.LoginUserHandler {
 		f.loginUserHandler = command.NewLoginUserHandler(
 			f.txManager,
 			f.providerRepo,
+			f.userRepo,
 			f.sessionRepo,
 			f.tokenService,
 			f.passwordEncrypter,
 			f.sessionEncrypter,
+			f.auditRecorder,
+			f.metrics,
 			f.refreshTokenDuration,
 			f.accessTokenDuration,
 		)
@@ -114,6 +128,8 @@ func (f *AuthHandlerFactory) LoginGoogleHandler() *command.LoginGoogleHandler {
 			f.tokenService,
 			f.sessionEncrypter,
 			f.stateService,
+			f.auditRecorder,
+			f.metrics,
 			f.refreshTokenDuration,
 			f.accessTokenDuration,
 		)
@@ -126,9 +142,12 @@ func (f *AuthHandlerFactory) RefreshTokenHandler() *command.RefreshTokenHandler
 		f.refreshTokenHandler = command.NewRefreshTokenHandler(
 			f.txManager,
 			f.sessionRepo,
+			f.userRepo,
 			f.blacklistRepo,
+			f.outboxRepo,
 			f.tokenService,
 			f.sessionEncrypter,
+			f.auditRecorder,
 			f.refreshTokenDuration,
 			f.accessTokenDuration,
 		)
@@ -138,7 +157,7 @@ func (f *AuthHandlerFactory) RefreshTokenHandler() *command.RefreshTokenHandler
 
 func (f *AuthHandlerFactory) LogoutHandler() *command.LogoutHandler {
 	if f.logoutHandler == nil {
-		f.logoutHandler = command.NewLogoutHandler(f.sessionRepo, f.blacklistRepo, f.sessionEncrypter)
+		f.logoutHandler = command.NewLogoutHandler(f.txManager, f.sessionRepo, f.outboxRepo, f.sessionEncrypter, f.auditRecorder)
 	}
 	return f.logoutHandler
 }
@@ -5,17 +5,29 @@ import (
 
 	"github.com/brunoibarbosa/url-shortener/internal/app/url/command"
 	"github.com/brunoibarbosa/url-shortener/internal/app/url/query"
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+	bd_domain "github.com/brunoibarbosa/url-shortener/internal/domain/bd"
+	outbox_domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
 	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	webhook_domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
 )
 
 type URLHandlerFactory struct {
+	txManager                 bd_domain.TransactionManager
 	persistRepo               domain.URLRepository
 	cacheRepo                 domain.URLCacheRepository
+	localCache                domain.URLLocalCache
 	queryRepo                 domain.URLQueryRepository
 	encrypter                 domain.URLEncrypter
+	blindIndexer              domain.URLBlindIndexer
 	shortCodeGenerator        domain.ShortCodeGenerator
+	auditRecorder             audit_domain.AuditRecorder
+	outboxRepo                outbox_domain.OutboxRepository
+	clickPublisher            webhook_domain.WebhookPublisher
+	metrics                   domain.URLMetrics
 	persistExpirationDuration time.Duration
 	cacheExpirationDuration   time.Duration
+	localCacheExpiration      time.Duration
 
 	createHandler *command.CreateShortURLHandler
 	deleteHandler *command.DeleteURLHandler
@@ -24,34 +36,57 @@ type URLHandlerFactory struct {
 }
 
 type URLFactoryDependencies struct {
-	PersistRepo               domain.URLRepository
-	CacheRepo                 domain.URLCacheRepository
-	QueryRepo                 domain.URLQueryRepository
-	Encrypter                 domain.URLEncrypter
-	ShortCodeGenerator        domain.ShortCodeGenerator
+	TxManager          bd_domain.TransactionManager
+	PersistRepo        domain.URLRepository
+	CacheRepo          domain.URLCacheRepository
+	LocalCache         domain.URLLocalCache
+	QueryRepo          domain.URLQueryRepository
+	Encrypter          domain.URLEncrypter
+	BlindIndexer       domain.URLBlindIndexer
+	ShortCodeGenerator domain.ShortCodeGenerator
+	AuditRecorder      audit_domain.AuditRecorder
+	OutboxRepo         outbox_domain.OutboxRepository
+	// ClickPublisher publishes url.clicked from the redirect path, so it
+	// should not block.
+	ClickPublisher            webhook_domain.WebhookPublisher
+	Metrics                   domain.URLMetrics
 	PersistExpirationDuration time.Duration
 	CacheExpirationDuration   time.Duration
+	LocalCacheExpiration      time.Duration
 }
 
 func NewURLHandlerFactory(deps URLFactoryDependencies) *URLHandlerFactory {
 	return &URLHandlerFactory{
+		txManager:                 deps.TxManager,
 		persistRepo:               deps.PersistRepo,
 		cacheRepo:                 deps.CacheRepo,
+		localCache:                deps.LocalCache,
 		queryRepo:                 deps.QueryRepo,
 		encrypter:                 deps.Encrypter,
+		blindIndexer:              deps.BlindIndexer,
 		shortCodeGenerator:        deps.ShortCodeGenerator,
+		auditRecorder:             deps.AuditRecorder,
+		outboxRepo:                deps.OutboxRepo,
+		clickPublisher:            deps.ClickPublisher,
+		metrics:                   deps.Metrics,
 		persistExpirationDuration: deps.PersistExpirationDuration,
 		cacheExpirationDuration:   deps.CacheExpirationDuration,
+		localCacheExpiration:      deps.LocalCacheExpiration,
 	}
 }
 
 func (f *URLHandlerFactory) CreateShortURLHandler() *command.CreateShortURLHandler {
 	if f.createHandler == nil {
 		f.createHandler = command.NewCreateShortURLHandler(
+			f.txManager,
 			f.persistRepo,
 			f.cacheRepo,
 			f.encrypter,
+			f.blindIndexer,
 			f.shortCodeGenerator,
+			f.auditRecorder,
+			f.outboxRepo,
+			f.metrics,
 			f.persistExpirationDuration,
 			f.cacheExpirationDuration,
 		)
@@ -64,8 +99,12 @@ func (f *URLHandlerFactory) GetOriginalURLHandler() *query.GetOriginalURLHandler
 		f.getHandler = query.NewGetOriginalURLHandler(
 			f.persistRepo,
 			f.cacheRepo,
+			f.localCache,
 			f.encrypter,
+			f.metrics,
+			f.clickPublisher,
 			f.cacheExpirationDuration,
+			f.localCacheExpiration,
 		)
 	}
 	return f.getHandler
@@ -80,7 +119,7 @@ func (f *URLHandlerFactory) ListUserURLsHandler() *query.ListUserURLsHandler {
 
 func (f *URLHandlerFactory) DeleteURLHandler() *command.DeleteURLHandler {
 	if f.deleteHandler == nil {
-		f.deleteHandler = command.NewDeleteURLHandler(f.persistRepo, f.cacheRepo)
+		f.deleteHandler = command.NewDeleteURLHandler(f.txManager, f.persistRepo, f.outboxRepo, f.auditRecorder)
 	}
 	return f.deleteHandler
 }
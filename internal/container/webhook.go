@@ -0,0 +1,79 @@
+package container
+
+import (
+	"github.com/brunoibarbosa/url-shortener/internal/app/webhook/command"
+	"github.com/brunoibarbosa/url-shortener/internal/app/webhook/query"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+)
+
+type WebhookHandlerFactory struct {
+	endpointRepo        domain.WebhookEndpointRepository
+	deliveryRepo        domain.WebhookDeliveryRepository
+	deliveryQueryRepo   domain.WebhookDeliveryQueryRepository
+	maxEndpointsPerUser int
+
+	createHandler         *command.CreateWebhookEndpointHandler
+	deleteHandler         *command.DeleteWebhookEndpointHandler
+	sendTestHandler       *command.SendTestWebhookHandler
+	retryHandler          *command.RetryWebhookDeliveryHandler
+	listHandler           *query.ListWebhookEndpointsHandler
+	listDeliveriesHandler *query.ListWebhookDeliveriesHandler
+}
+
+type WebhookFactoryDependencies struct {
+	EndpointRepo        domain.WebhookEndpointRepository
+	DeliveryRepo        domain.WebhookDeliveryRepository
+	DeliveryQueryRepo   domain.WebhookDeliveryQueryRepository
+	MaxEndpointsPerUser int
+}
+
+func NewWebhookHandlerFactory(deps WebhookFactoryDependencies) *WebhookHandlerFactory {
+	return &WebhookHandlerFactory{
+		endpointRepo:        deps.EndpointRepo,
+		deliveryRepo:        deps.DeliveryRepo,
+		deliveryQueryRepo:   deps.DeliveryQueryRepo,
+		maxEndpointsPerUser: deps.MaxEndpointsPerUser,
+	}
+}
+
+func (f *WebhookHandlerFactory) CreateWebhookEndpointHandler() *command.CreateWebhookEndpointHandler {
+	if f.createHandler == nil {
+		f.createHandler = command.NewCreateWebhookEndpointHandler(f.endpointRepo, f.maxEndpointsPerUser)
+	}
+	return f.createHandler
+}
+
+func (f *WebhookHandlerFactory) DeleteWebhookEndpointHandler() *command.DeleteWebhookEndpointHandler {
+	if f.deleteHandler == nil {
+		f.deleteHandler = command.NewDeleteWebhookEndpointHandler(f.endpointRepo)
+	}
+	return f.deleteHandler
+}
+
+func (f *WebhookHandlerFactory) SendTestWebhookHandler() *command.SendTestWebhookHandler {
+	if f.sendTestHandler == nil {
+		f.sendTestHandler = command.NewSendTestWebhookHandler(f.endpointRepo, f.deliveryRepo)
+	}
+	return f.sendTestHandler
+}
+
+func (f *WebhookHandlerFactory) RetryWebhookDeliveryHandler() *command.RetryWebhookDeliveryHandler {
+	if f.retryHandler == nil {
+		f.retryHandler = command.NewRetryWebhookDeliveryHandler(f.deliveryRepo)
+	}
+	return f.retryHandler
+}
+
+func (f *WebhookHandlerFactory) ListWebhookEndpointsHandler() *query.ListWebhookEndpointsHandler {
+	if f.listHandler == nil {
+		f.listHandler = query.NewListWebhookEndpointsHandler(f.endpointRepo)
+	}
+	return f.listHandler
+}
+
+func (f *WebhookHandlerFactory) ListWebhookDeliveriesHandler() *query.ListWebhookDeliveriesHandler {
+	if f.listDeliveriesHandler == nil {
+		f.listDeliveriesHandler = query.NewListWebhookDeliveriesHandler(f.endpointRepo, f.deliveryQueryRepo)
+	}
+	return f.listDeliveriesHandler
+}
@@ -0,0 +1,39 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Action string
+
+const (
+	ActionLogin           Action = "auth.login"
+	ActionLoginFailed     Action = "auth.login_failed"
+	ActionRefresh         Action = "auth.refresh"
+	ActionLogout          Action = "auth.logout"
+	ActionProviderLinked  Action = "user.provider_linked"
+	ActionURLCreated      Action = "url.created"
+	ActionURLDeleted      Action = "url.deleted"
+	ActionURLDisabled     Action = "url.disabled"
+	ActionSessionsRevoked Action = "auth.sessions_revoked"
+)
+
+const (
+	TargetSession  = "session"
+	TargetUser     = "user"
+	TargetProvider = "provider"
+	TargetURL      = "url"
+)
+
+type Event struct {
+	ID         int64
+	ActorID    *uuid.UUID
+	Action     Action
+	TargetType string
+	TargetID   string
+	IPAddress  string
+	UserAgent  string
+	CreatedAt  time.Time
+}
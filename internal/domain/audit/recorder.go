@@ -0,0 +1,7 @@
+package audit
+
+import "context"
+
+type AuditRecorder interface {
+	Record(ctx context.Context, e *Event) error
+}
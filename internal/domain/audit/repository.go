@@ -0,0 +1,39 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/domain"
+	"github.com/google/uuid"
+)
+
+type AuditQueryRepository interface {
+	List(ctx context.Context, params ListEventsParams) ([]ListEventsDTO, uint64, error)
+}
+
+type ListEventsDTO struct {
+	ID         int64
+	ActorID    *uuid.UUID
+	Action     Action
+	TargetType string
+	TargetID   string
+	IPAddress  string
+	UserAgent  string
+	CreatedAt  time.Time
+}
+
+type ListEventsFilter struct {
+	ActorID   *uuid.UUID
+	Action    *Action
+	TargetID  *string
+	IPAddress *string
+	From      *time.Time
+	To        *time.Time
+}
+
+type ListEventsParams struct {
+	Filter     ListEventsFilter
+	SortKind   domain.SortKind
+	Pagination domain.Pagination
+}
@@ -0,0 +1,70 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+const MaxKeyLength = 255
+
+var (
+	ErrInvalidKey = errors.New("idempotency key must be 1 to 255 printable ASCII characters")
+	// ErrReservationLost means the lock of a request expired and the key was
+	// reserved again by a retry, which now owns it.
+	ErrReservationLost = errors.New("idempotency key is no longer reserved by this request")
+)
+
+// Response is what a replayed request gets back instead of running again.
+// Header holds only the headers that describe the stored body.
+type Response struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   []byte      `json:"body,omitempty"`
+}
+
+// Record ties a key to the request that first used it. Response stays nil
+// while that request is still in flight, and Token identifies it until then.
+type Record struct {
+	Fingerprint string    `json:"fingerprint"`
+	Token       string    `json:"token,omitempty"`
+	Response    *Response `json:"response,omitempty"`
+}
+
+func (r *Record) Matches(fingerprint string) bool {
+	return r.Fingerprint == fingerprint
+}
+
+func (r *Record) Completed() bool {
+	return r.Response != nil
+}
+
+type IdempotencyRepository interface {
+	// Reserve claims key for the request identified by token for at most
+	// lockTTL. When the key is already taken it returns the existing record
+	// and claims nothing.
+	Reserve(ctx context.Context, key, token, fingerprint string, lockTTL time.Duration) (*Record, error)
+	// Complete and Release only act on a key still reserved by token, and
+	// return ErrReservationLost otherwise.
+	Complete(ctx context.Context, key, token string, record *Record, ttl time.Duration) error
+	Release(ctx context.Context, key, token string) error
+}
+
+func ValidateKey(key string) error {
+	if key == "" || len(key) > MaxKeyLength {
+		return ErrInvalidKey
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x21 || key[i] > 0x7e {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
+
+// Storable reports whether a response may be replayed. Server errors are
+// not stored so the client can retry them with the same key.
+func Storable(status int) bool {
+	return status < http.StatusInternalServerError
+}
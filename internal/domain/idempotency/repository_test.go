@@ -0,0 +1,54 @@
+package idempotency_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/domain/idempotency"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{name: "uuid", key: "7f1d4a4e-8a43-4c55-9d4b-2a4f0f0c1e11"},
+		{name: "max length", key: strings.Repeat("a", idempotency.MaxKeyLength)},
+		{name: "empty", key: "", wantErr: true},
+		{name: "too long", key: strings.Repeat("a", idempotency.MaxKeyLength+1), wantErr: true},
+		{name: "space", key: "abc def", wantErr: true},
+		{name: "non ascii", key: "chave-única", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := idempotency.ValidateKey(tt.key)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, idempotency.ErrInvalidKey)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRecord(t *testing.T) {
+	r := &idempotency.Record{Fingerprint: "abc"}
+
+	assert.True(t, r.Matches("abc"))
+	assert.False(t, r.Matches("abd"))
+	assert.False(t, r.Completed())
+
+	r.Response = &idempotency.Response{Status: http.StatusCreated}
+	assert.True(t, r.Completed())
+}
+
+func TestStorable(t *testing.T) {
+	assert.True(t, idempotency.Storable(http.StatusCreated))
+	assert.True(t, idempotency.Storable(http.StatusConflict))
+	assert.False(t, idempotency.Storable(http.StatusInternalServerError))
+	assert.False(t, idempotency.Storable(http.StatusServiceUnavailable))
+}
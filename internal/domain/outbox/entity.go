@@ -0,0 +1,37 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message is a domain event written in the same transaction as the change
+// that caused it. The relay hands it to the topic's subscribers at least
+// once, so they must tolerate seeing it again.
+type Message struct {
+	ID         uuid.UUID
+	Topic      string
+	Payload    []byte
+	OccurredAt time.Time
+	Attempts   int
+}
+
+func NewMessage(topic string, data any) (*Message, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		ID:         uuid.New(),
+		Topic:      topic,
+		Payload:    payload,
+		OccurredAt: time.Now().UTC(),
+	}, nil
+}
+
+func (m *Message) Decode(v any) error {
+	return json.Unmarshal(m.Payload, v)
+}
@@ -0,0 +1,53 @@
+package outbox_test
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMessage(t *testing.T) {
+	type data struct {
+		ShortCode string `json:"shortCode"`
+	}
+
+	m, err := domain.NewMessage("url.deleted", data{ShortCode: "abc123"})
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, m.ID)
+	assert.Equal(t, "url.deleted", m.Topic)
+	assert.JSONEq(t, `{"shortCode":"abc123"}`, string(m.Payload))
+	assert.False(t, m.OccurredAt.IsZero())
+
+	var decoded data
+	require.NoError(t, m.Decode(&decoded))
+	assert.Equal(t, "abc123", decoded.ShortCode)
+}
+
+func TestNewMessage_UnencodableData(t *testing.T) {
+	_, err := domain.NewMessage("url.deleted", make(chan int))
+	assert.Error(t, err)
+}
+
+func TestSubscriptions_Subscribe(t *testing.T) {
+	var calls []string
+	subscriber := func(name string) domain.Subscriber {
+		return domain.SubscriberFunc(func(ctx context.Context, m *domain.Message) error {
+			calls = append(calls, name)
+			return nil
+		})
+	}
+
+	subs := domain.Subscriptions{}
+	subs.Subscribe("url.deleted", subscriber("cache"))
+	subs.Subscribe("url.deleted", subscriber("webhook"))
+
+	for _, s := range subs["url.deleted"] {
+		require.NoError(t, s.Handle(context.Background(), &domain.Message{}))
+	}
+	assert.Equal(t, []string{"cache", "webhook"}, calls)
+	assert.Empty(t, subs["url.created"])
+}
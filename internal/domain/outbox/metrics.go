@@ -0,0 +1,6 @@
+package outbox
+
+type OutboxMetrics interface {
+	MessageRelayed(topic string)
+	MessageFailed(topic string, dead bool)
+}
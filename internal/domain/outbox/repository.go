@@ -0,0 +1,20 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OutboxRepository interface {
+	// Append joins the transaction in ctx, if any, so the message is only
+	// stored when the change it describes is.
+	Append(ctx context.Context, m *Message) error
+	// ClaimDue hides the returned messages from other relays for lease.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Message, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// MarkFailed moves the message to the dead state, where it is no longer
+	// claimed, when nextAttemptAt is nil.
+	MarkFailed(ctx context.Context, id uuid.UUID, nextAttemptAt *time.Time, lastError string) error
+}
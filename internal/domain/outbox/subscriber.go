@@ -0,0 +1,20 @@
+package outbox
+
+import "context"
+
+type Subscriber interface {
+	Handle(ctx context.Context, m *Message) error
+}
+
+type SubscriberFunc func(ctx context.Context, m *Message) error
+
+func (f SubscriberFunc) Handle(ctx context.Context, m *Message) error {
+	return f(ctx, m)
+}
+
+// Subscriptions maps a topic to the subscribers of its messages.
+type Subscriptions map[string][]Subscriber
+
+func (s Subscriptions) Subscribe(topic string, subscriber Subscriber) {
+	s[topic] = append(s[topic], subscriber)
+}
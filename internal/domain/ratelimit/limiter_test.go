@@ -0,0 +1,51 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLimit(t *testing.T) {
+	t.Run("should parse requests per period", func(t *testing.T) {
+		limit, err := domain.ParseLimit("10/1m")
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Limit{Requests: 10, Period: time.Minute}, limit)
+	})
+
+	t.Run("should ignore surrounding whitespace", func(t *testing.T) {
+		limit, err := domain.ParseLimit(" 100/1h ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Limit{Requests: 100, Period: time.Hour}, limit)
+	})
+
+	invalid := []string{"", "10", "10/", "/1m", "abc/1m", "10/abc", "0/1m", "-1/1m", "10/0s"}
+	for _, s := range invalid {
+		t.Run("should reject "+s, func(t *testing.T) {
+			_, err := domain.ParseLimit(s)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidLimit)
+		})
+	}
+}
+
+func TestLimit_IsZero(t *testing.T) {
+	assert.True(t, domain.Limit{}.IsZero())
+	assert.True(t, domain.Limit{Requests: 10}.IsZero())
+	assert.False(t, domain.Limit{Requests: 10, Period: time.Minute}.IsZero())
+}
+
+func TestLimit_TextRoundTrip(t *testing.T) {
+	original := domain.Limit{Requests: 60, Period: time.Minute}
+
+	text, err := original.MarshalText()
+	assert.NoError(t, err)
+
+	var parsed domain.Limit
+	assert.NoError(t, parsed.UnmarshalText(text))
+	assert.Equal(t, original, parsed)
+}
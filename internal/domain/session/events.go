@@ -0,0 +1,12 @@
+package session
+
+import "time"
+
+// TopicSessionRevoked is published when a refresh token stops being valid
+// before it expires.
+const TopicSessionRevoked = "session.revoked"
+
+type SessionRevokedEvent struct {
+	RefreshTokenHash string    `json:"refreshTokenHash"`
+	ExpiresAt        time.Time `json:"expiresAt"`
+}
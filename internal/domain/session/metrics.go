@@ -0,0 +1,6 @@
+package session
+
+type AuthMetrics interface {
+	LoginSucceeded(provider string)
+	LoginFailed(provider string)
+}
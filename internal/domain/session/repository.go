@@ -12,6 +12,13 @@ type SessionRepository interface {
 	Create(ctx context.Context, s *Session) error
 	FindByRefreshToken(ctx context.Context, hash string) (*Session, error)
 	Revoke(ctx context.Context, id uuid.UUID) error
+	RevokeAllByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+type SessionCleanupRepository interface {
+	// DeleteInactive removes up to limit sessions that expired or were
+	// revoked before cutoff, returning how many were removed.
+	DeleteInactive(ctx context.Context, cutoff time.Time, limit int) (int64, error)
 }
 
 type BlacklistRepository interface {
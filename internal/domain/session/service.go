@@ -25,6 +25,8 @@ type TokenParams struct {
 	UserID    uuid.UUID
 	SessionID uuid.UUID
 	Duration  time.Duration
+	// Locale is the profile locale of the user, empty when none was chosen.
+	Locale string
 }
 
 type TokenClaims struct {
@@ -1,6 +1,22 @@
 package url
 
+import "context"
+
 type URLEncrypter interface {
-	Encrypt(string) (string, error)
-	Decrypt(string) (string, error)
+	Encrypt(ctx context.Context, text string) (string, error)
+	Decrypt(ctx context.Context, text string) (string, error)
+}
+
+// URLRotatingEncrypter is an encrypter whose ciphertexts name the key that
+// sealed them, so values written under a retired key can be found and
+// re-encrypted.
+type URLRotatingEncrypter interface {
+	URLEncrypter
+	NeedsReencryption(text string) bool
+}
+
+// URLBlindIndexer derives a keyed, deterministic index from a normalized URL
+// so equal destinations can be matched without decrypting them.
+type URLBlindIndexer interface {
+	Index(normalizedURL string) []byte
 }
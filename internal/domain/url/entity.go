@@ -21,9 +21,12 @@ var (
 type URL struct {
 	ShortCode    string
 	EncryptedURL string
-	UserID       *uuid.UUID
-	ExpiresAt    *time.Time
-	DeletedAt    *time.Time
+	// DestinationIndex is the blind index of the normalized destination.
+	DestinationIndex []byte
+	UserID           *uuid.UUID
+	ExpiresAt        *time.Time
+	DeletedAt        *time.Time
+	RedirectOptions
 }
 
 func (u *URL) RemainingTTL(now time.Time) time.Duration {
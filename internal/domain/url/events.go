@@ -0,0 +1,23 @@
+package url
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Outbox topics of the URL events.
+const (
+	TopicURLCreated = "url.created"
+	TopicURLDeleted = "url.deleted"
+	TopicURLExpired = "url.expired"
+)
+
+// URLEvent is the outbox payload of the URL topics. UserID is nil for URLs
+// created anonymously; ID is only known when the URL is deleted by id.
+type URLEvent struct {
+	ID        *uuid.UUID `json:"id,omitempty"`
+	ShortCode string     `json:"shortCode"`
+	UserID    *uuid.UUID `json:"userId,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
@@ -0,0 +1,9 @@
+package url
+
+type URLMetrics interface {
+	CacheHit()
+	CacheMiss()
+	LocalCacheHit()
+	LocalCacheMiss()
+	ShortCodeCollision()
+}
@@ -0,0 +1,46 @@
+package url
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// NormalizeURL returns a canonical form of rawURL used to recognise the same
+// destination written differently: scheme and host are lowercased, default
+// ports dropped and trailing slashes removed from the path. Query and
+// fragment are kept as they are, since servers may treat them as
+// significant.
+func NormalizeURL(rawURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "", ErrInvalidURLFormat
+	}
+	if u.Scheme == "" {
+		return "", ErrMissingURLSchema
+	}
+	if u.Host == "" {
+		return "", ErrMissingURLHost
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+
+	host := strings.ToLower(u.Hostname())
+	if port := u.Port(); port != "" && port != defaultPorts[u.Scheme] {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		// IPv6 literal without a port
+		host = "[" + host + "]"
+	}
+	u.Host = host
+
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = strings.TrimRight(u.RawPath, "/")
+
+	return u.String(), nil
+}
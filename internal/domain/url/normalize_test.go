@@ -0,0 +1,58 @@
+package url_test
+
+import (
+	"testing"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeURL(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"lowercases scheme and host", "HTTPS://Example.COM/Path", "https://example.com/Path"},
+		{"drops the default http port", "http://example.com:80/a", "http://example.com/a"},
+		{"drops the default https port", "https://example.com:443/a", "https://example.com/a"},
+		{"keeps other ports", "https://example.com:8443/a", "https://example.com:8443/a"},
+		{"keeps port 443 for http", "http://example.com:443/a", "http://example.com:443/a"},
+		{"removes a root slash", "https://example.com/", "https://example.com"},
+		{"removes trailing slashes", "https://example.com/a/b//", "https://example.com/a/b"},
+		{"keeps the query", "https://example.com/a/?q=1&b=2", "https://example.com/a?q=1&b=2"},
+		{"keeps the fragment", "https://example.com/a#Top", "https://example.com/a#Top"},
+		{"keeps ipv6 hosts", "http://[::1]:80/", "http://[::1]"},
+		{"keeps ipv6 hosts with a port", "http://[::1]:8080/", "http://[::1]:8080"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			normalized, err := domain.NormalizeURL(tc.input)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, normalized)
+		})
+	}
+}
+
+func TestNormalizeURL_Equivalents(t *testing.T) {
+	a, err := domain.NormalizeURL("https://Example.com:443/docs/")
+	require.NoError(t, err)
+	b, err := domain.NormalizeURL("https://example.com/docs")
+	require.NoError(t, err)
+
+	assert.Equal(t, a, b)
+}
+
+func TestNormalizeURL_Errors(t *testing.T) {
+	_, err := domain.NormalizeURL("example.com/path")
+	assert.ErrorIs(t, err, domain.ErrMissingURLSchema)
+
+	_, err = domain.NormalizeURL("https://")
+	assert.ErrorIs(t, err, domain.ErrMissingURLHost)
+
+	_, err = domain.NormalizeURL("https://exa mple.com/%zz")
+	assert.ErrorIs(t, err, domain.ErrInvalidURLFormat)
+}
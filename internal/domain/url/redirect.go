@@ -0,0 +1,161 @@
+package url
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RedirectType is the HTTP status a short code redirects with. The zero
+// value stands for DefaultRedirectType, so URLs stored before it existed
+// keep redirecting as they did.
+type RedirectType int
+
+const (
+	RedirectMovedPermanently  RedirectType = http.StatusMovedPermanently
+	RedirectFound             RedirectType = http.StatusFound
+	RedirectTemporaryRedirect RedirectType = http.StatusTemporaryRedirect
+	RedirectPermanentRedirect RedirectType = http.StatusPermanentRedirect
+
+	DefaultRedirectType = RedirectFound
+)
+
+// MaxUTMValueLength bounds each stored UTM default.
+const MaxUTMValueLength = 255
+
+var (
+	ErrInvalidRedirectType = errors.New("unsupported redirect type")
+	ErrInvalidUTMValue     = errors.New("utm value too long")
+	ErrInvalidForwardPath  = errors.New("invalid forwarded path")
+)
+
+func (t RedirectType) IsValid() bool {
+	switch t {
+	case RedirectMovedPermanently, RedirectFound, RedirectTemporaryRedirect, RedirectPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+// Status returns the HTTP status of t, falling back to DefaultRedirectType.
+func (t RedirectType) Status() int {
+	if !t.IsValid() {
+		return int(DefaultRedirectType)
+	}
+	return int(t)
+}
+
+// UTMParams are the utm_* parameters added to a destination when neither it
+// nor the forwarded query already carries them. Empty values are skipped.
+type UTMParams struct {
+	Source   string `json:"source,omitempty"`
+	Medium   string `json:"medium,omitempty"`
+	Campaign string `json:"campaign,omitempty"`
+	Term     string `json:"term,omitempty"`
+	Content  string `json:"content,omitempty"`
+}
+
+func (p UTMParams) IsZero() bool {
+	return p == UTMParams{}
+}
+
+func (p UTMParams) Validate() error {
+	for _, v := range p.values() {
+		if len(v[1]) > MaxUTMValueLength {
+			return ErrInvalidUTMValue
+		}
+	}
+	return nil
+}
+
+func (p UTMParams) values() [][2]string {
+	return [][2]string{
+		{"utm_source", p.Source},
+		{"utm_medium", p.Medium},
+		{"utm_campaign", p.Campaign},
+		{"utm_term", p.Term},
+		{"utm_content", p.Content},
+	}
+}
+
+// RedirectOptions control how a redirect to the destination is built.
+type RedirectOptions struct {
+	RedirectType RedirectType
+	// ForwardQuery appends the query string of the request to the destination.
+	ForwardQuery bool
+	// ForwardPath appends the path after the short code to the destination.
+	ForwardPath bool
+	UTM         UTMParams
+}
+
+func (o RedirectOptions) Validate() error {
+	if o.RedirectType != 0 && !o.RedirectType.IsValid() {
+		return ErrInvalidRedirectType
+	}
+	return o.UTM.Validate()
+}
+
+// WithDefaults returns o with a zero RedirectType set to DefaultRedirectType.
+func (o RedirectOptions) WithDefaults() RedirectOptions {
+	if o.RedirectType == 0 {
+		o.RedirectType = DefaultRedirectType
+	}
+	return o
+}
+
+// Redirect is a decrypted destination together with the options of its URL.
+type Redirect struct {
+	Destination string
+	RedirectOptions
+}
+
+// Location returns the URL to redirect to for a request with the given
+// trailing path and raw query. Both are ignored unless the matching option
+// is set; a dot segment in path is rejected with ErrInvalidForwardPath so the
+// result never leaves the destination path.
+func (r Redirect) Location(path, rawQuery string) (string, error) {
+	u, err := url.Parse(r.Destination)
+	if err != nil {
+		return "", ErrInvalidURLFormat
+	}
+
+	path = strings.Trim(path, "/")
+	if r.ForwardPath && path != "" {
+		for _, segment := range strings.Split(path, "/") {
+			if segment == "." || segment == ".." {
+				return "", ErrInvalidForwardPath
+			}
+		}
+		u = u.JoinPath(path)
+	}
+
+	present := u.Query()
+	var queries []string
+	if u.RawQuery != "" {
+		queries = append(queries, u.RawQuery)
+	}
+
+	if r.ForwardQuery && rawQuery != "" {
+		forwarded, _ := url.ParseQuery(rawQuery)
+		if len(forwarded) > 0 {
+			queries = append(queries, forwarded.Encode())
+			for k := range forwarded {
+				present[k] = append(present[k], forwarded[k]...)
+			}
+		}
+	}
+
+	utm := url.Values{}
+	for _, kv := range r.UTM.values() {
+		if kv[1] != "" && !present.Has(kv[0]) {
+			utm.Set(kv[0], kv[1])
+		}
+	}
+	if len(utm) > 0 {
+		queries = append(queries, utm.Encode())
+	}
+
+	u.RawQuery = strings.Join(queries, "&")
+	return u.String(), nil
+}
@@ -0,0 +1,96 @@
+package url_test
+
+import (
+	"net/http"
+	"testing"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedirectType_Status(t *testing.T) {
+	assert.Equal(t, http.StatusFound, domain.RedirectType(0).Status())
+	assert.Equal(t, http.StatusMovedPermanently, domain.RedirectMovedPermanently.Status())
+	assert.Equal(t, http.StatusPermanentRedirect, domain.RedirectPermanentRedirect.Status())
+	assert.Equal(t, http.StatusFound, domain.RedirectType(303).Status())
+}
+
+func TestRedirectOptions_Validate(t *testing.T) {
+	assert.NoError(t, domain.RedirectOptions{}.Validate())
+	assert.NoError(t, domain.RedirectOptions{RedirectType: domain.RedirectTemporaryRedirect}.Validate())
+	assert.ErrorIs(t, domain.RedirectOptions{RedirectType: 303}.Validate(), domain.ErrInvalidRedirectType)
+
+	long := make([]byte, domain.MaxUTMValueLength+1)
+	for i := range long {
+		long[i] = 'a'
+	}
+	assert.ErrorIs(t, domain.RedirectOptions{UTM: domain.UTMParams{Term: string(long)}}.Validate(), domain.ErrInvalidUTMValue)
+}
+
+func TestRedirect_Location(t *testing.T) {
+	testCases := []struct {
+		name        string
+		destination string
+		options     domain.RedirectOptions
+		path        string
+		rawQuery    string
+		expected    string
+	}{
+		{"ignores path and query by default", "https://example.com/a?x=1", domain.RedirectOptions{}, "b", "y=2", "https://example.com/a?x=1"},
+		{"appends the query", "https://example.com/a?x=1", domain.RedirectOptions{ForwardQuery: true}, "", "y=2", "https://example.com/a?x=1&y=2"},
+		{"appends the query without one on the destination", "https://example.com/a", domain.RedirectOptions{ForwardQuery: true}, "", "y=2&y=3", "https://example.com/a?y=2&y=3"},
+		{"appends the path", "https://example.com/a", domain.RedirectOptions{ForwardPath: true}, "b/c", "", "https://example.com/a/b/c"},
+		{"appends the path after a trailing slash", "https://example.com/a/", domain.RedirectOptions{ForwardPath: true}, "b", "", "https://example.com/a/b"},
+		{"keeps the query and fragment when appending the path", "https://example.com/a?x=1#top", domain.RedirectOptions{ForwardPath: true}, "b", "", "https://example.com/a/b?x=1#top"},
+		{"escapes the path", "https://example.com", domain.RedirectOptions{ForwardPath: true}, "a b", "", "https://example.com/a%20b"},
+		{
+			"adds utm defaults",
+			"https://example.com",
+			domain.RedirectOptions{UTM: domain.UTMParams{Source: "short", Medium: "social", Campaign: "launch"}},
+			"", "",
+			"https://example.com?utm_campaign=launch&utm_medium=social&utm_source=short",
+		},
+		{
+			"keeps utm parameters of the destination",
+			"https://example.com?utm_source=site",
+			domain.RedirectOptions{UTM: domain.UTMParams{Source: "short", Medium: "social"}},
+			"", "",
+			"https://example.com?utm_source=site&utm_medium=social",
+		},
+		{
+			"keeps forwarded utm parameters",
+			"https://example.com",
+			domain.RedirectOptions{ForwardQuery: true, UTM: domain.UTMParams{Source: "short", Medium: "social"}},
+			"", "utm_source=ad",
+			"https://example.com?utm_source=ad&utm_medium=social",
+		},
+		{
+			"overrides utm defaults only when the query is forwarded",
+			"https://example.com",
+			domain.RedirectOptions{UTM: domain.UTMParams{Source: "short"}},
+			"", "utm_source=ad",
+			"https://example.com?utm_source=short",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := domain.Redirect{Destination: tc.destination, RedirectOptions: tc.options}
+
+			location, err := r.Location(tc.path, tc.rawQuery)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, location)
+		})
+	}
+}
+
+func TestRedirect_Location_RejectsDotSegments(t *testing.T) {
+	r := domain.Redirect{Destination: "https://example.com/a", RedirectOptions: domain.RedirectOptions{ForwardPath: true}}
+
+	for _, path := range []string{"..", "../b", "b/./c", "b/../../c"} {
+		_, err := r.Location(path, "")
+		assert.ErrorIs(t, err, domain.ErrInvalidForwardPath, path)
+	}
+}
@@ -9,10 +9,36 @@ import (
 )
 
 type URLRepository interface {
+	// Save returns ErrShortCodeTaken when another URL already uses the
+	// short code or it was retired by PurgeDeleted.
 	Save(ctx context.Context, url *URL) error
 	Exists(ctx context.Context, shortCode string) (bool, error)
 	FindByShortCode(ctx context.Context, shortCode string) (*URL, error)
 	SoftDelete(ctx context.Context, id uuid.UUID, userID uuid.UUID) (string, error)
+	// DisableByShortCode marks the URL as deleted whoever owns it and
+	// returns its short code and owner, or ErrURLNotFound.
+	DisableByShortCode(ctx context.Context, shortCode string) (*URL, error)
+	// FindActiveByDestination returns the newest URL of the user with the
+	// given destination index that is neither deleted nor expired, or
+	// ErrURLNotFound.
+	FindActiveByDestination(ctx context.Context, userID uuid.UUID, destinationIndex []byte) (*URL, error)
+}
+
+type URLReencryptionRepository interface {
+	ListEncrypted(ctx context.Context, afterShortCode string, limit int) ([]*URL, error)
+	// UpdateEncryptedURL replaces the ciphertext only if it still equals
+	// previous, reporting whether the row was updated.
+	UpdateEncryptedURL(ctx context.Context, shortCode, previous, encryptedURL string) (bool, error)
+}
+
+type URLCleanupRepository interface {
+	// SoftDeleteExpired marks up to limit URLs that expired before now as
+	// deleted and returns their short code, owner and expiration.
+	SoftDeleteExpired(ctx context.Context, now time.Time, limit int) ([]*URL, error)
+	// PurgeDeleted permanently removes up to limit URLs deleted before
+	// cutoff, returning how many were removed. Their short codes are retired
+	// and never issued again.
+	PurgeDeleted(ctx context.Context, cutoff time.Time, limit int) (int64, error)
 }
 
 type URLCacheRepository interface {
@@ -22,6 +48,16 @@ type URLCacheRepository interface {
 	FindByShortCode(ctx context.Context, shortCode string) (*URL, error)
 }
 
+// URLLocalCache keeps decrypted redirects in process memory, in front of
+// URLCacheRepository. Deleting from URLCacheRepository evicts the entry from
+// the local cache of every instance.
+type URLLocalCache interface {
+	Get(shortCode string) (Redirect, bool)
+	Set(shortCode string, redirect Redirect, ttl time.Duration)
+	Delete(shortCode string)
+	Purge()
+}
+
 type URLQueryRepository interface {
 	ListByUserID(ctx context.Context, userID uuid.UUID, params ListURLsParams) ([]ListURLsDTO, uint64, error)
 }
@@ -1,13 +1,36 @@
 package url
 
 import (
+	"context"
 	"errors"
 )
 
 var ShortCodeCharset = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
 
 type ShortCodeGenerator interface {
-	Generate(length int) (string, error)
+	Generate(ctx context.Context, length int) (string, error)
 }
 
-var ErrMaxRetries = errors.New("max retries reached, unable to generate unique short code")
+// ShortCodeCounterRepository hands out counter blocks. Every call returns a
+// number never returned before, to any instance, starting at 1.
+type ShortCodeCounterRepository interface {
+	NextBlock(ctx context.Context) (int64, error)
+}
+
+// ShortCodePoolRepository stores pre-generated short codes. A claimed code is
+// never handed out again.
+type ShortCodePoolRepository interface {
+	Claim(ctx context.Context, length, limit int) ([]string, error)
+	CountAvailable(ctx context.Context, length int) (int64, error)
+	// Fill adds the codes that are neither pooled nor used by a URL yet and
+	// returns how many were added.
+	Fill(ctx context.Context, codes []string) (int64, error)
+}
+
+var (
+	ErrMaxRetries                 = errors.New("max retries reached, unable to generate unique short code")
+	ErrShortCodeSpaceExhausted    = errors.New("no short codes left for the configured length")
+	ErrShortCodeLengthUnsupported = errors.New("short code length not supported by the generator")
+	ErrShortCodePoolEmpty         = errors.New("short code pool is empty")
+	ErrShortCodeTaken             = errors.New("short code already in use")
+)
@@ -35,6 +35,9 @@ type UserProfile struct {
 	ID        int64
 	Name      string
 	AvatarURL *string
+	// Locale is the language the user chose for responses, overriding
+	// Accept-Language.
+	Locale *string
 }
 
 type UserProvider struct {
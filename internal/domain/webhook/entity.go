@@ -0,0 +1,165 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEndpointNotFound     = errors.New("webhook endpoint not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrDeliveryNotRetryable = errors.New("only dead webhook deliveries can be retried")
+	ErrInvalidEndpointURL   = errors.New("webhook URL must be an absolute http or https URL")
+	ErrUnknownEventType     = errors.New("unknown webhook event type")
+	ErrNoEventTypes         = errors.New("at least one webhook event type is required")
+	ErrTooManyEndpoints     = errors.New("webhook endpoint limit reached")
+	ErrSecretGeneration     = errors.New("failed to generate webhook secret")
+	ErrUnexpectedStatus     = errors.New("webhook endpoint answered with a non-2xx status")
+	ErrForbiddenDestination = errors.New("webhook destination resolves to a private address")
+)
+
+type EventType string
+
+const (
+	EventURLCreated EventType = "url.created"
+	EventURLDeleted EventType = "url.deleted"
+	EventURLClicked EventType = "url.clicked"
+	EventURLExpired EventType = "url.expired"
+	// EventTest is sent by the "send test event" endpoint; endpoints cannot
+	// subscribe to it.
+	EventTest EventType = "webhook.test"
+)
+
+// EventTypes lists the events an endpoint can subscribe to.
+var EventTypes = []EventType{
+	EventURLCreated,
+	EventURLDeleted,
+	EventURLClicked,
+	EventURLExpired,
+}
+
+func (t EventType) IsSubscribable() bool {
+	return slices.Contains(EventTypes, t)
+}
+
+type Endpoint struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	URL    string
+	// Secret signs the payloads sent to the endpoint. It is only shown to
+	// the user when the endpoint is created.
+	Secret    string
+	Events    []EventType
+	CreatedAt time.Time
+}
+
+func (e *Endpoint) IsSubscribed(t EventType) bool {
+	return t == EventTest || slices.Contains(e.Events, t)
+}
+
+type Event struct {
+	ID         uuid.UUID
+	Type       EventType
+	OccurredAt time.Time
+	Data       any
+}
+
+func NewEvent(t EventType, data any) Event {
+	return Event{
+		ID:         uuid.New(),
+		Type:       t,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+}
+
+// Payload is the JSON body delivered to the endpoints. The bytes are stored
+// as they are signed, so a retry sends exactly the same body.
+func (e Event) Payload() ([]byte, error) {
+	return json.Marshal(struct {
+		ID         uuid.UUID `json:"id"`
+		Type       EventType `json:"type"`
+		OccurredAt time.Time `json:"occurredAt"`
+		Data       any       `json:"data"`
+	}{e.ID, e.Type, e.OccurredAt, e.Data})
+}
+
+// URLEventData is the data of the url.created, url.deleted and url.expired
+// events.
+type URLEventData struct {
+	ID        *uuid.UUID `json:"id,omitempty"`
+	ShortCode string     `json:"shortCode"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type ClickEventData struct {
+	ShortCode string `json:"shortCode"`
+	Referer   string `json:"referer,omitempty"`
+	UserAgent string `json:"userAgent,omitempty"`
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	// DeliveryDead is the dead-letter state: every attempt failed and the
+	// delivery is only sent again when the user retries it.
+	DeliveryDead DeliveryStatus = "dead"
+)
+
+type Delivery struct {
+	ID                 uuid.UUID
+	EndpointID         uuid.UUID
+	EventID            uuid.UUID
+	EventType          EventType
+	Payload            []byte
+	Status             DeliveryStatus
+	Attempts           int
+	NextAttemptAt      *time.Time
+	LastAttemptAt      *time.Time
+	LastResponseStatus *int
+	LastError          string
+	CreatedAt          time.Time
+	DeliveredAt        *time.Time
+}
+
+// DueDelivery is a claimed delivery with what is needed to send it.
+type DueDelivery struct {
+	Delivery
+	URL    string
+	Secret string
+}
+
+// AttemptResult is the outcome of sending a delivery once.
+type AttemptResult struct {
+	AttemptedAt    time.Time
+	ResponseStatus *int
+	Error          string
+}
+
+func ValidateEndpointURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || !u.IsAbs() || u.Host == "" || u.User != nil {
+		return ErrInvalidEndpointURL
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ErrInvalidEndpointURL
+	}
+	return nil
+}
+
+func GenerateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", ErrSecretGeneration
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
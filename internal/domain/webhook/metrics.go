@@ -0,0 +1,7 @@
+package webhook
+
+type WebhookMetrics interface {
+	DeliverySucceeded()
+	DeliveryFailed(dead bool)
+	EventDropped()
+}
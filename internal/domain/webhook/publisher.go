@@ -0,0 +1,15 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// WebhookPublisher queues events for the endpoints subscribed to them.
+type WebhookPublisher interface {
+	Publish(ctx context.Context, userID uuid.UUID, e Event) error
+	// PublishToOwner publishes e to the owner of the URL with shortCode;
+	// URLs created anonymously have none.
+	PublishToOwner(ctx context.Context, shortCode string, e Event) error
+}
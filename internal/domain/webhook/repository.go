@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/domain"
+	"github.com/google/uuid"
+)
+
+type WebhookEndpointRepository interface {
+	Create(ctx context.Context, e *Endpoint) error
+	CountByUserID(ctx context.Context, userID uuid.UUID) (int, error)
+	// FindByID returns ErrEndpointNotFound unless the endpoint belongs to
+	// userID.
+	FindByID(ctx context.Context, id, userID uuid.UUID) (*Endpoint, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]Endpoint, error)
+	Delete(ctx context.Context, id, userID uuid.UUID) error
+}
+
+type WebhookDeliveryRepository interface {
+	// Enqueue queues e for a single endpoint, regardless of its
+	// subscriptions.
+	Enqueue(ctx context.Context, endpointID uuid.UUID, e Event) (*Delivery, error)
+	// ClaimDue returns up to limit pending deliveries due at now and hides
+	// them from other callers for lease, so a delivery whose sender died is
+	// picked up again once the lease runs out.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*DueDelivery, error)
+	MarkSucceeded(ctx context.Context, id uuid.UUID, result AttemptResult) error
+	// MarkFailed schedules the next attempt at nextAttemptAt, or moves the
+	// delivery to the dead-letter state when it is nil.
+	MarkFailed(ctx context.Context, id uuid.UUID, result AttemptResult, nextAttemptAt *time.Time) error
+	// Retry queues a dead delivery of the user's endpoint again.
+	Retry(ctx context.Context, id, endpointID, userID uuid.UUID, now time.Time) error
+	// DeleteFinished removes up to limit succeeded or dead deliveries created
+	// before cutoff, returning how many were removed.
+	DeleteFinished(ctx context.Context, cutoff time.Time, limit int) (int64, error)
+}
+
+type WebhookDeliveryQueryRepository interface {
+	List(ctx context.Context, params ListDeliveriesParams) ([]Delivery, uint64, error)
+}
+
+type ListDeliveriesParams struct {
+	EndpointID uuid.UUID
+	UserID     uuid.UUID
+	Status     *DeliveryStatus
+	Pagination domain.Pagination
+}
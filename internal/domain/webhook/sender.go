@@ -0,0 +1,9 @@
+package webhook
+
+import "context"
+
+type WebhookSender interface {
+	// Send posts the delivery to its endpoint, returning the response status
+	// when one was received. Any status outside 2xx is an error.
+	Send(ctx context.Context, d *DueDelivery) (*int, error)
+}
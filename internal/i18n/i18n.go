@@ -4,7 +4,7 @@ import (
 	"context"
 	"embed"
 	"fmt"
-	"net/http"
+	"io/fs"
 
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"golang.org/x/text/language"
@@ -17,14 +17,24 @@ type contextKey string
 
 const localeKey contextKey = "locale"
 
-var bundle *i18n.Bundle
+var (
+	bundle  *i18n.Bundle
+	matcher language.Matcher
+	// supported lists the loaded locales with the default first, in the
+	// order the matcher reports its matches.
+	supported []language.Tag
+)
+
+var DefaultLocale = language.English
 
+// Init loads every translation/<locale>.json; adding a locale only takes a
+// new file.
 func Init() error {
-	bundle = i18n.NewBundle(language.English)
+	bundle = i18n.NewBundle(DefaultLocale)
 
-	files := []string{
-		"translation/en.json",
-		"translation/pt.json",
+	files, err := fs.Glob(localeFS, "translation/*.json")
+	if err != nil {
+		return err
 	}
 	for _, file := range files {
 		if _, err := bundle.LoadMessageFileFS(localeFS, file); err != nil {
@@ -32,24 +42,67 @@ func Init() error {
 		}
 	}
 
+	supported = []language.Tag{DefaultLocale}
+	for _, tag := range bundle.LanguageTags() {
+		if tag != DefaultLocale {
+			supported = append(supported, tag)
+		}
+	}
+	matcher = language.NewMatcher(supported)
+
 	return nil
 }
 
-func WithLocale(ctx context.Context, locale string) context.Context {
+func SupportedLocales() []language.Tag {
+	return supported
+}
+
+// ParseLocale matches an explicit choice, such as ?lang= or the profile
+// locale, to a loaded locale. Regional variants fall back to their base
+// language ("pt-BR" is "pt").
+func ParseLocale(s string) (language.Tag, bool) {
+	tag, err := language.Parse(s)
+	if err != nil {
+		return language.Und, false
+	}
+
+	_, index, confidence := matcher.Match(tag)
+	if confidence < language.High {
+		return language.Und, false
+	}
+	return supported[index], true
+}
+
+// MatchAcceptLanguage picks the loaded locale that best fits an
+// Accept-Language header, honouring quality values, and the default locale
+// when none does.
+func MatchAcceptLanguage(header string) language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return DefaultLocale
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLocale
+	}
+	return supported[index]
+}
+
+func WithLocale(ctx context.Context, locale language.Tag) context.Context {
 	return context.WithValue(ctx, localeKey, locale)
 }
 
-func LocalizerFromContext(ctx context.Context) *i18n.Localizer {
-	locale, ok := ctx.Value(localeKey).(string)
-	if !ok || locale == "" {
-		locale = "en"
+func LocaleFromContext(ctx context.Context) language.Tag {
+	locale, ok := ctx.Value(localeKey).(language.Tag)
+	if !ok {
+		return DefaultLocale
 	}
-	return i18n.NewLocalizer(bundle, locale)
+	return locale
 }
 
-func DetectLanguage(r *http.Request) string {
-	lang := r.Header.Get("Accept-Language")
-	return lang
+func LocalizerFromContext(ctx context.Context) *i18n.Localizer {
+	return i18n.NewLocalizer(bundle, LocaleFromContext(ctx).String())
 }
 
 func T(ctx context.Context, messageID string, templateData map[string]any) string {
@@ -0,0 +1,124 @@
+package i18n_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestMain(m *testing.M) {
+	if err := i18n.Init(); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+func loadMessages(t *testing.T, file string) map[string]string {
+	t.Helper()
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	var messages map[string]string
+	require.NoError(t, json.Unmarshal(data, &messages), file)
+	return messages
+}
+
+func TestTranslations_HaveEveryKeyOfEnglish(t *testing.T) {
+	reference := loadMessages(t, filepath.Join("translation", "en.json"))
+
+	files, err := filepath.Glob(filepath.Join("translation", "*.json"))
+	require.NoError(t, err)
+	require.Greater(t, len(files), 1)
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			messages := loadMessages(t, file)
+
+			for key := range reference {
+				assert.NotEmpty(t, messages[key], "missing key %q", key)
+			}
+			for key := range messages {
+				_, ok := reference[key]
+				assert.True(t, ok, "key %q is not in en.json", key)
+			}
+		})
+	}
+}
+
+func TestSupportedLocales(t *testing.T) {
+	locales := i18n.SupportedLocales()
+
+	require.NotEmpty(t, locales)
+	assert.Equal(t, language.English, locales[0])
+	assert.Contains(t, locales, language.Portuguese)
+	assert.Contains(t, locales, language.Spanish)
+}
+
+func TestMatchAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   language.Tag
+	}{
+		{header: "", want: language.English},
+		{header: "pt-BR,pt;q=0.9,en;q=0.8", want: language.Portuguese},
+		{header: "en;q=0.5, es;q=0.9", want: language.Spanish},
+		{header: "es-MX", want: language.Spanish},
+		{header: "fr-FR, de;q=0.8", want: language.English},
+		{header: "fr, pt;q=0.3", want: language.Portuguese},
+		{header: "*", want: language.English},
+		{header: "not a header;;;", want: language.English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, i18n.MatchAcceptLanguage(tt.header))
+		})
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		value string
+		want  language.Tag
+		ok    bool
+	}{
+		{value: "pt", want: language.Portuguese, ok: true},
+		{value: "pt-BR", want: language.Portuguese, ok: true},
+		{value: "ES", want: language.Spanish, ok: true},
+		{value: "en-GB", want: language.English, ok: true},
+		{value: "fr", ok: false},
+		{value: "", ok: false},
+		{value: "???", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := i18n.ParseLocale(tt.value)
+
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestT_UsesContextLocale(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Equal(t, "Invalid email format", i18n.T(ctx, "error.details.email.invalid_format", nil))
+
+	ctx = i18n.WithLocale(ctx, language.Portuguese)
+	assert.Equal(t, "Formato de email inválido", i18n.T(ctx, "error.details.email.invalid_format", nil))
+
+	ctx = i18n.WithLocale(ctx, language.Spanish)
+	assert.Equal(t, "Formato de correo electrónico no válido", i18n.T(ctx, "error.details.email.invalid_format", nil))
+}
@@ -0,0 +1,90 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrLockNotHeld = errors.New("advisory lock is not held")
+
+// AdvisoryLock elects a leader among the instances sharing a database: the
+// holder keeps a session-level advisory lock on a dedicated connection, and
+// Postgres releases it if that connection dies. It is not safe for
+// concurrent use.
+type AdvisoryLock struct {
+	pool *pgxpool.Pool
+	key  int64
+	conn *pgxpool.Conn
+}
+
+func NewAdvisoryLock(pool *pgxpool.Pool, name string) *AdvisoryLock {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+
+	return &AdvisoryLock{
+		pool: pool,
+		key:  int64(h.Sum64()),
+	}
+}
+
+// TryAcquire takes the lock if no other session holds it. It reports true
+// if the lock is held after the call.
+func (l *AdvisoryLock) TryAcquire(ctx context.Context) (bool, error) {
+	if l.conn != nil {
+		return true, nil
+	}
+
+	conn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", l.key).Scan(&acquired); err != nil {
+		discard(ctx, conn)
+		return false, err
+	}
+	if !acquired {
+		conn.Release()
+		return false, nil
+	}
+
+	l.conn = conn
+	return true, nil
+}
+
+// Check returns an error once the lock can no longer be trusted to be held.
+func (l *AdvisoryLock) Check(ctx context.Context) error {
+	if l.conn == nil {
+		return ErrLockNotHeld
+	}
+	if err := l.conn.Ping(ctx); err != nil {
+		discard(ctx, l.conn)
+		l.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (l *AdvisoryLock) Release(ctx context.Context) {
+	if l.conn == nil {
+		return
+	}
+
+	if _, err := l.conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", l.key); err != nil {
+		discard(ctx, l.conn)
+	} else {
+		l.conn.Release()
+	}
+	l.conn = nil
+}
+
+// discard closes conn before handing it back, so a connection that may
+// still hold the lock never returns to the pool.
+func discard(ctx context.Context, conn *pgxpool.Conn) {
+	conn.Conn().Close(ctx)
+	conn.Release()
+}
@@ -3,7 +3,8 @@ package pg
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"os"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -15,37 +16,74 @@ type PostgresConnection struct {
 	Password string
 	Name     string
 	Port     int
+	// ReplicaHost, if set, is a streaming replica of the same database that
+	// serves read-only queries.
+	ReplicaHost string
+	ReplicaPort int
+
+	MaxConns        int32
+	MinConns        int32
+	MaxConnIdleTime time.Duration
 }
 
 type Postgres struct {
 	Pool *pgxpool.Pool
+	// Replica is nil when no replica is configured.
+	Replica *pgxpool.Pool
+	Router  *Router
 }
 
 func NewPostgres(postgres PostgresConnection) *Postgres {
-	log.Printf("Connecting to Postgres at %s:%d/%s (user: %s)...", postgres.Host, postgres.Port, postgres.Name, postgres.User)
+	pool := connect(postgres, postgres.Host, postgres.Port)
 
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%d/%s", postgres.User, postgres.Password, postgres.Host, postgres.Port, postgres.Name)
+	var replica *pgxpool.Pool
+	router := NewRouter(pool, nil)
+	if postgres.ReplicaHost != "" {
+		replica = connect(postgres, postgres.ReplicaHost, postgres.ReplicaPort)
+		router = NewRouter(pool, replica)
+	}
+
+	return &Postgres{
+		Pool:    pool,
+		Replica: replica,
+		Router:  router,
+	}
+}
+
+func (p *Postgres) Close() {
+	if p.Replica != nil {
+		p.Replica.Close()
+	}
+	p.Pool.Close()
+}
+
+func connect(postgres PostgresConnection, host string, port int) *pgxpool.Pool {
+	slog.Info("Connecting to Postgres", "host", host, "port", port, "database", postgres.Name, "user", postgres.User)
+
+	dsn := fmt.Sprintf("postgres://%s:%s@%s:%d/%s", postgres.User, postgres.Password, host, port, postgres.Name)
 
 	config, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
-		log.Fatalf("Unable to parse Postgres connection string: %v", err)
+		slog.Error("Unable to parse Postgres connection string", "error", err)
+		os.Exit(1)
 	}
 
-	config.MaxConns = 10
-	config.MinConns = 2
-	config.MaxConnIdleTime = 6 * time.Minute
+	config.MaxConns = postgres.MaxConns
+	config.MinConns = postgres.MinConns
+	config.MaxConnIdleTime = postgres.MaxConnIdleTime
+	config.ConnConfig.Tracer = newQueryTracer()
 
 	pool, err := pgxpool.NewWithConfig(context.Background(), config)
 	if err != nil {
-		log.Fatalf("Unable to create Postgres pool: %v", err)
+		slog.Error("Unable to create Postgres pool", "error", err)
+		os.Exit(1)
 	}
 
 	if err := pool.Ping(context.Background()); err != nil {
-		log.Fatalf("Unable to ping Postgres: %v", err)
+		slog.Error("Unable to ping Postgres", "error", err)
+		os.Exit(1)
 	}
 
-	log.Printf("Successfully connected to Postgres at %s:%d/%s", postgres.Host, postgres.Port, postgres.Name)
-	return &Postgres{
-		Pool: pool,
-	}
+	slog.Info("Successfully connected to Postgres", "host", host, "port", port, "database", postgres.Name)
+	return pool
 }
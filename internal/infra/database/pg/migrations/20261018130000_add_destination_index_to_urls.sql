@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN destination_index BYTEA;
+CREATE INDEX idx_urls_user_id_destination_index ON urls(user_id, destination_index) WHERE deleted_at IS NULL AND destination_index IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_urls_user_id_destination_index;
+ALTER TABLE urls DROP COLUMN destination_index;
+-- +goose StatementEnd
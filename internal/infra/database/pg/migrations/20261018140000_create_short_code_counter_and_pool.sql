@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE SEQUENCE IF NOT EXISTS short_code_block_seq;
+
+CREATE TABLE IF NOT EXISTS short_code_pool (
+    code TEXT PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    claimed_at TIMESTAMPTZ
+);
+CREATE INDEX idx_short_code_pool_available ON short_code_pool(length(code)) WHERE claimed_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS short_code_pool;
+DROP SEQUENCE IF EXISTS short_code_block_seq;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS outbox (
+    id UUID PRIMARY KEY,
+    topic TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    available_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT
+);
+CREATE INDEX idx_outbox_available_at ON outbox(available_at, occurred_at);
+
+-- The relay can hand the same event to the webhook subscriber more than
+-- once; the event id keeps it from being queued twice for an endpoint.
+CREATE UNIQUE INDEX idx_webhook_deliveries_endpoint_event ON webhook_deliveries(endpoint_id, event_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_webhook_deliveries_endpoint_event;
+DROP INDEX IF EXISTS idx_outbox_available_at;
+DROP TABLE IF EXISTS outbox;
+-- +goose StatementEnd
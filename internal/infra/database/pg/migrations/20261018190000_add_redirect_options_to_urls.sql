@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN redirect_type SMALLINT NOT NULL DEFAULT 302 CHECK (redirect_type IN (301, 302, 307, 308));
+ALTER TABLE urls ADD COLUMN forward_query BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE urls ADD COLUMN forward_path BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE urls ADD COLUMN utm_defaults JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE urls DROP COLUMN utm_defaults;
+ALTER TABLE urls DROP COLUMN forward_path;
+ALTER TABLE urls DROP COLUMN forward_query;
+ALTER TABLE urls DROP COLUMN redirect_type;
+-- +goose StatementEnd
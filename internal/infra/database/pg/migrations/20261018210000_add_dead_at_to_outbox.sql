@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Messages that failed OUTBOX_MAX_ATTEMPTS times are kept for inspection
+-- but no longer claimed by the relay.
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS dead_at TIMESTAMPTZ;
+DROP INDEX IF EXISTS idx_outbox_available_at;
+CREATE INDEX idx_outbox_available_at ON outbox(available_at, occurred_at) WHERE dead_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_outbox_available_at;
+CREATE INDEX idx_outbox_available_at ON outbox(available_at, occurred_at);
+ALTER TABLE outbox DROP COLUMN IF EXISTS dead_at;
+-- +goose StatementEnd
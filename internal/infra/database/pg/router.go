@@ -0,0 +1,179 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type readYourWritesKey struct{}
+
+// Pool is the part of *pgxpool.Pool the router needs.
+type Pool interface {
+	Querier
+	TxStarter
+	Ping(ctx context.Context) error
+}
+
+// Router sends writes and transactions to the primary and lets read-only
+// queries use the replica, falling back to the primary while the replica is
+// unreachable, lagging behind or no longer streaming. Reads that fill a
+// cache, like the redirect lookup, accept that lag too: the cache is
+// invalidated after the change commits, and the lag is bounded by the
+// MonitorReplica maxLag.
+type Router struct {
+	primary Pool
+	replica Pool
+
+	replicaHealthy atomic.Bool
+}
+
+// NewRouter returns a router over primary and replica; replica may be nil.
+func NewRouter(primary, replica Pool) *Router {
+	r := &Router{primary: primary, replica: replica}
+	r.replicaHealthy.Store(replica != nil)
+	return r
+}
+
+func (r *Router) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return r.primary.Exec(ctx, sql, args...)
+}
+
+func (r *Router) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return r.primary.Query(ctx, sql, args...)
+}
+
+func (r *Router) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return r.primary.QueryRow(ctx, sql, args...)
+}
+
+func (r *Router) Begin(ctx context.Context) (pgx.Tx, error) {
+	return r.primary.Begin(ctx)
+}
+
+func (r *Router) Ping(ctx context.Context) error {
+	return r.primary.Ping(ctx)
+}
+
+func (r *Router) HasReplica() bool {
+	return r.replica != nil
+}
+
+func (r *Router) reader(ctx context.Context) Querier {
+	if !r.replicaHealthy.Load() || IsReadYourWrites(ctx) {
+		return r.primary
+	}
+	return r.replica
+}
+
+var (
+	ErrReplicaNotStreaming  = errors.New("replica is not receiving WAL from the primary")
+	ErrReplicaNeverReplayed = errors.New("replica has not replayed any transaction")
+)
+
+// replicaLagQuery reports zero while the replica has replayed everything it
+// received, so an idle primary does not look like lag, but only while a WAL
+// receiver is connected: a replica cut off from the primary has nothing left
+// to replay and would otherwise look caught up forever. Behind that, the lag
+// is the age of the last replayed transaction, NULL when there is none.
+const replicaLagQuery = `
+	SELECT
+		NOT pg_is_in_recovery() OR (pg_last_wal_receive_lsn() IS NOT NULL AND EXISTS (SELECT 1 FROM pg_stat_wal_receiver)),
+		CASE
+			WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+			ELSE EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())::float8
+		END`
+
+// ReplicaLag returns how far the replica's replay is behind the primary.
+func (r *Router) ReplicaLag(ctx context.Context) (time.Duration, error) {
+	if r.replica == nil {
+		return 0, nil
+	}
+
+	var streaming bool
+	var seconds *float64
+	if err := r.replica.QueryRow(ctx, replicaLagQuery).Scan(&streaming, &seconds); err != nil {
+		return 0, err
+	}
+	if !streaming {
+		return 0, ErrReplicaNotStreaming
+	}
+	if seconds == nil {
+		return 0, ErrReplicaNeverReplayed
+	}
+	return time.Duration(*seconds * float64(time.Second)), nil
+}
+
+// CheckReplica returns an error if the replica is unreachable or lags more
+// than maxLag.
+func (r *Router) CheckReplica(ctx context.Context, maxLag time.Duration) error {
+	lag, err := r.ReplicaLag(ctx)
+	if err != nil {
+		return err
+	}
+	if lag > maxLag {
+		return fmt.Errorf("replication lag %s exceeds %s", lag.Round(time.Millisecond), maxLag)
+	}
+	return nil
+}
+
+// MonitorReplica checks the replica every interval and routes reads to the
+// primary while the check fails, until ctx is done.
+func (r *Router) MonitorReplica(ctx context.Context, interval, maxLag time.Duration) {
+	if r.replica == nil {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		checkCtx, cancel := context.WithTimeout(ctx, interval)
+		err := r.CheckReplica(checkCtx, maxLag)
+		cancel()
+
+		healthy := err == nil
+		if r.replicaHealthy.Swap(healthy) != healthy {
+			if healthy {
+				slog.Info("Postgres replica recovered, routing reads to it")
+			} else if ctx.Err() == nil {
+				slog.Warn("Postgres replica unavailable, routing reads to the primary", "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// WithReadYourWrites marks ctx so reads go to the primary and observe writes
+// that may not have reached the replica yet.
+func WithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readYourWritesKey{}, true)
+}
+
+func IsReadYourWrites(ctx context.Context) bool {
+	v, _ := ctx.Value(readYourWritesKey{}).(bool)
+	return v
+}
+
+// GetReadQuerier is GetQuerier for read-only statements: outside a
+// transaction a Router fallback answers from its replica.
+func GetReadQuerier(ctx context.Context, fallback Querier) Querier {
+	if q, ok := ctx.Value(querierKey{}).(Querier); ok {
+		return q
+	}
+	if r, ok := fallback.(*Router); ok {
+		return r.reader(ctx)
+	}
+	return fallback
+}
@@ -0,0 +1,141 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePool struct {
+	lagSeconds   float64
+	notStreaming bool
+	neverReplay  bool
+	err          error
+}
+
+func (p *fakePool) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, p.err
+}
+
+func (p *fakePool) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	return nil, p.err
+}
+
+func (p *fakePool) QueryRow(context.Context, string, ...any) pgx.Row {
+	return fakeRow{p}
+}
+
+func (p *fakePool) Begin(context.Context) (pgx.Tx, error) {
+	return nil, p.err
+}
+
+func (p *fakePool) Ping(context.Context) error {
+	return p.err
+}
+
+type fakeRow struct {
+	pool *fakePool
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.pool.err != nil {
+		return r.pool.err
+	}
+	*dest[0].(*bool) = !r.pool.notStreaming
+	if !r.pool.neverReplay {
+		lag := r.pool.lagSeconds
+		*dest[1].(**float64) = &lag
+	}
+	return nil
+}
+
+func TestGetReadQuerier(t *testing.T) {
+	primary, replica := &fakePool{}, &fakePool{}
+	router := NewRouter(primary, replica)
+	ctx := context.Background()
+
+	t.Run("reads from the replica", func(t *testing.T) {
+		assert.Same(t, replica, GetReadQuerier(ctx, router))
+	})
+
+	t.Run("writes go to the primary", func(t *testing.T) {
+		assert.Same(t, router, GetQuerier(ctx, router))
+	})
+
+	t.Run("read-your-writes reads from the primary", func(t *testing.T) {
+		assert.Same(t, primary, GetReadQuerier(WithReadYourWrites(ctx), router))
+	})
+
+	t.Run("transactions read from the transaction", func(t *testing.T) {
+		tx := &fakePool{}
+		txCtx := context.WithValue(ctx, querierKey{}, Querier(tx))
+
+		assert.Same(t, tx, GetReadQuerier(txCtx, router))
+	})
+
+	t.Run("without a replica reads from the primary", func(t *testing.T) {
+		assert.Same(t, primary, GetReadQuerier(ctx, NewRouter(primary, nil)))
+	})
+
+	t.Run("plain queriers are returned as is", func(t *testing.T) {
+		assert.Same(t, primary, GetReadQuerier(ctx, primary))
+	})
+}
+
+func TestRouter_CheckReplica(t *testing.T) {
+	t.Run("within max lag", func(t *testing.T) {
+		router := NewRouter(&fakePool{}, &fakePool{lagSeconds: 0.5})
+
+		lag, err := router.ReplicaLag(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 500*time.Millisecond, lag)
+		assert.NoError(t, router.CheckReplica(context.Background(), time.Second))
+	})
+
+	t.Run("lagging", func(t *testing.T) {
+		router := NewRouter(&fakePool{}, &fakePool{lagSeconds: 30})
+
+		assert.ErrorContains(t, router.CheckReplica(context.Background(), time.Second), "replication lag")
+	})
+
+	t.Run("not streaming", func(t *testing.T) {
+		router := NewRouter(&fakePool{}, &fakePool{notStreaming: true})
+
+		assert.ErrorIs(t, router.CheckReplica(context.Background(), time.Second), ErrReplicaNotStreaming)
+	})
+
+	t.Run("never replayed", func(t *testing.T) {
+		router := NewRouter(&fakePool{}, &fakePool{neverReplay: true})
+
+		assert.ErrorIs(t, router.CheckReplica(context.Background(), time.Second), ErrReplicaNeverReplayed)
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		errDown := errors.New("connection refused")
+		router := NewRouter(&fakePool{}, &fakePool{err: errDown})
+
+		assert.ErrorIs(t, router.CheckReplica(context.Background(), time.Second), errDown)
+	})
+}
+
+func TestRouter_MonitorReplica(t *testing.T) {
+	primary, replica := &fakePool{}, &fakePool{lagSeconds: 30}
+	router := NewRouter(primary, replica)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	router.MonitorReplica(ctx, time.Minute, time.Second)
+
+	assert.Same(t, primary, GetReadQuerier(context.Background(), router))
+
+	replica.lagSeconds = 0
+	router.MonitorReplica(ctx, time.Minute, time.Second)
+
+	assert.Same(t, replica, GetReadQuerier(context.Background(), router))
+}
@@ -2,43 +2,139 @@ package redis
 
 import (
 	"context"
-	"log"
-	"sync"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
 	"time"
 
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
-var (
-	redisClient *redis.Client
-	once        sync.Once
+const (
+	ModeStandalone = "standalone"
+	ModeSentinel   = "sentinel"
+	ModeCluster    = "cluster"
 )
 
 type RedisConfig struct {
-	RedisAddress  string
-	RedisPassword string
-	RedisDB       int
+	Mode string
+	// Addresses is the server address in standalone mode, the Sentinel
+	// addresses in sentinel mode and the seed nodes in cluster mode.
+	Addresses        []string
+	MasterName       string
+	Username         string
+	Password         string
+	SentinelPassword string
+	DB               int
+	TLS              RedisTLSConfig
 }
 
-func GetRedisClient(redisConfig RedisConfig) *redis.Client {
-	once.Do(func() {
-		log.Printf("Connecting to Redis at %s (DB: %d)...", redisConfig.RedisAddress, redisConfig.RedisDB)
+type RedisTLSConfig struct {
+	Enabled bool
+	// CAFile verifies the server against a private CA instead of the system
+	// pool; CertFile and KeyFile enable client certificate authentication.
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+	ServerName         string
+	InsecureSkipVerify bool
+}
+
+// NewClient builds a client for the configured mode without connecting.
+func NewClient(config RedisConfig) (redis.UniversalClient, error) {
+	if len(config.Addresses) == 0 {
+		return nil, errors.New("no Redis address configured")
+	}
+
+	tlsConfig, err := config.TLS.build()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &redis.UniversalOptions{
+		Addrs:            config.Addresses,
+		MasterName:       config.MasterName,
+		Username:         config.Username,
+		Password:         config.Password,
+		SentinelPassword: config.SentinelPassword,
+		DB:               config.DB,
+		TLSConfig:        tlsConfig,
+	}
+
+	switch config.Mode {
+	case ModeStandalone:
+		return redis.NewClient(opts.Simple()), nil
+	case ModeSentinel:
+		return redis.NewFailoverClient(opts.Failover()), nil
+	case ModeCluster:
+		return redis.NewClusterClient(opts.Cluster()), nil
+	default:
+		return nil, fmt.Errorf("unknown Redis mode %q", config.Mode)
+	}
+}
+
+// NewRedis builds an instrumented client and pings it. A client that cannot
+// be built is returned as a nil client and the error; a failed ping is
+// logged and returned alongside the client, which stays usable and
+// reconnects on its own once Redis is back.
+func NewRedis(config RedisConfig) (redis.UniversalClient, error) {
+	slog.Info("Connecting to Redis", "mode", config.Mode, "addresses", config.Addresses, "db", config.DB, "tls", config.TLS.Enabled)
+
+	client, err := NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("create Redis client: %w", err)
+	}
 
-		redisClient = redis.NewClient(&redis.Options{
-			Addr:     redisConfig.RedisAddress,
-			Password: redisConfig.RedisPassword,
-			DB:       redisConfig.RedisDB,
-		})
+	if err := redisotel.InstrumentTracing(client); err != nil {
+		slog.Error("Failed to instrument Redis tracing", "error", err)
+	}
 
-		// Test the connection
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+	// Test the connection
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-		if err := redisClient.Ping(ctx).Err(); err != nil {
-			log.Printf("Failed to connect to Redis: %v", err)
-		} else {
-			log.Printf("Successfully connected to Redis at %s", redisConfig.RedisAddress)
+	if err := client.Ping(ctx).Err(); err != nil {
+		slog.Warn("Redis is unreachable, starting degraded", "error", err)
+		return client, err
+	}
+	slog.Info("Successfully connected to Redis", "mode", config.Mode, "addresses", config.Addresses)
+	return client, nil
+}
+
+func (c RedisTLSConfig) build() (*tls.Config, error) {
+	if !c.Enabled {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read Redis CA file: %w", err)
 		}
-	})
-	return redisClient
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in Redis CA file %q", c.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load Redis client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
 }
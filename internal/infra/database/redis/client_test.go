@@ -0,0 +1,114 @@
+package redis_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	db_redis "github.com/brunoibarbosa/url-shortener/internal/infra/database/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient_Modes(t *testing.T) {
+	t.Run("standalone", func(t *testing.T) {
+		client, err := db_redis.NewClient(db_redis.RedisConfig{
+			Mode:      db_redis.ModeStandalone,
+			Addresses: []string{"localhost:6379"},
+		})
+		require.NoError(t, err)
+		defer client.Close()
+
+		assert.IsType(t, &redis.Client{}, client)
+	})
+
+	t.Run("sentinel", func(t *testing.T) {
+		client, err := db_redis.NewClient(db_redis.RedisConfig{
+			Mode:       db_redis.ModeSentinel,
+			Addresses:  []string{"sentinel-1:26379", "sentinel-2:26379"},
+			MasterName: "mymaster",
+		})
+		require.NoError(t, err)
+		defer client.Close()
+
+		assert.IsType(t, &redis.Client{}, client)
+	})
+
+	t.Run("cluster with a single seed node", func(t *testing.T) {
+		client, err := db_redis.NewClient(db_redis.RedisConfig{
+			Mode:      db_redis.ModeCluster,
+			Addresses: []string{"node-1:6379"},
+		})
+		require.NoError(t, err)
+		defer client.Close()
+
+		assert.IsType(t, &redis.ClusterClient{}, client)
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		_, err := db_redis.NewClient(db_redis.RedisConfig{
+			Mode:      "ring",
+			Addresses: []string{"localhost:6379"},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("no address", func(t *testing.T) {
+		_, err := db_redis.NewClient(db_redis.RedisConfig{Mode: db_redis.ModeStandalone})
+		assert.Error(t, err)
+	})
+}
+
+func TestNewClient_TLS(t *testing.T) {
+	config := func(tls db_redis.RedisTLSConfig) db_redis.RedisConfig {
+		return db_redis.RedisConfig{
+			Mode:      db_redis.ModeStandalone,
+			Addresses: []string{"localhost:6379"},
+			TLS:       tls,
+		}
+	}
+
+	t.Run("enabled without files", func(t *testing.T) {
+		client, err := db_redis.NewClient(config(db_redis.RedisTLSConfig{Enabled: true, ServerName: "redis.internal"}))
+		require.NoError(t, err)
+		defer client.Close()
+
+		opts := client.(*redis.Client).Options()
+		require.NotNil(t, opts.TLSConfig)
+		assert.Equal(t, "redis.internal", opts.TLSConfig.ServerName)
+	})
+
+	t.Run("files are ignored while disabled", func(t *testing.T) {
+		client, err := db_redis.NewClient(config(db_redis.RedisTLSConfig{CAFile: "/does/not/exist"}))
+		require.NoError(t, err)
+		defer client.Close()
+
+		assert.Nil(t, client.(*redis.Client).Options().TLSConfig)
+	})
+
+	t.Run("missing CA file", func(t *testing.T) {
+		_, err := db_redis.NewClient(config(db_redis.RedisTLSConfig{Enabled: true, CAFile: "/does/not/exist"}))
+		assert.Error(t, err)
+	})
+
+	t.Run("CA file without certificates", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(path, []byte("not a certificate"), 0o600))
+
+		_, err := db_redis.NewClient(config(db_redis.RedisTLSConfig{Enabled: true, CAFile: path}))
+		assert.ErrorContains(t, err, "no certificates")
+	})
+
+	t.Run("unreadable client certificate", func(t *testing.T) {
+		_, err := db_redis.NewClient(config(db_redis.RedisTLSConfig{Enabled: true, CertFile: "/does/not/exist", KeyFile: "/does/not/exist"}))
+		assert.Error(t, err)
+	})
+}
+
+func TestNewRedis_InvalidConfig(t *testing.T) {
+	client, err := db_redis.NewRedis(db_redis.RedisConfig{Mode: "replicated", Addresses: []string{"localhost:6379"}})
+
+	assert.Nil(t, client)
+	assert.ErrorContains(t, err, `unknown Redis mode "replicated"`)
+}
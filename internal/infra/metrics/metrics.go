@@ -0,0 +1,215 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/infra/resilience"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/crypto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "url_shortener"
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequestDuration  *prometheus.HistogramVec
+	urlCacheLookups      *prometheus.CounterVec
+	urlLocalCacheLookups *prometheus.CounterVec
+	shortCodeCollisions  prometheus.Counter
+	logins               *prometheus.CounterVec
+	jobRuns              *prometheus.CounterVec
+	jobDuration          *prometheus.HistogramVec
+	jobAffectedRows      *prometheus.CounterVec
+	schedulerLeader      prometheus.Gauge
+	webhookDeliveries    *prometheus.CounterVec
+	webhookEventsDropped prometheus.Counter
+	outboxMessages       *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	factory := promauto.With(registry)
+
+	return &Metrics{
+		registry: registry,
+		httpRequestDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duration of HTTP requests by method, chi route pattern and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		urlCacheLookups: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "url_cache_lookups_total",
+			Help:      "Short code lookups in the URL cache by result (hit or miss).",
+		}, []string{"result"}),
+		urlLocalCacheLookups: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "url_local_cache_lookups_total",
+			Help:      "Short code lookups in the in-process URL cache by result (hit or miss).",
+		}, []string{"result"}),
+		shortCodeCollisions: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "short_code_collisions_total",
+			Help:      "Generated short codes that were already taken and had to be retried.",
+		}),
+		logins: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "auth_logins_total",
+			Help:      "Login attempts by provider and result (success or failure).",
+		}, []string{"provider", "result"}),
+		jobRuns: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "scheduler_job_runs_total",
+			Help:      "Scheduled job runs by job and result (success or failure).",
+		}, []string{"job", "result"}),
+		jobDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "scheduler_job_duration_seconds",
+			Help:      "Duration of scheduled job runs by job.",
+			Buckets:   []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300},
+		}, []string{"job"}),
+		jobAffectedRows: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "scheduler_job_affected_rows_total",
+			Help:      "Rows deleted or updated by scheduled jobs, by job.",
+		}, []string{"job"}),
+		schedulerLeader: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "scheduler_leader",
+			Help:      "Whether this instance holds the scheduler leader lock (1) or not (0).",
+		}),
+		webhookDeliveries: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "webhook_deliveries_total",
+			Help:      "Webhook delivery attempts by result (success, retry or dead).",
+		}, []string{"result"}),
+		webhookEventsDropped: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "webhook_events_dropped_total",
+			Help:      "Click events dropped because the webhook publisher buffer was full.",
+		}),
+		outboxMessages: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "outbox_messages_total",
+			Help:      "Outbox messages handed to their subscribers by topic and result (relayed, failed or dead).",
+		}, []string{"topic", "result"}),
+	}
+}
+
+// RegisterBreaker exports the state of b: 0 closed, 1 half-open, 2 open.
+func (m *Metrics) RegisterBreaker(b *resilience.Breaker) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   namespace,
+		Name:        "circuit_breaker_state",
+		Help:        "State of the circuit breaker guarding a dependency (0 closed, 1 half-open, 2 open).",
+		ConstLabels: prometheus.Labels{"name": b.Name()},
+	}, func() float64 {
+		return float64(b.State())
+	}))
+}
+
+func (m *Metrics) RegisterKeyring(name string, k *crypto.Keyring) {
+	m.registry.MustRegister(prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace:   namespace,
+		Name:        "data_key_stale_uses_total",
+		Help:        "Data keys used past their cache TTL because the key provider was unavailable.",
+		ConstLabels: prometheus.Labels{"keyring": name},
+	}, func() float64 {
+		return float64(k.StaleKeyUses())
+	}))
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	m.httpRequestDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+func (m *Metrics) CacheHit() {
+	m.urlCacheLookups.WithLabelValues("hit").Inc()
+}
+
+func (m *Metrics) CacheMiss() {
+	m.urlCacheLookups.WithLabelValues("miss").Inc()
+}
+
+func (m *Metrics) LocalCacheHit() {
+	m.urlLocalCacheLookups.WithLabelValues("hit").Inc()
+}
+
+func (m *Metrics) LocalCacheMiss() {
+	m.urlLocalCacheLookups.WithLabelValues("miss").Inc()
+}
+
+func (m *Metrics) ShortCodeCollision() {
+	m.shortCodeCollisions.Inc()
+}
+
+func (m *Metrics) LoginSucceeded(provider string) {
+	m.logins.WithLabelValues(provider, "success").Inc()
+}
+
+func (m *Metrics) LoginFailed(provider string) {
+	m.logins.WithLabelValues(provider, "failure").Inc()
+}
+
+func (m *Metrics) JobSucceeded(job string, duration time.Duration, affected int64) {
+	m.jobRuns.WithLabelValues(job, "success").Inc()
+	m.jobDuration.WithLabelValues(job).Observe(duration.Seconds())
+	m.jobAffectedRows.WithLabelValues(job).Add(float64(affected))
+}
+
+func (m *Metrics) JobFailed(job string, duration time.Duration) {
+	m.jobRuns.WithLabelValues(job, "failure").Inc()
+	m.jobDuration.WithLabelValues(job).Observe(duration.Seconds())
+}
+
+func (m *Metrics) SetLeader(leader bool) {
+	if leader {
+		m.schedulerLeader.Set(1)
+	} else {
+		m.schedulerLeader.Set(0)
+	}
+}
+
+func (m *Metrics) DeliverySucceeded() {
+	m.webhookDeliveries.WithLabelValues("success").Inc()
+}
+
+func (m *Metrics) DeliveryFailed(dead bool) {
+	if dead {
+		m.webhookDeliveries.WithLabelValues("dead").Inc()
+	} else {
+		m.webhookDeliveries.WithLabelValues("retry").Inc()
+	}
+}
+
+func (m *Metrics) EventDropped() {
+	m.webhookEventsDropped.Inc()
+}
+
+func (m *Metrics) MessageRelayed(topic string) {
+	m.outboxMessages.WithLabelValues(topic, "relayed").Inc()
+}
+
+func (m *Metrics) MessageFailed(topic string, dead bool) {
+	if dead {
+		m.outboxMessages.WithLabelValues(topic, "dead").Inc()
+	} else {
+		m.outboxMessages.WithLabelValues(topic, "failed").Inc()
+	}
+}
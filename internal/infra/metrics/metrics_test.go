@@ -0,0 +1,145 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/infra/metrics"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/resilience"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMetrics_Counters(t *testing.T) {
+	m := metrics.New()
+
+	m.CacheHit()
+	m.CacheHit()
+	m.CacheMiss()
+	m.LocalCacheHit()
+	m.LocalCacheMiss()
+	m.LocalCacheMiss()
+	m.ShortCodeCollision()
+	m.LoginSucceeded("password")
+	m.LoginFailed("password")
+	m.LoginFailed("password")
+
+	body := scrape(t, m)
+
+	assert.Contains(t, body, `url_shortener_url_cache_lookups_total{result="hit"} 2`)
+	assert.Contains(t, body, `url_shortener_url_cache_lookups_total{result="miss"} 1`)
+	assert.Contains(t, body, `url_shortener_url_local_cache_lookups_total{result="hit"} 1`)
+	assert.Contains(t, body, `url_shortener_url_local_cache_lookups_total{result="miss"} 2`)
+	assert.Contains(t, body, `url_shortener_short_code_collisions_total 1`)
+	assert.Contains(t, body, `url_shortener_auth_logins_total{provider="password",result="success"} 1`)
+	assert.Contains(t, body, `url_shortener_auth_logins_total{provider="password",result="failure"} 2`)
+}
+
+func TestMetrics_ObserveHTTPRequest(t *testing.T) {
+	m := metrics.New()
+
+	m.ObserveHTTPRequest(http.MethodGet, "/r/{shortCode}", http.StatusFound, 20*time.Millisecond)
+
+	body := scrape(t, m)
+
+	assert.Contains(t, body, `url_shortener_http_request_duration_seconds_count{method="GET",route="/r/{shortCode}",status="302"} 1`)
+}
+
+func TestMetrics_Scheduler(t *testing.T) {
+	m := metrics.New()
+
+	m.SetLeader(true)
+	m.JobSucceeded("url_cleanup", time.Second, 120)
+	m.JobSucceeded("url_cleanup", time.Second, 30)
+	m.JobFailed("session_cleanup", time.Second)
+
+	body := scrape(t, m)
+
+	assert.Contains(t, body, `url_shortener_scheduler_leader 1`)
+	assert.Contains(t, body, `url_shortener_scheduler_job_runs_total{job="url_cleanup",result="success"} 2`)
+	assert.Contains(t, body, `url_shortener_scheduler_job_runs_total{job="session_cleanup",result="failure"} 1`)
+	assert.Contains(t, body, `url_shortener_scheduler_job_affected_rows_total{job="url_cleanup"} 150`)
+	assert.Contains(t, body, `url_shortener_scheduler_job_duration_seconds_count{job="url_cleanup"} 2`)
+}
+
+func TestMetrics_Webhooks(t *testing.T) {
+	m := metrics.New()
+
+	m.DeliverySucceeded()
+	m.DeliveryFailed(false)
+	m.DeliveryFailed(false)
+	m.DeliveryFailed(true)
+	m.EventDropped()
+
+	body := scrape(t, m)
+
+	assert.Contains(t, body, `url_shortener_webhook_deliveries_total{result="success"} 1`)
+	assert.Contains(t, body, `url_shortener_webhook_deliveries_total{result="retry"} 2`)
+	assert.Contains(t, body, `url_shortener_webhook_deliveries_total{result="dead"} 1`)
+	assert.Contains(t, body, `url_shortener_webhook_events_dropped_total 1`)
+}
+
+func TestMetrics_Outbox(t *testing.T) {
+	m := metrics.New()
+
+	m.MessageRelayed("url.deleted")
+	m.MessageRelayed("url.deleted")
+	m.MessageFailed("session.revoked", false)
+	m.MessageFailed("url.deleted", true)
+
+	body := scrape(t, m)
+
+	assert.Contains(t, body, `url_shortener_outbox_messages_total{result="relayed",topic="url.deleted"} 2`)
+	assert.Contains(t, body, `url_shortener_outbox_messages_total{result="failed",topic="session.revoked"} 1`)
+	assert.Contains(t, body, `url_shortener_outbox_messages_total{result="dead",topic="url.deleted"} 1`)
+}
+
+func TestMetrics_RegisterBreaker(t *testing.T) {
+	m := metrics.New()
+	breaker := resilience.NewBreaker(resilience.BreakerConfig{
+		Name:             "redis",
+		Timeout:          time.Second,
+		FailureThreshold: 1,
+		OpenDuration:     time.Minute,
+	})
+	m.RegisterBreaker(breaker)
+
+	assert.Contains(t, scrape(t, m), `url_shortener_circuit_breaker_state{name="redis"} 0`)
+
+	_ = breaker.Execute(context.Background(), func(context.Context) error { return errors.New("down") })
+
+	assert.Contains(t, scrape(t, m), `url_shortener_circuit_breaker_state{name="redis"} 2`)
+}
+
+func TestMetrics_IncludesRuntimeCollectors(t *testing.T) {
+	m := metrics.New()
+
+	body := scrape(t, m)
+
+	assert.Contains(t, body, "go_goroutines")
+}
+
+func TestMetrics_RegisterKeyring(t *testing.T) {
+	m := metrics.New()
+	keyring, err := crypto.NewKeyring("k1", []crypto.Key{{ID: "k1", Secret: make([]byte, 32)}}, "")
+	require.NoError(t, err)
+	m.RegisterKeyring("url", keyring)
+
+	assert.Contains(t, scrape(t, m), `url_shortener_data_key_stale_uses_total{keyring="url"} 0`)
+}
@@ -0,0 +1,108 @@
+package memory_repo
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+)
+
+// URLLocalCache is a bounded LRU of decrypted redirects with per-entry
+// expiration. A capacity of zero disables it.
+type URLLocalCache struct {
+	capacity int
+
+	mu    sync.Mutex
+	order *list.List
+	items map[string]*list.Element
+}
+
+type localCacheEntry struct {
+	shortCode string
+	redirect  domain.Redirect
+	expires   time.Time
+}
+
+func NewURLLocalCache(capacity int) *URLLocalCache {
+	return &URLLocalCache{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *URLLocalCache) Get(shortCode string) (domain.Redirect, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[shortCode]
+	if !ok {
+		return domain.Redirect{}, false
+	}
+
+	entry := el.Value.(*localCacheEntry)
+	if !time.Now().Before(entry.expires) {
+		c.remove(el)
+		return domain.Redirect{}, false
+	}
+
+	c.order.MoveToFront(el)
+	return entry.redirect, true
+}
+
+func (c *URLLocalCache) Set(shortCode string, redirect domain.Redirect, ttl time.Duration) {
+	if c.capacity <= 0 || ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := time.Now().Add(ttl)
+	if el, ok := c.items[shortCode]; ok {
+		entry := el.Value.(*localCacheEntry)
+		entry.redirect = redirect
+		entry.expires = expires
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[shortCode] = c.order.PushFront(&localCacheEntry{
+		shortCode: shortCode,
+		redirect:  redirect,
+		expires:   expires,
+	})
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *URLLocalCache) Delete(shortCode string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[shortCode]; ok {
+		c.remove(el)
+	}
+}
+
+func (c *URLLocalCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	clear(c.items)
+}
+
+func (c *URLLocalCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *URLLocalCache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*localCacheEntry).shortCode)
+}
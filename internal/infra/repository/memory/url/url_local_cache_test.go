@@ -0,0 +1,114 @@
+package memory_repo_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	memory_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/memory/url"
+	"github.com/stretchr/testify/assert"
+)
+
+func redirect(destination string) domain.Redirect {
+	return domain.Redirect{Destination: destination}
+}
+
+func TestURLLocalCache_SetAndGet(t *testing.T) {
+	cache := memory_repo.NewURLLocalCache(10)
+
+	cache.Set("abc123", redirect("https://example.com"), time.Minute)
+	destination, ok := cache.Get("abc123")
+
+	assert.True(t, ok)
+	assert.Equal(t, "https://example.com", destination.Destination)
+
+	_, ok = cache.Get("missing")
+	assert.False(t, ok)
+}
+
+func TestURLLocalCache_Expiration(t *testing.T) {
+	cache := memory_repo.NewURLLocalCache(10)
+
+	cache.Set("abc123", redirect("https://example.com"), 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+
+	_, ok := cache.Get("abc123")
+	assert.False(t, ok)
+	assert.Zero(t, cache.Len())
+}
+
+func TestURLLocalCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := memory_repo.NewURLLocalCache(2)
+
+	cache.Set("a", redirect("https://a.com"), time.Minute)
+	cache.Set("b", redirect("https://b.com"), time.Minute)
+	cache.Get("a")
+	cache.Set("c", redirect("https://c.com"), time.Minute)
+
+	_, okA := cache.Get("a")
+	_, okB := cache.Get("b")
+	_, okC := cache.Get("c")
+	assert.True(t, okA)
+	assert.False(t, okB)
+	assert.True(t, okC)
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestURLLocalCache_SetReplacesEntry(t *testing.T) {
+	cache := memory_repo.NewURLLocalCache(2)
+
+	cache.Set("a", redirect("https://old.com"), time.Minute)
+	cache.Set("a", redirect("https://new.com"), time.Minute)
+
+	destination, _ := cache.Get("a")
+	assert.Equal(t, "https://new.com", destination.Destination)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestURLLocalCache_DeleteAndPurge(t *testing.T) {
+	cache := memory_repo.NewURLLocalCache(10)
+	cache.Set("a", redirect("https://a.com"), time.Minute)
+	cache.Set("b", redirect("https://b.com"), time.Minute)
+
+	cache.Delete("a")
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 1, cache.Len())
+
+	cache.Purge()
+	assert.Zero(t, cache.Len())
+}
+
+func TestURLLocalCache_Disabled(t *testing.T) {
+	cache := memory_repo.NewURLLocalCache(0)
+
+	cache.Set("a", redirect("https://a.com"), time.Minute)
+
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+}
+
+func TestURLLocalCache_Concurrent(t *testing.T) {
+	cache := memory_repo.NewURLLocalCache(100)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				code := fmt.Sprintf("code%d", (w*500+i)%300)
+				cache.Set(code, redirect("https://example.com"), time.Minute)
+				cache.Get(code)
+				if i%50 == 0 {
+					cache.Delete(code)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, cache.Len(), 100)
+}
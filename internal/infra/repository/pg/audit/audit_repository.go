@@ -0,0 +1,27 @@
+package pg_repo
+
+import (
+	"context"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
+	base "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/base"
+)
+
+type AuditRepository struct {
+	base.BaseRepository
+}
+
+func NewAuditRepository(q pg.Querier) *AuditRepository {
+	return &AuditRepository{
+		BaseRepository: base.NewBaseRepository(q),
+	}
+}
+
+func (r *AuditRepository) Record(ctx context.Context, e *domain.Event) error {
+	return r.Q(ctx).QueryRow(
+		ctx,
+		"INSERT INTO audit_events (actor_id, action, target_type, target_id, ip_address, user_agent) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at",
+		e.ActorID, string(e.Action), e.TargetType, e.TargetID, e.IPAddress, e.UserAgent,
+	).Scan(&e.ID, &e.CreatedAt)
+}
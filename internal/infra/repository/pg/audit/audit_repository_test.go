@@ -0,0 +1,243 @@
+package pg_repo_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/domain"
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
+	pg_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/audit"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+var (
+	testDB        *pgxpool.Pool
+	testContainer *postgres.PostgresContainer
+)
+
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+
+	container, err := postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second)),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	testContainer = container
+	defer func() {
+		if testDB != nil {
+			testDB.Close()
+		}
+		if testContainer != nil {
+			testContainer.Terminate(context.Background())
+		}
+	}()
+
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		panic(err)
+	}
+
+	pool, err := pgxpool.New(ctx, connStr)
+	if err != nil {
+		panic(err)
+	}
+
+	testDB = pool
+
+	if err := runMigrations(ctx); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	os.Exit(code)
+}
+
+func runMigrations(ctx context.Context) error {
+	_, err := testDB.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS users (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			email TEXT UNIQUE,
+			created_at TIMESTAMPTZ DEFAULT NOW(),
+			updated_at TIMESTAMPTZ
+		);
+
+		CREATE TABLE IF NOT EXISTS audit_events (
+			id BIGSERIAL PRIMARY KEY,
+			actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+			action TEXT NOT NULL,
+			target_type TEXT,
+			target_id TEXT,
+			ip_address TEXT,
+			user_agent TEXT,
+			created_at TIMESTAMPTZ DEFAULT NOW()
+		);
+	`)
+	return err
+}
+
+func cleanDB(t *testing.T) {
+	ctx := context.Background()
+	_, err := testDB.Exec(ctx, "TRUNCATE audit_events, users CASCADE")
+	require.NoError(t, err)
+}
+
+func createTestUser(t *testing.T, ctx context.Context, email string) uuid.UUID {
+	var userID uuid.UUID
+	err := testDB.QueryRow(ctx, "INSERT INTO users (email) VALUES ($1) RETURNING id", email).Scan(&userID)
+	require.NoError(t, err)
+	return userID
+}
+
+func TestAuditRepository_Record_Success(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, "audit@example.com")
+
+	repo := pg_repo.NewAuditRepository(testDB)
+
+	event := &audit_domain.Event{
+		ActorID:    &userID,
+		Action:     audit_domain.ActionLogin,
+		TargetType: audit_domain.TargetSession,
+		TargetID:   uuid.New().String(),
+		IPAddress:  "127.0.0.1",
+		UserAgent:  "Mozilla/5.0",
+	}
+
+	err := repo.Record(ctx, event)
+
+	require.NoError(t, err)
+	assert.NotZero(t, event.ID)
+	assert.False(t, event.CreatedAt.IsZero())
+}
+
+func TestAuditRepository_Record_WithoutActor(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+
+	repo := pg_repo.NewAuditRepository(testDB)
+
+	event := &audit_domain.Event{
+		Action:     audit_domain.ActionLoginFailed,
+		TargetType: audit_domain.TargetUser,
+		TargetID:   "unknown@example.com",
+	}
+
+	err := repo.Record(ctx, event)
+
+	require.NoError(t, err)
+	assert.NotZero(t, event.ID)
+}
+
+func TestAuditRepository_Record_WithinTransaction(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+
+	repo := pg_repo.NewAuditRepository(testDB)
+	txManager := pg.NewTxManager(testDB)
+
+	err := txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := repo.Record(txCtx, &audit_domain.Event{Action: audit_domain.ActionLogout}); err != nil {
+			return err
+		}
+		return assert.AnError
+	})
+	require.Error(t, err)
+
+	var count int
+	require.NoError(t, testDB.QueryRow(ctx, "SELECT COUNT(*) FROM audit_events").Scan(&count))
+	assert.Equal(t, 0, count)
+}
+
+func TestListAuditEventsRepository_List_Filters(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	userA := createTestUser(t, ctx, "a@example.com")
+	userB := createTestUser(t, ctx, "b@example.com")
+
+	recorder := pg_repo.NewAuditRepository(testDB)
+	for _, e := range []*audit_domain.Event{
+		{ActorID: &userA, Action: audit_domain.ActionLogin, IPAddress: "10.0.0.1"},
+		{ActorID: &userA, Action: audit_domain.ActionURLCreated, TargetID: "abc123", IPAddress: "10.0.0.1"},
+		{ActorID: &userB, Action: audit_domain.ActionLogin, IPAddress: "10.0.0.2"},
+		{Action: audit_domain.ActionLoginFailed, IPAddress: "10.0.0.3"},
+	} {
+		require.NoError(t, recorder.Record(ctx, e))
+	}
+
+	repo := pg_repo.NewListAuditEventsRepository(testDB)
+	pagination := domain.Pagination{Number: 1, Size: 10}
+
+	t.Run("by actor", func(t *testing.T) {
+		events, count, err := repo.List(ctx, audit_domain.ListEventsParams{
+			Filter:     audit_domain.ListEventsFilter{ActorID: &userA},
+			Pagination: pagination,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), count)
+		assert.Len(t, events, 2)
+	})
+
+	t.Run("by action and ip", func(t *testing.T) {
+		action := audit_domain.ActionLogin
+		ip := "10.0.0.2"
+		events, count, err := repo.List(ctx, audit_domain.ListEventsParams{
+			Filter:     audit_domain.ListEventsFilter{Action: &action, IPAddress: &ip},
+			Pagination: pagination,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), count)
+		require.Len(t, events, 1)
+		assert.Equal(t, &userB, events[0].ActorID)
+	})
+
+	t.Run("by target", func(t *testing.T) {
+		target := "abc123"
+		events, _, err := repo.List(ctx, audit_domain.ListEventsParams{
+			Filter:     audit_domain.ListEventsFilter{TargetID: &target},
+			Pagination: pagination,
+		})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, audit_domain.ActionURLCreated, events[0].Action)
+	})
+
+	t.Run("by time range", func(t *testing.T) {
+		future := time.Now().Add(time.Hour)
+		events, count, err := repo.List(ctx, audit_domain.ListEventsParams{
+			Filter:     audit_domain.ListEventsFilter{From: &future},
+			Pagination: pagination,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), count)
+		assert.Empty(t, events)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		events, count, err := repo.List(ctx, audit_domain.ListEventsParams{
+			Pagination: domain.Pagination{Number: 2, Size: 3},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), count)
+		assert.Len(t, events, 1)
+	})
+}
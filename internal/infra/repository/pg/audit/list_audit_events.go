@@ -0,0 +1,144 @@
+package pg_repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/brunoibarbosa/url-shortener/internal/domain"
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
+	base "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/base"
+)
+
+type ListAuditEventsRepository struct {
+	base.BaseRepository
+}
+
+func NewListAuditEventsRepository(q pg.Querier) *ListAuditEventsRepository {
+	return &ListAuditEventsRepository{
+		BaseRepository: base.NewBaseRepository(q),
+	}
+}
+
+func (r *ListAuditEventsRepository) List(ctx context.Context, p audit_domain.ListEventsParams) ([]audit_domain.ListEventsDTO, uint64, error) {
+	where, args := r.getWhere(p.Filter)
+	pagination := r.getPagination(p)
+	sort := r.getOrderByField(p)
+
+	var count uint64
+	if pagination != "" {
+		if err := r.Q(ctx).QueryRow(ctx, `
+			SELECT COUNT(a.id)
+			FROM audit_events a
+		`+where,
+			args...,
+		).Scan(&count); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	rows, err := r.Q(ctx).Query(ctx, `
+		SELECT
+			a.id,
+			a.actor_id,
+			a.action,
+			COALESCE(a.target_type, ''),
+			COALESCE(a.target_id, ''),
+			COALESCE(a.ip_address, ''),
+			COALESCE(a.user_agent, ''),
+			a.created_at
+		FROM audit_events a
+		`+where+
+		sort+
+		pagination,
+		args...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	events := []audit_domain.ListEventsDTO{}
+	for rows.Next() {
+		var e audit_domain.ListEventsDTO
+		if err := rows.Scan(
+			&e.ID,
+			&e.ActorID,
+			&e.Action,
+			&e.TargetType,
+			&e.TargetID,
+			&e.IPAddress,
+			&e.UserAgent,
+			&e.CreatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+
+		events = append(events, e)
+	}
+
+	if count == 0 {
+		count = uint64(len(events))
+	}
+
+	return events, count, nil
+}
+
+func (*ListAuditEventsRepository) getWhere(f audit_domain.ListEventsFilter) (string, []any) {
+	conditions := []string{}
+	args := []any{}
+
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if f.ActorID != nil {
+		add("a.actor_id = $%d", *f.ActorID)
+	}
+	if f.Action != nil {
+		add("a.action = $%d", string(*f.Action))
+	}
+	if f.TargetID != nil {
+		add("a.target_id = $%d", *f.TargetID)
+	}
+	if f.IPAddress != nil {
+		add("a.ip_address = $%d", *f.IPAddress)
+	}
+	if f.From != nil {
+		add("a.created_at >= $%d", f.From.UTC())
+	}
+	if f.To != nil {
+		add("a.created_at <= $%d", f.To.UTC())
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (*ListAuditEventsRepository) getOrderByField(p audit_domain.ListEventsParams) string {
+	sortKind := ""
+	switch p.SortKind {
+	case domain.SortAsc:
+		sortKind = "ASC"
+	default:
+		sortKind = "DESC"
+	}
+
+	return fmt.Sprintf(" ORDER BY a.created_at %s, a.id %s", sortKind, sortKind)
+}
+
+func (*ListAuditEventsRepository) getPagination(p audit_domain.ListEventsParams) string {
+	if p.Pagination.Size <= 0 {
+		return ""
+	}
+
+	offset := p.Pagination.Size * (p.Pagination.Number - 1)
+	pgn := fmt.Sprintf(" LIMIT %d OFFSET %d", p.Pagination.Size, offset)
+
+	return pgn
+}
@@ -17,3 +17,9 @@ func NewBaseRepository(q pg.Querier) BaseRepository {
 func (r BaseRepository) Q(ctx context.Context) pg.Querier {
 	return pg.GetQuerier(ctx, r.q)
 }
+
+// R returns the querier for read-only statements, which may be a replica
+// that lags behind the primary.
+func (r BaseRepository) R(ctx context.Context) pg.Querier {
+	return pg.GetReadQuerier(ctx, r.q)
+}
@@ -0,0 +1,81 @@
+package pg_repo
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
+	base "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/base"
+	"github.com/google/uuid"
+)
+
+type OutboxRepository struct {
+	base.BaseRepository
+}
+
+func NewOutboxRepository(q pg.Querier) *OutboxRepository {
+	return &OutboxRepository{
+		BaseRepository: base.NewBaseRepository(q),
+	}
+}
+
+func (r *OutboxRepository) Append(ctx context.Context, m *domain.Message) error {
+	_, err := r.Q(ctx).Exec(ctx, `
+		INSERT INTO outbox (id, topic, payload, occurred_at, available_at)
+		VALUES ($1, $2, $3, $4, $4)
+	`, m.ID, m.Topic, string(m.Payload), m.OccurredAt)
+	return err
+}
+
+func (r *OutboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.Message, error) {
+	rows, err := r.Q(ctx).Query(ctx, `
+		UPDATE outbox SET available_at = $2
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE dead_at IS NULL AND available_at <= $1
+			ORDER BY available_at, occurred_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, topic, payload, occurred_at, attempts
+	`, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*domain.Message
+	for rows.Next() {
+		var m domain.Message
+		if err := rows.Scan(&m.ID, &m.Topic, &m.Payload, &m.OccurredAt, &m.Attempts); err != nil {
+			return nil, err
+		}
+		messages = append(messages, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// RETURNING does not keep the order of the subquery.
+	slices.SortFunc(messages, func(a, b *domain.Message) int {
+		return a.OccurredAt.Compare(b.OccurredAt)
+	})
+	return messages, nil
+}
+
+func (r *OutboxRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.Q(ctx).Exec(ctx, "DELETE FROM outbox WHERE id = $1", id)
+	return err
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, nextAttemptAt *time.Time, lastError string) error {
+	_, err := r.Q(ctx).Exec(ctx, `
+		UPDATE outbox SET attempts = attempts + 1, last_error = $3,
+			available_at = COALESCE($2, available_at),
+			dead_at = CASE WHEN $2::timestamptz IS NULL THEN NOW() END
+		WHERE id = $1
+	`, id, nextAttemptAt, lastError)
+	return err
+}
@@ -0,0 +1,204 @@
+package pg_repo_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/outbox"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
+	pg_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/outbox"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+var (
+	testDB        *pgxpool.Pool
+	testContainer *postgres.PostgresContainer
+)
+
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+
+	container, err := postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second)),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	testContainer = container
+	defer func() {
+		if testDB != nil {
+			testDB.Close()
+		}
+		if testContainer != nil {
+			testContainer.Terminate(context.Background())
+		}
+	}()
+
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		panic(err)
+	}
+
+	pool, err := pgxpool.New(ctx, connStr)
+	if err != nil {
+		panic(err)
+	}
+
+	testDB = pool
+
+	if err := runMigrations(ctx); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	os.Exit(code)
+}
+
+func runMigrations(ctx context.Context) error {
+	_, err := testDB.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS outbox (
+			id UUID PRIMARY KEY,
+			topic TEXT NOT NULL,
+			payload TEXT NOT NULL,
+			occurred_at TIMESTAMPTZ NOT NULL,
+			available_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			attempts INT NOT NULL DEFAULT 0,
+			last_error TEXT,
+			dead_at TIMESTAMPTZ
+		);
+	`)
+	return err
+}
+
+func cleanDB(t *testing.T) {
+	ctx := context.Background()
+	_, err := testDB.Exec(ctx, "TRUNCATE outbox")
+	require.NoError(t, err)
+}
+
+func newMessage(t *testing.T, topic string) *domain.Message {
+	m, err := domain.NewMessage(topic, map[string]string{"shortCode": "abc123"})
+	require.NoError(t, err)
+	return m
+}
+
+func TestOutboxRepository_Append_JoinsTransaction(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	repo := pg_repo.NewOutboxRepository(testDB)
+	tx := pg.NewTxManager(testDB)
+
+	rollback := errors.New("rollback")
+	err := tx.WithinTransaction(ctx, func(txCtx context.Context) error {
+		require.NoError(t, repo.Append(txCtx, newMessage(t, "url.deleted")))
+		return rollback
+	})
+	require.ErrorIs(t, err, rollback)
+
+	committed := newMessage(t, "url.created")
+	require.NoError(t, tx.WithinTransaction(ctx, func(txCtx context.Context) error {
+		return repo.Append(txCtx, committed)
+	}))
+
+	due, err := repo.ClaimDue(ctx, time.Now().Add(time.Second), time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, committed.ID, due[0].ID)
+	assert.Equal(t, "url.created", due[0].Topic)
+	assert.JSONEq(t, string(committed.Payload), string(due[0].Payload))
+}
+
+func TestOutboxRepository_ClaimDue_OrdersAndHidesClaimed(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	repo := pg_repo.NewOutboxRepository(testDB)
+
+	first := newMessage(t, "url.created")
+	second := newMessage(t, "url.deleted")
+	second.OccurredAt = first.OccurredAt.Add(time.Millisecond)
+	require.NoError(t, repo.Append(ctx, second))
+	require.NoError(t, repo.Append(ctx, first))
+
+	now := time.Now().Add(time.Second)
+	due, err := repo.ClaimDue(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	assert.Equal(t, first.ID, due[0].ID)
+	assert.Equal(t, second.ID, due[1].ID)
+
+	again, err := repo.ClaimDue(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+
+	afterLease, err := repo.ClaimDue(ctx, now.Add(2*time.Minute), time.Minute, 10)
+	require.NoError(t, err)
+	assert.Len(t, afterLease, 2)
+}
+
+func TestOutboxRepository_MarkFailedAndDelete(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	repo := pg_repo.NewOutboxRepository(testDB)
+
+	m := newMessage(t, "session.revoked")
+	require.NoError(t, repo.Append(ctx, m))
+
+	now := time.Now().Add(time.Second)
+	next := now.Add(time.Hour)
+	require.NoError(t, repo.MarkFailed(ctx, m.ID, &next, "redis unavailable"))
+
+	due, err := repo.ClaimDue(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+
+	due, err = repo.ClaimDue(ctx, now.Add(2*time.Hour), time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, 1, due[0].Attempts)
+
+	var lastError string
+	require.NoError(t, testDB.QueryRow(ctx, "SELECT last_error FROM outbox WHERE id = $1", m.ID).Scan(&lastError))
+	assert.Equal(t, "redis unavailable", lastError)
+
+	require.NoError(t, repo.Delete(ctx, m.ID))
+	due, err = repo.ClaimDue(ctx, now.Add(3*time.Hour), time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+}
+
+func TestOutboxRepository_MarkFailed_Dead(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	repo := pg_repo.NewOutboxRepository(testDB)
+
+	m := newMessage(t, "url.deleted")
+	require.NoError(t, repo.Append(ctx, m))
+
+	require.NoError(t, repo.MarkFailed(ctx, m.ID, nil, "redis unavailable"))
+
+	due, err := repo.ClaimDue(ctx, time.Now().Add(24*time.Hour), time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+
+	var attempts int
+	var deadAt *time.Time
+	require.NoError(t, testDB.QueryRow(ctx, "SELECT attempts, dead_at FROM outbox WHERE id = $1", m.ID).Scan(&attempts, &deadAt))
+	assert.Equal(t, 1, attempts)
+	assert.NotNil(t, deadAt)
+}
@@ -89,6 +89,7 @@ func runMigrations(ctx context.Context) error {
 			user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
 			name TEXT,
 			avatar_url TEXT,
+			locale TEXT,
 			created_at TIMESTAMPTZ DEFAULT NOW(),
 			updated_at TIMESTAMPTZ
 		);
@@ -6,16 +6,17 @@ import (
 
 	"github.com/brunoibarbosa/url-shortener/internal/domain"
 	session_domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
+	base "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/base"
 )
 
 type ListSessionsRepository struct {
-	db *pgxpool.Pool
+	base.BaseRepository
 }
 
-func NewListSessionsRepository(pg *pgxpool.Pool) *ListSessionsRepository {
+func NewListSessionsRepository(q pg.Querier) *ListSessionsRepository {
 	return &ListSessionsRepository{
-		db: pg,
+		BaseRepository: base.NewBaseRepository(q),
 	}
 }
 
@@ -25,7 +26,7 @@ func (r *ListSessionsRepository) List(ctx context.Context, p session_domain.List
 
 	var count uint64
 	if pagination != "" {
-		if err := r.db.QueryRow(ctx, `
+		if err := r.R(ctx).QueryRow(ctx, `
 			SELECT COUNT(s.id)
 			FROM sessions s
 			WHERE s.revoked_at IS NULL
@@ -35,7 +36,7 @@ func (r *ListSessionsRepository) List(ctx context.Context, p session_domain.List
 		}
 	}
 
-	rows, err := r.db.Query(ctx, `
+	rows, err := r.R(ctx).Query(ctx, `
 		SELECT
 			s.user_agent,
 			s.ip_address,
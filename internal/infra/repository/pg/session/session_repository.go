@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
 	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
@@ -53,3 +54,27 @@ func (r *SessionRepository) Revoke(ctx context.Context, id uuid.UUID) error {
 	_, err := r.Q(ctx).Exec(ctx, "UPDATE sessions SET revoked_at = NOW() WHERE id = $1", id)
 	return err
 }
+
+func (r *SessionRepository) RevokeAllByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	tag, err := r.Q(ctx).Exec(ctx, "UPDATE sessions SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL", userID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *SessionRepository) DeleteInactive(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	tag, err := r.Q(ctx).Exec(ctx, `
+		DELETE FROM sessions
+		WHERE id IN (
+			SELECT id FROM sessions
+			WHERE expires_at < $1 OR revoked_at < $1
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+	`, cutoff, limit)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -215,6 +215,41 @@ func TestSessionRepository_Revoke_NonexistentSession(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestSessionRepository_RevokeAllByUserID(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx)
+
+	repo := pg_repo.NewSessionRepository(testDB)
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	for _, hash := range []string{"hash-a", "hash-b"} {
+		err := repo.Create(ctx, &session_domain.Session{
+			UserID:           userID,
+			RefreshTokenHash: hash,
+			UserAgent:        "Mozilla/5.0",
+			IPAddress:        "192.168.1.1",
+			ExpiresAt:        &expiresAt,
+		})
+		require.NoError(t, err)
+	}
+
+	revoked, err := repo.RevokeAllByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), revoked)
+
+	for _, hash := range []string{"hash-a", "hash-b"} {
+		found, err := repo.FindByRefreshToken(ctx, hash)
+		require.NoError(t, err)
+		assert.NotNil(t, found.RevokedAt)
+	}
+
+	// Already revoked sessions are not counted again
+	revoked, err = repo.RevokeAllByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), revoked)
+}
+
 func TestSessionRepository_MultipleSessionsPerUser(t *testing.T) {
 	cleanDB(t)
 	ctx := context.Background()
@@ -1045,3 +1080,46 @@ func BenchmarkSessionRepository_FindByRefreshToken(b *testing.B) {
 		_, _ = repo.FindByRefreshToken(ctx, "bench_find_hash")
 	}
 }
+
+func TestSessionRepository_DeleteInactive(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx)
+
+	repo := pg_repo.NewSessionRepository(testDB)
+
+	now := time.Now()
+	insert := func(hash string, expiresAt time.Time, revokedAt *time.Time) {
+		_, err := testDB.Exec(ctx,
+			"INSERT INTO sessions (user_id, refresh_token_hash, expires_at, revoked_at) VALUES ($1, $2, $3, $4)",
+			userID, hash, expiresAt, revokedAt)
+		require.NoError(t, err)
+	}
+
+	longRevoked := now.Add(-48 * time.Hour)
+	recentlyRevoked := now.Add(-time.Hour)
+	insert("expired", now.Add(-48*time.Hour), nil)
+	insert("revoked", now.Add(24*time.Hour), &longRevoked)
+	insert("recently-revoked", now.Add(24*time.Hour), &recentlyRevoked)
+	insert("active", now.Add(24*time.Hour), nil)
+
+	deleted, err := repo.DeleteInactive(ctx, now.Add(-24*time.Hour), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	deleted, err = repo.DeleteInactive(ctx, now.Add(-24*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	var remaining []string
+	rows, err := testDB.Query(ctx, "SELECT refresh_token_hash FROM sessions ORDER BY refresh_token_hash")
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var hash string
+		require.NoError(t, rows.Scan(&hash))
+		remaining = append(remaining, hash)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"active", "recently-revoked"}, remaining)
+}
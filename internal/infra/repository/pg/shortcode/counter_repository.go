@@ -0,0 +1,24 @@
+package pg_repo
+
+import (
+	"context"
+
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
+	base "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/base"
+)
+
+type ShortCodeCounterRepository struct {
+	base.BaseRepository
+}
+
+func NewShortCodeCounterRepository(q pg.Querier) *ShortCodeCounterRepository {
+	return &ShortCodeCounterRepository{
+		BaseRepository: base.NewBaseRepository(q),
+	}
+}
+
+func (r *ShortCodeCounterRepository) NextBlock(ctx context.Context) (int64, error) {
+	var block int64
+	err := r.Q(ctx).QueryRow(ctx, "SELECT nextval('short_code_block_seq')").Scan(&block)
+	return block, err
+}
@@ -0,0 +1,77 @@
+package pg_repo
+
+import (
+	"context"
+
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
+	base "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/base"
+)
+
+// ShortCodePoolRepository keeps claimed codes as tombstones until a URL uses
+// them, so a refill can never put a handed-out code back in the pool.
+type ShortCodePoolRepository struct {
+	base.BaseRepository
+}
+
+func NewShortCodePoolRepository(q pg.Querier) *ShortCodePoolRepository {
+	return &ShortCodePoolRepository{
+		BaseRepository: base.NewBaseRepository(q),
+	}
+}
+
+func (r *ShortCodePoolRepository) Claim(ctx context.Context, length, limit int) ([]string, error) {
+	query := `UPDATE short_code_pool SET claimed_at = now()
+		WHERE code IN (
+			SELECT code FROM short_code_pool
+			WHERE claimed_at IS NULL AND length(code) = $1
+			LIMIT $2 FOR UPDATE SKIP LOCKED
+		)
+		RETURNING code`
+	rows, err := r.Q(ctx).Query(ctx, query, length, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var codes []string
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (r *ShortCodePoolRepository) CountAvailable(ctx context.Context, length int) (int64, error) {
+	var count int64
+	err := r.Q(ctx).QueryRow(ctx, "SELECT count(*) FROM short_code_pool WHERE claimed_at IS NULL AND length(code) = $1", length).Scan(&count)
+	return count, err
+}
+
+func (r *ShortCodePoolRepository) Fill(ctx context.Context, codes []string) (int64, error) {
+	// Tombstones whose URL now exists are redundant with the urls check below.
+	_, err := r.Q(ctx).Exec(ctx, `DELETE FROM short_code_pool p
+		WHERE p.claimed_at IS NOT NULL AND EXISTS (SELECT 1 FROM urls u WHERE u.short_code = p.code)`)
+	if err != nil {
+		return 0, err
+	}
+
+	tag, err := r.Q(ctx).Exec(ctx, `INSERT INTO short_code_pool (code)
+		SELECT c FROM unnest($1::text[]) AS c
+		WHERE NOT EXISTS (SELECT 1 FROM urls WHERE short_code = c)
+			AND NOT EXISTS (SELECT 1 FROM retired_short_codes WHERE code = c)
+		ON CONFLICT (code) DO NOTHING`, codes)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
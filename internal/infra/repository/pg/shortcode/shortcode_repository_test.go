@@ -0,0 +1,200 @@
+package pg_repo_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	pg_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/shortcode"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+var (
+	testDB        *pgxpool.Pool
+	testContainer *postgres.PostgresContainer
+)
+
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+
+	container, err := postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second)),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	testContainer = container
+	defer func() {
+		if testDB != nil {
+			testDB.Close()
+		}
+		if testContainer != nil {
+			testContainer.Terminate(context.Background())
+		}
+	}()
+
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		panic(err)
+	}
+
+	pool, err := pgxpool.New(ctx, connStr)
+	if err != nil {
+		panic(err)
+	}
+
+	testDB = pool
+
+	if err := runMigrations(ctx); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	os.Exit(code)
+}
+
+func runMigrations(ctx context.Context) error {
+	_, err := testDB.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS urls (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			short_code TEXT NOT NULL UNIQUE,
+			encrypted_url TEXT NOT NULL
+		);
+
+		CREATE SEQUENCE IF NOT EXISTS short_code_block_seq;
+
+		CREATE TABLE IF NOT EXISTS short_code_pool (
+			code TEXT PRIMARY KEY,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			claimed_at TIMESTAMPTZ
+		);
+
+		CREATE TABLE IF NOT EXISTS retired_short_codes (
+			code TEXT PRIMARY KEY,
+			retired_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+	`)
+	return err
+}
+
+func cleanDB(t *testing.T) {
+	ctx := context.Background()
+	_, err := testDB.Exec(ctx, "TRUNCATE short_code_pool, urls, retired_short_codes")
+	require.NoError(t, err)
+}
+
+func TestShortCodeCounterRepository_NextBlock(t *testing.T) {
+	ctx := context.Background()
+	repo := pg_repo.NewShortCodeCounterRepository(testDB)
+
+	first, err := repo.NextBlock(ctx)
+	require.NoError(t, err)
+	second, err := repo.NextBlock(ctx)
+	require.NoError(t, err)
+
+	assert.Positive(t, first)
+	assert.Greater(t, second, first)
+}
+
+func TestShortCodePoolRepository_FillAndClaim(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	repo := pg_repo.NewShortCodePoolRepository(testDB)
+
+	added, err := repo.Fill(ctx, []string{"aaaaaa", "bbbbbb", "cccccc", "dddd"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), added)
+
+	available, err := repo.CountAvailable(ctx, 6)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), available)
+
+	codes, err := repo.Claim(ctx, 6, 2)
+	require.NoError(t, err)
+	assert.Len(t, codes, 2)
+
+	rest, err := repo.Claim(ctx, 6, 2)
+	require.NoError(t, err)
+	assert.Len(t, rest, 1)
+	assert.NotContains(t, codes, rest[0])
+
+	available, err = repo.CountAvailable(ctx, 6)
+	require.NoError(t, err)
+	assert.Zero(t, available)
+}
+
+func TestShortCodePoolRepository_Fill_SkipsUsedCodes(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	repo := pg_repo.NewShortCodePoolRepository(testDB)
+
+	_, err := testDB.Exec(ctx, "INSERT INTO urls (short_code, encrypted_url) VALUES ('usedup', 'x')")
+	require.NoError(t, err)
+
+	added, err := repo.Fill(ctx, []string{"usedup", "fresh1"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), added)
+
+	codes, err := repo.Claim(ctx, 6, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fresh1"}, codes)
+}
+
+func TestShortCodePoolRepository_Fill_SkipsRetiredCodes(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	repo := pg_repo.NewShortCodePoolRepository(testDB)
+
+	_, err := testDB.Exec(ctx, "INSERT INTO retired_short_codes (code) VALUES ('purged')")
+	require.NoError(t, err)
+
+	added, err := repo.Fill(ctx, []string{"purged", "fresh1"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), added)
+
+	codes, err := repo.Claim(ctx, 6, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fresh1"}, codes)
+}
+
+func TestShortCodePoolRepository_Fill_DoesNotReturnClaimedCodes(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	repo := pg_repo.NewShortCodePoolRepository(testDB)
+
+	_, err := repo.Fill(ctx, []string{"aaaaaa"})
+	require.NoError(t, err)
+	_, err = repo.Claim(ctx, 6, 1)
+	require.NoError(t, err)
+
+	// Claimed but not yet used by a URL
+	added, err := repo.Fill(ctx, []string{"aaaaaa"})
+	require.NoError(t, err)
+	assert.Zero(t, added)
+
+	// Once the URL exists the tombstone is dropped and the code still skipped
+	_, err = testDB.Exec(ctx, "INSERT INTO urls (short_code, encrypted_url) VALUES ('aaaaaa', 'x')")
+	require.NoError(t, err)
+	added, err = repo.Fill(ctx, []string{"aaaaaa"})
+	require.NoError(t, err)
+	assert.Zero(t, added)
+
+	var tombstones int
+	err = testDB.QueryRow(ctx, "SELECT count(*) FROM short_code_pool").Scan(&tombstones)
+	require.NoError(t, err)
+	assert.Zero(t, tombstones)
+}
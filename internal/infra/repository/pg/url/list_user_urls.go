@@ -6,16 +6,16 @@ import (
 
 	"github.com/brunoibarbosa/url-shortener/internal/domain"
 	url_domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
 	base "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/base"
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type ListUserURLsRepository struct {
 	base.BaseRepository
 }
 
-func NewListUserURLsRepository(q *pgxpool.Pool) *ListUserURLsRepository {
+func NewListUserURLsRepository(q pg.Querier) *ListUserURLsRepository {
 	return &ListUserURLsRepository{
 		BaseRepository: base.NewBaseRepository(q),
 	}
@@ -27,7 +27,7 @@ func (r *ListUserURLsRepository) ListByUserID(ctx context.Context, userID uuid.U
 
 	var count uint64
 	if pagination != "" {
-		if err := r.Q(ctx).QueryRow(ctx, `
+		if err := r.R(ctx).QueryRow(ctx, `
 			SELECT COUNT(u.id)
 			FROM urls u
 			WHERE u.user_id = $1
@@ -38,7 +38,7 @@ func (r *ListUserURLsRepository) ListByUserID(ctx context.Context, userID uuid.U
 		}
 	}
 
-	rows, err := r.Q(ctx).Query(ctx, `
+	rows, err := r.R(ctx).Query(ctx, `
 		SELECT id, short_code, expires_at, created_at, deleted_at
 		FROM urls
 		WHERE user_id = $1
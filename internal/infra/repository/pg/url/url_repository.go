@@ -2,11 +2,20 @@ package pg_repo
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
 	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
 	base "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/base"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	uniqueViolation     = "23505"
+	shortCodeConstraint = "urls_short_code_key"
 )
 
 type URLRepository struct {
@@ -30,8 +39,27 @@ func (r *URLRepository) Save(ctx context.Context, u *domain.URL) error {
 	if u.ExpiresAt != nil {
 		expiresAt = u.ExpiresAt.UTC()
 	}
-	_, err := r.Q(ctx).Exec(ctx, "INSERT INTO urls (short_code, encrypted_url, user_id, expires_at) VALUES ($1, $2, $3, $4)", u.ShortCode, u.EncryptedURL, u.UserID, expiresAt)
-	return err
+	var utm *domain.UTMParams
+	if !u.UTM.IsZero() {
+		utm = &u.UTM
+	}
+	// Codes of purged URLs are retired so old links never reach a new one.
+	tag, err := r.Q(ctx).Exec(ctx, `INSERT INTO urls (short_code, encrypted_url, destination_index, user_id, expires_at, redirect_type, forward_query, forward_path, utm_defaults)
+		SELECT $1::text, $2::text, $3::bytea, $4::uuid, $5::timestamptz, $6::smallint, $7::boolean, $8::boolean, $9::jsonb
+		WHERE NOT EXISTS (SELECT 1 FROM retired_short_codes WHERE code = $1)`,
+		u.ShortCode, u.EncryptedURL, u.DestinationIndex, u.UserID, expiresAt, u.RedirectType.Status(), u.ForwardQuery, u.ForwardPath, utm)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == shortCodeConstraint {
+		return domain.ErrShortCodeTaken
+	}
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrShortCodeTaken
+	}
+	return nil
 }
 
 func (r *URLRepository) FindByShortCode(ctx context.Context, shortCode string) (*domain.URL, error) {
@@ -42,11 +70,16 @@ func (r *URLRepository) FindByShortCode(ctx context.Context, shortCode string) (
 		ExpiresAt:    nil,
 		DeletedAt:    nil,
 	}
-	err := r.Q(ctx).QueryRow(ctx, "SELECT encrypted_url, user_id, expires_at, deleted_at FROM urls WHERE short_code = $1 AND deleted_at IS NULL LIMIT 1", shortCode).Scan(&u.EncryptedURL, &u.UserID, &u.ExpiresAt, &u.DeletedAt)
+	var utm *domain.UTMParams
+	err := r.R(ctx).QueryRow(ctx, "SELECT encrypted_url, user_id, expires_at, deleted_at, redirect_type, forward_query, forward_path, utm_defaults FROM urls WHERE short_code = $1 AND deleted_at IS NULL LIMIT 1", shortCode).
+		Scan(&u.EncryptedURL, &u.UserID, &u.ExpiresAt, &u.DeletedAt, &u.RedirectType, &u.ForwardQuery, &u.ForwardPath, &utm)
 
 	if err != nil {
 		return nil, err
 	}
+	if utm != nil {
+		u.UTM = *utm
+	}
 
 	return &u, nil
 }
@@ -57,3 +90,126 @@ func (r *URLRepository) SoftDelete(ctx context.Context, id uuid.UUID, userID uui
 	err := r.Q(ctx).QueryRow(ctx, query, id, userID).Scan(&shortCode)
 	return shortCode, err
 }
+
+func (r *URLRepository) DisableByShortCode(ctx context.Context, shortCode string) (*domain.URL, error) {
+	var u domain.URL
+	query := `UPDATE urls SET deleted_at = now() WHERE short_code = $1 AND deleted_at IS NULL
+		RETURNING short_code, user_id, expires_at, deleted_at`
+	err := r.Q(ctx).QueryRow(ctx, query, shortCode).Scan(&u.ShortCode, &u.UserID, &u.ExpiresAt, &u.DeletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrURLNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r *URLRepository) FindActiveByDestination(ctx context.Context, userID uuid.UUID, destinationIndex []byte) (*domain.URL, error) {
+	u := domain.URL{DestinationIndex: destinationIndex}
+	var utm *domain.UTMParams
+	query := `SELECT short_code, encrypted_url, user_id, expires_at, redirect_type, forward_query, forward_path, utm_defaults FROM urls
+		WHERE user_id = $1 AND destination_index = $2 AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > now())
+		ORDER BY created_at DESC LIMIT 1`
+	err := r.Q(ctx).QueryRow(ctx, query, userID, destinationIndex).
+		Scan(&u.ShortCode, &u.EncryptedURL, &u.UserID, &u.ExpiresAt, &u.RedirectType, &u.ForwardQuery, &u.ForwardPath, &utm)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrURLNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if utm != nil {
+		u.UTM = *utm
+	}
+
+	return &u, nil
+}
+
+func (r *URLRepository) ListEncrypted(ctx context.Context, afterShortCode string, limit int) ([]*domain.URL, error) {
+	rows, err := r.Q(ctx).Query(ctx, "SELECT short_code, encrypted_url FROM urls WHERE short_code > $1 ORDER BY short_code LIMIT $2", afterShortCode, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var urls []*domain.URL
+	for rows.Next() {
+		u := &domain.URL{}
+		if err := rows.Scan(&u.ShortCode, &u.EncryptedURL); err != nil {
+			return nil, err
+		}
+		urls = append(urls, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return urls, nil
+}
+
+func (r *URLRepository) UpdateEncryptedURL(ctx context.Context, shortCode, previous, encryptedURL string) (bool, error) {
+	tag, err := r.Q(ctx).Exec(ctx, "UPDATE urls SET encrypted_url = $3 WHERE short_code = $1 AND encrypted_url = $2", shortCode, previous, encryptedURL)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *URLRepository) SoftDeleteExpired(ctx context.Context, now time.Time, limit int) ([]*domain.URL, error) {
+	rows, err := r.Q(ctx).Query(ctx, `
+		UPDATE urls SET deleted_at = $1
+		WHERE id IN (
+			SELECT id FROM urls
+			WHERE deleted_at IS NULL AND expires_at < $1
+			ORDER BY expires_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING short_code, user_id, expires_at, deleted_at
+	`, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var urls []*domain.URL
+	for rows.Next() {
+		var u domain.URL
+		if err := rows.Scan(&u.ShortCode, &u.UserID, &u.ExpiresAt, &u.DeletedAt); err != nil {
+			return nil, err
+		}
+		urls = append(urls, &u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return urls, nil
+}
+
+func (r *URLRepository) PurgeDeleted(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	var purged int64
+	err := r.Q(ctx).QueryRow(ctx, `
+		WITH purged AS (
+			DELETE FROM urls
+			WHERE id IN (
+				SELECT id FROM urls
+				WHERE deleted_at < $1
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING short_code
+		), retired AS (
+			INSERT INTO retired_short_codes (code)
+			SELECT short_code FROM purged
+			ON CONFLICT (code) DO NOTHING
+		)
+		SELECT count(*) FROM purged
+	`, cutoff, limit).Scan(&purged)
+	return purged, err
+}
@@ -65,7 +65,7 @@ func TestMain(m *testing.M) {
 
 	testDB = pool
 
-	if err := runMigrations(ctx); err != nil {
+	if err := runMigrations(ctx, testDB); err != nil {
 		panic(err)
 	}
 
@@ -73,8 +73,8 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
-func runMigrations(ctx context.Context) error {
-	_, err := testDB.Exec(ctx, `
+func runMigrations(ctx context.Context, db *pgxpool.Pool) error {
+	_, err := db.Exec(ctx, `
 		CREATE TABLE IF NOT EXISTS users (
 			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
 			email TEXT UNIQUE,
@@ -86,11 +86,21 @@ func runMigrations(ctx context.Context) error {
 			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
 			short_code TEXT NOT NULL UNIQUE,
 			encrypted_url TEXT NOT NULL,
+			destination_index BYTEA,
 			user_id UUID REFERENCES users(id),
 			created_at TIMESTAMPTZ DEFAULT NOW(),
 			updated_at TIMESTAMPTZ,
 			expires_at TIMESTAMPTZ,
-			deleted_at TIMESTAMPTZ
+			deleted_at TIMESTAMPTZ,
+			redirect_type SMALLINT NOT NULL DEFAULT 302,
+			forward_query BOOLEAN NOT NULL DEFAULT FALSE,
+			forward_path BOOLEAN NOT NULL DEFAULT FALSE,
+			utm_defaults JSONB
+		);
+
+		CREATE TABLE IF NOT EXISTS retired_short_codes (
+			code TEXT PRIMARY KEY,
+			retired_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 		);
 	`)
 	return err
@@ -98,7 +108,7 @@ func runMigrations(ctx context.Context) error {
 
 func cleanDB(t *testing.T) {
 	ctx := context.Background()
-	_, err := testDB.Exec(ctx, "TRUNCATE urls, users CASCADE")
+	_, err := testDB.Exec(ctx, "TRUNCATE urls, users, retired_short_codes CASCADE")
 	require.NoError(t, err)
 }
 
@@ -228,6 +238,79 @@ func TestURLRepository_FindByShortCode_Success(t *testing.T) {
 	assert.Nil(t, found.DeletedAt)
 }
 
+func TestURLRepository_FindByShortCode_RedirectOptions(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+
+	repo := pg_repo.NewURLRepository(testDB)
+
+	options := url_domain.RedirectOptions{
+		RedirectType: url_domain.RedirectMovedPermanently,
+		ForwardQuery: true,
+		ForwardPath:  true,
+		UTM:          url_domain.UTMParams{Source: "newsletter", Medium: "email"},
+	}
+	err := repo.Save(ctx, &url_domain.URL{
+		ShortCode:       "opts123",
+		EncryptedURL:    "encrypted-test-data",
+		RedirectOptions: options,
+	})
+	require.NoError(t, err)
+
+	found, err := repo.FindByShortCode(ctx, "opts123")
+
+	require.NoError(t, err)
+	assert.Equal(t, options, found.RedirectOptions)
+}
+
+func TestURLRepository_FindByShortCode_DefaultRedirectOptions(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+
+	repo := pg_repo.NewURLRepository(testDB)
+
+	err := repo.Save(ctx, &url_domain.URL{ShortCode: "plain123", EncryptedURL: "encrypted-test-data"})
+	require.NoError(t, err)
+
+	found, err := repo.FindByShortCode(ctx, "plain123")
+
+	require.NoError(t, err)
+	assert.Equal(t, url_domain.RedirectOptions{RedirectType: url_domain.DefaultRedirectType}, found.RedirectOptions)
+}
+
+func TestURLRepository_FindByShortCode_IgnoresStaleReplica(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+
+	// A second database stands in for a replica that has not replayed the
+	// delete yet.
+	_, err := testDB.Exec(ctx, "DROP DATABASE IF EXISTS stale_replica")
+	require.NoError(t, err)
+	_, err = testDB.Exec(ctx, "CREATE DATABASE stale_replica")
+	require.NoError(t, err)
+	connStr, err := testContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+	cfg, err := pgxpool.ParseConfig(connStr)
+	require.NoError(t, err)
+	cfg.ConnConfig.Database = "stale_replica"
+	replica, err := pgxpool.NewWithConfig(ctx, cfg)
+	require.NoError(t, err)
+	defer replica.Close()
+	require.NoError(t, runMigrations(ctx, replica))
+
+	url := &url_domain.URL{ShortCode: "stale123", EncryptedURL: "encrypted-test-data"}
+	require.NoError(t, pg_repo.NewURLRepository(testDB).Save(ctx, url))
+	require.NoError(t, pg_repo.NewURLRepository(replica).Save(ctx, url))
+	_, err = pg_repo.NewURLRepository(testDB).DisableByShortCode(ctx, "stale123")
+	require.NoError(t, err)
+
+	repo := pg_repo.NewURLRepository(pg.NewRouter(testDB, replica))
+	found, err := repo.FindByShortCode(ctx, "stale123")
+
+	assert.Error(t, err)
+	assert.Nil(t, found)
+}
+
 func TestURLRepository_FindByShortCode_NotFound(t *testing.T) {
 	cleanDB(t)
 	ctx := context.Background()
@@ -287,6 +370,124 @@ func TestURLRepository_SoftDelete_AlreadyDeleted(t *testing.T) {
 	assert.Empty(t, shortCode)
 }
 
+func TestURLRepository_DisableByShortCode_Success(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+
+	repo := pg_repo.NewURLRepository(testDB)
+
+	userID := createTestUser(t, ctx)
+	_, err := testDB.Exec(ctx, "INSERT INTO urls (short_code, encrypted_url, user_id) VALUES ($1, $2, $3)", "dis123", "encrypted-data", userID)
+	require.NoError(t, err)
+
+	disabled, err := repo.DisableByShortCode(ctx, "dis123")
+	require.NoError(t, err)
+	assert.Equal(t, "dis123", disabled.ShortCode)
+	assert.Equal(t, &userID, disabled.UserID)
+	assert.NotNil(t, disabled.DeletedAt)
+
+	_, err = repo.DisableByShortCode(ctx, "dis123")
+	assert.ErrorIs(t, err, url_domain.ErrURLNotFound)
+
+	found, err := repo.FindByShortCode(ctx, "dis123")
+	assert.Error(t, err)
+	assert.Nil(t, found)
+}
+
+func TestURLRepository_DisableByShortCode_NotFound(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+
+	repo := pg_repo.NewURLRepository(testDB)
+
+	_, err := repo.DisableByShortCode(ctx, "missing")
+
+	assert.ErrorIs(t, err, url_domain.ErrURLNotFound)
+}
+
+func TestURLRepository_FindActiveByDestination(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx)
+	var otherUserID uuid.UUID
+	require.NoError(t, testDB.QueryRow(ctx, "INSERT INTO users (email) VALUES ($1) RETURNING id", "other@example.com").Scan(&otherUserID))
+
+	repo := pg_repo.NewURLRepository(testDB)
+	index := []byte("destination-index")
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	require.NoError(t, repo.Save(ctx, &url_domain.URL{ShortCode: "expired", EncryptedURL: "enc", DestinationIndex: index, UserID: &userID, ExpiresAt: &past}))
+	require.NoError(t, repo.Save(ctx, &url_domain.URL{ShortCode: "other", EncryptedURL: "enc", DestinationIndex: index, UserID: &otherUserID, ExpiresAt: &future}))
+
+	_, err := repo.FindActiveByDestination(ctx, userID, index)
+	assert.ErrorIs(t, err, url_domain.ErrURLNotFound, "expired URLs and other users' URLs must not match")
+
+	require.NoError(t, repo.Save(ctx, &url_domain.URL{ShortCode: "active", EncryptedURL: "enc", DestinationIndex: index, UserID: &userID, ExpiresAt: &future}))
+
+	found, err := repo.FindActiveByDestination(ctx, userID, index)
+	require.NoError(t, err)
+	assert.Equal(t, "active", found.ShortCode)
+	assert.Equal(t, &userID, found.UserID)
+
+	_, err = repo.DisableByShortCode(ctx, "active")
+	require.NoError(t, err)
+
+	_, err = repo.FindActiveByDestination(ctx, userID, index)
+	assert.ErrorIs(t, err, url_domain.ErrURLNotFound)
+}
+
+func TestURLRepository_ListEncrypted_Paginates(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+
+	repo := pg_repo.NewURLRepository(testDB)
+
+	for _, code := range []string{"ccc", "aaa", "bbb"} {
+		_, err := testDB.Exec(ctx, "INSERT INTO urls (short_code, encrypted_url) VALUES ($1, $2)", code, "enc-"+code)
+		require.NoError(t, err)
+	}
+
+	first, err := repo.ListEncrypted(ctx, "", 2)
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+	assert.Equal(t, "aaa", first[0].ShortCode)
+	assert.Equal(t, "enc-aaa", first[0].EncryptedURL)
+	assert.Equal(t, "bbb", first[1].ShortCode)
+
+	second, err := repo.ListEncrypted(ctx, "bbb", 2)
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+	assert.Equal(t, "ccc", second[0].ShortCode)
+
+	rest, err := repo.ListEncrypted(ctx, "ccc", 2)
+	require.NoError(t, err)
+	assert.Empty(t, rest)
+}
+
+func TestURLRepository_UpdateEncryptedURL(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+
+	repo := pg_repo.NewURLRepository(testDB)
+
+	_, err := testDB.Exec(ctx, "INSERT INTO urls (short_code, encrypted_url) VALUES ($1, $2)", "upd123", "old-data")
+	require.NoError(t, err)
+
+	updated, err := repo.UpdateEncryptedURL(ctx, "upd123", "old-data", "new-data")
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	// A stale previous value must not overwrite a concurrent change
+	updated, err = repo.UpdateEncryptedURL(ctx, "upd123", "old-data", "other-data")
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	found, err := repo.FindByShortCode(ctx, "upd123")
+	require.NoError(t, err)
+	assert.Equal(t, "new-data", found.EncryptedURL)
+}
+
 func TestURLRepository_DuplicateShortCode(t *testing.T) {
 	cleanDB(t)
 	ctx := context.Background()
@@ -308,7 +509,7 @@ func TestURLRepository_DuplicateShortCode(t *testing.T) {
 	}
 	err = repo.Save(ctx, url2)
 
-	assert.Error(t, err) // Should fail due to unique constraint
+	assert.ErrorIs(t, err, url_domain.ErrShortCodeTaken) // Should fail due to unique constraint
 }
 
 func TestURLRepository_MultipleURLs(t *testing.T) {
@@ -821,8 +1022,7 @@ func TestURLRepository_ShortCode_UniqueConstraint(t *testing.T) {
 	}
 
 	err = repo.Save(ctx, url2)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "duplicate key")
+	assert.ErrorIs(t, err, url_domain.ErrShortCodeTaken)
 }
 
 func TestURLRepository_UserID_ForeignKeyConstraint(t *testing.T) {
@@ -1181,3 +1381,97 @@ func BenchmarkURLRepository_FindByShortCode(b *testing.B) {
 		_, _ = repo.FindByShortCode(ctx, "benchfind")
 	}
 }
+
+func TestURLRepository_SoftDeleteExpired(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+
+	repo := pg_repo.NewURLRepository(testDB)
+
+	now := time.Now()
+	expiresAt := map[string]time.Time{
+		"expired1": now.Add(-2 * time.Hour),
+		"expired2": now.Add(-time.Hour),
+		"future":   now.Add(time.Hour),
+	}
+	for code, at := range expiresAt {
+		_, err := testDB.Exec(ctx, "INSERT INTO urls (short_code, encrypted_url, expires_at) VALUES ($1, $2, $3)", code, "enc", at)
+		require.NoError(t, err)
+	}
+	_, err := testDB.Exec(ctx, "INSERT INTO urls (short_code, encrypted_url) VALUES ($1, $2)", "forever", "enc")
+	require.NoError(t, err)
+
+	first, err := repo.SoftDeleteExpired(ctx, now, 1)
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+	assert.Equal(t, "expired1", first[0].ShortCode)
+	assert.NotNil(t, first[0].ExpiresAt)
+	assert.True(t, first[0].IsDeleted())
+
+	rest, err := repo.SoftDeleteExpired(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Equal(t, "expired2", rest[0].ShortCode)
+
+	// Already soft-deleted rows are not returned again
+	again, err := repo.SoftDeleteExpired(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+
+	var deleted int
+	err = testDB.QueryRow(ctx, "SELECT COUNT(*) FROM urls WHERE deleted_at IS NOT NULL").Scan(&deleted)
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+}
+
+func TestURLRepository_PurgeDeleted(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+
+	repo := pg_repo.NewURLRepository(testDB)
+
+	now := time.Now()
+	_, err := testDB.Exec(ctx, "INSERT INTO urls (short_code, encrypted_url, deleted_at) VALUES ($1, $2, $3)", "old", "enc", now.Add(-48*time.Hour))
+	require.NoError(t, err)
+	_, err = testDB.Exec(ctx, "INSERT INTO urls (short_code, encrypted_url, deleted_at) VALUES ($1, $2, $3)", "recent", "enc", now.Add(-time.Hour))
+	require.NoError(t, err)
+	_, err = testDB.Exec(ctx, "INSERT INTO urls (short_code, encrypted_url) VALUES ($1, $2)", "active", "enc")
+	require.NoError(t, err)
+
+	purged, err := repo.PurgeDeleted(ctx, now.Add(-24*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	exists, err := repo.Exists(ctx, "old")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	var remaining int
+	err = testDB.QueryRow(ctx, "SELECT COUNT(*) FROM urls").Scan(&remaining)
+	require.NoError(t, err)
+	assert.Equal(t, 2, remaining)
+
+	var retired string
+	err = testDB.QueryRow(ctx, "SELECT code FROM retired_short_codes").Scan(&retired)
+	require.NoError(t, err)
+	assert.Equal(t, "old", retired)
+}
+
+func TestURLRepository_Save_RetiredShortCode(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+
+	repo := pg_repo.NewURLRepository(testDB)
+
+	_, err := testDB.Exec(ctx, "INSERT INTO urls (short_code, encrypted_url, deleted_at) VALUES ($1, $2, $3)", "reused", "enc", time.Now().Add(-48*time.Hour))
+	require.NoError(t, err)
+	_, err = repo.PurgeDeleted(ctx, time.Now(), 10)
+	require.NoError(t, err)
+
+	err = repo.Save(ctx, &url_domain.URL{ShortCode: "reused", EncryptedURL: "new"})
+	assert.ErrorIs(t, err, url_domain.ErrShortCodeTaken)
+
+	exists, err := repo.Exists(ctx, "reused")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
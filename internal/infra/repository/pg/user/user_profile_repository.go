@@ -21,8 +21,8 @@ func NewUserProfileRepository(q pg.Querier) *UserProfileRepository {
 
 func (r *UserProfileRepository) Create(ctx context.Context, userID uuid.UUID, pv *domain.UserProfile) error {
 	_, err := r.Q(ctx).Exec(ctx,
-		"INSERT INTO user_profiles (user_id, name, avatar_url) VALUES ($1, $2, $3)",
-		userID, pv.Name, pv.AvatarURL,
+		"INSERT INTO user_profiles (user_id, name, avatar_url, locale) VALUES ($1, $2, $3, $4)",
+		userID, pv.Name, pv.AvatarURL, pv.Locale,
 	)
 	return err
 }
@@ -33,6 +33,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Use
 	var profileID sql.NullInt64
 	var profileName sql.NullString
 	var profileAvatarURL sql.NullString
+	var profileLocale sql.NullString
 
 	err := r.Q(ctx).QueryRow(ctx, `
 		SELECT 
@@ -42,11 +43,12 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Use
 			u.updated_at,
 			p.id,
 			p.name, 
-			p.avatar_url
+			p.avatar_url,
+			p.locale
 		FROM users u
         LEFT JOIN user_profiles p ON p.user_id = u.id
 		WHERE u.id=$1
-	`, id).Scan(&u.ID, &u.Email, &u.CreatedAt, &u.UpdatedAt, &profileID, &profileName, &profileAvatarURL)
+	`, id).Scan(&u.ID, &u.Email, &u.CreatedAt, &u.UpdatedAt, &profileID, &profileName, &profileAvatarURL, &profileLocale)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -63,6 +65,9 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Use
 		if profileAvatarURL.Valid {
 			u.Profile.AvatarURL = &profileAvatarURL.String
 		}
+		if profileLocale.Valid {
+			u.Profile.Locale = &profileLocale.String
+		}
 	}
 
 	return &u, err
@@ -73,6 +78,7 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 	var profileID sql.NullInt64
 	var profileName sql.NullString
 	var profileAvatarURL sql.NullString
+	var profileLocale sql.NullString
 
 	err := r.Q(ctx).QueryRow(ctx, `
 		SELECT 
@@ -82,11 +88,12 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 			u.updated_at,
 			p.id,
 			p.name, 
-			p.avatar_url
+			p.avatar_url,
+			p.locale
 		FROM users u
         LEFT JOIN user_profiles p ON p.user_id = u.id
 		WHERE email=$1
-	`, email).Scan(&u.ID, &u.Email, &u.CreatedAt, &u.UpdatedAt, &profileID, &profileName, &profileAvatarURL)
+	`, email).Scan(&u.ID, &u.Email, &u.CreatedAt, &u.UpdatedAt, &profileID, &profileName, &profileAvatarURL, &profileLocale)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -103,6 +110,9 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 		if profileAvatarURL.Valid {
 			u.Profile.AvatarURL = &profileAvatarURL.String
 		}
+		if profileLocale.Valid {
+			u.Profile.Locale = &profileLocale.String
+		}
 	}
 
 	return &u, err
@@ -86,6 +86,7 @@ func runMigrations(ctx context.Context) error {
 			user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
 			name TEXT,
 			avatar_url TEXT,
+			locale TEXT,
 			created_at TIMESTAMPTZ DEFAULT NOW(),
 			updated_at TIMESTAMPTZ
 		);
@@ -281,6 +282,27 @@ func TestUserRepository_GetByID_WithProfile(t *testing.T) {
 	assert.Equal(t, "https://example.com/avatar.jpg", *found.Profile.AvatarURL)
 }
 
+func TestUserRepository_GetByID_WithProfileLocale(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+
+	repo := pg_repo.NewUserRepository(testDB)
+	profileRepo := pg_repo.NewUserProfileRepository(testDB)
+
+	user := &user_domain.User{Email: "locale@example.com"}
+	require.NoError(t, repo.Create(ctx, user))
+
+	locale := "pt"
+	require.NoError(t, profileRepo.Create(ctx, user.ID, &user_domain.UserProfile{Name: "Locale User", Locale: &locale}))
+
+	found, err := repo.GetByID(ctx, user.ID)
+
+	require.NoError(t, err)
+	require.NotNil(t, found.Profile)
+	require.NotNil(t, found.Profile.Locale)
+	assert.Equal(t, "pt", *found.Profile.Locale)
+}
+
 func TestUserRepository_GetByEmail_WithProfile(t *testing.T) {
 	cleanDB(t)
 	ctx := context.Background()
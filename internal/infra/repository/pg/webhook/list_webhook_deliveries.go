@@ -0,0 +1,92 @@
+package pg_repo
+
+import (
+	"context"
+	"fmt"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
+	base "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/base"
+)
+
+type ListWebhookDeliveriesRepository struct {
+	base.BaseRepository
+}
+
+func NewListWebhookDeliveriesRepository(q pg.Querier) *ListWebhookDeliveriesRepository {
+	return &ListWebhookDeliveriesRepository{
+		BaseRepository: base.NewBaseRepository(q),
+	}
+}
+
+func (r *ListWebhookDeliveriesRepository) List(ctx context.Context, p domain.ListDeliveriesParams) ([]domain.Delivery, uint64, error) {
+	where, args := r.getWhere(p)
+	pagination := r.getPagination(p)
+
+	var count uint64
+	if pagination != "" {
+		if err := r.R(ctx).QueryRow(ctx, `
+			SELECT COUNT(d.id)
+			FROM webhook_deliveries d
+			JOIN webhook_endpoints e ON e.id = d.endpoint_id
+		`+where,
+			args...,
+		).Scan(&count); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	rows, err := r.R(ctx).Query(ctx, `
+		SELECT `+deliveryColumns+`
+		FROM webhook_deliveries d
+		JOIN webhook_endpoints e ON e.id = d.endpoint_id
+		`+where+
+		" ORDER BY d.created_at DESC, d.id DESC"+
+		pagination,
+		args...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	deliveries := []domain.Delivery{}
+	for rows.Next() {
+		var d domain.Delivery
+		if err := rows.Scan(deliveryFields(&d)...); err != nil {
+			return nil, 0, err
+		}
+
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		count = uint64(len(deliveries))
+	}
+
+	return deliveries, count, nil
+}
+
+func (*ListWebhookDeliveriesRepository) getWhere(p domain.ListDeliveriesParams) (string, []any) {
+	args := []any{p.EndpointID, p.UserID}
+	where := " WHERE d.endpoint_id = $1 AND e.user_id = $2"
+
+	if p.Status != nil {
+		args = append(args, string(*p.Status))
+		where += fmt.Sprintf(" AND d.status = $%d", len(args))
+	}
+
+	return where, args
+}
+
+func (*ListWebhookDeliveriesRepository) getPagination(p domain.ListDeliveriesParams) string {
+	if p.Pagination.Size <= 0 {
+		return ""
+	}
+
+	offset := p.Pagination.Size * (p.Pagination.Number - 1)
+	return fmt.Sprintf(" LIMIT %d OFFSET %d", p.Pagination.Size, offset)
+}
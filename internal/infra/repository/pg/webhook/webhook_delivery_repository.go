@@ -0,0 +1,217 @@
+package pg_repo
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
+	base "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/base"
+	"github.com/google/uuid"
+)
+
+const deliveryColumns = `
+	d.id,
+	d.endpoint_id,
+	d.event_id,
+	d.event_type,
+	d.payload,
+	d.status,
+	d.attempts,
+	d.next_attempt_at,
+	d.last_attempt_at,
+	d.last_response_status,
+	COALESCE(d.last_error, ''),
+	d.created_at,
+	d.delivered_at`
+
+// WebhookDeliveryRepository is the delivery queue. It also implements
+// WebhookPublisher, fanning an event out to the subscribed endpoints in a
+// single statement; publishing an event again queues nothing new.
+type WebhookDeliveryRepository struct {
+	base.BaseRepository
+}
+
+func NewWebhookDeliveryRepository(q pg.Querier) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		BaseRepository: base.NewBaseRepository(q),
+	}
+}
+
+func (r *WebhookDeliveryRepository) Publish(ctx context.Context, userID uuid.UUID, e domain.Event) error {
+	payload, err := e.Payload()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Q(ctx).Exec(ctx, `
+		INSERT INTO webhook_deliveries (endpoint_id, event_id, event_type, payload, next_attempt_at)
+		SELECT id, $2, $3, $4, NOW()
+		FROM webhook_endpoints
+		WHERE user_id = $1 AND $3 = ANY(events)
+		ON CONFLICT (endpoint_id, event_id) DO NOTHING
+	`, userID, e.ID, string(e.Type), string(payload))
+	return err
+}
+
+func (r *WebhookDeliveryRepository) PublishToOwner(ctx context.Context, shortCode string, e domain.Event) error {
+	payload, err := e.Payload()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Q(ctx).Exec(ctx, `
+		INSERT INTO webhook_deliveries (endpoint_id, event_id, event_type, payload, next_attempt_at)
+		SELECT e.id, $2, $3, $4, NOW()
+		FROM webhook_endpoints e
+		JOIN urls u ON u.user_id = e.user_id
+		WHERE u.short_code = $1 AND $3 = ANY(e.events)
+		ON CONFLICT (endpoint_id, event_id) DO NOTHING
+	`, shortCode, e.ID, string(e.Type), string(payload))
+	return err
+}
+
+func (r *WebhookDeliveryRepository) Enqueue(ctx context.Context, endpointID uuid.UUID, e domain.Event) (*domain.Delivery, error) {
+	payload, err := e.Payload()
+	if err != nil {
+		return nil, err
+	}
+
+	d := domain.Delivery{
+		EndpointID: endpointID,
+		EventID:    e.ID,
+		EventType:  e.Type,
+		Payload:    payload,
+		Status:     domain.DeliveryPending,
+	}
+	err = r.Q(ctx).QueryRow(ctx, `
+		INSERT INTO webhook_deliveries (endpoint_id, event_id, event_type, payload, next_attempt_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING id, next_attempt_at, created_at
+	`, endpointID, e.ID, string(e.Type), string(payload)).Scan(&d.ID, &d.NextAttemptAt, &d.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.DueDelivery, error) {
+	rows, err := r.Q(ctx).Query(ctx, `
+		UPDATE webhook_deliveries d SET next_attempt_at = $2
+		FROM webhook_endpoints e
+		WHERE e.id = d.endpoint_id AND d.id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+deliveryColumns+`, e.url, e.secret
+	`, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var due []*domain.DueDelivery
+	for rows.Next() {
+		var d domain.DueDelivery
+		if err := rows.Scan(append(deliveryFields(&d.Delivery), &d.URL, &d.Secret)...); err != nil {
+			return nil, err
+		}
+		due = append(due, &d)
+	}
+
+	return due, rows.Err()
+}
+
+func (r *WebhookDeliveryRepository) MarkSucceeded(ctx context.Context, id uuid.UUID, result domain.AttemptResult) error {
+	_, err := r.Q(ctx).Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = 'succeeded', attempts = attempts + 1, next_attempt_at = NULL,
+			last_attempt_at = $2, last_response_status = $3, last_error = NULL, delivered_at = $2
+		WHERE id = $1
+	`, id, result.AttemptedAt, result.ResponseStatus)
+	return err
+}
+
+func (r *WebhookDeliveryRepository) MarkFailed(ctx context.Context, id uuid.UUID, result domain.AttemptResult, nextAttemptAt *time.Time) error {
+	status := domain.DeliveryPending
+	if nextAttemptAt == nil {
+		status = domain.DeliveryDead
+	}
+
+	_, err := r.Q(ctx).Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = attempts + 1, next_attempt_at = $3,
+			last_attempt_at = $4, last_response_status = $5, last_error = $6
+		WHERE id = $1
+	`, id, string(status), nextAttemptAt, result.AttemptedAt, result.ResponseStatus, result.Error)
+	return err
+}
+
+func (r *WebhookDeliveryRepository) Retry(ctx context.Context, id, endpointID, userID uuid.UUID, now time.Time) error {
+	tag, err := r.Q(ctx).Exec(ctx, `
+		UPDATE webhook_deliveries d SET status = 'pending', attempts = 0, next_attempt_at = $4
+		FROM webhook_endpoints e
+		WHERE e.id = d.endpoint_id AND d.id = $1 AND d.endpoint_id = $2 AND e.user_id = $3 AND d.status = 'dead'
+	`, id, endpointID, userID, now)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+
+	var exists bool
+	if err := r.Q(ctx).QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM webhook_deliveries d
+			JOIN webhook_endpoints e ON e.id = d.endpoint_id
+			WHERE d.id = $1 AND d.endpoint_id = $2 AND e.user_id = $3
+		)
+	`, id, endpointID, userID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrDeliveryNotFound
+	}
+	return domain.ErrDeliveryNotRetryable
+}
+
+func (r *WebhookDeliveryRepository) DeleteFinished(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	tag, err := r.Q(ctx).Exec(ctx, `
+		DELETE FROM webhook_deliveries
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status <> 'pending' AND created_at < $1
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+	`, cutoff, limit)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// deliveryFields returns the scan targets of deliveryColumns.
+func deliveryFields(d *domain.Delivery) []any {
+	return []any{
+		&d.ID,
+		&d.EndpointID,
+		&d.EventID,
+		&d.EventType,
+		&d.Payload,
+		&d.Status,
+		&d.Attempts,
+		&d.NextAttemptAt,
+		&d.LastAttemptAt,
+		&d.LastResponseStatus,
+		&d.LastError,
+		&d.CreatedAt,
+		&d.DeliveredAt,
+	}
+}
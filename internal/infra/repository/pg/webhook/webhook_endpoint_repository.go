@@ -0,0 +1,107 @@
+package pg_repo
+
+import (
+	"context"
+	"errors"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
+	base "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/base"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type WebhookEndpointRepository struct {
+	base.BaseRepository
+}
+
+func NewWebhookEndpointRepository(q pg.Querier) *WebhookEndpointRepository {
+	return &WebhookEndpointRepository{
+		BaseRepository: base.NewBaseRepository(q),
+	}
+}
+
+func (r *WebhookEndpointRepository) Create(ctx context.Context, e *domain.Endpoint) error {
+	return r.Q(ctx).QueryRow(
+		ctx,
+		"INSERT INTO webhook_endpoints (user_id, url, secret, events) VALUES ($1, $2, $3, $4) RETURNING id, created_at",
+		e.UserID, e.URL, e.Secret, eventsToStrings(e.Events),
+	).Scan(&e.ID, &e.CreatedAt)
+}
+
+func (r *WebhookEndpointRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.Q(ctx).QueryRow(ctx, "SELECT COUNT(*) FROM webhook_endpoints WHERE user_id = $1", userID).Scan(&count)
+	return count, err
+}
+
+func (r *WebhookEndpointRepository) FindByID(ctx context.Context, id, userID uuid.UUID) (*domain.Endpoint, error) {
+	var e domain.Endpoint
+	var events []string
+	err := r.Q(ctx).QueryRow(
+		ctx,
+		"SELECT id, user_id, url, secret, events, created_at FROM webhook_endpoints WHERE id = $1 AND user_id = $2",
+		id, userID,
+	).Scan(&e.ID, &e.UserID, &e.URL, &e.Secret, &events, &e.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrEndpointNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	e.Events = stringsToEvents(events)
+	return &e, nil
+}
+
+func (r *WebhookEndpointRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Endpoint, error) {
+	rows, err := r.R(ctx).Query(
+		ctx,
+		"SELECT id, user_id, url, secret, events, created_at FROM webhook_endpoints WHERE user_id = $1 ORDER BY created_at, id",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	endpoints := []domain.Endpoint{}
+	for rows.Next() {
+		var e domain.Endpoint
+		var events []string
+		if err := rows.Scan(&e.ID, &e.UserID, &e.URL, &e.Secret, &events, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.Events = stringsToEvents(events)
+		endpoints = append(endpoints, e)
+	}
+
+	return endpoints, rows.Err()
+}
+
+func (r *WebhookEndpointRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	tag, err := r.Q(ctx).Exec(ctx, "DELETE FROM webhook_endpoints WHERE id = $1 AND user_id = $2", id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrEndpointNotFound
+	}
+	return nil
+}
+
+func eventsToStrings(events []domain.EventType) []string {
+	s := make([]string, len(events))
+	for i, e := range events {
+		s[i] = string(e)
+	}
+	return s
+}
+
+func stringsToEvents(s []string) []domain.EventType {
+	events := make([]domain.EventType, len(s))
+	for i, e := range s {
+		events[i] = domain.EventType(e)
+	}
+	return events
+}
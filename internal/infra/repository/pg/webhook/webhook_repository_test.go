@@ -0,0 +1,317 @@
+package pg_repo_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	webhook_domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	pg_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/webhook"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+var (
+	testDB        *pgxpool.Pool
+	testContainer *postgres.PostgresContainer
+)
+
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+
+	container, err := postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second)),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	testContainer = container
+	defer func() {
+		if testDB != nil {
+			testDB.Close()
+		}
+		if testContainer != nil {
+			testContainer.Terminate(context.Background())
+		}
+	}()
+
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		panic(err)
+	}
+
+	pool, err := pgxpool.New(ctx, connStr)
+	if err != nil {
+		panic(err)
+	}
+
+	testDB = pool
+
+	if err := runMigrations(ctx); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	os.Exit(code)
+}
+func runMigrations(ctx context.Context) error {
+	_, err := testDB.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS users (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			email TEXT UNIQUE,
+			created_at TIMESTAMPTZ DEFAULT NOW(),
+			updated_at TIMESTAMPTZ
+		);
+
+		CREATE TABLE IF NOT EXISTS urls (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			short_code TEXT NOT NULL UNIQUE,
+			encrypted_url TEXT NOT NULL,
+			user_id UUID REFERENCES users(id),
+			created_at TIMESTAMPTZ DEFAULT NOW(),
+			expires_at TIMESTAMPTZ,
+			deleted_at TIMESTAMPTZ
+		);
+
+		CREATE TABLE IF NOT EXISTS webhook_endpoints (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			url TEXT NOT NULL,
+			secret TEXT NOT NULL,
+			events TEXT[] NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+
+		CREATE TABLE IF NOT EXISTS webhook_deliveries (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			endpoint_id UUID NOT NULL REFERENCES webhook_endpoints(id) ON DELETE CASCADE,
+			event_id UUID NOT NULL,
+			event_type TEXT NOT NULL,
+			payload TEXT NOT NULL,
+			status TEXT NOT NULL DEFAULT 'pending',
+			attempts INT NOT NULL DEFAULT 0,
+			next_attempt_at TIMESTAMPTZ,
+			last_attempt_at TIMESTAMPTZ,
+			last_response_status INT,
+			last_error TEXT,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			delivered_at TIMESTAMPTZ
+		);
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_endpoint_event ON webhook_deliveries(endpoint_id, event_id);
+	`)
+	return err
+}
+
+func cleanDB(t *testing.T) {
+	ctx := context.Background()
+	_, err := testDB.Exec(ctx, "TRUNCATE webhook_deliveries, webhook_endpoints, urls, users CASCADE")
+	require.NoError(t, err)
+}
+
+func createTestUser(t *testing.T, ctx context.Context, email string) uuid.UUID {
+	var userID uuid.UUID
+	err := testDB.QueryRow(ctx, "INSERT INTO users (email) VALUES ($1) RETURNING id", email).Scan(&userID)
+	require.NoError(t, err)
+	return userID
+}
+
+func createTestEndpoint(t *testing.T, ctx context.Context, userID uuid.UUID, events ...webhook_domain.EventType) *webhook_domain.Endpoint {
+	e := &webhook_domain.Endpoint{
+		UserID: userID,
+		URL:    "https://hooks.example.com/" + uuid.NewString(),
+		Secret: "whsec_test",
+		Events: events,
+	}
+	require.NoError(t, pg_repo.NewWebhookEndpointRepository(testDB).Create(ctx, e))
+	return e
+}
+
+func TestWebhookEndpointRepository_CRUD(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, "owner@example.com")
+	otherID := createTestUser(t, ctx, "other@example.com")
+
+	repo := pg_repo.NewWebhookEndpointRepository(testDB)
+	e := createTestEndpoint(t, ctx, userID, webhook_domain.EventURLCreated, webhook_domain.EventURLClicked)
+	assert.NotEqual(t, uuid.Nil, e.ID)
+
+	found, err := repo.FindByID(ctx, e.ID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, e.URL, found.URL)
+	assert.Equal(t, e.Events, found.Events)
+
+	_, err = repo.FindByID(ctx, e.ID, otherID)
+	assert.ErrorIs(t, err, webhook_domain.ErrEndpointNotFound)
+
+	count, err := repo.CountByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	list, err := repo.ListByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	assert.ErrorIs(t, repo.Delete(ctx, e.ID, otherID), webhook_domain.ErrEndpointNotFound)
+	require.NoError(t, repo.Delete(ctx, e.ID, userID))
+	assert.ErrorIs(t, repo.Delete(ctx, e.ID, userID), webhook_domain.ErrEndpointNotFound)
+}
+
+func TestWebhookDeliveryRepository_Publish_FansOutToSubscribers(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, "owner@example.com")
+	subscribed := createTestEndpoint(t, ctx, userID, webhook_domain.EventURLCreated)
+	createTestEndpoint(t, ctx, userID, webhook_domain.EventURLDeleted)
+
+	repo := pg_repo.NewWebhookDeliveryRepository(testDB)
+	event := webhook_domain.NewEvent(webhook_domain.EventURLCreated, webhook_domain.URLEventData{ShortCode: "abc123"})
+	require.NoError(t, repo.Publish(ctx, userID, event))
+
+	due, err := repo.ClaimDue(ctx, time.Now().Add(time.Second), time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, subscribed.ID, due[0].EndpointID)
+	assert.Equal(t, subscribed.URL, due[0].URL)
+	assert.Equal(t, event.ID, due[0].EventID)
+
+	payload, err := event.Payload()
+	require.NoError(t, err)
+	assert.JSONEq(t, string(payload), string(due[0].Payload))
+}
+
+func TestWebhookDeliveryRepository_Publish_IgnoresReplayedEvent(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, "owner@example.com")
+	createTestEndpoint(t, ctx, userID, webhook_domain.EventURLCreated)
+
+	repo := pg_repo.NewWebhookDeliveryRepository(testDB)
+	event := webhook_domain.NewEvent(webhook_domain.EventURLCreated, webhook_domain.URLEventData{ShortCode: "abc123"})
+	require.NoError(t, repo.Publish(ctx, userID, event))
+	require.NoError(t, repo.Publish(ctx, userID, event))
+
+	var count int
+	require.NoError(t, testDB.QueryRow(ctx, "SELECT COUNT(*) FROM webhook_deliveries").Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func TestWebhookDeliveryRepository_PublishToOwner(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, "owner@example.com")
+	endpoint := createTestEndpoint(t, ctx, userID, webhook_domain.EventURLClicked)
+
+	_, err := testDB.Exec(ctx, "INSERT INTO urls (short_code, encrypted_url, user_id) VALUES ('owned', 'x', $1), ('anon', 'x', NULL)", userID)
+	require.NoError(t, err)
+
+	repo := pg_repo.NewWebhookDeliveryRepository(testDB)
+	require.NoError(t, repo.PublishToOwner(ctx, "owned", webhook_domain.NewEvent(webhook_domain.EventURLClicked, webhook_domain.ClickEventData{ShortCode: "owned"})))
+	require.NoError(t, repo.PublishToOwner(ctx, "anon", webhook_domain.NewEvent(webhook_domain.EventURLClicked, webhook_domain.ClickEventData{ShortCode: "anon"})))
+
+	due, err := repo.ClaimDue(ctx, time.Now().Add(time.Second), time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, endpoint.ID, due[0].EndpointID)
+}
+
+func TestWebhookDeliveryRepository_ClaimDue_HidesClaimedDeliveries(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, "owner@example.com")
+	endpoint := createTestEndpoint(t, ctx, userID, webhook_domain.EventURLCreated)
+
+	repo := pg_repo.NewWebhookDeliveryRepository(testDB)
+	_, err := repo.Enqueue(ctx, endpoint.ID, webhook_domain.NewEvent(webhook_domain.EventTest, nil))
+	require.NoError(t, err)
+
+	now := time.Now().Add(time.Second)
+	due, err := repo.ClaimDue(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+
+	again, err := repo.ClaimDue(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+
+	afterLease, err := repo.ClaimDue(ctx, now.Add(2*time.Minute), time.Minute, 10)
+	require.NoError(t, err)
+	assert.Len(t, afterLease, 1)
+}
+
+func TestWebhookDeliveryRepository_MarkFailed_DeadLetterAndRetry(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, "owner@example.com")
+	otherID := createTestUser(t, ctx, "other@example.com")
+	endpoint := createTestEndpoint(t, ctx, userID, webhook_domain.EventURLCreated)
+
+	repo := pg_repo.NewWebhookDeliveryRepository(testDB)
+	listRepo := pg_repo.NewListWebhookDeliveriesRepository(testDB)
+	d, err := repo.Enqueue(ctx, endpoint.ID, webhook_domain.NewEvent(webhook_domain.EventTest, nil))
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, repo.Retry(ctx, d.ID, endpoint.ID, userID, time.Now()), webhook_domain.ErrDeliveryNotRetryable)
+
+	status := 500
+	require.NoError(t, repo.MarkFailed(ctx, d.ID, webhook_domain.AttemptResult{
+		AttemptedAt:    time.Now(),
+		ResponseStatus: &status,
+		Error:          "boom",
+	}, nil))
+
+	dead := webhook_domain.DeliveryDead
+	list, total, err := listRepo.List(ctx, webhook_domain.ListDeliveriesParams{EndpointID: endpoint.ID, UserID: userID, Status: &dead})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, uint64(1), total)
+	assert.Equal(t, 1, list[0].Attempts)
+	assert.Equal(t, "boom", list[0].LastError)
+	assert.Equal(t, &status, list[0].LastResponseStatus)
+	assert.Nil(t, list[0].NextAttemptAt)
+
+	assert.ErrorIs(t, repo.Retry(ctx, d.ID, endpoint.ID, otherID, time.Now()), webhook_domain.ErrDeliveryNotFound)
+	require.NoError(t, repo.Retry(ctx, d.ID, endpoint.ID, userID, time.Now()))
+
+	due, err := repo.ClaimDue(ctx, time.Now().Add(time.Second), time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, 0, due[0].Attempts)
+}
+
+func TestWebhookDeliveryRepository_DeleteFinished(t *testing.T) {
+	cleanDB(t)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, "owner@example.com")
+	endpoint := createTestEndpoint(t, ctx, userID, webhook_domain.EventURLCreated)
+
+	repo := pg_repo.NewWebhookDeliveryRepository(testDB)
+	succeeded, err := repo.Enqueue(ctx, endpoint.ID, webhook_domain.NewEvent(webhook_domain.EventTest, nil))
+	require.NoError(t, err)
+	_, err = repo.Enqueue(ctx, endpoint.ID, webhook_domain.NewEvent(webhook_domain.EventTest, nil))
+	require.NoError(t, err)
+	require.NoError(t, repo.MarkSucceeded(ctx, succeeded.ID, webhook_domain.AttemptResult{AttemptedAt: time.Now()}))
+
+	deleted, err := repo.DeleteFinished(ctx, time.Now().Add(time.Minute), 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	list, _, err := pg_repo.NewListWebhookDeliveriesRepository(testDB).List(ctx, webhook_domain.ListDeliveriesParams{EndpointID: endpoint.ID, UserID: userID})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, webhook_domain.DeliveryPending, list[0].Status)
+}
@@ -0,0 +1,107 @@
+package redis_repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/idempotency"
+	"github.com/redis/go-redis/v9"
+)
+
+// reserveScript returns the stored record, or stores the pending one and
+// returns nothing, so two requests can never both see the key as free.
+var reserveScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if current then
+	return current
+end
+
+redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+return false
+`)
+
+// ownedPrelude leaves the script when the key is not the pending record of
+// the request holding ARGV[1], e.g. because its lock expired and a retry
+// reserved the key again.
+const ownedPrelude = `
+local current = redis.call("GET", KEYS[1])
+if not current then
+	return 0
+end
+local ok, record = pcall(cjson.decode, current)
+if not ok or record.token ~= ARGV[1] then
+	return 0
+end
+`
+
+var completeScript = redis.NewScript(ownedPrelude + `
+redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+return 1
+`)
+
+var releaseScript = redis.NewScript(ownedPrelude + `
+redis.call("DEL", KEYS[1])
+return 1
+`)
+
+type IdempotencyRepository struct {
+	client redis.UniversalClient
+}
+
+func NewIdempotencyRepository(client redis.UniversalClient) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		client: client,
+	}
+}
+
+func (r *IdempotencyRepository) Reserve(ctx context.Context, key, token, fingerprint string, lockTTL time.Duration) (*domain.Record, error) {
+	pending, err := json.Marshal(domain.Record{Fingerprint: fingerprint, Token: token})
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := reserveScript.Run(ctx, r.client, []string{r.getKey(key)}, pending, lockTTL.Milliseconds()).Text()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var record domain.Record
+	if err := json.Unmarshal([]byte(current), &record); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, key, token string, record *domain.Record, ttl time.Duration) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return owned(completeScript.Run(ctx, r.client, []string{r.getKey(key)}, token, data, ttl.Milliseconds()).Int())
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, key, token string) error {
+	return owned(releaseScript.Run(ctx, r.client, []string{r.getKey(key)}, token).Int())
+}
+
+func owned(n int, err error) error {
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrReservationLost
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) getKey(key string) string {
+	return fmt.Sprintf("idempotency:%s", key)
+}
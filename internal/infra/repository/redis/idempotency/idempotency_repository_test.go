@@ -0,0 +1,205 @@
+package redis_repo_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/idempotency"
+	redis_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/redis/idempotency"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+var idempotencyRedisClient redis.UniversalClient
+
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+
+	req := testcontainers.ContainerRequest{
+		Image:        "redis:7-alpine",
+		ExposedPorts: []string{"6379/tcp"},
+		WaitingFor:   wait.ForLog("Ready to accept connections"),
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	port, err := container.MappedPort(ctx, "6379")
+	if err != nil {
+		panic(err)
+	}
+
+	idempotencyRedisClient = redis.NewClient(&redis.Options{
+		Addr: host + ":" + port.Port(),
+	})
+
+	if err := idempotencyRedisClient.Ping(ctx).Err(); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+
+	idempotencyRedisClient.Close()
+	container.Terminate(ctx)
+
+	os.Exit(code)
+}
+
+func cleanIdempotencyRedis(t *testing.T) {
+	ctx := context.Background()
+	err := idempotencyRedisClient.FlushDB(ctx).Err()
+	require.NoError(t, err)
+}
+
+func TestIdempotencyRepository_Reserve_FreeKey(t *testing.T) {
+	cleanIdempotencyRedis(t)
+
+	repo := redis_repo.NewIdempotencyRepository(idempotencyRedisClient)
+	ctx := context.Background()
+
+	record, err := repo.Reserve(ctx, "shorten:anonymous:key-1", "token-1", "fp-1", time.Minute)
+
+	require.NoError(t, err)
+	assert.Nil(t, record)
+
+	ttl, err := idempotencyRedisClient.PTTL(ctx, "idempotency:shorten:anonymous:key-1").Result()
+	require.NoError(t, err)
+	assert.Greater(t, ttl, time.Duration(0))
+	assert.LessOrEqual(t, ttl, time.Minute)
+}
+
+func TestIdempotencyRepository_Reserve_InFlight(t *testing.T) {
+	cleanIdempotencyRedis(t)
+
+	repo := redis_repo.NewIdempotencyRepository(idempotencyRedisClient)
+	ctx := context.Background()
+
+	_, err := repo.Reserve(ctx, "key", "token-1", "fp-1", time.Minute)
+	require.NoError(t, err)
+
+	record, err := repo.Reserve(ctx, "key", "token-2", "fp-2", time.Minute)
+
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, "fp-1", record.Fingerprint)
+	assert.False(t, record.Completed())
+}
+
+func TestIdempotencyRepository_Complete(t *testing.T) {
+	cleanIdempotencyRedis(t)
+
+	repo := redis_repo.NewIdempotencyRepository(idempotencyRedisClient)
+	ctx := context.Background()
+
+	_, err := repo.Reserve(ctx, "key", "token-1", "fp-1", time.Minute)
+	require.NoError(t, err)
+
+	err = repo.Complete(ctx, "key", "token-1", &domain.Record{
+		Fingerprint: "fp-1",
+		Response: &domain.Response{
+			Status: 201,
+			Header: http.Header{"Content-Type": {"application/json"}, "Content-Language": {"pt"}},
+			Body:   []byte(`{"shortCode":"abc"}`),
+		},
+	}, 24*time.Hour)
+	require.NoError(t, err)
+
+	record, err := repo.Reserve(ctx, "key", "token-1", "fp-1", time.Minute)
+
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.True(t, record.Completed())
+	assert.Equal(t, 201, record.Response.Status)
+	assert.Equal(t, "application/json", record.Response.Header.Get("Content-Type"))
+	assert.Equal(t, "pt", record.Response.Header.Get("Content-Language"))
+	assert.JSONEq(t, `{"shortCode":"abc"}`, string(record.Response.Body))
+
+	ttl, err := idempotencyRedisClient.PTTL(ctx, "idempotency:key").Result()
+	require.NoError(t, err)
+	assert.Greater(t, ttl, time.Hour)
+}
+
+func TestIdempotencyRepository_Release(t *testing.T) {
+	cleanIdempotencyRedis(t)
+
+	repo := redis_repo.NewIdempotencyRepository(idempotencyRedisClient)
+	ctx := context.Background()
+
+	_, err := repo.Reserve(ctx, "key", "token-1", "fp-1", time.Minute)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Release(ctx, "key", "token-1"))
+
+	record, err := repo.Reserve(ctx, "key", "token-2", "fp-2", time.Minute)
+
+	require.NoError(t, err)
+	assert.Nil(t, record)
+}
+
+func TestIdempotencyRepository_ExpiredLock(t *testing.T) {
+	cleanIdempotencyRedis(t)
+
+	repo := redis_repo.NewIdempotencyRepository(idempotencyRedisClient)
+	ctx := context.Background()
+
+	_, err := repo.Reserve(ctx, "key", "token-1", "fp-1", 50*time.Millisecond)
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+
+	record, err := repo.Reserve(ctx, "key", "token-2", "fp-1", time.Minute)
+	require.NoError(t, err)
+	require.Nil(t, record)
+
+	// The first request finishing late must not touch the retry's reservation.
+	err = repo.Complete(ctx, "key", "token-1", &domain.Record{
+		Fingerprint: "fp-1",
+		Response:    &domain.Response{Status: 201},
+	}, 24*time.Hour)
+	assert.ErrorIs(t, err, domain.ErrReservationLost)
+	assert.ErrorIs(t, repo.Release(ctx, "key", "token-1"), domain.ErrReservationLost)
+
+	record, err = repo.Reserve(ctx, "key", "token-3", "fp-1", time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.False(t, record.Completed())
+	assert.Equal(t, "token-2", record.Token)
+
+	require.NoError(t, repo.Release(ctx, "key", "token-2"))
+}
+
+func TestIdempotencyRepository_CompletedKeyIsNotOwned(t *testing.T) {
+	cleanIdempotencyRedis(t)
+
+	repo := redis_repo.NewIdempotencyRepository(idempotencyRedisClient)
+	ctx := context.Background()
+
+	_, err := repo.Reserve(ctx, "key", "token-1", "fp-1", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, repo.Complete(ctx, "key", "token-1", &domain.Record{
+		Fingerprint: "fp-1",
+		Response:    &domain.Response{Status: 201},
+	}, time.Hour))
+
+	assert.ErrorIs(t, repo.Release(ctx, "key", "token-1"), domain.ErrReservationLost)
+
+	record, err := repo.Reserve(ctx, "key", "token-2", "fp-1", time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.True(t, record.Completed())
+}
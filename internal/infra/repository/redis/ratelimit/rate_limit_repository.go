@@ -0,0 +1,101 @@
+package redis_repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/ratelimit"
+	"github.com/redis/go-redis/v9"
+)
+
+// gcraScript implements the generic cell rate algorithm. The key stores the
+// theoretical arrival time (TAT) in milliseconds, and the clock comes from
+// Redis so every instance shares the same notion of "now".
+var gcraScript = redis.NewScript(`
+local key = KEYS[1]
+local emission = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000 + tonumber(t[2]) / 1000
+
+local tat = tonumber(redis.call("GET", key))
+if not tat or tat < now then
+	tat = now
+end
+
+local tolerance = emission * burst
+local new_tat = tat + emission
+local diff = now - (new_tat - tolerance)
+
+if diff < 0 then
+	return {0, 0, tostring(-diff), tostring(tat - now)}
+end
+
+local reset_after = new_tat - now
+redis.call("SET", key, tostring(new_tat), "PX", math.ceil(reset_after))
+
+local remaining = math.floor((tolerance - reset_after) / emission)
+return {1, remaining, "0", tostring(reset_after)}
+`)
+
+type RateLimitRepository struct {
+	client redis.UniversalClient
+}
+
+func NewRateLimitRepository(client redis.UniversalClient) *RateLimitRepository {
+	return &RateLimitRepository{
+		client: client,
+	}
+}
+
+func (r *RateLimitRepository) Allow(ctx context.Context, key string, limit domain.Limit) (domain.Result, error) {
+	emission := float64(limit.Period.Milliseconds()) / float64(limit.Requests)
+
+	values, err := gcraScript.Run(ctx, r.client, []string{r.getKey(key)}, emission, limit.Requests).Slice()
+	if err != nil {
+		return domain.Result{}, err
+	}
+
+	if len(values) != 4 {
+		return domain.Result{}, fmt.Errorf("unexpected rate limit script result: %v", values)
+	}
+
+	allowed, _ := values[0].(int64)
+	remaining, _ := values[1].(int64)
+	retryAfter, err := parseMillis(values[2])
+	if err != nil {
+		return domain.Result{}, err
+	}
+	resetAfter, err := parseMillis(values[3])
+	if err != nil {
+		return domain.Result{}, err
+	}
+
+	return domain.Result{
+		Allowed:    allowed == 1,
+		Limit:      limit.Requests,
+		Remaining:  int(remaining),
+		RetryAfter: retryAfter,
+		ResetAfter: resetAfter,
+	}, nil
+}
+
+func (r *RateLimitRepository) getKey(key string) string {
+	return fmt.Sprintf("ratelimit:%s", key)
+}
+
+func parseMillis(v any) (time.Duration, error) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected rate limit duration: %v", v)
+	}
+
+	var ms float64
+	if _, err := fmt.Sscan(s, &ms); err != nil {
+		return 0, err
+	}
+
+	return time.Duration(ms * float64(time.Millisecond)), nil
+}
@@ -0,0 +1,157 @@
+package redis_repo_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/ratelimit"
+	redis_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/redis/ratelimit"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+var rateLimitRedisClient redis.UniversalClient
+
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+
+	req := testcontainers.ContainerRequest{
+		Image:        "redis:7-alpine",
+		ExposedPorts: []string{"6379/tcp"},
+		WaitingFor:   wait.ForLog("Ready to accept connections"),
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	port, err := container.MappedPort(ctx, "6379")
+	if err != nil {
+		panic(err)
+	}
+
+	rateLimitRedisClient = redis.NewClient(&redis.Options{
+		Addr: host + ":" + port.Port(),
+	})
+
+	if err := rateLimitRedisClient.Ping(ctx).Err(); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+
+	rateLimitRedisClient.Close()
+	container.Terminate(ctx)
+
+	os.Exit(code)
+}
+
+func cleanRateLimitRedis(t *testing.T) {
+	ctx := context.Background()
+	err := rateLimitRedisClient.FlushDB(ctx).Err()
+	require.NoError(t, err)
+}
+
+func TestRateLimitRepository_Allow_WithinLimit(t *testing.T) {
+	cleanRateLimitRedis(t)
+
+	repo := redis_repo.NewRateLimitRepository(rateLimitRedisClient)
+	ctx := context.Background()
+	limit := domain.Limit{Requests: 3, Period: time.Minute}
+
+	for i := 0; i < 3; i++ {
+		result, err := repo.Allow(ctx, "shorten:ip:127.0.0.1", limit)
+
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 3, result.Limit)
+		assert.Equal(t, 2-i, result.Remaining)
+	}
+}
+
+func TestRateLimitRepository_Allow_Exceeded(t *testing.T) {
+	cleanRateLimitRedis(t)
+
+	repo := redis_repo.NewRateLimitRepository(rateLimitRedisClient)
+	ctx := context.Background()
+	limit := domain.Limit{Requests: 2, Period: time.Minute}
+
+	for i := 0; i < 2; i++ {
+		_, err := repo.Allow(ctx, "shorten:ip:127.0.0.1", limit)
+		require.NoError(t, err)
+	}
+
+	result, err := repo.Allow(ctx, "shorten:ip:127.0.0.1", limit)
+
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	assert.Greater(t, result.RetryAfter, time.Duration(0))
+	assert.LessOrEqual(t, result.RetryAfter, 30*time.Second)
+}
+
+func TestRateLimitRepository_Allow_Recovers(t *testing.T) {
+	cleanRateLimitRedis(t)
+
+	repo := redis_repo.NewRateLimitRepository(rateLimitRedisClient)
+	ctx := context.Background()
+	limit := domain.Limit{Requests: 1, Period: time.Second}
+
+	result, err := repo.Allow(ctx, "key", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	result, err = repo.Allow(ctx, "key", limit)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+
+	time.Sleep(result.RetryAfter + 100*time.Millisecond)
+
+	result, err = repo.Allow(ctx, "key", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
+
+func TestRateLimitRepository_Allow_SeparateKeys(t *testing.T) {
+	cleanRateLimitRedis(t)
+
+	repo := redis_repo.NewRateLimitRepository(rateLimitRedisClient)
+	ctx := context.Background()
+	limit := domain.Limit{Requests: 1, Period: time.Minute}
+
+	result, err := repo.Allow(ctx, "shorten:ip:10.0.0.1", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	result, err = repo.Allow(ctx, "shorten:ip:10.0.0.2", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
+
+func TestRateLimitRepository_KeyFormat(t *testing.T) {
+	cleanRateLimitRedis(t)
+
+	repo := redis_repo.NewRateLimitRepository(rateLimitRedisClient)
+	ctx := context.Background()
+
+	_, err := repo.Allow(ctx, "shorten:user:abc", domain.Limit{Requests: 5, Period: time.Minute})
+	require.NoError(t, err)
+
+	ttl, err := rateLimitRedisClient.PTTL(ctx, "ratelimit:shorten:user:abc").Result()
+	require.NoError(t, err)
+	assert.Greater(t, ttl, time.Duration(0))
+}
@@ -9,10 +9,10 @@ import (
 )
 
 type BlacklistRepository struct {
-	client *redis.Client
+	client redis.UniversalClient
 }
 
-func NewBlacklistRepository(client *redis.Client) *BlacklistRepository {
+func NewBlacklistRepository(client redis.UniversalClient) *BlacklistRepository {
 	return &BlacklistRepository{
 		client: client,
 	}
@@ -9,15 +9,15 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
-const StateExpiration = 2 * time.Minute
-
 type StateRepository struct {
-	client *redis.Client
+	client     redis.UniversalClient
+	expiration time.Duration
 }
 
-func NewStateRepository(client *redis.Client) *StateRepository {
+func NewStateRepository(client redis.UniversalClient, expiration time.Duration) *StateRepository {
 	return &StateRepository{
-		client: client,
+		client:     client,
+		expiration: expiration,
 	}
 }
 
@@ -28,7 +28,7 @@ func (r *StateRepository) GenerateState(ctx context.Context) (string, error) {
 	}
 
 	key := r.getKey(state)
-	err = r.client.Set(ctx, key, "1", StateExpiration).Err()
+	err = r.client.Set(ctx, key, "1", r.expiration).Err()
 	if err != nil {
 		return "", session_domain.ErrStateGeneration
 	}
@@ -44,7 +44,7 @@ func (r *StateRepository) ValidateState(ctx context.Context, state string) error
 	key := r.getKey(state)
 	exists, err := r.client.Exists(ctx, key).Result()
 	if err != nil {
-		return session_domain.ErrInvalidState
+		return err
 	}
 
 	if exists == 0 {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	session_domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
 	cache "github.com/brunoibarbosa/url-shortener/internal/infra/repository/redis/session"
@@ -15,7 +16,7 @@ import (
 )
 
 var (
-	sharedRedisClient *redis.Client
+	sharedRedisClient redis.UniversalClient
 	sharedContainer   testcontainers.Container
 )
 
@@ -73,7 +74,7 @@ func cleanRedis(t *testing.T) {
 func TestStateRepository_GenerateState_Success(t *testing.T) {
 	cleanRedis(t)
 
-	repo := cache.NewStateRepository(sharedRedisClient)
+	repo := cache.NewStateRepository(sharedRedisClient, 2*time.Minute)
 	ctx := context.Background()
 
 	state, err := repo.GenerateState(ctx)
@@ -86,7 +87,7 @@ func TestStateRepository_GenerateState_Success(t *testing.T) {
 func TestStateRepository_GenerateState_Unique(t *testing.T) {
 	cleanRedis(t)
 
-	repo := cache.NewStateRepository(sharedRedisClient)
+	repo := cache.NewStateRepository(sharedRedisClient, 2*time.Minute)
 	ctx := context.Background()
 
 	states := make(map[string]bool)
@@ -105,7 +106,7 @@ func TestStateRepository_GenerateState_Unique(t *testing.T) {
 func TestStateRepository_ValidateState_Valid(t *testing.T) {
 	cleanRedis(t)
 
-	repo := cache.NewStateRepository(sharedRedisClient)
+	repo := cache.NewStateRepository(sharedRedisClient, 2*time.Minute)
 	ctx := context.Background()
 
 	state, err := repo.GenerateState(ctx)
@@ -119,7 +120,7 @@ func TestStateRepository_ValidateState_Valid(t *testing.T) {
 func TestStateRepository_ValidateState_Invalid(t *testing.T) {
 	cleanRedis(t)
 
-	repo := cache.NewStateRepository(sharedRedisClient)
+	repo := cache.NewStateRepository(sharedRedisClient, 2*time.Minute)
 	ctx := context.Background()
 
 	err := repo.ValidateState(ctx, "invalid-state-12345")
@@ -131,7 +132,7 @@ func TestStateRepository_ValidateState_Invalid(t *testing.T) {
 func TestStateRepository_ValidateState_Empty(t *testing.T) {
 	cleanRedis(t)
 
-	repo := cache.NewStateRepository(sharedRedisClient)
+	repo := cache.NewStateRepository(sharedRedisClient, 2*time.Minute)
 	ctx := context.Background()
 
 	err := repo.ValidateState(ctx, "")
@@ -143,7 +144,7 @@ func TestStateRepository_ValidateState_Empty(t *testing.T) {
 func TestStateRepository_ValidateState_Consumed(t *testing.T) {
 	cleanRedis(t)
 
-	repo := cache.NewStateRepository(sharedRedisClient)
+	repo := cache.NewStateRepository(sharedRedisClient, 2*time.Minute)
 	ctx := context.Background()
 
 	state, err := repo.GenerateState(ctx)
@@ -166,7 +167,7 @@ func TestStateRepository_ValidateState_Consumed(t *testing.T) {
 func TestStateRepository_ValidateState_Expiration(t *testing.T) {
 	cleanRedis(t)
 
-	repo := cache.NewStateRepository(sharedRedisClient)
+	repo := cache.NewStateRepository(sharedRedisClient, 30*time.Second)
 	ctx := context.Background()
 
 	// Generate state
@@ -177,15 +178,17 @@ func TestStateRepository_ValidateState_Expiration(t *testing.T) {
 	err = repo.ValidateState(ctx, state)
 	require.NoError(t, err)
 
-	// Note: We can't easily test expiration without waiting 2 minutes
-	// or mocking time, so we'll skip the actual expiration test
-	// In real scenario, state expires after 2 minutes
+	// The key carries the configured expiration
+	ttl, err := sharedRedisClient.TTL(ctx, "oauth:state:"+state).Result()
+	require.NoError(t, err)
+	assert.Greater(t, ttl, time.Duration(0))
+	assert.LessOrEqual(t, ttl, 30*time.Second)
 }
 
 func TestStateRepository_KeyFormat(t *testing.T) {
 	cleanRedis(t)
 
-	repo := cache.NewStateRepository(sharedRedisClient)
+	repo := cache.NewStateRepository(sharedRedisClient, 2*time.Minute)
 	ctx := context.Background()
 
 	state, err := repo.GenerateState(ctx)
@@ -201,7 +204,7 @@ func TestStateRepository_KeyFormat(t *testing.T) {
 func TestStateRepository_ConcurrentGenerate(t *testing.T) {
 	cleanRedis(t)
 
-	repo := cache.NewStateRepository(sharedRedisClient)
+	repo := cache.NewStateRepository(sharedRedisClient, 2*time.Minute)
 	ctx := context.Background()
 
 	iterations := 50
@@ -238,7 +241,7 @@ func TestStateRepository_ConcurrentGenerate(t *testing.T) {
 func TestStateRepository_MultipleValidations(t *testing.T) {
 	cleanRedis(t)
 
-	repo := cache.NewStateRepository(sharedRedisClient)
+	repo := cache.NewStateRepository(sharedRedisClient, 2*time.Minute)
 	ctx := context.Background()
 
 	// Generate multiple states
@@ -271,7 +274,7 @@ func TestStateRepository_MultipleValidations(t *testing.T) {
 func TestStateRepository_InvalidateAfterUse(t *testing.T) {
 	cleanRedis(t)
 
-	repo := cache.NewStateRepository(sharedRedisClient)
+	repo := cache.NewStateRepository(sharedRedisClient, 2*time.Minute)
 	ctx := context.Background()
 
 	state, err := repo.GenerateState(ctx)
@@ -292,7 +295,7 @@ func TestStateRepository_InvalidateAfterUse(t *testing.T) {
 func TestStateRepository_DifferentStatesIndependent(t *testing.T) {
 	cleanRedis(t)
 
-	repo := cache.NewStateRepository(sharedRedisClient)
+	repo := cache.NewStateRepository(sharedRedisClient, 2*time.Minute)
 	ctx := context.Background()
 
 	state1, err := repo.GenerateState(ctx)
@@ -0,0 +1,26 @@
+package redis_repo
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const counterKey = "shortcode:counter:block"
+
+// ShortCodeCounterRepository allocates counter blocks with INCR. Redis must
+// persist the key (AOF or RDB with a short interval): if it is lost the
+// counter restarts and earlier codes would be generated again.
+type ShortCodeCounterRepository struct {
+	client redis.UniversalClient
+}
+
+func NewShortCodeCounterRepository(client redis.UniversalClient) *ShortCodeCounterRepository {
+	return &ShortCodeCounterRepository{
+		client: client,
+	}
+}
+
+func (r *ShortCodeCounterRepository) NextBlock(ctx context.Context) (int64, error) {
+	return r.client.Incr(ctx, counterKey).Result()
+}
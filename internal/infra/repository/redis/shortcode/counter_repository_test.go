@@ -0,0 +1,73 @@
+package redis_repo_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	redis_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/redis/shortcode"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+var counterRedisClient redis.UniversalClient
+
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+
+	req := testcontainers.ContainerRequest{
+		Image:        "redis:7-alpine",
+		ExposedPorts: []string{"6379/tcp"},
+		WaitingFor:   wait.ForLog("Ready to accept connections"),
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	port, err := container.MappedPort(ctx, "6379")
+	if err != nil {
+		panic(err)
+	}
+
+	counterRedisClient = redis.NewClient(&redis.Options{
+		Addr: host + ":" + port.Port(),
+	})
+
+	if err := counterRedisClient.Ping(ctx).Err(); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+
+	counterRedisClient.Close()
+	container.Terminate(ctx)
+
+	os.Exit(code)
+}
+
+func TestShortCodeCounterRepository_NextBlock(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, counterRedisClient.FlushDB(ctx).Err())
+
+	repo := redis_repo.NewShortCodeCounterRepository(counterRedisClient)
+
+	for want := int64(1); want <= 3; want++ {
+		block, err := repo.NextBlock(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, want, block)
+	}
+}
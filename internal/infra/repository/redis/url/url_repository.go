@@ -2,18 +2,44 @@ package redis_repo
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
+// invalidationChannel carries the short codes deleted from the cache, so
+// every instance drops them from its local cache too.
+const invalidationChannel = "url:invalidate"
+
+// cacheRecordVersion is bumped whenever cachedURL changes incompatibly;
+// records of another version are treated as misses.
+const cacheRecordVersion = 1
+
+// cachedURL is the JSON record stored per short code. Entries written before
+// it existed hold only the ciphertext as a plain string. The redirect options
+// are optional, so records written without them read as the defaults.
+type cachedURL struct {
+	Version      int                 `json:"v"`
+	EncryptedURL string              `json:"url"`
+	UserID       *uuid.UUID          `json:"uid,omitempty"`
+	ExpiresAt    *time.Time          `json:"exp,omitempty"`
+	DeletedAt    *time.Time          `json:"del,omitempty"`
+	RedirectType domain.RedirectType `json:"rt,omitempty"`
+	ForwardQuery bool                `json:"fq,omitempty"`
+	ForwardPath  bool                `json:"fp,omitempty"`
+	UTM          *domain.UTMParams   `json:"utm,omitempty"`
+}
+
 type URLCacheRepository struct {
-	client *redis.Client
+	client redis.UniversalClient
 }
 
-func NewURLCacheRepository(client *redis.Client) *URLCacheRepository {
+func NewURLCacheRepository(client redis.UniversalClient) *URLCacheRepository {
 	return &URLCacheRepository{
 		client: client,
 	}
@@ -27,12 +53,30 @@ func (r *URLCacheRepository) Exists(ctx context.Context, shortCode string) (bool
 
 func (r *URLCacheRepository) Save(ctx context.Context, url *domain.URL, expires time.Duration) error {
 	key := r.getKey(url.ShortCode)
-	return r.client.Set(ctx, key, url.EncryptedURL, expires).Err()
+	record := cachedURL{
+		Version:      cacheRecordVersion,
+		EncryptedURL: url.EncryptedURL,
+		UserID:       url.UserID,
+		ExpiresAt:    url.ExpiresAt,
+		DeletedAt:    url.DeletedAt,
+		RedirectType: url.RedirectType,
+		ForwardQuery: url.ForwardQuery,
+		ForwardPath:  url.ForwardPath,
+	}
+	if !url.UTM.IsZero() {
+		record.UTM = &url.UTM
+	}
+
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, key, value, expires).Err()
 }
 
 func (r *URLCacheRepository) FindByShortCode(ctx context.Context, shortCode string) (*domain.URL, error) {
 	key := r.getKey(shortCode)
-	encryptedUrl, err := r.client.Get(ctx, key).Result()
+	value, err := r.client.Get(ctx, key).Result()
 
 	if err != nil {
 		if err == redis.Nil {
@@ -41,16 +85,78 @@ func (r *URLCacheRepository) FindByShortCode(ctx context.Context, shortCode stri
 		return nil, err
 	}
 
-	return &domain.URL{
+	// Ciphertexts never start with a brace, so this is a legacy entry.
+	if !strings.HasPrefix(value, "{") {
+		return &domain.URL{
+			ShortCode:    shortCode,
+			EncryptedURL: value,
+			ExpiresAt:    nil,
+		}, nil
+	}
+
+	var record cachedURL
+	if err := json.Unmarshal([]byte(value), &record); err != nil || record.Version != cacheRecordVersion {
+		return nil, nil
+	}
+
+	u := &domain.URL{
 		ShortCode:    shortCode,
-		EncryptedURL: encryptedUrl,
-		ExpiresAt:    nil,
-	}, nil
+		EncryptedURL: record.EncryptedURL,
+		UserID:       record.UserID,
+		ExpiresAt:    record.ExpiresAt,
+		DeletedAt:    record.DeletedAt,
+		RedirectOptions: domain.RedirectOptions{
+			RedirectType: record.RedirectType,
+			ForwardQuery: record.ForwardQuery,
+			ForwardPath:  record.ForwardPath,
+		},
+	}
+	if record.UTM != nil {
+		u.UTM = *record.UTM
+	}
+
+	return u, nil
 }
 
 func (r *URLCacheRepository) Delete(ctx context.Context, shortCode string) error {
 	key := r.getKey(shortCode)
-	return r.client.Del(ctx, key).Err()
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.Publish(ctx, invalidationChannel, shortCode)
+		return nil
+	})
+	return err
+}
+
+// ListenInvalidations evicts deleted short codes from local until ctx is
+// cancelled. Invalidations published while the subscription was down are
+// lost, so local is purged whenever it (re)subscribes.
+func (r *URLCacheRepository) ListenInvalidations(ctx context.Context, local domain.URLLocalCache) {
+	pubsub := r.client.Subscribe(ctx, invalidationChannel)
+	stop := context.AfterFunc(ctx, func() { pubsub.Close() })
+	defer stop()
+
+	for {
+		msg, err := pubsub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+
+		switch msg := msg.(type) {
+		case *redis.Subscription:
+			local.Purge()
+		case *redis.Message:
+			local.Delete(msg.Payload)
+		}
+	}
 }
 
 func (r *URLCacheRepository) getKey(shortCode string) string {
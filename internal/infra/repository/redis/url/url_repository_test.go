@@ -7,7 +7,9 @@ import (
 	"time"
 
 	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	memory_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/memory/url"
 	redis_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/redis/url"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,7 +18,7 @@ import (
 )
 
 var (
-	urlRedisClient *redis.Client
+	urlRedisClient redis.UniversalClient
 	urlContainer   testcontainers.Container
 )
 
@@ -295,3 +297,143 @@ func TestURLCacheRepository_KeyFormat(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, int64(1), exists)
 }
+
+func TestURLCacheRepository_Delete_InvalidatesLocalCaches(t *testing.T) {
+	cleanURLRedis(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Another instance with its own local cache
+	local := memory_repo.NewURLLocalCache(10)
+	local.Set("other", domain.Redirect{Destination: "https://other.com"}, time.Minute)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		redis_repo.NewURLCacheRepository(urlRedisClient).ListenInvalidations(ctx, local)
+	}()
+
+	// Wait for the subscription, which purges the local cache
+	require.Eventually(t, func() bool { return local.Len() == 0 }, 5*time.Second, 10*time.Millisecond)
+	local.Set("invalidate123", domain.Redirect{Destination: "https://example.com"}, time.Minute)
+	local.Set("keep123", domain.Redirect{Destination: "https://example.org"}, time.Minute)
+
+	repo := redis_repo.NewURLCacheRepository(urlRedisClient)
+	require.NoError(t, repo.Delete(ctx, "invalidate123"))
+
+	require.Eventually(t, func() bool {
+		_, ok := local.Get("invalidate123")
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+	_, ok := local.Get("keep123")
+	assert.True(t, ok)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("ListenInvalidations did not stop after cancellation")
+	}
+}
+
+func TestURLCacheRepository_FindByShortCode_Metadata(t *testing.T) {
+	cleanURLRedis(t)
+
+	repo := redis_repo.NewURLCacheRepository(urlRedisClient)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	url := &domain.URL{
+		ShortCode:    "meta123",
+		EncryptedURL: "encrypted-data",
+		UserID:       &userID,
+		ExpiresAt:    &expiresAt,
+	}
+
+	require.NoError(t, repo.Save(ctx, url, 5*time.Minute))
+
+	foundURL, err := repo.FindByShortCode(ctx, "meta123")
+
+	require.NoError(t, err)
+	require.NotNil(t, foundURL)
+	assert.Equal(t, "encrypted-data", foundURL.EncryptedURL)
+	assert.Equal(t, &userID, foundURL.UserID)
+	require.NotNil(t, foundURL.ExpiresAt)
+	assert.True(t, expiresAt.Equal(*foundURL.ExpiresAt))
+	assert.Nil(t, foundURL.DeletedAt)
+}
+
+func TestURLCacheRepository_FindByShortCode_RedirectOptions(t *testing.T) {
+	cleanURLRedis(t)
+
+	repo := redis_repo.NewURLCacheRepository(urlRedisClient)
+	ctx := context.Background()
+
+	url := &domain.URL{
+		ShortCode:    "opts123",
+		EncryptedURL: "encrypted-data",
+		RedirectOptions: domain.RedirectOptions{
+			RedirectType: domain.RedirectPermanentRedirect,
+			ForwardQuery: true,
+			ForwardPath:  true,
+			UTM:          domain.UTMParams{Source: "newsletter", Campaign: "launch"},
+		},
+	}
+
+	require.NoError(t, repo.Save(ctx, url, 5*time.Minute))
+
+	foundURL, err := repo.FindByShortCode(ctx, "opts123")
+
+	require.NoError(t, err)
+	require.NotNil(t, foundURL)
+	assert.Equal(t, url.RedirectOptions, foundURL.RedirectOptions)
+}
+
+func TestURLCacheRepository_FindByShortCode_RecordWithoutOptions(t *testing.T) {
+	cleanURLRedis(t)
+
+	repo := redis_repo.NewURLCacheRepository(urlRedisClient)
+	ctx := context.Background()
+
+	err := urlRedisClient.Set(ctx, "url:short_code:plain1", `{"v":1,"url":"x"}`, 5*time.Minute).Err()
+	require.NoError(t, err)
+
+	foundURL, err := repo.FindByShortCode(ctx, "plain1")
+
+	require.NoError(t, err)
+	require.NotNil(t, foundURL)
+	assert.Equal(t, domain.RedirectOptions{}, foundURL.RedirectOptions)
+}
+
+func TestURLCacheRepository_FindByShortCode_LegacyEntry(t *testing.T) {
+	cleanURLRedis(t)
+
+	repo := redis_repo.NewURLCacheRepository(urlRedisClient)
+	ctx := context.Background()
+
+	err := urlRedisClient.Set(ctx, "url:short_code:legacy1", "k1:bGVnYWN5", 5*time.Minute).Err()
+	require.NoError(t, err)
+
+	foundURL, err := repo.FindByShortCode(ctx, "legacy1")
+
+	require.NoError(t, err)
+	require.NotNil(t, foundURL)
+	assert.Equal(t, "k1:bGVnYWN5", foundURL.EncryptedURL)
+	assert.Nil(t, foundURL.ExpiresAt)
+}
+
+func TestURLCacheRepository_FindByShortCode_UnknownVersionIsMiss(t *testing.T) {
+	cleanURLRedis(t)
+
+	repo := redis_repo.NewURLCacheRepository(urlRedisClient)
+	ctx := context.Background()
+
+	err := urlRedisClient.Set(ctx, "url:short_code:future1", `{"v":99,"url":"x"}`, 5*time.Minute).Err()
+	require.NoError(t, err)
+
+	foundURL, err := repo.FindByShortCode(ctx, "future1")
+
+	require.NoError(t, err)
+	assert.Nil(t, foundURL)
+}
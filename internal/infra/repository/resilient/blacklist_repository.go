@@ -0,0 +1,52 @@
+package resilient_repo
+
+import (
+	"context"
+	"time"
+
+	session_domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/resilience"
+)
+
+// BlacklistRepository applies policy when the blacklist is unavailable.
+// Failing open is safe as long as sessions are also revoked in Postgres,
+// which every caller does before touching the blacklist.
+type BlacklistRepository struct {
+	inner   session_domain.BlacklistRepository
+	breaker *resilience.Breaker
+	policy  resilience.FailurePolicy
+}
+
+func NewBlacklistRepository(inner session_domain.BlacklistRepository, breaker *resilience.Breaker, policy resilience.FailurePolicy) *BlacklistRepository {
+	return &BlacklistRepository{
+		inner:   inner,
+		breaker: breaker,
+		policy:  policy,
+	}
+}
+
+func (r *BlacklistRepository) IsRevoked(ctx context.Context, token string) (bool, error) {
+	var revoked bool
+	err := r.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		revoked, err = r.inner.IsRevoked(ctx, token)
+		return err
+	})
+	if err != nil {
+		if r.policy == resilience.FailOpen {
+			return false, nil
+		}
+		return false, err
+	}
+	return revoked, nil
+}
+
+func (r *BlacklistRepository) Revoke(ctx context.Context, token string, expiresIn time.Duration) error {
+	err := r.breaker.Execute(ctx, func(ctx context.Context) error {
+		return r.inner.Revoke(ctx, token, expiresIn)
+	})
+	if err != nil && r.policy == resilience.FailOpen {
+		return nil
+	}
+	return err
+}
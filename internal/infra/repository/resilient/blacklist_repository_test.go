@@ -0,0 +1,80 @@
+package resilient_repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	resilient_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/resilient"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/resilience"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBlacklistRepository_IsRevoked(t *testing.T) {
+	t.Run("passes results through", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockBlacklistRepository(ctrl)
+		repo := resilient_repo.NewBlacklistRepository(inner, newBreaker(), resilience.FailOpen)
+
+		inner.EXPECT().IsRevoked(gomock.Any(), "token").Return(true, nil)
+
+		revoked, err := repo.IsRevoked(context.Background(), "token")
+		require.NoError(t, err)
+		assert.True(t, revoked)
+	})
+
+	t.Run("fail open", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockBlacklistRepository(ctrl)
+		repo := resilient_repo.NewBlacklistRepository(inner, newBreaker(), resilience.FailOpen)
+
+		inner.EXPECT().IsRevoked(gomock.Any(), "token").Return(false, errRedis)
+
+		revoked, err := repo.IsRevoked(context.Background(), "token")
+		require.NoError(t, err)
+		assert.False(t, revoked)
+
+		revoked, err = repo.IsRevoked(context.Background(), "token")
+		require.NoError(t, err)
+		assert.False(t, revoked)
+	})
+
+	t.Run("fail closed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockBlacklistRepository(ctrl)
+		repo := resilient_repo.NewBlacklistRepository(inner, newBreaker(), resilience.FailClosed)
+
+		inner.EXPECT().IsRevoked(gomock.Any(), "token").Return(false, errRedis)
+
+		_, err := repo.IsRevoked(context.Background(), "token")
+		assert.ErrorIs(t, err, errRedis)
+
+		_, err = repo.IsRevoked(context.Background(), "token")
+		assert.ErrorIs(t, err, resilience.ErrCircuitOpen)
+	})
+}
+
+func TestBlacklistRepository_Revoke(t *testing.T) {
+	t.Run("fail open", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockBlacklistRepository(ctrl)
+		repo := resilient_repo.NewBlacklistRepository(inner, newBreaker(), resilience.FailOpen)
+
+		inner.EXPECT().Revoke(gomock.Any(), "token", time.Hour).Return(errRedis)
+
+		assert.NoError(t, repo.Revoke(context.Background(), "token", time.Hour))
+	})
+
+	t.Run("fail closed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockBlacklistRepository(ctrl)
+		repo := resilient_repo.NewBlacklistRepository(inner, newBreaker(), resilience.FailClosed)
+
+		inner.EXPECT().Revoke(gomock.Any(), "token", time.Hour).Return(errRedis)
+
+		assert.ErrorIs(t, repo.Revoke(context.Background(), "token", time.Hour), errRedis)
+	})
+}
@@ -0,0 +1,65 @@
+package resilient_repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/domain/idempotency"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/resilience"
+)
+
+// IdempotencyRepository stops waiting on an unavailable store; the
+// idempotency middleware already runs requests without a key when Reserve
+// fails.
+type IdempotencyRepository struct {
+	inner   idempotency.IdempotencyRepository
+	breaker *resilience.Breaker
+}
+
+func NewIdempotencyRepository(inner idempotency.IdempotencyRepository, breaker *resilience.Breaker) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		inner:   inner,
+		breaker: breaker,
+	}
+}
+
+func (r *IdempotencyRepository) Reserve(ctx context.Context, key, token, fingerprint string, lockTTL time.Duration) (*idempotency.Record, error) {
+	var record *idempotency.Record
+	err := r.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		record, err = r.inner.Reserve(ctx, key, token, fingerprint, lockTTL)
+		return err
+	})
+	return record, err
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, key, token string, record *idempotency.Record, ttl time.Duration) error {
+	return r.ownedCall(ctx, func(ctx context.Context) error {
+		return r.inner.Complete(ctx, key, token, record, ttl)
+	})
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, key, token string) error {
+	return r.ownedCall(ctx, func(ctx context.Context) error {
+		return r.inner.Release(ctx, key, token)
+	})
+}
+
+// ownedCall runs fn through the breaker without counting a lost reservation
+// as a failure of the store.
+func (r *IdempotencyRepository) ownedCall(ctx context.Context, fn func(ctx context.Context) error) error {
+	lost := false
+	err := r.breaker.Execute(ctx, func(ctx context.Context) error {
+		err := fn(ctx)
+		if errors.Is(err, idempotency.ErrReservationLost) {
+			lost = true
+			return nil
+		}
+		return err
+	})
+	if lost {
+		return idempotency.ErrReservationLost
+	}
+	return err
+}
@@ -0,0 +1,40 @@
+package resilient_repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/domain/idempotency"
+	resilient_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/resilient"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/resilience"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIdempotencyRepository_LostReservationIsNotAFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	inner := mocks.NewMockIdempotencyRepository(ctrl)
+	breaker := newBreaker()
+	repo := resilient_repo.NewIdempotencyRepository(inner, breaker)
+
+	inner.EXPECT().Complete(gomock.Any(), "key", "token", gomock.Any(), time.Hour).Return(idempotency.ErrReservationLost)
+	inner.EXPECT().Release(gomock.Any(), "key", "token").Return(idempotency.ErrReservationLost)
+
+	assert.ErrorIs(t, repo.Complete(context.Background(), "key", "token", &idempotency.Record{}, time.Hour), idempotency.ErrReservationLost)
+	assert.ErrorIs(t, repo.Release(context.Background(), "key", "token"), idempotency.ErrReservationLost)
+	assert.Equal(t, resilience.StateClosed, breaker.State())
+}
+
+func TestIdempotencyRepository_StoreErrorsOpenTheBreaker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	inner := mocks.NewMockIdempotencyRepository(ctrl)
+	breaker := newBreaker()
+	repo := resilient_repo.NewIdempotencyRepository(inner, breaker)
+
+	inner.EXPECT().Release(gomock.Any(), "key", "token").Return(errRedis)
+
+	assert.ErrorIs(t, repo.Release(context.Background(), "key", "token"), errRedis)
+	assert.Equal(t, resilience.StateOpen, breaker.State())
+}
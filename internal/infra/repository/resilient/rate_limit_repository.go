@@ -0,0 +1,32 @@
+package resilient_repo
+
+import (
+	"context"
+
+	"github.com/brunoibarbosa/url-shortener/internal/domain/ratelimit"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/resilience"
+)
+
+// RateLimitRepository stops waiting on an unavailable store; the rate limit
+// middleware already lets requests through when Allow fails.
+type RateLimitRepository struct {
+	inner   ratelimit.Limiter
+	breaker *resilience.Breaker
+}
+
+func NewRateLimitRepository(inner ratelimit.Limiter, breaker *resilience.Breaker) *RateLimitRepository {
+	return &RateLimitRepository{
+		inner:   inner,
+		breaker: breaker,
+	}
+}
+
+func (r *RateLimitRepository) Allow(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	var result ratelimit.Result
+	err := r.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = r.inner.Allow(ctx, key, limit)
+		return err
+	})
+	return result, err
+}
@@ -0,0 +1,69 @@
+package resilient_repo
+
+import (
+	"context"
+	"errors"
+
+	session_domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/resilience"
+)
+
+// StateService applies policy when OAuth states cannot be stored or checked.
+// Failing open keeps Google login working without CSRF protection on the
+// state parameter while the store is down.
+type StateService struct {
+	inner   session_domain.StateService
+	breaker *resilience.Breaker
+	policy  resilience.FailurePolicy
+}
+
+func NewStateService(inner session_domain.StateService, breaker *resilience.Breaker, policy resilience.FailurePolicy) *StateService {
+	return &StateService{
+		inner:   inner,
+		breaker: breaker,
+		policy:  policy,
+	}
+}
+
+func (s *StateService) GenerateState(ctx context.Context) (string, error) {
+	var state string
+	err := s.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		state, err = s.inner.GenerateState(ctx)
+		return err
+	})
+	if err != nil {
+		if s.policy == resilience.FailOpen {
+			return session_domain.GenerateRandomState()
+		}
+		return "", session_domain.ErrStateGeneration
+	}
+	return state, nil
+}
+
+func (s *StateService) ValidateState(ctx context.Context, state string) error {
+	invalid := false
+	err := s.breaker.Execute(ctx, func(ctx context.Context) error {
+		err := s.inner.ValidateState(ctx, state)
+		if errors.Is(err, session_domain.ErrInvalidState) {
+			invalid = true
+			return nil
+		}
+		return err
+	})
+	switch {
+	case invalid:
+		return session_domain.ErrInvalidState
+	case err != nil && s.policy == resilience.FailOpen && state != "":
+		return nil
+	case err != nil:
+		return session_domain.ErrInvalidState
+	}
+	return nil
+}
+
+func (s *StateService) DeleteState(ctx context.Context, state string) error {
+	return s.breaker.Execute(ctx, func(ctx context.Context) error {
+		return s.inner.DeleteState(ctx, state)
+	})
+}
@@ -0,0 +1,74 @@
+package resilient_repo_test
+
+import (
+	"context"
+	"testing"
+
+	session_domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
+	resilient_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/resilient"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/resilience"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStateService_GenerateState(t *testing.T) {
+	t.Run("fail open generates an unstored state", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockStateService(ctrl)
+		svc := resilient_repo.NewStateService(inner, newBreaker(), resilience.FailOpen)
+
+		inner.EXPECT().GenerateState(gomock.Any()).Return("", errRedis)
+
+		state, err := svc.GenerateState(context.Background())
+		require.NoError(t, err)
+		assert.NotEmpty(t, state)
+	})
+
+	t.Run("fail closed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockStateService(ctrl)
+		svc := resilient_repo.NewStateService(inner, newBreaker(), resilience.FailClosed)
+
+		inner.EXPECT().GenerateState(gomock.Any()).Return("", errRedis)
+
+		_, err := svc.GenerateState(context.Background())
+		assert.ErrorIs(t, err, session_domain.ErrStateGeneration)
+	})
+}
+
+func TestStateService_ValidateState(t *testing.T) {
+	t.Run("invalid state is not a failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockStateService(ctrl)
+		breaker := newBreaker()
+		svc := resilient_repo.NewStateService(inner, breaker, resilience.FailOpen)
+
+		inner.EXPECT().ValidateState(gomock.Any(), "state").Return(session_domain.ErrInvalidState)
+
+		assert.ErrorIs(t, svc.ValidateState(context.Background(), "state"), session_domain.ErrInvalidState)
+		assert.Equal(t, resilience.StateClosed, breaker.State())
+	})
+
+	t.Run("fail open", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockStateService(ctrl)
+		svc := resilient_repo.NewStateService(inner, newBreaker(), resilience.FailOpen)
+
+		inner.EXPECT().ValidateState(gomock.Any(), "state").Return(errRedis)
+
+		assert.NoError(t, svc.ValidateState(context.Background(), "state"))
+		assert.ErrorIs(t, svc.ValidateState(context.Background(), ""), session_domain.ErrInvalidState)
+	})
+
+	t.Run("fail closed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockStateService(ctrl)
+		svc := resilient_repo.NewStateService(inner, newBreaker(), resilience.FailClosed)
+
+		inner.EXPECT().ValidateState(gomock.Any(), "state").Return(errRedis)
+
+		assert.ErrorIs(t, svc.ValidateState(context.Background(), "state"), session_domain.ErrInvalidState)
+	})
+}
@@ -0,0 +1,61 @@
+package resilient_repo
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/resilience"
+)
+
+// URLCacheRepository treats an unavailable cache as empty, so lookups fall
+// through to Postgres instead of failing.
+type URLCacheRepository struct {
+	inner   domain.URLCacheRepository
+	breaker *resilience.Breaker
+}
+
+func NewURLCacheRepository(inner domain.URLCacheRepository, breaker *resilience.Breaker) *URLCacheRepository {
+	return &URLCacheRepository{
+		inner:   inner,
+		breaker: breaker,
+	}
+}
+
+func (r *URLCacheRepository) Exists(ctx context.Context, shortCode string) (bool, error) {
+	var exists bool
+	err := r.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		exists, err = r.inner.Exists(ctx, shortCode)
+		return err
+	})
+	if err != nil {
+		return false, nil
+	}
+	return exists, nil
+}
+
+func (r *URLCacheRepository) Save(ctx context.Context, url *domain.URL, expires time.Duration) error {
+	return r.breaker.Execute(ctx, func(ctx context.Context) error {
+		return r.inner.Save(ctx, url, expires)
+	})
+}
+
+func (r *URLCacheRepository) Delete(ctx context.Context, shortCode string) error {
+	return r.breaker.Execute(ctx, func(ctx context.Context) error {
+		return r.inner.Delete(ctx, shortCode)
+	})
+}
+
+func (r *URLCacheRepository) FindByShortCode(ctx context.Context, shortCode string) (*domain.URL, error) {
+	var url *domain.URL
+	err := r.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		url, err = r.inner.FindByShortCode(ctx, shortCode)
+		return err
+	})
+	if err != nil {
+		return nil, nil
+	}
+	return url, nil
+}
@@ -0,0 +1,81 @@
+package resilient_repo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	resilient_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/resilient"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/resilience"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var errRedis = errors.New("redis: connection refused")
+
+func newBreaker() *resilience.Breaker {
+	return resilience.NewBreaker(resilience.BreakerConfig{
+		Name:             "redis",
+		Timeout:          time.Second,
+		FailureThreshold: 1,
+		OpenDuration:     time.Minute,
+	})
+}
+
+func TestURLCacheRepository_FindByShortCode(t *testing.T) {
+	t.Run("passes hits through", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockURLCacheRepository(ctrl)
+		repo := resilient_repo.NewURLCacheRepository(inner, newBreaker())
+
+		want := &domain.URL{ShortCode: "abc123"}
+		inner.EXPECT().FindByShortCode(gomock.Any(), "abc123").Return(want, nil)
+
+		got, err := repo.FindByShortCode(context.Background(), "abc123")
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("treats errors as misses and stops calling once open", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockURLCacheRepository(ctrl)
+		breaker := newBreaker()
+		repo := resilient_repo.NewURLCacheRepository(inner, breaker)
+
+		inner.EXPECT().FindByShortCode(gomock.Any(), "abc123").Return(nil, errRedis).Times(1)
+
+		for range 2 {
+			got, err := repo.FindByShortCode(context.Background(), "abc123")
+			require.NoError(t, err)
+			assert.Nil(t, got)
+		}
+		assert.Equal(t, resilience.StateOpen, breaker.State())
+	})
+}
+
+func TestURLCacheRepository_Exists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	inner := mocks.NewMockURLCacheRepository(ctrl)
+	repo := resilient_repo.NewURLCacheRepository(inner, newBreaker())
+
+	inner.EXPECT().Exists(gomock.Any(), "abc123").Return(false, errRedis)
+
+	exists, err := repo.Exists(context.Background(), "abc123")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestURLCacheRepository_WritesReturnErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	inner := mocks.NewMockURLCacheRepository(ctrl)
+	repo := resilient_repo.NewURLCacheRepository(inner, newBreaker())
+
+	inner.EXPECT().Save(gomock.Any(), gomock.Any(), time.Minute).Return(errRedis)
+
+	assert.ErrorIs(t, repo.Save(context.Background(), &domain.URL{}, time.Minute), errRedis)
+	assert.ErrorIs(t, repo.Delete(context.Background(), "abc123"), resilience.ErrCircuitOpen)
+}
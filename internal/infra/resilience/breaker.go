@@ -0,0 +1,147 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateHalfOpen
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "open"
+	}
+}
+
+type BreakerConfig struct {
+	Name string
+	// Timeout bounds every call made through the breaker.
+	Timeout time.Duration
+	// FailureThreshold consecutive failures open the breaker.
+	FailureThreshold int
+	// OpenDuration is how long calls are rejected before a single trial call
+	// is let through.
+	OpenDuration time.Duration
+}
+
+// Breaker rejects calls to a dependency that keeps failing, so callers can
+// degrade immediately instead of waiting on timeouts.
+type Breaker struct {
+	cfg BreakerConfig
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+func NewBreaker(cfg BreakerConfig) *Breaker {
+	return &Breaker{cfg: cfg}
+}
+
+func (b *Breaker) Name() string {
+	return b.cfg.Name
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && time.Since(b.openedAt) >= b.cfg.OpenDuration {
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+// Trip opens the breaker as if the failure threshold had just been reached.
+func (b *Breaker) Trip() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = StateOpen
+	b.openedAt = time.Now()
+	b.trial = false
+}
+
+// Execute calls fn with a context bounded by the configured timeout, or
+// returns ErrCircuitOpen without calling it. Errors of fn count as failures
+// unless ctx itself was cancelled.
+func (b *Breaker) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !b.allow() {
+		return ErrCircuitOpen
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, b.cfg.Timeout)
+	defer cancel()
+
+	err := fn(callCtx)
+	if err != nil && ctx.Err() != nil {
+		b.release()
+		return err
+	}
+	b.record(err == nil)
+	return err
+}
+
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateClosed:
+		return true
+	case StateOpen:
+		if time.Since(b.openedAt) < b.cfg.OpenDuration {
+			return false
+		}
+		b.state = StateHalfOpen
+	}
+
+	if b.trial {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+// release gives up a call that neither succeeded nor failed.
+func (b *Breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+}
+
+func (b *Breaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	if success {
+		b.state = StateClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == StateHalfOpen || b.failures >= b.cfg.FailureThreshold {
+		b.state = StateOpen
+		b.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,152 @@
+package resilience_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/infra/resilience"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errDependency = errors.New("dependency failed")
+
+func newBreaker(openDuration time.Duration) *resilience.Breaker {
+	return resilience.NewBreaker(resilience.BreakerConfig{
+		Name:             "test",
+		Timeout:          time.Second,
+		FailureThreshold: 2,
+		OpenDuration:     openDuration,
+	})
+}
+
+func fail(context.Context) error    { return errDependency }
+func succeed(context.Context) error { return nil }
+
+func TestBreaker_OpensAfterThreshold(t *testing.T) {
+	b := newBreaker(time.Minute)
+
+	assert.ErrorIs(t, b.Execute(context.Background(), fail), errDependency)
+	assert.Equal(t, resilience.StateClosed, b.State())
+	assert.ErrorIs(t, b.Execute(context.Background(), fail), errDependency)
+	assert.Equal(t, resilience.StateOpen, b.State())
+
+	called := false
+	err := b.Execute(context.Background(), func(context.Context) error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, resilience.ErrCircuitOpen)
+	assert.False(t, called)
+}
+
+func TestBreaker_SuccessResetsFailures(t *testing.T) {
+	b := newBreaker(time.Minute)
+
+	require.Error(t, b.Execute(context.Background(), fail))
+	require.NoError(t, b.Execute(context.Background(), succeed))
+	require.Error(t, b.Execute(context.Background(), fail))
+
+	assert.Equal(t, resilience.StateClosed, b.State())
+}
+
+func TestBreaker_HalfOpen(t *testing.T) {
+	t.Run("closes after a successful trial", func(t *testing.T) {
+		b := newBreaker(10 * time.Millisecond)
+		require.Error(t, b.Execute(context.Background(), fail))
+		require.Error(t, b.Execute(context.Background(), fail))
+
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(t, resilience.StateHalfOpen, b.State())
+
+		require.NoError(t, b.Execute(context.Background(), succeed))
+		assert.Equal(t, resilience.StateClosed, b.State())
+	})
+
+	t.Run("reopens after a failed trial", func(t *testing.T) {
+		b := newBreaker(10 * time.Millisecond)
+		require.Error(t, b.Execute(context.Background(), fail))
+		require.Error(t, b.Execute(context.Background(), fail))
+
+		time.Sleep(20 * time.Millisecond)
+		assert.ErrorIs(t, b.Execute(context.Background(), fail), errDependency)
+		assert.Equal(t, resilience.StateOpen, b.State())
+		assert.ErrorIs(t, b.Execute(context.Background(), succeed), resilience.ErrCircuitOpen)
+	})
+
+	t.Run("lets a single trial through", func(t *testing.T) {
+		b := newBreaker(10 * time.Millisecond)
+		require.Error(t, b.Execute(context.Background(), fail))
+		require.Error(t, b.Execute(context.Background(), fail))
+
+		time.Sleep(20 * time.Millisecond)
+
+		started := make(chan struct{})
+		finish := make(chan struct{})
+		done := make(chan error)
+		go func() {
+			done <- b.Execute(context.Background(), func(context.Context) error {
+				close(started)
+				<-finish
+				return nil
+			})
+		}()
+		<-started
+
+		assert.ErrorIs(t, b.Execute(context.Background(), succeed), resilience.ErrCircuitOpen)
+
+		close(finish)
+		require.NoError(t, <-done)
+		assert.Equal(t, resilience.StateClosed, b.State())
+	})
+}
+
+func TestBreaker_Trip(t *testing.T) {
+	b := newBreaker(10 * time.Millisecond)
+
+	b.Trip()
+	assert.Equal(t, resilience.StateOpen, b.State())
+	assert.ErrorIs(t, b.Execute(context.Background(), succeed), resilience.ErrCircuitOpen)
+
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, b.Execute(context.Background(), succeed))
+	assert.Equal(t, resilience.StateClosed, b.State())
+}
+
+func TestBreaker_CallerCancellationIsNotAFailure(t *testing.T) {
+	b := newBreaker(time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for range 3 {
+		err := b.Execute(ctx, func(ctx context.Context) error { return ctx.Err() })
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+
+	assert.Equal(t, resilience.StateClosed, b.State())
+}
+
+func TestBreaker_AppliesTimeout(t *testing.T) {
+	b := resilience.NewBreaker(resilience.BreakerConfig{
+		Name:             "test",
+		Timeout:          10 * time.Millisecond,
+		FailureThreshold: 1,
+		OpenDuration:     time.Minute,
+	})
+
+	err := b.Execute(context.Background(), func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, resilience.StateOpen, b.State())
+}
+
+func TestState_String(t *testing.T) {
+	assert.Equal(t, "closed", resilience.StateClosed.String())
+	assert.Equal(t, "half-open", resilience.StateHalfOpen.String())
+	assert.Equal(t, "open", resilience.StateOpen.String())
+}
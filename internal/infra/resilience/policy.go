@@ -0,0 +1,32 @@
+package resilience
+
+import "fmt"
+
+// FailurePolicy decides what a lookup answers when its dependency is
+// unavailable.
+type FailurePolicy string
+
+const (
+	// FailOpen answers as if the lookup found nothing to block on.
+	FailOpen FailurePolicy = "open"
+	// FailClosed rejects the operation.
+	FailClosed FailurePolicy = "closed"
+)
+
+func ParseFailurePolicy(s string) (FailurePolicy, error) {
+	switch p := FailurePolicy(s); p {
+	case FailOpen, FailClosed:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown failure policy %q, expected open or closed", s)
+	}
+}
+
+func (p *FailurePolicy) UnmarshalText(text []byte) error {
+	policy, err := ParseFailurePolicy(string(text))
+	if err != nil {
+		return err
+	}
+	*p = policy
+	return nil
+}
@@ -0,0 +1,30 @@
+package resilience_test
+
+import (
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/infra/resilience"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFailurePolicy(t *testing.T) {
+	p, err := resilience.ParseFailurePolicy("open")
+	require.NoError(t, err)
+	assert.Equal(t, resilience.FailOpen, p)
+
+	p, err = resilience.ParseFailurePolicy("closed")
+	require.NoError(t, err)
+	assert.Equal(t, resilience.FailClosed, p)
+
+	_, err = resilience.ParseFailurePolicy("sometimes")
+	assert.Error(t, err)
+}
+
+func TestFailurePolicy_UnmarshalText(t *testing.T) {
+	var p resilience.FailurePolicy
+	require.NoError(t, p.UnmarshalText([]byte("closed")))
+	assert.Equal(t, resilience.FailClosed, p)
+
+	assert.Error(t, p.UnmarshalText([]byte("")))
+}
@@ -0,0 +1,145 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const releaseTimeout = 5 * time.Second
+
+// Leader is a lock that at most one instance holds at a time.
+type Leader interface {
+	TryAcquire(ctx context.Context) (bool, error)
+	// Check returns an error once leadership has been lost.
+	Check(ctx context.Context) error
+	Release(ctx context.Context)
+}
+
+type Metrics interface {
+	JobSucceeded(job string, duration time.Duration, affected int64)
+	JobFailed(job string, duration time.Duration)
+	SetLeader(leader bool)
+}
+
+type Job struct {
+	Name     string
+	Interval time.Duration
+	// Run returns the number of rows it affected.
+	Run func(ctx context.Context) (int64, error)
+}
+
+// Scheduler runs jobs on the instance that holds the leader lock. The other
+// instances keep trying to take the lock every election interval, so a new
+// leader picks the jobs up when the current one stops or loses its
+// connection.
+type Scheduler struct {
+	leader           Leader
+	metrics          Metrics
+	electionInterval time.Duration
+	jobs             []Job
+}
+
+func New(leader Leader, metrics Metrics, electionInterval time.Duration, jobs ...Job) *Scheduler {
+	return &Scheduler{
+		leader:           leader,
+		metrics:          metrics,
+		electionInterval: electionInterval,
+		jobs:             jobs,
+	}
+}
+
+// Run blocks until ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.electionInterval)
+	defer ticker.Stop()
+
+	for {
+		acquired, err := s.leader.TryAcquire(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			slog.Warn("Failed to take scheduler leadership", "error", err)
+		case acquired:
+			s.lead(ctx, ticker)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) lead(ctx context.Context, ticker *time.Ticker) {
+	slog.Info("Became scheduler leader", "jobs", len(s.jobs))
+	s.metrics.SetLeader(true)
+
+	jobsCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	for _, job := range s.jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.schedule(jobsCtx, job)
+		}()
+	}
+
+	defer func() {
+		cancel()
+		wg.Wait()
+
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+		defer cancel()
+		s.leader.Release(releaseCtx)
+		s.metrics.SetLeader(false)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.leader.Check(ctx); err != nil {
+				if ctx.Err() == nil {
+					slog.Warn("Lost scheduler leadership", "error", err)
+				}
+				return
+			}
+		}
+	}
+}
+
+func (s *Scheduler) schedule(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.run(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	start := time.Now()
+	affected, err := job.Run(ctx)
+	duration := time.Since(start)
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		s.metrics.JobFailed(job.Name, duration)
+		slog.Warn("Scheduled job failed", "job", job.Name, "duration", duration, "error", err)
+		return
+	}
+
+	s.metrics.JobSucceeded(job.Name, duration, affected)
+	slog.Info("Scheduled job finished", "job", job.Name, "affected", affected, "duration", duration)
+}
@@ -0,0 +1,225 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/infra/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLeader is a lock shared by several schedulers of the same test.
+type fakeLeader struct {
+	mu     *sync.Mutex
+	holder *string
+	id     string
+	lost   bool
+}
+
+func (l *fakeLeader) TryAcquire(context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if *l.holder == "" {
+		*l.holder = l.id
+	}
+	return *l.holder == l.id, nil
+}
+
+func (l *fakeLeader) Check(context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.lost {
+		*l.holder = ""
+		return errors.New("connection lost")
+	}
+	return nil
+}
+
+func (l *fakeLeader) Release(context.Context) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if *l.holder == l.id {
+		*l.holder = ""
+	}
+}
+
+func (l *fakeLeader) loseConnection() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lost = true
+}
+
+type fakeMetrics struct {
+	mu        sync.Mutex
+	succeeded map[string]int64
+	failed    map[string]int
+	leader    bool
+}
+
+func newFakeMetrics() *fakeMetrics {
+	return &fakeMetrics{succeeded: map[string]int64{}, failed: map[string]int{}}
+}
+
+func (m *fakeMetrics) JobSucceeded(job string, _ time.Duration, affected int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.succeeded[job] += affected
+}
+
+func (m *fakeMetrics) JobFailed(job string, _ time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failed[job]++
+}
+
+func (m *fakeMetrics) SetLeader(leader bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.leader = leader
+}
+
+func (m *fakeMetrics) snapshot() (map[string]int64, map[string]int, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	succeeded := map[string]int64{}
+	for k, v := range m.succeeded {
+		succeeded[k] = v
+	}
+	failed := map[string]int{}
+	for k, v := range m.failed {
+		failed[k] = v
+	}
+	return succeeded, failed, m.leader
+}
+
+func TestScheduler_OnlyLeaderRunsJobs(t *testing.T) {
+	var mu sync.Mutex
+	holder := ""
+	runs := map[string]int{}
+
+	newScheduler := func(id string, metrics *fakeMetrics) *scheduler.Scheduler {
+		return scheduler.New(&fakeLeader{mu: &mu, holder: &holder, id: id}, metrics, 5*time.Millisecond, scheduler.Job{
+			Name:     "cleanup",
+			Interval: 5 * time.Millisecond,
+			Run: func(context.Context) (int64, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				runs[id]++
+				return 1, nil
+			},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	first, second := newFakeMetrics(), newFakeMetrics()
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); newScheduler("a", first).Run(ctx) }()
+	go func() { defer wg.Done(); newScheduler("b", second).Run(ctx) }()
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, runs, 1, "jobs ran on more than one instance: %v", runs)
+	assert.Empty(t, holder, "leadership was not released on shutdown")
+
+	firstSucceeded, _, firstLeader := first.snapshot()
+	secondSucceeded, _, secondLeader := second.snapshot()
+	assert.Positive(t, firstSucceeded["cleanup"]+secondSucceeded["cleanup"])
+	assert.False(t, firstLeader)
+	assert.False(t, secondLeader)
+}
+
+func TestScheduler_HandsOverWhenLeadershipIsLost(t *testing.T) {
+	var mu sync.Mutex
+	holder := ""
+	ran := make(chan string, 100)
+
+	job := func(id string) scheduler.Job {
+		return scheduler.Job{
+			Name:     "cleanup",
+			Interval: time.Millisecond,
+			Run: func(context.Context) (int64, error) {
+				ran <- id
+				return 0, nil
+			},
+		}
+	}
+
+	leaderA := &fakeLeader{mu: &mu, holder: &holder, id: "a"}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	doneA := make(chan struct{})
+	go func() {
+		defer close(doneA)
+		scheduler.New(leaderA, newFakeMetrics(), 5*time.Millisecond, job("a")).Run(ctx)
+	}()
+	require.Equal(t, "a", <-ran)
+
+	leaderA.loseConnection()
+	go scheduler.New(&fakeLeader{mu: &mu, holder: &holder, id: "b"}, newFakeMetrics(), 5*time.Millisecond, job("b")).Run(ctx)
+
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case id := <-ran:
+			if id == "b" {
+				cancel()
+				<-doneA
+				return
+			}
+		case <-deadline:
+			t.Fatal("second instance never took over")
+		}
+	}
+}
+
+func TestScheduler_ReportsFailures(t *testing.T) {
+	var mu sync.Mutex
+	holder := ""
+	metrics := newFakeMetrics()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := scheduler.New(&fakeLeader{mu: &mu, holder: &holder, id: "a"}, metrics, time.Minute, scheduler.Job{
+		Name:     "cleanup",
+		Interval: time.Minute,
+		Run: func(context.Context) (int64, error) {
+			cancel()
+			return 0, errors.New("database error")
+		},
+	})
+	s.Run(ctx)
+
+	_, failed, _ := metrics.snapshot()
+	assert.Empty(t, failed, "failures caused by shutdown should not be reported")
+
+	ctx, cancel = context.WithCancel(context.Background())
+	done := make(chan struct{})
+	s = scheduler.New(&fakeLeader{mu: &mu, holder: &holder, id: "a"}, metrics, time.Minute, scheduler.Job{
+		Name:     "cleanup",
+		Interval: time.Minute,
+		Run: func(context.Context) (int64, error) {
+			defer close(done)
+			return 0, errors.New("database error")
+		},
+	})
+	go s.Run(ctx)
+	<-done
+	cancel()
+
+	require.Eventually(t, func() bool {
+		_, failed, _ := metrics.snapshot()
+		return failed["cleanup"] == 1
+	}, time.Second, time.Millisecond)
+}
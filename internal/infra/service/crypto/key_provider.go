@@ -0,0 +1,177 @@
+package crypto
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	KeyProviderLocal = "local"
+	KeyProviderVault = "vault"
+)
+
+var ErrInvalidWrappedKey = errors.New("invalid wrapped encryption key, expected <id>:<wrapped key>")
+
+// KeyProvider wraps and unwraps data keys with a master key that it keeps to
+// itself, so only wrapped data keys have to be stored in configuration.
+type KeyProvider interface {
+	WrapKey(ctx context.Context, plaintext []byte) (string, error)
+	UnwrapKey(ctx context.Context, wrapped string) ([]byte, error)
+}
+
+// WrappedKey is a data key as stored in configuration, encrypted by a
+// KeyProvider. The ID is stored in front of every ciphertext it seals.
+type WrappedKey struct {
+	ID      string
+	Wrapped string
+}
+
+// ParseWrappedKey parses the "<id>:<wrapped>" form used in configuration.
+func ParseWrappedKey(s string) (WrappedKey, error) {
+	id, wrapped, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok || wrapped == "" {
+		return WrappedKey{}, ErrInvalidWrappedKey
+	}
+	if !keyIDPattern.MatchString(id) {
+		return WrappedKey{}, ErrInvalidKeyID
+	}
+
+	return WrappedKey{ID: id, Wrapped: wrapped}, nil
+}
+
+func ParseWrappedKeys(specs []string) ([]WrappedKey, error) {
+	keys := make([]WrappedKey, 0, len(specs))
+	for i, spec := range specs {
+		key, err := ParseWrappedKey(spec)
+		if err != nil {
+			return nil, fmt.Errorf("key #%d: %w", i+1, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// NewDataKey generates a random 256-bit data key and returns it wrapped by
+// provider, in the "<id>:<wrapped>" form.
+func NewDataKey(ctx context.Context, provider KeyProvider, id string) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return WrapDataKey(ctx, provider, id, secret)
+}
+
+// WrapDataKey wraps an existing plaintext key, e.g. one configured before
+// envelope encryption, so values it sealed stay readable.
+func WrapDataKey(ctx context.Context, provider KeyProvider, id string, secret []byte) (string, error) {
+	if !keyIDPattern.MatchString(id) {
+		return "", ErrInvalidKeyID
+	}
+	if n := len(secret); n != 16 && n != 24 && n != 32 {
+		return "", ErrInvalidKey
+	}
+
+	wrapped, err := provider.WrapKey(ctx, secret)
+	if err != nil {
+		return "", err
+	}
+
+	return id + ":" + wrapped, nil
+}
+
+// dataKeyCache unwraps data keys on demand and keeps them in memory for ttl.
+// Concurrent misses for the same key share a single provider call. While the
+// provider fails, an expired key is still served for staleGrace, so a short
+// provider outage does not break every redirect once the TTL runs out.
+type dataKeyCache struct {
+	provider   KeyProvider
+	wrapped    map[string]string
+	ttl        time.Duration
+	staleGrace time.Duration
+
+	mu      sync.RWMutex
+	entries map[string]cachedDataKey
+	group   singleflight.Group
+	stale   atomic.Uint64
+}
+
+type cachedDataKey struct {
+	aead    cipher.AEAD
+	expires time.Time
+}
+
+func newDataKeyCache(provider KeyProvider, wrapped map[string]string, ttl, staleGrace time.Duration) *dataKeyCache {
+	return &dataKeyCache{
+		provider:   provider,
+		wrapped:    wrapped,
+		ttl:        ttl,
+		staleGrace: staleGrace,
+		entries:    make(map[string]cachedDataKey, len(wrapped)),
+	}
+}
+
+func (c *dataKeyCache) has(id string) bool {
+	_, ok := c.wrapped[id]
+	return ok
+}
+
+func (c *dataKeyCache) aead(ctx context.Context, id string) (cipher.AEAD, error) {
+	wrapped, ok := c.wrapped[id]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+
+	c.mu.RLock()
+	entry, ok := c.entries[id]
+	c.mu.RUnlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.aead, nil
+	}
+
+	v, err, _ := c.group.Do(id, func() (any, error) {
+		// Detached from the caller: other requests may be waiting on this call.
+		ctx, span := tracer.Start(context.WithoutCancel(ctx), "KeyProvider.UnwrapKey",
+			trace.WithAttributes(attribute.String("crypto.key_id", id)))
+		defer span.End()
+
+		secret, err := c.provider.UnwrapKey(ctx, wrapped)
+		if err != nil {
+			recordError(span, err)
+			return nil, fmt.Errorf("unwrap encryption key %q: %w", id, err)
+		}
+
+		aead, err := newAEAD(secret)
+		if err != nil {
+			recordError(span, err)
+			return nil, fmt.Errorf("encryption key %q: %w", id, err)
+		}
+
+		c.mu.Lock()
+		c.entries[id] = cachedDataKey{aead: aead, expires: time.Now().Add(c.ttl)}
+		c.mu.Unlock()
+
+		return aead, nil
+	})
+	if err != nil {
+		if ok && time.Now().Before(entry.expires.Add(c.staleGrace)) {
+			c.stale.Add(1)
+			slog.WarnContext(ctx, "Key provider unavailable, using an expired data key", "key_id", id, "expired_at", entry.expires, "error", err)
+			return entry.aead, nil
+		}
+		return nil, err
+	}
+
+	return v.(cipher.AEAD), nil
+}
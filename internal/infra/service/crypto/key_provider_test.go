@@ -0,0 +1,239 @@
+package crypto_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEnvelopeEncrypter(t *testing.T, provider crypto.KeyProvider, ttl time.Duration, ids ...string) *crypto.URLEncrypter {
+	t.Helper()
+
+	keys := make([]crypto.WrappedKey, 0, len(ids))
+	for _, id := range ids {
+		spec, err := crypto.NewDataKey(context.Background(), provider, id)
+		require.NoError(t, err)
+		key, err := crypto.ParseWrappedKey(spec)
+		require.NoError(t, err)
+		keys = append(keys, key)
+	}
+
+	keyring, err := crypto.NewEnvelopeKeyring(provider, ids[len(ids)-1], keys, ttl, 0, "")
+	require.NoError(t, err)
+	return crypto.NewURLEncrypter(keyring)
+}
+
+func TestParseWrappedKey(t *testing.T) {
+	t.Run("should split on the first colon", func(t *testing.T) {
+		key, err := crypto.ParseWrappedKey("k1:vault:v1:abc")
+
+		require.NoError(t, err)
+		assert.Equal(t, crypto.WrappedKey{ID: "k1", Wrapped: "vault:v1:abc"}, key)
+	})
+
+	t.Run("should reject malformed keys", func(t *testing.T) {
+		for _, spec := range []string{"k1", "k1:", ""} {
+			_, err := crypto.ParseWrappedKey(spec)
+			assert.ErrorIs(t, err, crypto.ErrInvalidWrappedKey, spec)
+		}
+
+		_, err := crypto.ParseWrappedKey("bad id:abc")
+		assert.ErrorIs(t, err, crypto.ErrInvalidKeyID)
+	})
+}
+
+func TestWrapDataKey_Errors(t *testing.T) {
+	ctx := context.Background()
+	provider := crypto.NewMemoryKeyProvider()
+
+	_, err := crypto.WrapDataKey(ctx, provider, "bad id", make([]byte, 32))
+	assert.ErrorIs(t, err, crypto.ErrInvalidKeyID)
+
+	_, err = crypto.WrapDataKey(ctx, provider, "k1", make([]byte, 10))
+	assert.ErrorIs(t, err, crypto.ErrInvalidKey)
+}
+
+func TestEnvelopeKeyring_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	encrypter := newEnvelopeEncrypter(t, crypto.NewMemoryKeyProvider(), time.Hour, "k1", "k2")
+
+	encrypted, err := encrypter.Encrypt(ctx, "https://example.com")
+	require.NoError(t, err)
+	assert.False(t, encrypter.NeedsReencryption(encrypted))
+
+	decrypted, err := encrypter.Decrypt(ctx, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", decrypted)
+}
+
+func TestEnvelopeKeyring_ImportedKeyReadsExistingCiphertexts(t *testing.T) {
+	ctx := context.Background()
+	raw := crypto.Key{ID: "k1", Secret: []byte("0123456789abcdef0123456789abcdef")}
+
+	plainKeyring, err := crypto.NewKeyring(raw.ID, []crypto.Key{raw}, "")
+	require.NoError(t, err)
+	encrypted, err := crypto.NewURLEncrypter(plainKeyring).Encrypt(ctx, "https://example.com")
+	require.NoError(t, err)
+
+	provider := crypto.NewMemoryKeyProvider()
+	spec, err := crypto.WrapDataKey(ctx, provider, raw.ID, raw.Secret)
+	require.NoError(t, err)
+	key, err := crypto.ParseWrappedKey(spec)
+	require.NoError(t, err)
+	envelopeKeyring, err := crypto.NewEnvelopeKeyring(provider, raw.ID, []crypto.WrappedKey{key}, time.Hour, 0, "")
+	require.NoError(t, err)
+
+	decrypted, err := crypto.NewURLEncrypter(envelopeKeyring).Decrypt(ctx, encrypted)
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", decrypted)
+}
+
+func TestEnvelopeKeyring_CachesUnwrappedKeys(t *testing.T) {
+	ctx := context.Background()
+	provider := crypto.NewMemoryKeyProvider()
+	encrypter := newEnvelopeEncrypter(t, provider, time.Hour, "k1")
+
+	encrypted, err := encrypter.Encrypt(ctx, "https://example.com")
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := encrypter.Decrypt(ctx, encrypted)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, provider.Unwraps())
+}
+
+func TestEnvelopeKeyring_RefreshesExpiredKeys(t *testing.T) {
+	ctx := context.Background()
+	provider := crypto.NewMemoryKeyProvider()
+	encrypter := newEnvelopeEncrypter(t, provider, time.Millisecond, "k1")
+
+	encrypted, err := encrypter.Encrypt(ctx, "https://example.com")
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+
+	_, err = encrypter.Decrypt(ctx, encrypted)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, provider.Unwraps())
+}
+
+type flakyKeyProvider struct {
+	crypto.KeyProvider
+	failing atomic.Bool
+}
+
+func (p *flakyKeyProvider) UnwrapKey(ctx context.Context, wrapped string) ([]byte, error) {
+	if p.failing.Load() {
+		return nil, errors.New("provider unavailable")
+	}
+	return p.KeyProvider.UnwrapKey(ctx, wrapped)
+}
+
+func TestEnvelopeKeyring_ServesExpiredKeysDuringGrace(t *testing.T) {
+	ctx := context.Background()
+	provider := &flakyKeyProvider{KeyProvider: crypto.NewMemoryKeyProvider()}
+	spec, err := crypto.NewDataKey(ctx, provider, "k1")
+	require.NoError(t, err)
+	key, err := crypto.ParseWrappedKey(spec)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		staleGrace time.Duration
+		served     bool
+	}{
+		{"within the grace period", time.Hour, true},
+		{"without a grace period", 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider.failing.Store(false)
+			keyring, err := crypto.NewEnvelopeKeyring(provider, "k1", []crypto.WrappedKey{key}, time.Millisecond, tc.staleGrace, "")
+			require.NoError(t, err)
+			encrypter := crypto.NewURLEncrypter(keyring)
+
+			encrypted, err := encrypter.Encrypt(ctx, "https://example.com")
+			require.NoError(t, err)
+			provider.failing.Store(true)
+			time.Sleep(5 * time.Millisecond)
+
+			decrypted, err := encrypter.Decrypt(ctx, encrypted)
+
+			if tc.served {
+				require.NoError(t, err)
+				assert.Equal(t, "https://example.com", decrypted)
+				assert.Equal(t, uint64(1), keyring.StaleKeyUses())
+			} else {
+				assert.ErrorContains(t, err, "provider unavailable")
+				assert.Zero(t, keyring.StaleKeyUses())
+			}
+		})
+	}
+}
+
+func TestEnvelopeKeyring_GraceRunsOut(t *testing.T) {
+	ctx := context.Background()
+	provider := &flakyKeyProvider{KeyProvider: crypto.NewMemoryKeyProvider()}
+	spec, err := crypto.NewDataKey(ctx, provider, "k1")
+	require.NoError(t, err)
+	key, err := crypto.ParseWrappedKey(spec)
+	require.NoError(t, err)
+
+	keyring, err := crypto.NewEnvelopeKeyring(provider, "k1", []crypto.WrappedKey{key}, time.Millisecond, time.Millisecond, "")
+	require.NoError(t, err)
+	encrypter := crypto.NewURLEncrypter(keyring)
+
+	encrypted, err := encrypter.Encrypt(ctx, "https://example.com")
+	require.NoError(t, err)
+	provider.failing.Store(true)
+	time.Sleep(5 * time.Millisecond)
+
+	_, err = encrypter.Decrypt(ctx, encrypted)
+
+	assert.ErrorContains(t, err, "provider unavailable")
+}
+
+type failingKeyProvider struct{ crypto.KeyProvider }
+
+func (failingKeyProvider) UnwrapKey(context.Context, string) ([]byte, error) {
+	return nil, errors.New("provider unavailable")
+}
+
+func TestEnvelopeKeyring_Errors(t *testing.T) {
+	ctx := context.Background()
+	key := crypto.WrappedKey{ID: "k1", Wrapped: "wrapped"}
+
+	t.Run("should require the active key to be present", func(t *testing.T) {
+		_, err := crypto.NewEnvelopeKeyring(crypto.NewMemoryKeyProvider(), "k2", []crypto.WrappedKey{key}, time.Hour, 0, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject duplicate ids", func(t *testing.T) {
+		_, err := crypto.NewEnvelopeKeyring(crypto.NewMemoryKeyProvider(), "k1", []crypto.WrappedKey{key, key}, time.Hour, 0, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("should report provider failures on preload", func(t *testing.T) {
+		keyring, err := crypto.NewEnvelopeKeyring(failingKeyProvider{}, "k1", []crypto.WrappedKey{key}, time.Hour, 0, "")
+		require.NoError(t, err)
+
+		assert.ErrorContains(t, keyring.Preload(ctx), "provider unavailable")
+	})
+}
@@ -0,0 +1,252 @@
+package crypto
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidKey        = errors.New("invalid encryption key, expected <id>:<base64 of 16, 24 or 32 bytes>")
+	ErrInvalidKeyID      = errors.New("invalid key id, expected 1 to 32 letters, digits, '-' or '_'")
+	ErrUnknownKey        = errors.New("ciphertext was encrypted with a key that is not in the keyring")
+	ErrInvalidCiphertext = errors.New("invalid ciphertext")
+)
+
+var keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// Key is a named AES key. The ID is stored in front of every ciphertext so
+// the matching key can be found after rotation.
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+// ParseKey parses the "<id>:<base64>" form used in configuration.
+func ParseKey(s string) (Key, error) {
+	id, encoded, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return Key{}, ErrInvalidKey
+	}
+	if !keyIDPattern.MatchString(id) {
+		return Key{}, ErrInvalidKeyID
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return Key{}, ErrInvalidKey
+	}
+	if n := len(secret); n != 16 && n != 24 && n != 32 {
+		return Key{}, ErrInvalidKey
+	}
+
+	return Key{ID: id, Secret: secret}, nil
+}
+
+func ParseKeys(specs []string) ([]Key, error) {
+	keys := make([]Key, 0, len(specs))
+	for i, spec := range specs {
+		key, err := ParseKey(spec)
+		if err != nil {
+			return nil, fmt.Errorf("key #%d: %w", i+1, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// GenerateKey returns a random 256-bit key in the "<id>:<base64>" form.
+func GenerateKey(id string) (string, error) {
+	if !keyIDPattern.MatchString(id) {
+		return "", ErrInvalidKeyID
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return id + ":" + base64.StdEncoding.EncodeToString(secret), nil
+}
+
+// Keyring seals with the active key and opens with whichever key the
+// ciphertext names. A legacy AES-CTR secret can be configured to read values
+// written before ciphertexts were versioned.
+type Keyring struct {
+	active string
+	keys   keySource
+	legacy cipher.Block
+}
+
+type keySource interface {
+	aead(ctx context.Context, id string) (cipher.AEAD, error)
+	has(id string) bool
+}
+
+type staticKeys map[string]cipher.AEAD
+
+func (s staticKeys) aead(_ context.Context, id string) (cipher.AEAD, error) {
+	aead, ok := s[id]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return aead, nil
+}
+
+func (s staticKeys) has(id string) bool {
+	_, ok := s[id]
+	return ok
+}
+
+// NewKeyring builds a keyring from plaintext keys.
+func NewKeyring(activeID string, keys []Key, legacySecret string) (*Keyring, error) {
+	static := make(staticKeys, len(keys))
+	for _, key := range keys {
+		if _, dup := static[key.ID]; dup {
+			return nil, fmt.Errorf("duplicate encryption key id %q", key.ID)
+		}
+
+		aead, err := newAEAD(key.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %q: %w", key.ID, err)
+		}
+		static[key.ID] = aead
+	}
+
+	return newKeyring(activeID, static, legacySecret)
+}
+
+// NewEnvelopeKeyring builds a keyring from data keys wrapped by provider.
+// Data keys are unwrapped on first use and cached for cacheTTL, so the
+// provider is only called again once an entry expires; if that call fails,
+// the expired key keeps being used for up to staleGrace.
+func NewEnvelopeKeyring(provider KeyProvider, activeID string, keys []WrappedKey, cacheTTL, staleGrace time.Duration, legacySecret string) (*Keyring, error) {
+	wrapped := make(map[string]string, len(keys))
+	for _, key := range keys {
+		if _, dup := wrapped[key.ID]; dup {
+			return nil, fmt.Errorf("duplicate encryption key id %q", key.ID)
+		}
+		wrapped[key.ID] = key.Wrapped
+	}
+
+	return newKeyring(activeID, newDataKeyCache(provider, wrapped, cacheTTL, staleGrace), legacySecret)
+}
+
+func newKeyring(activeID string, keys keySource, legacySecret string) (*Keyring, error) {
+	if !keys.has(activeID) {
+		return nil, fmt.Errorf("active encryption key %q is not in the keyring", activeID)
+	}
+
+	k := &Keyring{active: activeID, keys: keys}
+	if legacySecret != "" {
+		block, err := aes.NewCipher([]byte(legacySecret))
+		if err != nil {
+			return nil, fmt.Errorf("legacy secret: %w", err)
+		}
+		k.legacy = block
+	}
+
+	return k, nil
+}
+
+func newAEAD(secret []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(secret)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (k *Keyring) ActiveKeyID() string {
+	return k.active
+}
+
+// StaleKeyUses counts the data keys used past their cache TTL because the
+// key provider could not unwrap them again.
+func (k *Keyring) StaleKeyUses() uint64 {
+	if c, ok := k.keys.(*dataKeyCache); ok {
+		return c.stale.Load()
+	}
+	return 0
+}
+
+// Preload resolves the active key so a misconfigured key provider is
+// reported at startup instead of on the first request.
+func (k *Keyring) Preload(ctx context.Context) error {
+	_, err := k.keys.aead(ctx, k.active)
+	return err
+}
+
+// Seal encrypts with the active key, returning "<key id>:<base64url(nonce|ciphertext)>".
+// The key ID is authenticated as additional data.
+func (k *Keyring) Seal(ctx context.Context, plaintext []byte) (string, error) {
+	aead, err := k.keys.aead(ctx, k.active)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(k.active))
+	return k.active + ":" + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+func (k *Keyring) Open(ctx context.Context, ciphertext string) ([]byte, error) {
+	id, encoded, versioned := strings.Cut(ciphertext, ":")
+	if !versioned {
+		return k.openLegacy(ciphertext)
+	}
+
+	aead, err := k.keys.aead(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrInvalidCiphertext
+	}
+
+	nonce, sealed := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, []byte(id))
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+
+	return plaintext, nil
+}
+
+// IsActive reports whether the ciphertext was sealed with the active key.
+func (k *Keyring) IsActive(ciphertext string) bool {
+	return strings.HasPrefix(ciphertext, k.active+":")
+}
+
+// openLegacy reads the unversioned hex(iv|AES-CTR) format. It is not
+// authenticated, so a wrong secret yields garbage rather than an error.
+func (k *Keyring) openLegacy(ciphertext string) ([]byte, error) {
+	if k.legacy == nil {
+		return nil, ErrUnknownKey
+	}
+
+	raw, err := hex.DecodeString(ciphertext)
+	if err != nil || len(raw) < aes.BlockSize {
+		return nil, ErrInvalidCiphertext
+	}
+
+	iv, raw := raw[:aes.BlockSize], raw[aes.BlockSize:]
+	plaintext := make([]byte, len(raw))
+	cipher.NewCTR(k.legacy, iv).XORKeyStream(plaintext, raw)
+
+	return plaintext, nil
+}
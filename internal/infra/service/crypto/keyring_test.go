@@ -0,0 +1,166 @@
+package crypto_test
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	oldKey = crypto.Key{ID: "2025", Secret: []byte("0123456789abcdef0123456789abcdef")}
+	newKey = crypto.Key{ID: "2026", Secret: []byte("fedcba9876543210fedcba9876543210")}
+)
+
+func TestParseKey(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef"))
+
+	t.Run("should parse id and base64 secret", func(t *testing.T) {
+		key, err := crypto.ParseKey(" v1:" + secret + " ")
+
+		require.NoError(t, err)
+		assert.Equal(t, "v1", key.ID)
+		assert.Equal(t, []byte("0123456789abcdef"), key.Secret)
+	})
+
+	t.Run("should reject malformed keys", func(t *testing.T) {
+		for _, spec := range []string{
+			secret,
+			"v1:not-base64!",
+			"v1:" + base64.StdEncoding.EncodeToString([]byte("short")),
+		} {
+			_, err := crypto.ParseKey(spec)
+			assert.ErrorIs(t, err, crypto.ErrInvalidKey, spec)
+		}
+	})
+
+	t.Run("should reject invalid ids", func(t *testing.T) {
+		_, err := crypto.ParseKey("bad id:" + secret)
+
+		assert.ErrorIs(t, err, crypto.ErrInvalidKeyID)
+	})
+}
+
+func TestGenerateKey(t *testing.T) {
+	spec, err := crypto.GenerateKey("k9")
+	require.NoError(t, err)
+
+	key, err := crypto.ParseKey(spec)
+	require.NoError(t, err)
+	assert.Equal(t, "k9", key.ID)
+	assert.Len(t, key.Secret, 32)
+}
+
+func TestNewKeyring_Errors(t *testing.T) {
+	t.Run("should require the active key to be present", func(t *testing.T) {
+		_, err := crypto.NewKeyring("missing", []crypto.Key{oldKey}, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject duplicate ids", func(t *testing.T) {
+		_, err := crypto.NewKeyring(oldKey.ID, []crypto.Key{oldKey, oldKey}, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject an invalid legacy secret", func(t *testing.T) {
+		_, err := crypto.NewKeyring(oldKey.ID, []crypto.Key{oldKey}, "short")
+		assert.Error(t, err)
+	})
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	ctx := context.Background()
+
+	before, err := crypto.NewKeyring(oldKey.ID, []crypto.Key{oldKey}, "")
+	require.NoError(t, err)
+	after, err := crypto.NewKeyring(newKey.ID, []crypto.Key{oldKey, newKey}, "")
+	require.NoError(t, err)
+
+	oldEncrypter := crypto.NewURLEncrypter(before)
+	newEncrypter := crypto.NewURLEncrypter(after)
+
+	encrypted, err := oldEncrypter.Encrypt(ctx, "https://example.com")
+	require.NoError(t, err)
+
+	assert.True(t, newEncrypter.NeedsReencryption(encrypted))
+
+	decrypted, err := newEncrypter.Decrypt(ctx, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", decrypted)
+
+	reencrypted, err := newEncrypter.Encrypt(ctx, decrypted)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(reencrypted, newKey.ID+":"))
+	assert.False(t, newEncrypter.NeedsReencryption(reencrypted))
+
+	// Once a key is removed from the keyring its ciphertexts can no longer be read
+	_, err = oldEncrypter.Decrypt(ctx, reencrypted)
+	assert.ErrorIs(t, err, crypto.ErrUnknownKey)
+}
+
+func TestKeyring_KeyIDIsAuthenticated(t *testing.T) {
+	ctx := context.Background()
+
+	keyring, err := crypto.NewKeyring(newKey.ID, []crypto.Key{{ID: oldKey.ID, Secret: newKey.Secret}, newKey}, "")
+	require.NoError(t, err)
+	encrypter := crypto.NewURLEncrypter(keyring)
+
+	encrypted, err := encrypter.Encrypt(ctx, "https://example.com")
+	require.NoError(t, err)
+
+	// Same secret under another id: relabelling the ciphertext must fail
+	relabelled := oldKey.ID + strings.TrimPrefix(encrypted, newKey.ID)
+	_, err = encrypter.Decrypt(ctx, relabelled)
+
+	assert.ErrorIs(t, err, crypto.ErrInvalidCiphertext)
+}
+
+func TestKeyring_LegacyCiphertext(t *testing.T) {
+	ctx := context.Background()
+	legacySecret := "12345678901234567890123456789012"
+
+	// hex(iv|AES-CTR) as written before ciphertexts were versioned
+	block, err := aes.NewCipher([]byte(legacySecret))
+	require.NoError(t, err)
+	plain := []byte("https://example.com/legacy")
+	raw := make([]byte, aes.BlockSize+len(plain))
+	cipher.NewCTR(block, raw[:aes.BlockSize]).XORKeyStream(raw[aes.BlockSize:], plain)
+	legacy := hex.EncodeToString(raw)
+
+	t.Run("should decrypt with the legacy secret", func(t *testing.T) {
+		keyring, err := crypto.NewKeyring(newKey.ID, []crypto.Key{newKey}, legacySecret)
+		require.NoError(t, err)
+		encrypter := crypto.NewURLEncrypter(keyring)
+
+		decrypted, err := encrypter.Decrypt(ctx, legacy)
+
+		require.NoError(t, err)
+		assert.Equal(t, string(plain), decrypted)
+		assert.True(t, encrypter.NeedsReencryption(legacy))
+	})
+
+	t.Run("should fail without a legacy secret", func(t *testing.T) {
+		keyring, err := crypto.NewKeyring(newKey.ID, []crypto.Key{newKey}, "")
+		require.NoError(t, err)
+
+		_, err = crypto.NewURLEncrypter(keyring).Decrypt(ctx, legacy)
+
+		assert.ErrorIs(t, err, crypto.ErrUnknownKey)
+	})
+
+	t.Run("should reject ciphertext shorter than a block instead of panicking", func(t *testing.T) {
+		keyring, err := crypto.NewKeyring(newKey.ID, []crypto.Key{newKey}, legacySecret)
+		require.NoError(t, err)
+
+		_, err = crypto.NewURLEncrypter(keyring).Decrypt(ctx, "0123456789abcdef")
+
+		assert.ErrorIs(t, err, crypto.ErrInvalidCiphertext)
+	})
+}
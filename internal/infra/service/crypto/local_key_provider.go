@@ -0,0 +1,59 @@
+package crypto
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LocalKeyProvider wraps data keys with master keys read from a file, one
+// "<id>:<base64>" key per line (blank lines and # comments are ignored). The
+// first key wraps new data keys; the others can still unwrap, which allows
+// the master key to be rotated.
+type LocalKeyProvider struct {
+	keyring *Keyring
+}
+
+func NewLocalKeyProvider(path string) (*LocalKeyProvider, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var specs []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" && !strings.HasPrefix(line, "#") {
+			specs = append(specs, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("master key file %s: no keys found", path)
+	}
+
+	keys, err := ParseKeys(specs)
+	if err != nil {
+		return nil, fmt.Errorf("master key file %s: %w", path, err)
+	}
+
+	keyring, err := NewKeyring(keys[0].ID, keys, "")
+	if err != nil {
+		return nil, fmt.Errorf("master key file %s: %w", path, err)
+	}
+
+	return &LocalKeyProvider{keyring}, nil
+}
+
+func (p *LocalKeyProvider) WrapKey(ctx context.Context, plaintext []byte) (string, error) {
+	return p.keyring.Seal(ctx, plaintext)
+}
+
+func (p *LocalKeyProvider) UnwrapKey(ctx context.Context, wrapped string) ([]byte, error) {
+	return p.keyring.Open(ctx, wrapped)
+}
@@ -0,0 +1,76 @@
+package crypto_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeMasterKeys(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "master.keys")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLocalKeyProvider_WrapUnwrap(t *testing.T) {
+	ctx := context.Background()
+	masterKey, err := crypto.GenerateKey("m1")
+	require.NoError(t, err)
+
+	provider, err := crypto.NewLocalKeyProvider(writeMasterKeys(t, "# comment\n\n"+masterKey+"\n"))
+	require.NoError(t, err)
+
+	wrapped, err := provider.WrapKey(ctx, []byte("data key"))
+	require.NoError(t, err)
+	assert.NotContains(t, wrapped, "data key")
+
+	unwrapped, err := provider.UnwrapKey(ctx, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data key"), unwrapped)
+}
+
+func TestLocalKeyProvider_Rotation(t *testing.T) {
+	ctx := context.Background()
+	oldMaster, err := crypto.GenerateKey("m1")
+	require.NoError(t, err)
+	newMaster, err := crypto.GenerateKey("m2")
+	require.NoError(t, err)
+
+	before, err := crypto.NewLocalKeyProvider(writeMasterKeys(t, oldMaster))
+	require.NoError(t, err)
+	wrapped, err := before.WrapKey(ctx, []byte("data key"))
+	require.NoError(t, err)
+
+	after, err := crypto.NewLocalKeyProvider(writeMasterKeys(t, newMaster+"\n"+oldMaster))
+	require.NoError(t, err)
+
+	unwrapped, err := after.UnwrapKey(ctx, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data key"), unwrapped)
+
+	rewrapped, err := after.WrapKey(ctx, unwrapped)
+	require.NoError(t, err)
+	assert.Contains(t, rewrapped, "m2:")
+}
+
+func TestNewLocalKeyProvider_Errors(t *testing.T) {
+	t.Run("should fail when the file does not exist", func(t *testing.T) {
+		_, err := crypto.NewLocalKeyProvider(filepath.Join(t.TempDir(), "missing.keys"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("should fail when the file has no keys", func(t *testing.T) {
+		_, err := crypto.NewLocalKeyProvider(writeMasterKeys(t, "# nothing here\n"))
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail on malformed keys", func(t *testing.T) {
+		_, err := crypto.NewLocalKeyProvider(writeMasterKeys(t, "m1:not-base64!"))
+		assert.ErrorIs(t, err, crypto.ErrInvalidKey)
+	})
+}
@@ -0,0 +1,43 @@
+package crypto
+
+import (
+	"context"
+	"crypto/rand"
+	"sync/atomic"
+)
+
+// MemoryKeyProvider is a KeyProvider backed by a random master key that only
+// lives in memory. It is meant for tests and counts unwrap calls so caching
+// can be asserted.
+type MemoryKeyProvider struct {
+	keyring *Keyring
+	unwraps atomic.Int64
+}
+
+func NewMemoryKeyProvider() *MemoryKeyProvider {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic(err)
+	}
+
+	keyring, err := NewKeyring("memory", []Key{{ID: "memory", Secret: secret}}, "")
+	if err != nil {
+		panic(err)
+	}
+
+	return &MemoryKeyProvider{keyring: keyring}
+}
+
+func (p *MemoryKeyProvider) WrapKey(ctx context.Context, plaintext []byte) (string, error) {
+	return p.keyring.Seal(ctx, plaintext)
+}
+
+func (p *MemoryKeyProvider) UnwrapKey(ctx context.Context, wrapped string) ([]byte, error) {
+	p.unwraps.Add(1)
+	return p.keyring.Open(ctx, wrapped)
+}
+
+// Unwraps returns how many times UnwrapKey has been called.
+func (p *MemoryKeyProvider) Unwraps() int {
+	return int(p.unwraps.Load())
+}
@@ -0,0 +1,29 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+)
+
+var ErrBlindIndexKeyTooShort = errors.New("blind index key must be at least 16 bytes")
+
+// URLBlindIndexer computes HMAC-SHA256 of normalized URLs. The key is
+// separate from the encryption keys so encryption keys can rotate without
+// invalidating stored indexes.
+type URLBlindIndexer struct {
+	key []byte
+}
+
+func NewURLBlindIndexer(key []byte) (*URLBlindIndexer, error) {
+	if len(key) < 16 {
+		return nil, ErrBlindIndexKeyTooShort
+	}
+	return &URLBlindIndexer{key}, nil
+}
+
+func (i *URLBlindIndexer) Index(normalizedURL string) []byte {
+	mac := hmac.New(sha256.New, i.key)
+	mac.Write([]byte(normalizedURL))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,35 @@
+package crypto_test
+
+import (
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLBlindIndexer_Index(t *testing.T) {
+	indexer, err := crypto.NewURLBlindIndexer([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+
+	first := indexer.Index("https://example.com")
+
+	assert.Len(t, first, 32)
+	assert.Equal(t, first, indexer.Index("https://example.com"), "the index must be deterministic")
+	assert.NotEqual(t, first, indexer.Index("https://example.org"))
+}
+
+func TestURLBlindIndexer_DependsOnKey(t *testing.T) {
+	a, err := crypto.NewURLBlindIndexer([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	b, err := crypto.NewURLBlindIndexer([]byte("fedcba9876543210fedcba9876543210"))
+	require.NoError(t, err)
+
+	assert.NotEqual(t, a.Index("https://example.com"), b.Index("https://example.com"))
+}
+
+func TestNewURLBlindIndexer_ShortKey(t *testing.T) {
+	_, err := crypto.NewURLBlindIndexer([]byte("short"))
+
+	assert.ErrorIs(t, err, crypto.ErrBlindIndexKeyTooShort)
+}
@@ -1,59 +1,50 @@
 package crypto
 
 import (
-	"crypto/aes"
-	"crypto/cipher"
-	"crypto/rand"
-	"encoding/hex"
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/brunoibarbosa/url-shortener/internal/infra/service/crypto")
+
 type URLEncrypter struct {
-	secretKey string
+	keyring *Keyring
 }
 
-func NewURLEncrypter(secretKey string) *URLEncrypter {
+func NewURLEncrypter(keyring *Keyring) *URLEncrypter {
 	return &URLEncrypter{
-		secretKey,
+		keyring,
 	}
 }
 
-func (e *URLEncrypter) Encrypt(text string) (string, error) {
-	block, err := aes.NewCipher([]byte(e.secretKey))
-	if err != nil {
-		return "", err
-	}
+func (e *URLEncrypter) Encrypt(ctx context.Context, text string) (string, error) {
+	ctx, span := tracer.Start(ctx, "URLEncrypter.Encrypt")
+	defer span.End()
 
-	plainText := []byte(text)
-	cipherText := make([]byte, aes.BlockSize+len(plainText))
-
-	iv := cipherText[:aes.BlockSize]
-
-	if _, err := rand.Read(iv); err != nil {
-		return "", err
-	}
+	encrypted, err := e.keyring.Seal(ctx, []byte(text))
+	recordError(span, err)
+	return encrypted, err
+}
 
-	stream := cipher.NewCTR(block, iv)
-	stream.XORKeyStream(cipherText[aes.BlockSize:], plainText)
+func (e *URLEncrypter) Decrypt(ctx context.Context, text string) (string, error) {
+	ctx, span := tracer.Start(ctx, "URLEncrypter.Decrypt")
+	defer span.End()
 
-	return hex.EncodeToString(cipherText), nil
+	decrypted, err := e.keyring.Open(ctx, text)
+	recordError(span, err)
+	return string(decrypted), err
 }
 
-func (e *URLEncrypter) Decrypt(text string) (string, error) {
-	block, err := aes.NewCipher([]byte(e.secretKey))
-	if err != nil {
-		return "", err
-	}
+func (e *URLEncrypter) NeedsReencryption(text string) bool {
+	return !e.keyring.IsActive(text)
+}
 
-	cipherText, err := hex.DecodeString(text)
+func recordError(span trace.Span, err error) {
 	if err != nil {
-		return "", err
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 	}
-
-	iv := cipherText[:aes.BlockSize]
-	cipherText = cipherText[aes.BlockSize:]
-
-	stream := cipher.NewCTR(block, iv)
-	stream.XORKeyStream(cipherText, cipherText)
-
-	return string(cipherText), nil
 }
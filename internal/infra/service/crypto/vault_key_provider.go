@@ -0,0 +1,122 @@
+package crypto
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+type VaultTransitConfig struct {
+	Address   string
+	Token     string
+	Namespace string
+	Mount     string
+	KeyName   string
+	Timeout   time.Duration
+}
+
+// VaultTransitKeyProvider wraps data keys with the encrypt/decrypt endpoints
+// of a HashiCorp Vault transit secrets engine (or a compatible server). The
+// master key never leaves Vault.
+type VaultTransitKeyProvider struct {
+	client     *http.Client
+	encryptURL string
+	decryptURL string
+	token      string
+	namespace  string
+}
+
+func NewVaultTransitKeyProvider(cfg VaultTransitConfig) *VaultTransitKeyProvider {
+	base := strings.TrimRight(cfg.Address, "/") + "/v1/" + strings.Trim(cfg.Mount, "/")
+	key := url.PathEscape(cfg.KeyName)
+
+	return &VaultTransitKeyProvider{
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+		},
+		encryptURL: base + "/encrypt/" + key,
+		decryptURL: base + "/decrypt/" + key,
+		token:      cfg.Token,
+		namespace:  cfg.Namespace,
+	}
+}
+
+type vaultTransitData struct {
+	Plaintext  string `json:"plaintext,omitempty"`
+	Ciphertext string `json:"ciphertext,omitempty"`
+}
+
+type vaultResponse struct {
+	Data   vaultTransitData `json:"data"`
+	Errors []string         `json:"errors"`
+}
+
+func (p *VaultTransitKeyProvider) WrapKey(ctx context.Context, plaintext []byte) (string, error) {
+	data, err := p.call(ctx, p.encryptURL, vaultTransitData{Plaintext: base64.StdEncoding.EncodeToString(plaintext)})
+	if err != nil {
+		return "", err
+	}
+	if data.Ciphertext == "" {
+		return "", fmt.Errorf("vault transit encrypt: empty ciphertext in response")
+	}
+	return data.Ciphertext, nil
+}
+
+func (p *VaultTransitKeyProvider) UnwrapKey(ctx context.Context, wrapped string) ([]byte, error) {
+	data, err := p.call(ctx, p.decryptURL, vaultTransitData{Ciphertext: wrapped})
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := base64.StdEncoding.DecodeString(data.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("vault transit decrypt: invalid plaintext in response: %w", err)
+	}
+	return plaintext, nil
+}
+
+func (p *VaultTransitKeyProvider) call(ctx context.Context, endpoint string, body vaultTransitData) (vaultTransitData, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return vaultTransitData{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return vaultTransitData{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", p.token)
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return vaultTransitData{}, fmt.Errorf("vault transit: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var decoded vaultResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil && resp.StatusCode == http.StatusOK {
+		return vaultTransitData{}, fmt.Errorf("vault transit: invalid response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if len(decoded.Errors) > 0 {
+			return vaultTransitData{}, fmt.Errorf("vault transit: %s: %s", resp.Status, strings.Join(decoded.Errors, "; "))
+		}
+		return vaultTransitData{}, fmt.Errorf("vault transit: %s", resp.Status)
+	}
+
+	return decoded.Data, nil
+}
@@ -0,0 +1,125 @@
+package crypto_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTransit mimics the encrypt/decrypt endpoints of Vault's transit engine.
+// Ciphertexts are "vault:v1:" + base64 of the reversed plaintext.
+func fakeTransit(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]any{"errors": []string{"permission denied"}})
+			return
+		}
+		assert.Equal(t, "team", r.Header.Get("X-Vault-Namespace"))
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		var data map[string]string
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/urls":
+			plaintext, err := base64.StdEncoding.DecodeString(body["plaintext"])
+			require.NoError(t, err)
+			data = map[string]string{"ciphertext": "vault:v1:" + base64.StdEncoding.EncodeToString(reverse(plaintext))}
+		case "/v1/transit/decrypt/urls":
+			sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(body["ciphertext"], "vault:v1:"))
+			if err != nil || !strings.HasPrefix(body["ciphertext"], "vault:v1:") {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]any{"errors": []string{"invalid ciphertext"}})
+				return
+			}
+			data = map[string]string{"plaintext": base64.StdEncoding.EncodeToString(reverse(sealed))}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+}
+
+func reverse(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[len(b)-1-i] = b[i]
+	}
+	return out
+}
+
+func newVaultProvider(address, token string) *crypto.VaultTransitKeyProvider {
+	return crypto.NewVaultTransitKeyProvider(crypto.VaultTransitConfig{
+		Address:   address,
+		Token:     token,
+		Namespace: "team",
+		Mount:     "transit",
+		KeyName:   "urls",
+		Timeout:   time.Second,
+	})
+}
+
+func TestVaultTransitKeyProvider_WrapUnwrap(t *testing.T) {
+	ctx := context.Background()
+	server := fakeTransit(t)
+	defer server.Close()
+	provider := newVaultProvider(server.URL+"/", "root")
+
+	wrapped, err := provider.WrapKey(ctx, []byte("data key"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(wrapped, "vault:v1:"))
+
+	unwrapped, err := provider.UnwrapKey(ctx, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data key"), unwrapped)
+}
+
+func TestVaultTransitKeyProvider_EnvelopeKeyring(t *testing.T) {
+	ctx := context.Background()
+	server := fakeTransit(t)
+	defer server.Close()
+
+	encrypter := newEnvelopeEncrypter(t, newVaultProvider(server.URL, "root"), time.Hour, "k1")
+
+	encrypted, err := encrypter.Encrypt(ctx, "https://example.com")
+	require.NoError(t, err)
+	decrypted, err := encrypter.Decrypt(ctx, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", decrypted)
+}
+
+func TestVaultTransitKeyProvider_Errors(t *testing.T) {
+	ctx := context.Background()
+	server := fakeTransit(t)
+	defer server.Close()
+
+	t.Run("should surface vault errors", func(t *testing.T) {
+		_, err := newVaultProvider(server.URL, "wrong").WrapKey(ctx, []byte("data key"))
+
+		assert.ErrorContains(t, err, "permission denied")
+	})
+
+	t.Run("should reject invalid ciphertexts", func(t *testing.T) {
+		_, err := newVaultProvider(server.URL, "root").UnwrapKey(ctx, "not-a-vault-ciphertext")
+
+		assert.ErrorContains(t, err, "invalid ciphertext")
+	})
+
+	t.Run("should fail when vault is unreachable", func(t *testing.T) {
+		_, err := newVaultProvider("http://127.0.0.1:1", "root").UnwrapKey(ctx, "vault:v1:abc")
+
+		assert.Error(t, err)
+	})
+}
@@ -28,6 +28,9 @@ func (s *TokenService) GenerateAccessToken(params *session.TokenParams) (string,
 		"exp": time.Now().Add(params.Duration).Unix(),
 		"iat": time.Now().Unix(),
 	}
+	if params.Locale != "" {
+		claims["locale"] = params.Locale
+	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	signed, err := token.SignedString([]byte(s.secret))
 	if err != nil {
@@ -321,3 +321,33 @@ func TestTokenService_GenerateAccessToken_ContainsAllRequiredClaims(t *testing.T
 	assert.Contains(t, claims, "exp")
 	assert.Contains(t, claims, "iat")
 }
+
+func TestTokenService_GenerateAccessToken_Locale(t *testing.T) {
+	secret := "test-secret-key-with-32-bytes!!"
+	service := jwt.NewTokenService(secret)
+
+	parse := func(token string) jwtlib.MapClaims {
+		parsedToken, err := jwtlib.Parse(token, func(token *jwtlib.Token) (interface{}, error) {
+			return []byte(secret), nil
+		})
+		require.NoError(t, err)
+		return parsedToken.Claims.(jwtlib.MapClaims)
+	}
+
+	token, err := service.GenerateAccessToken(&session.TokenParams{
+		UserID:    uuid.New(),
+		SessionID: uuid.New(),
+		Duration:  15 * time.Minute,
+		Locale:    "pt",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pt", parse(token)["locale"])
+
+	token, err = service.GenerateAccessToken(&session.TokenParams{
+		UserID:    uuid.New(),
+		SessionID: uuid.New(),
+		Duration:  15 * time.Minute,
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, parse(token), "locale")
+}
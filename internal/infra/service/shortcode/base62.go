@@ -0,0 +1,30 @@
+package shortcode
+
+import (
+	"github.com/brunoibarbosa/url-shortener/internal/domain/url"
+)
+
+// MaxCounterLength is the longest code a uint64 counter can fill: 62^10 fits
+// in 64 bits, 62^11 does not.
+const MaxCounterLength = 10
+
+// codeSpace returns 62^length, the number of distinct codes of that length.
+func codeSpace(length int) uint64 {
+	space := uint64(1)
+	for i := 0; i < length; i++ {
+		space *= uint64(len(url.ShortCodeCharset))
+	}
+	return space
+}
+
+// encodeBase62 writes n in the short-code charset, left-padded to length.
+// n must be below codeSpace(length).
+func encodeBase62(n uint64, length int) string {
+	base := uint64(len(url.ShortCodeCharset))
+	r := make([]rune, length)
+	for i := length - 1; i >= 0; i-- {
+		r[i] = url.ShortCodeCharset[n%base]
+		n /= base
+	}
+	return string(r)
+}
@@ -0,0 +1,84 @@
+package shortcode
+
+import (
+	"context"
+	"sync"
+
+	"github.com/brunoibarbosa/url-shortener/internal/domain/url"
+)
+
+const (
+	StrategyRandom  = "random"
+	StrategyCounter = "counter"
+	StrategyPool    = "pool"
+
+	CounterSourcePostgres = "postgres"
+	CounterSourceRedis    = "redis"
+)
+
+// CounterShortCodeGenerator encodes a shared counter in base62. The counter
+// is reserved in blocks from a repository (a Postgres sequence or Redis
+// INCR), so codes are unique across instances without a lookup per attempt
+// and most calls do not leave the process. With an obfuscation key the
+// counter goes through a keyed Feistel permutation first, so codes are not
+// sequential; the key (and the code length) must not change once codes have
+// been issued.
+type CounterShortCodeGenerator struct {
+	counter        url.ShortCodeCounterRepository
+	blockSize      int64
+	obfuscationKey []byte
+
+	mu   sync.Mutex
+	next int64
+	end  int64
+}
+
+func NewCounterShortCodeGenerator(counter url.ShortCodeCounterRepository, blockSize int64, obfuscationKey []byte) *CounterShortCodeGenerator {
+	return &CounterShortCodeGenerator{
+		counter:        counter,
+		blockSize:      blockSize,
+		obfuscationKey: obfuscationKey,
+	}
+}
+
+func (g *CounterShortCodeGenerator) Generate(ctx context.Context, length int) (string, error) {
+	if length < 1 || length > MaxCounterLength {
+		return "", url.ErrShortCodeLengthUnsupported
+	}
+
+	n, err := g.nextValue(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	space := codeSpace(length)
+	if uint64(n) >= space {
+		return "", url.ErrShortCodeSpaceExhausted
+	}
+
+	value := uint64(n)
+	if len(g.obfuscationKey) > 0 {
+		value = newFeistel(g.obfuscationKey, space).permute(value)
+	}
+
+	return encodeBase62(value, length), nil
+}
+
+func (g *CounterShortCodeGenerator) nextValue(ctx context.Context) (int64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.next == g.end {
+		block, err := g.counter.NextBlock(ctx)
+		if err != nil {
+			return 0, err
+		}
+		// Blocks start at 1; block b owns [(b-1)*size, b*size).
+		g.next = (block - 1) * g.blockSize
+		g.end = g.next + g.blockSize
+	}
+
+	n := g.next
+	g.next++
+	return n, nil
+}
@@ -0,0 +1,162 @@
+package shortcode_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/shortcode"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// sharedCounter stands in for a Postgres sequence or Redis key shared by
+// several instances.
+type sharedCounter struct {
+	blocks atomic.Int64
+}
+
+func (c *sharedCounter) NextBlock(context.Context) (int64, error) {
+	return c.blocks.Add(1), nil
+}
+
+func TestCounterShortCodeGenerator_Sequential(t *testing.T) {
+	ctx := context.Background()
+	generator := shortcode.NewCounterShortCodeGenerator(&sharedCounter{}, 10, nil)
+
+	var codes []string
+	for i := 0; i < 3; i++ {
+		code, err := generator.Generate(ctx, 6)
+		require.NoError(t, err)
+		codes = append(codes, code)
+	}
+
+	assert.Equal(t, []string{"aaaaaa", "aaaaab", "aaaaac"}, codes)
+}
+
+func TestCounterShortCodeGenerator_AllocatesBlocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	counter := mocks.NewMockShortCodeCounterRepository(ctrl)
+	gomock.InOrder(
+		counter.EXPECT().NextBlock(ctx).Return(int64(1), nil),
+		counter.EXPECT().NextBlock(ctx).Return(int64(5), nil),
+	)
+	generator := shortcode.NewCounterShortCodeGenerator(counter, 2, nil)
+
+	var codes []string
+	for i := 0; i < 3; i++ {
+		code, err := generator.Generate(ctx, 4)
+		require.NoError(t, err)
+		codes = append(codes, code)
+	}
+
+	// Block 1 holds values 0 and 1, block 5 starts at 8
+	assert.Equal(t, []string{"aaaa", "aaab", "aaai"}, codes)
+}
+
+func TestCounterShortCodeGenerator_CounterError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	expectedError := errors.New("sequence unavailable")
+
+	counter := mocks.NewMockShortCodeCounterRepository(ctrl)
+	counter.EXPECT().NextBlock(ctx).Return(int64(0), expectedError)
+	generator := shortcode.NewCounterShortCodeGenerator(counter, 100, nil)
+
+	_, err := generator.Generate(ctx, 6)
+
+	assert.Equal(t, expectedError, err)
+}
+
+func TestCounterShortCodeGenerator_ObfuscatedCodesAreAPermutation(t *testing.T) {
+	ctx := context.Background()
+	generator := shortcode.NewCounterShortCodeGenerator(&sharedCounter{}, 100, []byte("obfuscation-key"))
+
+	// Every two-character code exactly once, then nothing left
+	space := len(url.ShortCodeCharset) * len(url.ShortCodeCharset)
+	seen := make(map[string]bool, space)
+	sequential := 0
+	previous := ""
+	for i := 0; i < space; i++ {
+		code, err := generator.Generate(ctx, 2)
+		require.NoError(t, err)
+		require.Len(t, code, 2)
+		require.False(t, seen[code], "duplicate code %q", code)
+		seen[code] = true
+
+		if previous != "" && code[0] == previous[0] {
+			sequential++
+		}
+		previous = code
+	}
+
+	_, err := generator.Generate(ctx, 2)
+	assert.ErrorIs(t, err, url.ErrShortCodeSpaceExhausted)
+	assert.Less(t, sequential, space/10, "consecutive counters should not share a prefix")
+}
+
+func TestCounterShortCodeGenerator_ObfuscationDependsOnKey(t *testing.T) {
+	ctx := context.Background()
+	a := shortcode.NewCounterShortCodeGenerator(&sharedCounter{}, 100, []byte("key-a"))
+	b := shortcode.NewCounterShortCodeGenerator(&sharedCounter{}, 100, []byte("key-b"))
+
+	differ := false
+	for i := 0; i < 10; i++ {
+		codeA, err := a.Generate(ctx, 6)
+		require.NoError(t, err)
+		codeB, err := b.Generate(ctx, 6)
+		require.NoError(t, err)
+		differ = differ || codeA != codeB
+	}
+
+	assert.True(t, differ)
+}
+
+func TestCounterShortCodeGenerator_UniqueAcrossInstances(t *testing.T) {
+	ctx := context.Background()
+	counter := &sharedCounter{}
+	instances := []*shortcode.CounterShortCodeGenerator{
+		shortcode.NewCounterShortCodeGenerator(counter, 7, []byte("key")),
+		shortcode.NewCounterShortCodeGenerator(counter, 7, []byte("key")),
+		shortcode.NewCounterShortCodeGenerator(counter, 7, []byte("key")),
+	}
+
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+	var wg sync.WaitGroup
+	for _, generator := range instances {
+		for w := 0; w < 4; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 250; i++ {
+					code, err := generator.Generate(ctx, 6)
+					assert.NoError(t, err)
+
+					mu.Lock()
+					assert.False(t, seen[code], "duplicate code %q", code)
+					seen[code] = true
+					mu.Unlock()
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	assert.Len(t, seen, 3*4*250)
+}
+
+func TestCounterShortCodeGenerator_UnsupportedLength(t *testing.T) {
+	generator := shortcode.NewCounterShortCodeGenerator(&sharedCounter{}, 100, nil)
+
+	_, err := generator.Generate(context.Background(), shortcode.MaxCounterLength+1)
+
+	assert.ErrorIs(t, err, url.ErrShortCodeLengthUnsupported)
+}
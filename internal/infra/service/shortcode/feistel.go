@@ -0,0 +1,56 @@
+package shortcode
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/bits"
+)
+
+const feistelRounds = 4
+
+// feistel is a keyed permutation of [0, max): a balanced Feistel network over
+// the smallest even bit width covering max, cycle-walking values that land
+// outside the range. Being a bijection, distinct counters always give
+// distinct outputs, while consecutive counters look unrelated.
+type feistel struct {
+	key      []byte
+	max      uint64
+	halfBits uint
+}
+
+func newFeistel(key []byte, max uint64) feistel {
+	width := uint(bits.Len64(max - 1))
+	if width%2 == 1 {
+		width++
+	}
+	return feistel{key: key, max: max, halfBits: width / 2}
+}
+
+func (f feistel) permute(x uint64) uint64 {
+	for {
+		x = f.encrypt(x)
+		if x < f.max {
+			return x
+		}
+	}
+}
+
+func (f feistel) encrypt(x uint64) uint64 {
+	mask := uint64(1)<<f.halfBits - 1
+	left, right := x>>f.halfBits, x&mask
+	for round := 0; round < feistelRounds; round++ {
+		left, right = right, left^(f.round(round, right)&mask)
+	}
+	return left<<f.halfBits | right
+}
+
+func (f feistel) round(round int, half uint64) uint64 {
+	var buf [9]byte
+	buf[0] = byte(round)
+	binary.BigEndian.PutUint64(buf[1:], half)
+
+	mac := hmac.New(sha256.New, f.key)
+	mac.Write(buf[:])
+	return binary.BigEndian.Uint64(mac.Sum(nil))
+}
@@ -0,0 +1,104 @@
+package shortcode
+
+import (
+	"context"
+	"sync"
+
+	"github.com/brunoibarbosa/url-shortener/internal/domain/url"
+)
+
+type PoolConfig struct {
+	// Length of the codes kept in the pool.
+	Length int
+	// MinAvailable is the pool size below which Refill adds codes.
+	MinAvailable int64
+	// RefillBatch is how many random codes Refill tries to add at once.
+	RefillBatch int
+	// Prefetch is how many codes are claimed per round trip and kept in
+	// memory.
+	Prefetch int
+}
+
+// PoolShortCodeGenerator hands out codes generated ahead of time. Codes are
+// claimed atomically, so concurrent instances never receive the same one,
+// and a claimed code is never returned to the pool. Refill is meant to run
+// periodically in the background; an empty pool is refilled inline.
+type PoolShortCodeGenerator struct {
+	pool   url.ShortCodePoolRepository
+	random *RandomShortCodeGenerator
+	cfg    PoolConfig
+
+	mu     sync.Mutex
+	buffer []string
+}
+
+func NewPoolShortCodeGenerator(pool url.ShortCodePoolRepository, cfg PoolConfig) *PoolShortCodeGenerator {
+	return &PoolShortCodeGenerator{
+		pool:   pool,
+		random: NewRandomShortCodeGenerator(),
+		cfg:    cfg,
+	}
+}
+
+func (g *PoolShortCodeGenerator) Generate(ctx context.Context, length int) (string, error) {
+	if length != g.cfg.Length {
+		return "", url.ErrShortCodeLengthUnsupported
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.buffer) == 0 {
+		if err := g.claim(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	code := g.buffer[0]
+	g.buffer = g.buffer[1:]
+	return code, nil
+}
+
+func (g *PoolShortCodeGenerator) claim(ctx context.Context) error {
+	codes, err := g.pool.Claim(ctx, g.cfg.Length, g.cfg.Prefetch)
+	if err != nil {
+		return err
+	}
+
+	if len(codes) == 0 {
+		if _, err := g.Refill(ctx); err != nil {
+			return err
+		}
+		if codes, err = g.pool.Claim(ctx, g.cfg.Length, g.cfg.Prefetch); err != nil {
+			return err
+		}
+	}
+
+	if len(codes) == 0 {
+		return url.ErrShortCodePoolEmpty
+	}
+
+	g.buffer = codes
+	return nil
+}
+
+// Refill tops the pool up with random codes when it runs low, returning how
+// many were added.
+func (g *PoolShortCodeGenerator) Refill(ctx context.Context) (int64, error) {
+	available, err := g.pool.CountAvailable(ctx, g.cfg.Length)
+	if err != nil {
+		return 0, err
+	}
+	if available >= g.cfg.MinAvailable {
+		return 0, nil
+	}
+
+	codes := make([]string, g.cfg.RefillBatch)
+	for i := range codes {
+		if codes[i], err = g.random.Generate(ctx, g.cfg.Length); err != nil {
+			return 0, err
+		}
+	}
+
+	return g.pool.Fill(ctx, codes)
+}
@@ -0,0 +1,134 @@
+package shortcode_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/shortcode"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var poolConfig = shortcode.PoolConfig{
+	Length:       6,
+	MinAvailable: 10,
+	RefillBatch:  5,
+	Prefetch:     2,
+}
+
+func TestPoolShortCodeGenerator_ClaimsAndBuffers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	pool := mocks.NewMockShortCodePoolRepository(ctrl)
+	gomock.InOrder(
+		pool.EXPECT().Claim(ctx, 6, 2).Return([]string{"aaaaaa", "bbbbbb"}, nil),
+		pool.EXPECT().Claim(ctx, 6, 2).Return([]string{"cccccc"}, nil),
+	)
+	generator := shortcode.NewPoolShortCodeGenerator(pool, poolConfig)
+
+	var codes []string
+	for i := 0; i < 3; i++ {
+		code, err := generator.Generate(ctx, 6)
+		require.NoError(t, err)
+		codes = append(codes, code)
+	}
+
+	assert.Equal(t, []string{"aaaaaa", "bbbbbb", "cccccc"}, codes)
+}
+
+func TestPoolShortCodeGenerator_RefillsWhenEmpty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	pool := mocks.NewMockShortCodePoolRepository(ctrl)
+	gomock.InOrder(
+		pool.EXPECT().Claim(ctx, 6, 2).Return(nil, nil),
+		pool.EXPECT().CountAvailable(ctx, 6).Return(int64(0), nil),
+		pool.EXPECT().Fill(ctx, gomock.Len(5)).Return(int64(5), nil),
+		pool.EXPECT().Claim(ctx, 6, 2).Return([]string{"aaaaaa"}, nil),
+	)
+	generator := shortcode.NewPoolShortCodeGenerator(pool, poolConfig)
+
+	code, err := generator.Generate(ctx, 6)
+
+	require.NoError(t, err)
+	assert.Equal(t, "aaaaaa", code)
+}
+
+func TestPoolShortCodeGenerator_Empty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	pool := mocks.NewMockShortCodePoolRepository(ctrl)
+	pool.EXPECT().Claim(ctx, 6, 2).Return(nil, nil).Times(2)
+	pool.EXPECT().CountAvailable(ctx, 6).Return(int64(0), nil)
+	pool.EXPECT().Fill(ctx, gomock.Any()).Return(int64(0), nil)
+	generator := shortcode.NewPoolShortCodeGenerator(pool, poolConfig)
+
+	_, err := generator.Generate(ctx, 6)
+
+	assert.ErrorIs(t, err, url.ErrShortCodePoolEmpty)
+}
+
+func TestPoolShortCodeGenerator_ClaimError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	expectedError := errors.New("database error")
+
+	pool := mocks.NewMockShortCodePoolRepository(ctrl)
+	pool.EXPECT().Claim(ctx, 6, 2).Return(nil, expectedError)
+	generator := shortcode.NewPoolShortCodeGenerator(pool, poolConfig)
+
+	_, err := generator.Generate(ctx, 6)
+
+	assert.Equal(t, expectedError, err)
+}
+
+func TestPoolShortCodeGenerator_UnsupportedLength(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	generator := shortcode.NewPoolShortCodeGenerator(mocks.NewMockShortCodePoolRepository(ctrl), poolConfig)
+
+	_, err := generator.Generate(context.Background(), 8)
+
+	assert.ErrorIs(t, err, url.ErrShortCodeLengthUnsupported)
+}
+
+func TestPoolShortCodeGenerator_Refill(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should do nothing while enough codes are available", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		pool := mocks.NewMockShortCodePoolRepository(ctrl)
+		pool.EXPECT().CountAvailable(ctx, 6).Return(int64(10), nil)
+		generator := shortcode.NewPoolShortCodeGenerator(pool, poolConfig)
+
+		added, err := generator.Refill(ctx)
+
+		require.NoError(t, err)
+		assert.Zero(t, added)
+	})
+
+	t.Run("should add random codes of the pool length", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		pool := mocks.NewMockShortCodePoolRepository(ctrl)
+		pool.EXPECT().CountAvailable(ctx, 6).Return(int64(3), nil)
+		pool.EXPECT().Fill(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, codes []string) (int64, error) {
+			assert.Len(t, codes, 5)
+			for _, code := range codes {
+				assert.Len(t, code, 6)
+			}
+			return int64(4), nil
+		})
+		generator := shortcode.NewPoolShortCodeGenerator(pool, poolConfig)
+
+		added, err := generator.Refill(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), added)
+	})
+}
@@ -1,6 +1,7 @@
 package shortcode
 
 import (
+	"context"
 	"crypto/rand"
 	"math/big"
 
@@ -13,7 +14,7 @@ func NewRandomShortCodeGenerator() *RandomShortCodeGenerator {
 	return &RandomShortCodeGenerator{}
 }
 
-func (g *RandomShortCodeGenerator) Generate(length int) (string, error) {
+func (g *RandomShortCodeGenerator) Generate(_ context.Context, length int) (string, error) {
 	r := make([]rune, length)
 	for i := range r {
 		num, err := rand.Int(rand.Reader, big.NewInt(int64(len(url.ShortCodeCharset))))
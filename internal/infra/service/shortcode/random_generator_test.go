@@ -1,6 +1,7 @@
 package shortcode_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/brunoibarbosa/url-shortener/internal/domain/url"
@@ -20,7 +21,7 @@ func TestRandomShortCodeGenerator_Generate_Success(t *testing.T) {
 
 	length := 8
 
-	code, err := generator.Generate(length)
+	code, err := generator.Generate(context.Background(), length)
 
 	require.NoError(t, err)
 	assert.Len(t, code, length)
@@ -33,7 +34,7 @@ func TestRandomShortCodeGenerator_Generate_DifferentLengths(t *testing.T) {
 
 	for _, length := range lengths {
 		t.Run(string(rune(length)), func(t *testing.T) {
-			code, err := generator.Generate(length)
+			code, err := generator.Generate(context.Background(), length)
 
 			require.NoError(t, err)
 			assert.Len(t, code, length)
@@ -44,7 +45,7 @@ func TestRandomShortCodeGenerator_Generate_DifferentLengths(t *testing.T) {
 func TestRandomShortCodeGenerator_Generate_ZeroLength(t *testing.T) {
 	generator := shortcode.NewRandomShortCodeGenerator()
 
-	code, err := generator.Generate(0)
+	code, err := generator.Generate(context.Background(), 0)
 
 	require.NoError(t, err)
 	assert.Empty(t, code)
@@ -54,7 +55,7 @@ func TestRandomShortCodeGenerator_Generate_OnlyValidCharacters(t *testing.T) {
 	generator := shortcode.NewRandomShortCodeGenerator()
 
 	length := 100
-	code, err := generator.Generate(length)
+	code, err := generator.Generate(context.Background(), length)
 
 	require.NoError(t, err)
 
@@ -79,7 +80,7 @@ func TestRandomShortCodeGenerator_Generate_CodesAreRandom(t *testing.T) {
 	codes := make(map[string]bool)
 
 	for i := 0; i < iterations; i++ {
-		code, err := generator.Generate(length)
+		code, err := generator.Generate(context.Background(), length)
 		require.NoError(t, err)
 
 		// Each code should be unique (statistically very unlikely to collide)
@@ -96,7 +97,7 @@ func TestRandomShortCodeGenerator_Generate_ContainsAlphanumeric(t *testing.T) {
 
 	// Generate many codes to ensure we get good variety
 	length := 1000
-	code, err := generator.Generate(length)
+	code, err := generator.Generate(context.Background(), length)
 
 	require.NoError(t, err)
 
@@ -126,7 +127,7 @@ func TestRandomShortCodeGenerator_Generate_NoSpecialCharacters(t *testing.T) {
 	generator := shortcode.NewRandomShortCodeGenerator()
 
 	length := 100
-	code, err := generator.Generate(length)
+	code, err := generator.Generate(context.Background(), length)
 
 	require.NoError(t, err)
 
@@ -146,7 +147,7 @@ func TestRandomShortCodeGenerator_Generate_Consistency(t *testing.T) {
 
 	// Generate multiple codes
 	for i := 0; i < 10; i++ {
-		code, err := generator.Generate(length)
+		code, err := generator.Generate(context.Background(), length)
 
 		require.NoError(t, err)
 		assert.Len(t, code, length)
@@ -159,7 +160,7 @@ func TestRandomShortCodeGenerator_Generate_LargeLength(t *testing.T) {
 
 	length := 10000
 
-	code, err := generator.Generate(length)
+	code, err := generator.Generate(context.Background(), length)
 
 	require.NoError(t, err)
 	assert.Len(t, code, length)
@@ -176,7 +177,7 @@ func TestRandomShortCodeGenerator_Generate_ConcurrentGeneration(t *testing.T) {
 
 	for i := 0; i < iterations; i++ {
 		go func() {
-			code, err := generator.Generate(length)
+			code, err := generator.Generate(context.Background(), length)
 			if err != nil {
 				errors <- err
 			} else {
@@ -210,7 +211,7 @@ func TestRandomShortCodeGenerator_Generate_Distribution(t *testing.T) {
 	charCount := make(map[rune]int)
 
 	for i := 0; i < iterations; i++ {
-		code, err := generator.Generate(length)
+		code, err := generator.Generate(context.Background(), length)
 		require.NoError(t, err)
 		charCount[rune(code[0])]++
 	}
@@ -228,7 +229,7 @@ func TestRandomShortCodeGenerator_Generate_CharsetConsistency(t *testing.T) {
 	allChars := make(map[rune]bool)
 
 	for i := 0; i < 10; i++ {
-		code, err := generator.Generate(length)
+		code, err := generator.Generate(context.Background(), length)
 		require.NoError(t, err)
 
 		for _, char := range code {
@@ -256,7 +257,7 @@ func TestRandomShortCodeGenerator_Generate_RepeatedCalls(t *testing.T) {
 	previousCode := ""
 
 	for i := 0; i < 20; i++ {
-		code, err := generator.Generate(length)
+		code, err := generator.Generate(context.Background(), length)
 
 		require.NoError(t, err)
 		assert.Len(t, code, length)
@@ -274,7 +275,7 @@ func TestRandomShortCodeGenerator_Generate_URLSafeCharacters(t *testing.T) {
 	generator := shortcode.NewRandomShortCodeGenerator()
 
 	length := 100
-	code, err := generator.Generate(length)
+	code, err := generator.Generate(context.Background(), length)
 
 	require.NoError(t, err)
 
@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"context"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/google/uuid"
+)
+
+type ownerEvent struct {
+	shortCode string
+	event     domain.Event
+}
+
+// AsyncPublisher takes PublishToOwner off the request path: events are
+// buffered and handed to the next publisher by Run. When the buffer is full
+// the event is dropped, so a slow database never delays a redirect.
+type AsyncPublisher struct {
+	next    domain.WebhookPublisher
+	metrics domain.WebhookMetrics
+	events  chan ownerEvent
+}
+
+func NewAsyncPublisher(next domain.WebhookPublisher, metrics domain.WebhookMetrics, buffer int) *AsyncPublisher {
+	return &AsyncPublisher{
+		next:    next,
+		metrics: metrics,
+		events:  make(chan ownerEvent, buffer),
+	}
+}
+
+// Publish is synchronous: it is only used for events that are rare enough
+// to be written in the request.
+func (p *AsyncPublisher) Publish(ctx context.Context, userID uuid.UUID, e domain.Event) error {
+	return p.next.Publish(ctx, userID, e)
+}
+
+func (p *AsyncPublisher) PublishToOwner(_ context.Context, shortCode string, e domain.Event) error {
+	select {
+	case p.events <- ownerEvent{shortCode: shortCode, event: e}:
+	default:
+		p.metrics.EventDropped()
+	}
+	return nil
+}
+
+// Run publishes buffered events until ctx is done, then publishes what is
+// left in the buffer before returning.
+func (p *AsyncPublisher) Run(ctx context.Context) {
+	publish := func(e ownerEvent) {
+		_ = p.next.PublishToOwner(context.WithoutCancel(ctx), e.shortCode, e.event)
+	}
+
+	for {
+		select {
+		case e := <-p.events:
+			publish(e)
+		case <-ctx.Done():
+			for {
+				select {
+				case e := <-p.events:
+					publish(e)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package webhook_test
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/webhook"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAsyncPublisher_PublishToOwner_DeliveredByRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	next := mocks.NewMockWebhookPublisher(ctrl)
+	metrics := mocks.NewMockWebhookMetrics(ctrl)
+
+	p := webhook.NewAsyncPublisher(next, metrics, 2)
+	e := domain.NewEvent(domain.EventURLClicked, domain.ClickEventData{ShortCode: "abc123"})
+
+	assert.NoError(t, p.PublishToOwner(context.Background(), "abc123", e))
+
+	next.EXPECT().PublishToOwner(gomock.Any(), "abc123", e).Return(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p.Run(ctx)
+}
+
+func TestAsyncPublisher_PublishToOwner_DropsWhenFull(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	next := mocks.NewMockWebhookPublisher(ctrl)
+	metrics := mocks.NewMockWebhookMetrics(ctrl)
+
+	p := webhook.NewAsyncPublisher(next, metrics, 1)
+	e := domain.NewEvent(domain.EventURLClicked, domain.ClickEventData{ShortCode: "abc123"})
+
+	metrics.EXPECT().EventDropped().Times(1)
+
+	assert.NoError(t, p.PublishToOwner(context.Background(), "abc123", e))
+	assert.NoError(t, p.PublishToOwner(context.Background(), "abc123", e))
+}
+
+func TestAsyncPublisher_Publish_IsSynchronous(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	next := mocks.NewMockWebhookPublisher(ctrl)
+
+	userID := uuid.New()
+	e := domain.NewEvent(domain.EventURLCreated, domain.URLEventData{ShortCode: "abc123"})
+	next.EXPECT().Publish(gomock.Any(), userID, e).Return(nil)
+
+	assert.NoError(t, webhook.NewAsyncPublisher(next, mocks.NewMockWebhookMetrics(ctrl), 1).Publish(context.Background(), userID, e))
+}
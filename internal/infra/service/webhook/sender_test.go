@@ -0,0 +1,100 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/service/webhook"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDueDelivery(url string) *domain.DueDelivery {
+	return &domain.DueDelivery{
+		Delivery: domain.Delivery{
+			ID:        uuid.New(),
+			EventType: domain.EventURLCreated,
+			Payload:   []byte(`{"type":"url.created"}`),
+		},
+		URL:    url,
+		Secret: "whsec_test",
+	}
+}
+
+func TestHTTPSender_Send_SignsPayload(t *testing.T) {
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	d := newDueDelivery(server.URL)
+	status, err := webhook.NewHTTPSender(time.Second, true).Send(context.Background(), d)
+
+	require.NoError(t, err)
+	require.NotNil(t, status)
+	assert.Equal(t, http.StatusNoContent, *status)
+	assert.Equal(t, d.Payload, body)
+	assert.Equal(t, d.ID.String(), received.Header.Get(webhook.HeaderID))
+	assert.Equal(t, "url.created", received.Header.Get(webhook.HeaderEvent))
+
+	timestamp := received.Header.Get(webhook.HeaderTimestamp)
+	assert.Equal(t, "sha256="+webhook.Sign(d.Secret, timestamp, d.Payload), received.Header.Get(webhook.HeaderSignature))
+}
+
+func TestHTTPSender_Send_Non2xxIsAnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	status, err := webhook.NewHTTPSender(time.Second, true).Send(context.Background(), newDueDelivery(server.URL))
+
+	assert.ErrorIs(t, err, domain.ErrUnexpectedStatus)
+	require.NotNil(t, status)
+	assert.Equal(t, http.StatusServiceUnavailable, *status)
+}
+
+func TestHTTPSender_Send_DoesNotFollowRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/elsewhere", http.StatusFound)
+	}))
+	defer server.Close()
+
+	status, err := webhook.NewHTTPSender(time.Second, true).Send(context.Background(), newDueDelivery(server.URL))
+
+	assert.ErrorIs(t, err, domain.ErrUnexpectedStatus)
+	require.NotNil(t, status)
+	assert.Equal(t, http.StatusFound, *status)
+}
+
+func TestHTTPSender_Send_RejectsPrivateAddresses(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	status, err := webhook.NewHTTPSender(time.Second, false).Send(context.Background(), newDueDelivery(server.URL))
+
+	assert.ErrorIs(t, err, domain.ErrForbiddenDestination)
+	assert.Nil(t, status)
+	assert.False(t, called)
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t,
+		"b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163",
+		webhook.Sign("secret", "1700000000", []byte("{}")),
+	)
+}
@@ -0,0 +1,34 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/infra/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInit_None(t *testing.T) {
+	shutdown, err := tracing.Init(context.Background(), tracing.Config{Exporter: tracing.ExporterNone})
+
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestInit_Stdout(t *testing.T) {
+	shutdown, err := tracing.Init(context.Background(), tracing.Config{
+		Exporter:    tracing.ExporterStdout,
+		ServiceName: "test",
+		SampleRatio: 1,
+	})
+
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestInit_UnknownExporter(t *testing.T) {
+	_, err := tracing.Init(context.Background(), tracing.Config{Exporter: "jaeger"})
+
+	assert.ErrorIs(t, err, tracing.ErrUnknownExporter)
+}
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/audit/repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/audit/repository.go -destination=internal/mocks/audit_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	audit "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuditQueryRepository is a mock of AuditQueryRepository interface.
+type MockAuditQueryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditQueryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditQueryRepositoryMockRecorder is the mock recorder for MockAuditQueryRepository.
+type MockAuditQueryRepositoryMockRecorder struct {
+	mock *MockAuditQueryRepository
+}
+
+// NewMockAuditQueryRepository creates a new mock instance.
+func NewMockAuditQueryRepository(ctrl *gomock.Controller) *MockAuditQueryRepository {
+	mock := &MockAuditQueryRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditQueryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditQueryRepository) EXPECT() *MockAuditQueryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockAuditQueryRepository) List(ctx context.Context, params audit.ListEventsParams) ([]audit.ListEventsDTO, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, params)
+	ret0, _ := ret[0].([]audit.ListEventsDTO)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockAuditQueryRepositoryMockRecorder) List(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAuditQueryRepository)(nil).List), ctx, params)
+}
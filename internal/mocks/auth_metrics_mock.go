@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/session/metrics.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/session/metrics.go -destination=internal/mocks/auth_metrics_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthMetrics is a mock of AuthMetrics interface.
+type MockAuthMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthMetricsMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthMetricsMockRecorder is the mock recorder for MockAuthMetrics.
+type MockAuthMetricsMockRecorder struct {
+	mock *MockAuthMetrics
+}
+
+// NewMockAuthMetrics creates a new mock instance.
+func NewMockAuthMetrics(ctrl *gomock.Controller) *MockAuthMetrics {
+	mock := &MockAuthMetrics{ctrl: ctrl}
+	mock.recorder = &MockAuthMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthMetrics) EXPECT() *MockAuthMetricsMockRecorder {
+	return m.recorder
+}
+
+// LoginFailed mocks base method.
+func (m *MockAuthMetrics) LoginFailed(provider string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LoginFailed", provider)
+}
+
+// LoginFailed indicates an expected call of LoginFailed.
+func (mr *MockAuthMetricsMockRecorder) LoginFailed(provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginFailed", reflect.TypeOf((*MockAuthMetrics)(nil).LoginFailed), provider)
+}
+
+// LoginSucceeded mocks base method.
+func (m *MockAuthMetrics) LoginSucceeded(provider string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LoginSucceeded", provider)
+}
+
+// LoginSucceeded indicates an expected call of LoginSucceeded.
+func (mr *MockAuthMetricsMockRecorder) LoginSucceeded(provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginSucceeded", reflect.TypeOf((*MockAuthMetrics)(nil).LoginSucceeded), provider)
+}
@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/outbox/metrics.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/outbox/metrics.go -destination=internal/mocks/outbox_metrics_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutboxMetrics is a mock of OutboxMetrics interface.
+type MockOutboxMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMetricsMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxMetricsMockRecorder is the mock recorder for MockOutboxMetrics.
+type MockOutboxMetricsMockRecorder struct {
+	mock *MockOutboxMetrics
+}
+
+// NewMockOutboxMetrics creates a new mock instance.
+func NewMockOutboxMetrics(ctrl *gomock.Controller) *MockOutboxMetrics {
+	mock := &MockOutboxMetrics{ctrl: ctrl}
+	mock.recorder = &MockOutboxMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxMetrics) EXPECT() *MockOutboxMetricsMockRecorder {
+	return m.recorder
+}
+
+// MessageFailed mocks base method.
+func (m *MockOutboxMetrics) MessageFailed(topic string, dead bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MessageFailed", topic, dead)
+}
+
+// MessageFailed indicates an expected call of MessageFailed.
+func (mr *MockOutboxMetricsMockRecorder) MessageFailed(topic, dead any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MessageFailed", reflect.TypeOf((*MockOutboxMetrics)(nil).MessageFailed), topic, dead)
+}
+
+// MessageRelayed mocks base method.
+func (m *MockOutboxMetrics) MessageRelayed(topic string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MessageRelayed", topic)
+}
+
+// MessageRelayed indicates an expected call of MessageRelayed.
+func (mr *MockOutboxMetricsMockRecorder) MessageRelayed(topic any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MessageRelayed", reflect.TypeOf((*MockOutboxMetrics)(nil).MessageRelayed), topic)
+}
@@ -86,6 +86,60 @@ func (mr *MockSessionRepositoryMockRecorder) Revoke(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockSessionRepository)(nil).Revoke), ctx, id)
 }
 
+// RevokeAllByUserID mocks base method.
+func (m *MockSessionRepository) RevokeAllByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllByUserID", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAllByUserID indicates an expected call of RevokeAllByUserID.
+func (mr *MockSessionRepositoryMockRecorder) RevokeAllByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllByUserID", reflect.TypeOf((*MockSessionRepository)(nil).RevokeAllByUserID), ctx, userID)
+}
+
+// MockSessionCleanupRepository is a mock of SessionCleanupRepository interface.
+type MockSessionCleanupRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionCleanupRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSessionCleanupRepositoryMockRecorder is the mock recorder for MockSessionCleanupRepository.
+type MockSessionCleanupRepositoryMockRecorder struct {
+	mock *MockSessionCleanupRepository
+}
+
+// NewMockSessionCleanupRepository creates a new mock instance.
+func NewMockSessionCleanupRepository(ctrl *gomock.Controller) *MockSessionCleanupRepository {
+	mock := &MockSessionCleanupRepository{ctrl: ctrl}
+	mock.recorder = &MockSessionCleanupRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionCleanupRepository) EXPECT() *MockSessionCleanupRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteInactive mocks base method.
+func (m *MockSessionCleanupRepository) DeleteInactive(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInactive", ctx, cutoff, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteInactive indicates an expected call of DeleteInactive.
+func (mr *MockSessionCleanupRepositoryMockRecorder) DeleteInactive(ctx, cutoff, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInactive", reflect.TypeOf((*MockSessionCleanupRepository)(nil).DeleteInactive), ctx, cutoff, limit)
+}
+
 // MockBlacklistRepository is a mock of BlacklistRepository interface.
 type MockBlacklistRepository struct {
 	ctrl     *gomock.Controller
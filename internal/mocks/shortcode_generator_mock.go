@@ -10,6 +10,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -40,16 +41,124 @@ func (m *MockShortCodeGenerator) EXPECT() *MockShortCodeGeneratorMockRecorder {
 }
 
 // Generate mocks base method.
-func (m *MockShortCodeGenerator) Generate(length int) (string, error) {
+func (m *MockShortCodeGenerator) Generate(ctx context.Context, length int) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Generate", length)
+	ret := m.ctrl.Call(m, "Generate", ctx, length)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Generate indicates an expected call of Generate.
-func (mr *MockShortCodeGeneratorMockRecorder) Generate(length any) *gomock.Call {
+func (mr *MockShortCodeGeneratorMockRecorder) Generate(ctx, length any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockShortCodeGenerator)(nil).Generate), length)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockShortCodeGenerator)(nil).Generate), ctx, length)
+}
+
+// MockShortCodeCounterRepository is a mock of ShortCodeCounterRepository interface.
+type MockShortCodeCounterRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShortCodeCounterRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockShortCodeCounterRepositoryMockRecorder is the mock recorder for MockShortCodeCounterRepository.
+type MockShortCodeCounterRepositoryMockRecorder struct {
+	mock *MockShortCodeCounterRepository
+}
+
+// NewMockShortCodeCounterRepository creates a new mock instance.
+func NewMockShortCodeCounterRepository(ctrl *gomock.Controller) *MockShortCodeCounterRepository {
+	mock := &MockShortCodeCounterRepository{ctrl: ctrl}
+	mock.recorder = &MockShortCodeCounterRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShortCodeCounterRepository) EXPECT() *MockShortCodeCounterRepositoryMockRecorder {
+	return m.recorder
+}
+
+// NextBlock mocks base method.
+func (m *MockShortCodeCounterRepository) NextBlock(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextBlock", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextBlock indicates an expected call of NextBlock.
+func (mr *MockShortCodeCounterRepositoryMockRecorder) NextBlock(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextBlock", reflect.TypeOf((*MockShortCodeCounterRepository)(nil).NextBlock), ctx)
+}
+
+// MockShortCodePoolRepository is a mock of ShortCodePoolRepository interface.
+type MockShortCodePoolRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShortCodePoolRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockShortCodePoolRepositoryMockRecorder is the mock recorder for MockShortCodePoolRepository.
+type MockShortCodePoolRepositoryMockRecorder struct {
+	mock *MockShortCodePoolRepository
+}
+
+// NewMockShortCodePoolRepository creates a new mock instance.
+func NewMockShortCodePoolRepository(ctrl *gomock.Controller) *MockShortCodePoolRepository {
+	mock := &MockShortCodePoolRepository{ctrl: ctrl}
+	mock.recorder = &MockShortCodePoolRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShortCodePoolRepository) EXPECT() *MockShortCodePoolRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockShortCodePoolRepository) Claim(ctx context.Context, length, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, length, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockShortCodePoolRepositoryMockRecorder) Claim(ctx, length, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockShortCodePoolRepository)(nil).Claim), ctx, length, limit)
+}
+
+// CountAvailable mocks base method.
+func (m *MockShortCodePoolRepository) CountAvailable(ctx context.Context, length int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAvailable", ctx, length)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAvailable indicates an expected call of CountAvailable.
+func (mr *MockShortCodePoolRepositoryMockRecorder) CountAvailable(ctx, length any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAvailable", reflect.TypeOf((*MockShortCodePoolRepository)(nil).CountAvailable), ctx, length)
+}
+
+// Fill mocks base method.
+func (m *MockShortCodePoolRepository) Fill(ctx context.Context, codes []string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fill", ctx, codes)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Fill indicates an expected call of Fill.
+func (mr *MockShortCodePoolRepositoryMockRecorder) Fill(ctx, codes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fill", reflect.TypeOf((*MockShortCodePoolRepository)(nil).Fill), ctx, codes)
 }
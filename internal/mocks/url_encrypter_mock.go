@@ -10,6 +10,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -40,31 +41,137 @@ func (m *MockURLEncrypter) EXPECT() *MockURLEncrypterMockRecorder {
 }
 
 // Decrypt mocks base method.
-func (m *MockURLEncrypter) Decrypt(arg0 string) (string, error) {
+func (m *MockURLEncrypter) Decrypt(ctx context.Context, text string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Decrypt", arg0)
+	ret := m.ctrl.Call(m, "Decrypt", ctx, text)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Decrypt indicates an expected call of Decrypt.
-func (mr *MockURLEncrypterMockRecorder) Decrypt(arg0 any) *gomock.Call {
+func (mr *MockURLEncrypterMockRecorder) Decrypt(ctx, text any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrypt", reflect.TypeOf((*MockURLEncrypter)(nil).Decrypt), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrypt", reflect.TypeOf((*MockURLEncrypter)(nil).Decrypt), ctx, text)
 }
 
 // Encrypt mocks base method.
-func (m *MockURLEncrypter) Encrypt(arg0 string) (string, error) {
+func (m *MockURLEncrypter) Encrypt(ctx context.Context, text string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Encrypt", arg0)
+	ret := m.ctrl.Call(m, "Encrypt", ctx, text)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Encrypt indicates an expected call of Encrypt.
-func (mr *MockURLEncrypterMockRecorder) Encrypt(arg0 any) *gomock.Call {
+func (mr *MockURLEncrypterMockRecorder) Encrypt(ctx, text any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encrypt", reflect.TypeOf((*MockURLEncrypter)(nil).Encrypt), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encrypt", reflect.TypeOf((*MockURLEncrypter)(nil).Encrypt), ctx, text)
+}
+
+// MockURLRotatingEncrypter is a mock of URLRotatingEncrypter interface.
+type MockURLRotatingEncrypter struct {
+	ctrl     *gomock.Controller
+	recorder *MockURLRotatingEncrypterMockRecorder
+	isgomock struct{}
+}
+
+// MockURLRotatingEncrypterMockRecorder is the mock recorder for MockURLRotatingEncrypter.
+type MockURLRotatingEncrypterMockRecorder struct {
+	mock *MockURLRotatingEncrypter
+}
+
+// NewMockURLRotatingEncrypter creates a new mock instance.
+func NewMockURLRotatingEncrypter(ctrl *gomock.Controller) *MockURLRotatingEncrypter {
+	mock := &MockURLRotatingEncrypter{ctrl: ctrl}
+	mock.recorder = &MockURLRotatingEncrypterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockURLRotatingEncrypter) EXPECT() *MockURLRotatingEncrypterMockRecorder {
+	return m.recorder
+}
+
+// Decrypt mocks base method.
+func (m *MockURLRotatingEncrypter) Decrypt(ctx context.Context, text string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decrypt", ctx, text)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decrypt indicates an expected call of Decrypt.
+func (mr *MockURLRotatingEncrypterMockRecorder) Decrypt(ctx, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrypt", reflect.TypeOf((*MockURLRotatingEncrypter)(nil).Decrypt), ctx, text)
+}
+
+// Encrypt mocks base method.
+func (m *MockURLRotatingEncrypter) Encrypt(ctx context.Context, text string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Encrypt", ctx, text)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Encrypt indicates an expected call of Encrypt.
+func (mr *MockURLRotatingEncrypterMockRecorder) Encrypt(ctx, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encrypt", reflect.TypeOf((*MockURLRotatingEncrypter)(nil).Encrypt), ctx, text)
+}
+
+// NeedsReencryption mocks base method.
+func (m *MockURLRotatingEncrypter) NeedsReencryption(text string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedsReencryption", text)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NeedsReencryption indicates an expected call of NeedsReencryption.
+func (mr *MockURLRotatingEncrypterMockRecorder) NeedsReencryption(text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsReencryption", reflect.TypeOf((*MockURLRotatingEncrypter)(nil).NeedsReencryption), text)
+}
+
+// MockURLBlindIndexer is a mock of URLBlindIndexer interface.
+type MockURLBlindIndexer struct {
+	ctrl     *gomock.Controller
+	recorder *MockURLBlindIndexerMockRecorder
+	isgomock struct{}
+}
+
+// MockURLBlindIndexerMockRecorder is the mock recorder for MockURLBlindIndexer.
+type MockURLBlindIndexerMockRecorder struct {
+	mock *MockURLBlindIndexer
+}
+
+// NewMockURLBlindIndexer creates a new mock instance.
+func NewMockURLBlindIndexer(ctrl *gomock.Controller) *MockURLBlindIndexer {
+	mock := &MockURLBlindIndexer{ctrl: ctrl}
+	mock.recorder = &MockURLBlindIndexerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockURLBlindIndexer) EXPECT() *MockURLBlindIndexerMockRecorder {
+	return m.recorder
+}
+
+// Index mocks base method.
+func (m *MockURLBlindIndexer) Index(normalizedURL string) []byte {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Index", normalizedURL)
+	ret0, _ := ret[0].([]byte)
+	return ret0
+}
+
+// Index indicates an expected call of Index.
+func (mr *MockURLBlindIndexerMockRecorder) Index(normalizedURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockURLBlindIndexer)(nil).Index), normalizedURL)
 }
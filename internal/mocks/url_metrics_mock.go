@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/url/metrics.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/url/metrics.go -destination=internal/mocks/url_metrics_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockURLMetrics is a mock of URLMetrics interface.
+type MockURLMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockURLMetricsMockRecorder
+	isgomock struct{}
+}
+
+// MockURLMetricsMockRecorder is the mock recorder for MockURLMetrics.
+type MockURLMetricsMockRecorder struct {
+	mock *MockURLMetrics
+}
+
+// NewMockURLMetrics creates a new mock instance.
+func NewMockURLMetrics(ctrl *gomock.Controller) *MockURLMetrics {
+	mock := &MockURLMetrics{ctrl: ctrl}
+	mock.recorder = &MockURLMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockURLMetrics) EXPECT() *MockURLMetricsMockRecorder {
+	return m.recorder
+}
+
+// CacheHit mocks base method.
+func (m *MockURLMetrics) CacheHit() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CacheHit")
+}
+
+// CacheHit indicates an expected call of CacheHit.
+func (mr *MockURLMetricsMockRecorder) CacheHit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheHit", reflect.TypeOf((*MockURLMetrics)(nil).CacheHit))
+}
+
+// CacheMiss mocks base method.
+func (m *MockURLMetrics) CacheMiss() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CacheMiss")
+}
+
+// CacheMiss indicates an expected call of CacheMiss.
+func (mr *MockURLMetricsMockRecorder) CacheMiss() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheMiss", reflect.TypeOf((*MockURLMetrics)(nil).CacheMiss))
+}
+
+// LocalCacheHit mocks base method.
+func (m *MockURLMetrics) LocalCacheHit() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LocalCacheHit")
+}
+
+// LocalCacheHit indicates an expected call of LocalCacheHit.
+func (mr *MockURLMetricsMockRecorder) LocalCacheHit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LocalCacheHit", reflect.TypeOf((*MockURLMetrics)(nil).LocalCacheHit))
+}
+
+// LocalCacheMiss mocks base method.
+func (m *MockURLMetrics) LocalCacheMiss() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LocalCacheMiss")
+}
+
+// LocalCacheMiss indicates an expected call of LocalCacheMiss.
+func (mr *MockURLMetricsMockRecorder) LocalCacheMiss() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LocalCacheMiss", reflect.TypeOf((*MockURLMetrics)(nil).LocalCacheMiss))
+}
+
+// ShortCodeCollision mocks base method.
+func (m *MockURLMetrics) ShortCodeCollision() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ShortCodeCollision")
+}
+
+// ShortCodeCollision indicates an expected call of ShortCodeCollision.
+func (mr *MockURLMetricsMockRecorder) ShortCodeCollision() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShortCodeCollision", reflect.TypeOf((*MockURLMetrics)(nil).ShortCodeCollision))
+}
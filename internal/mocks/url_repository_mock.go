@@ -43,6 +43,21 @@ func (m *MockURLRepository) EXPECT() *MockURLRepositoryMockRecorder {
 	return m.recorder
 }
 
+// DisableByShortCode mocks base method.
+func (m *MockURLRepository) DisableByShortCode(ctx context.Context, shortCode string) (*url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableByShortCode", ctx, shortCode)
+	ret0, _ := ret[0].(*url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableByShortCode indicates an expected call of DisableByShortCode.
+func (mr *MockURLRepositoryMockRecorder) DisableByShortCode(ctx, shortCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableByShortCode", reflect.TypeOf((*MockURLRepository)(nil).DisableByShortCode), ctx, shortCode)
+}
+
 // Exists mocks base method.
 func (m *MockURLRepository) Exists(ctx context.Context, shortCode string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -58,6 +73,21 @@ func (mr *MockURLRepositoryMockRecorder) Exists(ctx, shortCode any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockURLRepository)(nil).Exists), ctx, shortCode)
 }
 
+// FindActiveByDestination mocks base method.
+func (m *MockURLRepository) FindActiveByDestination(ctx context.Context, userID uuid.UUID, destinationIndex []byte) (*url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveByDestination", ctx, userID, destinationIndex)
+	ret0, _ := ret[0].(*url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveByDestination indicates an expected call of FindActiveByDestination.
+func (mr *MockURLRepositoryMockRecorder) FindActiveByDestination(ctx, userID, destinationIndex any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveByDestination", reflect.TypeOf((*MockURLRepository)(nil).FindActiveByDestination), ctx, userID, destinationIndex)
+}
+
 // FindByShortCode mocks base method.
 func (m *MockURLRepository) FindByShortCode(ctx context.Context, shortCode string) (*url.URL, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +132,114 @@ func (mr *MockURLRepositoryMockRecorder) SoftDelete(ctx, id, userID any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDelete", reflect.TypeOf((*MockURLRepository)(nil).SoftDelete), ctx, id, userID)
 }
 
+// MockURLReencryptionRepository is a mock of URLReencryptionRepository interface.
+type MockURLReencryptionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockURLReencryptionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockURLReencryptionRepositoryMockRecorder is the mock recorder for MockURLReencryptionRepository.
+type MockURLReencryptionRepositoryMockRecorder struct {
+	mock *MockURLReencryptionRepository
+}
+
+// NewMockURLReencryptionRepository creates a new mock instance.
+func NewMockURLReencryptionRepository(ctrl *gomock.Controller) *MockURLReencryptionRepository {
+	mock := &MockURLReencryptionRepository{ctrl: ctrl}
+	mock.recorder = &MockURLReencryptionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockURLReencryptionRepository) EXPECT() *MockURLReencryptionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListEncrypted mocks base method.
+func (m *MockURLReencryptionRepository) ListEncrypted(ctx context.Context, afterShortCode string, limit int) ([]*url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEncrypted", ctx, afterShortCode, limit)
+	ret0, _ := ret[0].([]*url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEncrypted indicates an expected call of ListEncrypted.
+func (mr *MockURLReencryptionRepositoryMockRecorder) ListEncrypted(ctx, afterShortCode, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEncrypted", reflect.TypeOf((*MockURLReencryptionRepository)(nil).ListEncrypted), ctx, afterShortCode, limit)
+}
+
+// UpdateEncryptedURL mocks base method.
+func (m *MockURLReencryptionRepository) UpdateEncryptedURL(ctx context.Context, shortCode, previous, encryptedURL string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEncryptedURL", ctx, shortCode, previous, encryptedURL)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEncryptedURL indicates an expected call of UpdateEncryptedURL.
+func (mr *MockURLReencryptionRepositoryMockRecorder) UpdateEncryptedURL(ctx, shortCode, previous, encryptedURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEncryptedURL", reflect.TypeOf((*MockURLReencryptionRepository)(nil).UpdateEncryptedURL), ctx, shortCode, previous, encryptedURL)
+}
+
+// MockURLCleanupRepository is a mock of URLCleanupRepository interface.
+type MockURLCleanupRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockURLCleanupRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockURLCleanupRepositoryMockRecorder is the mock recorder for MockURLCleanupRepository.
+type MockURLCleanupRepositoryMockRecorder struct {
+	mock *MockURLCleanupRepository
+}
+
+// NewMockURLCleanupRepository creates a new mock instance.
+func NewMockURLCleanupRepository(ctrl *gomock.Controller) *MockURLCleanupRepository {
+	mock := &MockURLCleanupRepository{ctrl: ctrl}
+	mock.recorder = &MockURLCleanupRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockURLCleanupRepository) EXPECT() *MockURLCleanupRepositoryMockRecorder {
+	return m.recorder
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockURLCleanupRepository) PurgeDeleted(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, cutoff, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockURLCleanupRepositoryMockRecorder) PurgeDeleted(ctx, cutoff, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockURLCleanupRepository)(nil).PurgeDeleted), ctx, cutoff, limit)
+}
+
+// SoftDeleteExpired mocks base method.
+func (m *MockURLCleanupRepository) SoftDeleteExpired(ctx context.Context, now time.Time, limit int) ([]*url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteExpired", ctx, now, limit)
+	ret0, _ := ret[0].([]*url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SoftDeleteExpired indicates an expected call of SoftDeleteExpired.
+func (mr *MockURLCleanupRepositoryMockRecorder) SoftDeleteExpired(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteExpired", reflect.TypeOf((*MockURLCleanupRepository)(nil).SoftDeleteExpired), ctx, now, limit)
+}
+
 // MockURLCacheRepository is a mock of URLCacheRepository interface.
 type MockURLCacheRepository struct {
 	ctrl     *gomock.Controller
@@ -184,6 +322,81 @@ func (mr *MockURLCacheRepositoryMockRecorder) Save(ctx, arg1, expires any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockURLCacheRepository)(nil).Save), ctx, arg1, expires)
 }
 
+// MockURLLocalCache is a mock of URLLocalCache interface.
+type MockURLLocalCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockURLLocalCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockURLLocalCacheMockRecorder is the mock recorder for MockURLLocalCache.
+type MockURLLocalCacheMockRecorder struct {
+	mock *MockURLLocalCache
+}
+
+// NewMockURLLocalCache creates a new mock instance.
+func NewMockURLLocalCache(ctrl *gomock.Controller) *MockURLLocalCache {
+	mock := &MockURLLocalCache{ctrl: ctrl}
+	mock.recorder = &MockURLLocalCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockURLLocalCache) EXPECT() *MockURLLocalCacheMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockURLLocalCache) Delete(shortCode string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Delete", shortCode)
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockURLLocalCacheMockRecorder) Delete(shortCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockURLLocalCache)(nil).Delete), shortCode)
+}
+
+// Get mocks base method.
+func (m *MockURLLocalCache) Get(shortCode string) (url.Redirect, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", shortCode)
+	ret0, _ := ret[0].(url.Redirect)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockURLLocalCacheMockRecorder) Get(shortCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockURLLocalCache)(nil).Get), shortCode)
+}
+
+// Purge mocks base method.
+func (m *MockURLLocalCache) Purge() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Purge")
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockURLLocalCacheMockRecorder) Purge() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockURLLocalCache)(nil).Purge))
+}
+
+// Set mocks base method.
+func (m *MockURLLocalCache) Set(shortCode string, redirect url.Redirect, ttl time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Set", shortCode, redirect, ttl)
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockURLLocalCacheMockRecorder) Set(shortCode, redirect, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockURLLocalCache)(nil).Set), shortCode, redirect, ttl)
+}
+
 // MockURLQueryRepository is a mock of URLQueryRepository interface.
 type MockURLQueryRepository struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,76 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/webhook/metrics.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/webhook/metrics.go -destination=internal/mocks/webhook_metrics_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookMetrics is a mock of WebhookMetrics interface.
+type MockWebhookMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMetricsMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookMetricsMockRecorder is the mock recorder for MockWebhookMetrics.
+type MockWebhookMetricsMockRecorder struct {
+	mock *MockWebhookMetrics
+}
+
+// NewMockWebhookMetrics creates a new mock instance.
+func NewMockWebhookMetrics(ctrl *gomock.Controller) *MockWebhookMetrics {
+	mock := &MockWebhookMetrics{ctrl: ctrl}
+	mock.recorder = &MockWebhookMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookMetrics) EXPECT() *MockWebhookMetricsMockRecorder {
+	return m.recorder
+}
+
+// DeliveryFailed mocks base method.
+func (m *MockWebhookMetrics) DeliveryFailed(dead bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeliveryFailed", dead)
+}
+
+// DeliveryFailed indicates an expected call of DeliveryFailed.
+func (mr *MockWebhookMetricsMockRecorder) DeliveryFailed(dead any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliveryFailed", reflect.TypeOf((*MockWebhookMetrics)(nil).DeliveryFailed), dead)
+}
+
+// DeliverySucceeded mocks base method.
+func (m *MockWebhookMetrics) DeliverySucceeded() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeliverySucceeded")
+}
+
+// DeliverySucceeded indicates an expected call of DeliverySucceeded.
+func (mr *MockWebhookMetricsMockRecorder) DeliverySucceeded() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverySucceeded", reflect.TypeOf((*MockWebhookMetrics)(nil).DeliverySucceeded))
+}
+
+// EventDropped mocks base method.
+func (m *MockWebhookMetrics) EventDropped() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EventDropped")
+}
+
+// EventDropped indicates an expected call of EventDropped.
+func (mr *MockWebhookMetricsMockRecorder) EventDropped() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventDropped", reflect.TypeOf((*MockWebhookMetrics)(nil).EventDropped))
+}
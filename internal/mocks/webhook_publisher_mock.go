@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/webhook/publisher.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/webhook/publisher.go -destination=internal/mocks/webhook_publisher_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	webhook "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookPublisher is a mock of WebhookPublisher interface.
+type MockWebhookPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookPublisherMockRecorder is the mock recorder for MockWebhookPublisher.
+type MockWebhookPublisherMockRecorder struct {
+	mock *MockWebhookPublisher
+}
+
+// NewMockWebhookPublisher creates a new mock instance.
+func NewMockWebhookPublisher(ctrl *gomock.Controller) *MockWebhookPublisher {
+	mock := &MockWebhookPublisher{ctrl: ctrl}
+	mock.recorder = &MockWebhookPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookPublisher) EXPECT() *MockWebhookPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockWebhookPublisher) Publish(ctx context.Context, userID uuid.UUID, e webhook.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, userID, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockWebhookPublisherMockRecorder) Publish(ctx, userID, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockWebhookPublisher)(nil).Publish), ctx, userID, e)
+}
+
+// PublishToOwner mocks base method.
+func (m *MockWebhookPublisher) PublishToOwner(ctx context.Context, shortCode string, e webhook.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishToOwner", ctx, shortCode, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishToOwner indicates an expected call of PublishToOwner.
+func (mr *MockWebhookPublisherMockRecorder) PublishToOwner(ctx, shortCode, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishToOwner", reflect.TypeOf((*MockWebhookPublisher)(nil).PublishToOwner), ctx, shortCode, e)
+}
@@ -0,0 +1,268 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/webhook/repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/webhook/repository.go -destination=internal/mocks/webhook_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	webhook "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookEndpointRepository is a mock of WebhookEndpointRepository interface.
+type MockWebhookEndpointRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookEndpointRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookEndpointRepositoryMockRecorder is the mock recorder for MockWebhookEndpointRepository.
+type MockWebhookEndpointRepositoryMockRecorder struct {
+	mock *MockWebhookEndpointRepository
+}
+
+// NewMockWebhookEndpointRepository creates a new mock instance.
+func NewMockWebhookEndpointRepository(ctrl *gomock.Controller) *MockWebhookEndpointRepository {
+	mock := &MockWebhookEndpointRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookEndpointRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookEndpointRepository) EXPECT() *MockWebhookEndpointRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByUserID mocks base method.
+func (m *MockWebhookEndpointRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByUserID", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByUserID indicates an expected call of CountByUserID.
+func (mr *MockWebhookEndpointRepositoryMockRecorder) CountByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByUserID", reflect.TypeOf((*MockWebhookEndpointRepository)(nil).CountByUserID), ctx, userID)
+}
+
+// Create mocks base method.
+func (m *MockWebhookEndpointRepository) Create(ctx context.Context, e *webhook.Endpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookEndpointRepositoryMockRecorder) Create(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookEndpointRepository)(nil).Create), ctx, e)
+}
+
+// Delete mocks base method.
+func (m *MockWebhookEndpointRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookEndpointRepositoryMockRecorder) Delete(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookEndpointRepository)(nil).Delete), ctx, id, userID)
+}
+
+// FindByID mocks base method.
+func (m *MockWebhookEndpointRepository) FindByID(ctx context.Context, id, userID uuid.UUID) (*webhook.Endpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id, userID)
+	ret0, _ := ret[0].(*webhook.Endpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockWebhookEndpointRepositoryMockRecorder) FindByID(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockWebhookEndpointRepository)(nil).FindByID), ctx, id, userID)
+}
+
+// ListByUserID mocks base method.
+func (m *MockWebhookEndpointRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]webhook.Endpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUserID", ctx, userID)
+	ret0, _ := ret[0].([]webhook.Endpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUserID indicates an expected call of ListByUserID.
+func (mr *MockWebhookEndpointRepositoryMockRecorder) ListByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockWebhookEndpointRepository)(nil).ListByUserID), ctx, userID)
+}
+
+// MockWebhookDeliveryRepository is a mock of WebhookDeliveryRepository interface.
+type MockWebhookDeliveryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookDeliveryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookDeliveryRepositoryMockRecorder is the mock recorder for MockWebhookDeliveryRepository.
+type MockWebhookDeliveryRepositoryMockRecorder struct {
+	mock *MockWebhookDeliveryRepository
+}
+
+// NewMockWebhookDeliveryRepository creates a new mock instance.
+func NewMockWebhookDeliveryRepository(ctrl *gomock.Controller) *MockWebhookDeliveryRepository {
+	mock := &MockWebhookDeliveryRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookDeliveryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookDeliveryRepository) EXPECT() *MockWebhookDeliveryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDue mocks base method.
+func (m *MockWebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*webhook.DueDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDue", ctx, now, lease, limit)
+	ret0, _ := ret[0].([]*webhook.DueDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDue indicates an expected call of ClaimDue.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) ClaimDue(ctx, now, lease, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDue", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).ClaimDue), ctx, now, lease, limit)
+}
+
+// DeleteFinished mocks base method.
+func (m *MockWebhookDeliveryRepository) DeleteFinished(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFinished", ctx, cutoff, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFinished indicates an expected call of DeleteFinished.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) DeleteFinished(ctx, cutoff, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFinished", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).DeleteFinished), ctx, cutoff, limit)
+}
+
+// Enqueue mocks base method.
+func (m *MockWebhookDeliveryRepository) Enqueue(ctx context.Context, endpointID uuid.UUID, e webhook.Event) (*webhook.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", ctx, endpointID, e)
+	ret0, _ := ret[0].(*webhook.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) Enqueue(ctx, endpointID, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).Enqueue), ctx, endpointID, e)
+}
+
+// MarkFailed mocks base method.
+func (m *MockWebhookDeliveryRepository) MarkFailed(ctx context.Context, id uuid.UUID, result webhook.AttemptResult, nextAttemptAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, id, result, nextAttemptAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) MarkFailed(ctx, id, result, nextAttemptAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).MarkFailed), ctx, id, result, nextAttemptAt)
+}
+
+// MarkSucceeded mocks base method.
+func (m *MockWebhookDeliveryRepository) MarkSucceeded(ctx context.Context, id uuid.UUID, result webhook.AttemptResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSucceeded", ctx, id, result)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSucceeded indicates an expected call of MarkSucceeded.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) MarkSucceeded(ctx, id, result any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSucceeded", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).MarkSucceeded), ctx, id, result)
+}
+
+// Retry mocks base method.
+func (m *MockWebhookDeliveryRepository) Retry(ctx context.Context, id, endpointID, userID uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", ctx, id, endpointID, userID, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) Retry(ctx, id, endpointID, userID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).Retry), ctx, id, endpointID, userID, now)
+}
+
+// MockWebhookDeliveryQueryRepository is a mock of WebhookDeliveryQueryRepository interface.
+type MockWebhookDeliveryQueryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookDeliveryQueryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookDeliveryQueryRepositoryMockRecorder is the mock recorder for MockWebhookDeliveryQueryRepository.
+type MockWebhookDeliveryQueryRepositoryMockRecorder struct {
+	mock *MockWebhookDeliveryQueryRepository
+}
+
+// NewMockWebhookDeliveryQueryRepository creates a new mock instance.
+func NewMockWebhookDeliveryQueryRepository(ctrl *gomock.Controller) *MockWebhookDeliveryQueryRepository {
+	mock := &MockWebhookDeliveryQueryRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookDeliveryQueryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookDeliveryQueryRepository) EXPECT() *MockWebhookDeliveryQueryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockWebhookDeliveryQueryRepository) List(ctx context.Context, params webhook.ListDeliveriesParams) ([]webhook.Delivery, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, params)
+	ret0, _ := ret[0].([]webhook.Delivery)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockWebhookDeliveryQueryRepositoryMockRecorder) List(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWebhookDeliveryQueryRepository)(nil).List), ctx, params)
+}
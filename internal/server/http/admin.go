@@ -0,0 +1,26 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type AdminServerConfig struct {
+	MetricsHandler http.Handler
+	Timeouts       ServerTimeouts
+}
+
+func NewAdminServer(addr string, config AdminServerConfig) *http.Server {
+	r := chi.NewRouter()
+	r.Method(http.MethodGet, "/metrics", config.MetricsHandler)
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           r,
+		IdleTimeout:       config.Timeouts.Idle,
+		ReadTimeout:       config.Timeouts.Read,
+		WriteTimeout:      config.Timeouts.Write,
+		ReadHeaderTimeout: config.Timeouts.ReadHeader,
+	}
+}
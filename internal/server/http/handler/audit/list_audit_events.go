@@ -0,0 +1,200 @@
+package http_handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/audit/query"
+	"github.com/brunoibarbosa/url-shortener/internal/domain"
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	"github.com/brunoibarbosa/url-shortener/pkg/errors"
+	"github.com/google/uuid"
+)
+
+// maxListLimit bounds the page size, so one request cannot scan the whole
+// audit table.
+const maxListLimit = 100
+
+type ListAuditEventsParams struct {
+	Limit     uint64
+	Page      uint64
+	SortKind  domain.SortKind
+	ActorID   *uuid.UUID
+	Action    *audit_domain.Action
+	TargetID  *string
+	IPAddress *string
+	From      *time.Time
+	To        *time.Time
+}
+
+type AuditEventItem struct {
+	ID         int64      `json:"id"`
+	ActorID    *uuid.UUID `json:"actorId"`
+	Action     string     `json:"action"`
+	TargetType string     `json:"targetType"`
+	TargetID   string     `json:"targetId"`
+	IPAddress  string     `json:"ipAddress"`
+	UserAgent  string     `json:"userAgent"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+type ListAuditEvents200Response struct {
+	Data  []AuditEventItem `json:"data"`
+	Count uint64           `json:"count"`
+	Page  uint64           `json:"page"`
+	Limit uint64           `json:"limit"`
+}
+
+type ListAuditEventsHTTPHandler struct {
+	qry *query.ListAuditEventsHandler
+}
+
+func NewListAuditEventsHTTPHandler(qry *query.ListAuditEventsHandler) *ListAuditEventsHTTPHandler {
+	return &ListAuditEventsHTTPHandler{qry}
+}
+
+func (h *ListAuditEventsHTTPHandler) Handle(w http.ResponseWriter, r *http.Request) *http_handler.HTTPError {
+	ctx := r.Context()
+
+	payload, validationErr := validateListAuditEventsParams(r, ctx, true)
+	if validationErr != nil {
+		return validationErr
+	}
+
+	list, count, handleErr := h.qry.Handle(ctx, payload.toDomain())
+	if handleErr != nil {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusInternalServerError, errors.CodeInternalError, "error.server.internal", nil)
+	}
+
+	response := ListAuditEvents200Response{
+		Data:  toAuditEventItems(list),
+		Count: count,
+		Page:  payload.Page,
+		Limit: payload.Limit,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if encodeErr := json.NewEncoder(w).Encode(response); encodeErr != nil {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusInternalServerError, errors.CodeInternalError, "error.common.encode_failed", nil)
+	}
+
+	return nil
+}
+
+func (p ListAuditEventsParams) toDomain() audit_domain.ListEventsParams {
+	return audit_domain.ListEventsParams{
+		Filter: audit_domain.ListEventsFilter{
+			ActorID:   p.ActorID,
+			Action:    p.Action,
+			TargetID:  p.TargetID,
+			IPAddress: p.IPAddress,
+			From:      p.From,
+			To:        p.To,
+		},
+		SortKind: p.SortKind,
+		Pagination: domain.Pagination{
+			Number: p.Page,
+			Size:   p.Limit,
+		},
+	}
+}
+
+func validateListAuditEventsParams(r *http.Request, ctx context.Context, allowActorFilters bool) (ListAuditEventsParams, *http_handler.HTTPError) {
+	var params ListAuditEventsParams
+
+	ec := http_handler.NewErrorCollector(ctx)
+	q := r.URL.Query()
+
+	v := q.Get("page")
+	if v == "" {
+		ec.AddFieldError("page", "error.details.field_required")
+	} else {
+		page, parseErr := strconv.ParseUint(v, 10, 64)
+		if page == 0 || parseErr != nil {
+			ec.AddFieldError("page", "error.details.parameter_must_be_positive")
+		}
+		params.Page = page
+	}
+
+	v = q.Get("limit")
+	if v == "" {
+		ec.AddFieldError("limit", "error.details.field_required")
+	} else {
+		limit, parseErr := strconv.ParseUint(v, 10, 64)
+		if limit == 0 || parseErr != nil {
+			ec.AddFieldError("limit", "error.details.parameter_must_be_positive")
+		} else if limit > maxListLimit {
+			ec.AddFieldError("limit", "error.details.limit.too_large")
+		}
+		params.Limit = limit
+	}
+
+	if ec.HasErrors() {
+		return ListAuditEventsParams{}, ec.ToHTTPError(http.StatusBadRequest, errors.CodeValidationError, "error.common.required_pagination")
+	}
+
+	v = q.Get("sortKind")
+	var sortKind = domain.SortNone
+	if v != "" {
+		switch strings.ToUpper(v) {
+		case "ASC":
+			sortKind = domain.SortAsc
+		case "DESC":
+			sortKind = domain.SortDesc
+		default:
+			ec.AddFieldError("sortKind", "error.details.parameter_invalid_sort")
+		}
+	}
+	params.SortKind = sortKind
+
+	if v = q.Get("action"); v != "" {
+		action := audit_domain.Action(v)
+		params.Action = &action
+	}
+
+	if v = q.Get("from"); v != "" {
+		from, parseErr := time.Parse(time.RFC3339, v)
+		if parseErr != nil {
+			ec.AddFieldError("from", "error.details.parameter_invalid")
+		}
+		params.From = &from
+	}
+
+	if v = q.Get("to"); v != "" {
+		to, parseErr := time.Parse(time.RFC3339, v)
+		if parseErr != nil {
+			ec.AddFieldError("to", "error.details.parameter_invalid")
+		}
+		params.To = &to
+	}
+
+	if allowActorFilters {
+		if v = q.Get("actorId"); v != "" {
+			actorID, parseErr := uuid.Parse(v)
+			if parseErr != nil {
+				ec.AddFieldError("actorId", "error.details.parameter_invalid")
+			}
+			params.ActorID = &actorID
+		}
+
+		if targetID := q.Get("targetId"); targetID != "" {
+			params.TargetID = &targetID
+		}
+
+		if ip := q.Get("ipAddress"); ip != "" {
+			params.IPAddress = &ip
+		}
+	}
+
+	if ec.HasErrors() {
+		return ListAuditEventsParams{}, ec.ToHTTPError(http.StatusBadRequest, errors.CodeValidationError, "error.validation.failed")
+	}
+
+	return params, nil
+}
@@ -0,0 +1,35 @@
+package http_handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	audit_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAuditEventsHTTPHandler_RejectsInvalidLimits(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+	}{
+		{"missing limit", "page=1"},
+		{"zero limit", "page=1&limit=0"},
+		{"limit above the maximum", "page=1&limit=101"},
+	}
+
+	h := audit_handler.NewListAuditEventsHTTPHandler(nil)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/audit-events?"+tc.query, nil)
+
+			httpErr := h.Handle(httptest.NewRecorder(), req)
+
+			require.NotNil(t, httpErr)
+			assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+		})
+	}
+}
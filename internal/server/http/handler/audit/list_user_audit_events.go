@@ -0,0 +1,72 @@
+package http_handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/audit/query"
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	http_middleware "github.com/brunoibarbosa/url-shortener/internal/server/http/middleware"
+	"github.com/brunoibarbosa/url-shortener/pkg/errors"
+	"github.com/google/uuid"
+)
+
+type ListUserAuditEventsHTTPHandler struct {
+	qry *query.ListAuditEventsHandler
+}
+
+func NewListUserAuditEventsHTTPHandler(qry *query.ListAuditEventsHandler) *ListUserAuditEventsHTTPHandler {
+	return &ListUserAuditEventsHTTPHandler{qry}
+}
+
+func (h *ListUserAuditEventsHTTPHandler) Handle(w http.ResponseWriter, r *http.Request) *http_handler.HTTPError {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(http_middleware.UserIDKey).(uuid.UUID)
+	if !ok {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusUnauthorized, errors.CodeUnauthorized, "error.session.missing_access_token", nil)
+	}
+
+	payload, validationErr := validateListAuditEventsParams(r, ctx, false)
+	if validationErr != nil {
+		return validationErr
+	}
+
+	list, count, handleErr := h.qry.HandleForUser(ctx, userID, payload.toDomain())
+	if handleErr != nil {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusInternalServerError, errors.CodeInternalError, "error.server.internal", nil)
+	}
+
+	response := ListAuditEvents200Response{
+		Data:  toAuditEventItems(list),
+		Count: count,
+		Page:  payload.Page,
+		Limit: payload.Limit,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if encodeErr := json.NewEncoder(w).Encode(response); encodeErr != nil {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusInternalServerError, errors.CodeInternalError, "error.common.encode_failed", nil)
+	}
+
+	return nil
+}
+
+func toAuditEventItems(list []audit_domain.ListEventsDTO) []AuditEventItem {
+	events := make([]AuditEventItem, len(list))
+	for i, dto := range list {
+		events[i] = AuditEventItem{
+			ID:         dto.ID,
+			ActorID:    dto.ActorID,
+			Action:     string(dto.Action),
+			TargetType: dto.TargetType,
+			TargetID:   dto.TargetID,
+			IPAddress:  dto.IPAddress,
+			UserAgent:  dto.UserAgent,
+			CreatedAt:  dto.CreatedAt,
+		}
+	}
+	return events
+}
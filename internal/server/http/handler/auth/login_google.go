@@ -10,6 +10,7 @@ import (
 	"github.com/brunoibarbosa/url-shortener/internal/app/auth/command"
 	session_domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
 	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	http_middleware "github.com/brunoibarbosa/url-shortener/internal/server/http/middleware"
 	pkg_errors "github.com/brunoibarbosa/url-shortener/pkg/errors"
 )
 
@@ -46,7 +47,7 @@ func (h *LoginGoogleHTTPHandler) Handle(w http.ResponseWriter, r *http.Request)
 		Code:      payload.Code,
 		State:     payload.State,
 		UserAgent: r.UserAgent(),
-		IPAddress: r.RemoteAddr,
+		IPAddress: http_middleware.ClientIP(r),
 	}
 
 	accessToken, refreshToken, err := h.cmd.Handle(ctx, appCmd)
@@ -11,6 +11,7 @@ import (
 	"github.com/brunoibarbosa/url-shortener/internal/app/auth/command"
 	domain "github.com/brunoibarbosa/url-shortener/internal/domain/user"
 	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	http_middleware "github.com/brunoibarbosa/url-shortener/internal/server/http/middleware"
 	"github.com/brunoibarbosa/url-shortener/internal/validation"
 	"github.com/brunoibarbosa/url-shortener/pkg/errors"
 )
@@ -48,7 +49,7 @@ func (h *LoginUserHTTPHandler) Handle(w http.ResponseWriter, r *http.Request) *h
 		Email:     payload.Email,
 		Password:  payload.Password,
 		UserAgent: r.UserAgent(),
-		IPAddress: r.RemoteAddr,
+		IPAddress: http_middleware.ClientIP(r),
 	}
 	accessToken, refreshToken, handleErr := h.cmd.Handle(r.Context(), appCmd)
 	if handleErr != nil {
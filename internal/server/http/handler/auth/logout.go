@@ -8,6 +8,7 @@ import (
 	"github.com/brunoibarbosa/url-shortener/internal/app/auth/command"
 	sd "github.com/brunoibarbosa/url-shortener/internal/domain/session"
 	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	http_middleware "github.com/brunoibarbosa/url-shortener/internal/server/http/middleware"
 	"github.com/brunoibarbosa/url-shortener/pkg/errors"
 )
 
@@ -35,6 +36,8 @@ func (h *LogoutHTTPHandler) Handle(w http.ResponseWriter, r *http.Request) *http
 
 	appCmd := command.LogoutCommand{
 		RefreshToken: payload.RefreshToken,
+		UserAgent:    r.UserAgent(),
+		IPAddress:    http_middleware.ClientIP(r),
 	}
 	handleErr := h.cmd.Handle(r.Context(), appCmd)
 	if handleErr != nil {
@@ -10,6 +10,7 @@ import (
 	"github.com/brunoibarbosa/url-shortener/internal/app/auth/command"
 	sd "github.com/brunoibarbosa/url-shortener/internal/domain/session"
 	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	http_middleware "github.com/brunoibarbosa/url-shortener/internal/server/http/middleware"
 	"github.com/brunoibarbosa/url-shortener/pkg/errors"
 )
 
@@ -44,7 +45,7 @@ func (h *RefreshTokenHTTPHandler) Handle(w http.ResponseWriter, r *http.Request)
 	appCmd := command.RefreshTokenCommand{
 		RefreshToken: payload.RefreshToken,
 		UserAgent:    r.UserAgent(),
-		IPAddress:    r.RemoteAddr,
+		IPAddress:    http_middleware.ClientIP(r),
 	}
 	response, handleErr := h.cmd.Handle(r.Context(), appCmd)
 	if handleErr != nil {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/brunoibarbosa/url-shortener/internal/app/auth/command"
 	domain "github.com/brunoibarbosa/url-shortener/internal/domain/user"
+	"github.com/brunoibarbosa/url-shortener/internal/i18n"
 	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
 	"github.com/brunoibarbosa/url-shortener/internal/validation"
 	"github.com/brunoibarbosa/url-shortener/pkg/errors"
@@ -20,10 +21,13 @@ type RegisterUserPayload struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 	Name     string `json:"name"`
+	// Locale replaces Accept-Language for the user once they log in.
+	Locale *string `json:"locale"`
 }
 
 type RegisterUserProfile201Response struct {
-	Name string `json:"name"`
+	Name   string  `json:"name"`
+	Locale *string `json:"locale,omitempty"`
 }
 
 type RegisterUser201Response struct {
@@ -51,7 +55,7 @@ func (h *RegisterUserHTTPHandler) Handle(w http.ResponseWriter, r *http.Request)
 		return validationErr
 	}
 
-	appCmd := command.RegisterUserCommand{Email: payload.Email, Password: payload.Password, Name: payload.Name}
+	appCmd := command.RegisterUserCommand{Email: payload.Email, Password: payload.Password, Name: payload.Name, Locale: payload.Locale}
 	user, handleErr := h.cmd.Handle(r.Context(), appCmd)
 	if handleErr != nil {
 		switch {
@@ -66,7 +70,8 @@ func (h *RegisterUserHTTPHandler) Handle(w http.ResponseWriter, r *http.Request)
 		ID:    user.ID,
 		Email: user.Email,
 		Profile: RegisterUserProfile201Response{
-			Name: user.Profile.Name,
+			Name:   user.Profile.Name,
+			Locale: user.Profile.Locale,
 		},
 		CreatedAt: user.CreatedAt,
 	}
@@ -121,6 +126,15 @@ func validateRegisterPayload(r *http.Request, ctx context.Context) (RegisterUser
 		ec.AddFieldError("name", "error.details.field_required")
 	}
 
+	if payload.Locale != nil {
+		if tag, ok := i18n.ParseLocale(*payload.Locale); ok {
+			locale := tag.String()
+			payload.Locale = &locale
+		} else {
+			ec.AddFieldError("locale", "error.details.locale.unsupported")
+		}
+	}
+
 	if ec.HasErrors() {
 		return RegisterUserPayload{}, ec.ToHTTPError(http.StatusBadRequest, errors.CodeValidationError, "error.validation.failed")
 	}
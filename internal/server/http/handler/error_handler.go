@@ -8,11 +8,14 @@ import (
 	myi18n "github.com/brunoibarbosa/url-shortener/internal/i18n"
 )
 
+const RequestIDHeader = "X-Request-ID"
+
 type ErrorDetail struct {
-	Code    string `json:"code"`
-	SubCode string `json:"sub_code"`
-	Message string `json:"message"`
-	Details []any  `json:"details,omitempty"`
+	Code      string `json:"code"`
+	SubCode   string `json:"sub_code"`
+	Message   string `json:"message"`
+	Details   []any  `json:"details,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
 }
 
 type ErrorResponse struct {
@@ -45,47 +48,42 @@ func NewI18nHTTPError(ctx context.Context, status int, code, messageID string, d
 	}
 }
 
-func WriteI18nJSONError(ctx context.Context, w http.ResponseWriter, status int, code string, messageID string, details ErrorDetails) {
-	var detailsArray []any
-	if details != nil {
-		detailsArray = details.ToArray()
+// WriteError writes err as a problem details document (RFC 9457) when the
+// client asks for application/problem+json, and as ErrorResponse otherwise.
+func WriteError(w http.ResponseWriter, r *http.Request, err *HTTPError) {
+	requestID := w.Header().Get(RequestIDHeader)
+	w.Header().Add("Vary", "Accept")
+
+	if AcceptsProblemJSON(r) {
+		w.Header().Set("Content-Type", ProblemContentType)
+		w.WriteHeader(err.Status)
+		json.NewEncoder(w).Encode(NewProblem(r, err, requestID))
+		return
 	}
+
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
+	w.WriteHeader(err.Status)
 
 	json.NewEncoder(w).Encode(ErrorResponse{
 		Error: ErrorDetail{
-			Message: myi18n.T(ctx, messageID, nil),
-			Code:    code,
-			SubCode: messageID,
-			Details: detailsArray,
+			Message:   err.Message,
+			Code:      err.Code,
+			SubCode:   err.SubCode,
+			Details:   err.Details,
+			RequestID: requestID,
 		},
 	})
 }
 
-func WriteJSONError(w http.ResponseWriter, status int, code, message, subCode string) {
-	WriteJSONErrorWithDetails(w, status, code, message, subCode, nil)
-}
-
-func WriteJSONErrorWithDetails(w http.ResponseWriter, status int, code, message, subCode string, details []any) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-
-	json.NewEncoder(w).Encode(ErrorResponse{
-		Error: ErrorDetail{
-			Message: message,
-			Code:    code,
-			SubCode: subCode,
-			Details: details,
-		},
-	})
+func WriteI18nError(w http.ResponseWriter, r *http.Request, status int, code, messageID string, details ErrorDetails) {
+	WriteError(w, r, NewI18nHTTPError(r.Context(), status, code, messageID, details))
 }
 
 func RequestValidator(h HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		err := h(w, r)
 		if err != nil {
-			WriteJSONErrorWithDetails(w, err.Status, err.Code, err.Message, err.SubCode, err.Details)
+			WriteError(w, r, err)
 		}
 	}
 }
@@ -0,0 +1,63 @@
+package http_handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteError(t *testing.T) {
+	httpErr := &http_handler.HTTPError{
+		Status:  http.StatusNotFound,
+		Code:    "NOT_FOUND",
+		SubCode: "url_not_found",
+		Message: "url not found",
+	}
+
+	testCases := []struct {
+		name        string
+		accept      string
+		contentType string
+		body        string
+	}{
+		{
+			"writes error response by default",
+			"",
+			"application/json",
+			`{"error":{"code":"NOT_FOUND","sub_code":"url_not_found","message":"url not found","request_id":"req-1"}}`,
+		},
+		{
+			"writes error response when json is preferred",
+			"application/json, application/problem+json;q=0.5",
+			"application/json",
+			`{"error":{"code":"NOT_FOUND","sub_code":"url_not_found","message":"url not found","request_id":"req-1"}}`,
+		},
+		{
+			"writes problem details when asked",
+			"application/problem+json",
+			http_handler.ProblemContentType,
+			`{"type":"urn:url-shortener:problem:url_not_found","title":"Not Found","status":404,"detail":"url not found","instance":"/abc123","code":"NOT_FOUND","sub_code":"url_not_found","request_id":"req-1"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+			rec := httptest.NewRecorder()
+			rec.Header().Set(http_handler.RequestIDHeader, "req-1")
+
+			http_handler.WriteError(rec, req, httpErr)
+
+			assert.Equal(t, http.StatusNotFound, rec.Code)
+			assert.Equal(t, tc.contentType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, []string{"Accept"}, rec.Header().Values("Vary"))
+			assert.JSONEq(t, tc.body, rec.Body.String())
+		})
+	}
+}
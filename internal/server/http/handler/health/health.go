@@ -0,0 +1,132 @@
+package http_handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+)
+
+const (
+	StatusUp       = "up"
+	StatusDown     = "down"
+	StatusDegraded = "degraded"
+)
+
+type Check struct {
+	Name  string
+	Check func(ctx context.Context) error
+	// Optional dependencies have a fallback: when they are down the service
+	// is degraded but still ready.
+	Optional bool
+	// Breaker, if set, reports the state of the circuit breaker guarding the
+	// dependency.
+	Breaker func() string
+}
+
+type DependencyStatus struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latencyMs"`
+	Breaker   string `json:"breaker,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type Health200Response struct {
+	Status string `json:"status"`
+}
+
+type Readiness200Response struct {
+	Status string                      `json:"status"`
+	Checks map[string]DependencyStatus `json:"checks"`
+}
+
+type HealthHTTPHandler struct {
+	checks       []Check
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+func NewHealthHTTPHandler(timeout time.Duration, checks ...Check) *HealthHTTPHandler {
+	return &HealthHTTPHandler{
+		checks:  checks,
+		timeout: timeout,
+	}
+}
+
+// MarkShuttingDown makes readiness fail so load balancers stop routing new
+// traffic while in-flight requests drain.
+func (h *HealthHTTPHandler) MarkShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+func (h *HealthHTTPHandler) Live(w http.ResponseWriter, r *http.Request) *http_handler.HTTPError {
+	writeJSON(w, http.StatusOK, Health200Response{Status: StatusUp})
+	return nil
+}
+
+func (h *HealthHTTPHandler) Ready(w http.ResponseWriter, r *http.Request) *http_handler.HTTPError {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	checks := make(map[string]DependencyStatus, len(h.checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, c := range h.checks {
+		wg.Add(1)
+		go func(c Check) {
+			defer wg.Done()
+
+			start := time.Now()
+			err := c.Check(ctx)
+
+			status := DependencyStatus{Status: StatusUp, LatencyMs: time.Since(start).Milliseconds()}
+			if err != nil {
+				status.Status = StatusDown
+				status.Error = err.Error()
+			}
+			if c.Breaker != nil {
+				status.Breaker = c.Breaker()
+			}
+
+			mu.Lock()
+			checks[c.Name] = status
+			mu.Unlock()
+		}(c)
+	}
+	wg.Wait()
+
+	response := Readiness200Response{Status: StatusUp, Checks: checks}
+	for _, c := range h.checks {
+		if checks[c.Name].Status == StatusUp {
+			continue
+		}
+		if !c.Optional {
+			response.Status = StatusDown
+		} else if response.Status == StatusUp {
+			response.Status = StatusDegraded
+		}
+	}
+	if h.shuttingDown.Load() {
+		response.Status = StatusDown
+	}
+
+	status := http.StatusOK
+	if response.Status == StatusDown {
+		status = http.StatusServiceUnavailable
+	}
+
+	writeJSON(w, status, response)
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
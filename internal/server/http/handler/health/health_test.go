@@ -0,0 +1,121 @@
+package http_handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	health "github.com/brunoibarbosa/url-shortener/internal/server/http/handler/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func up(context.Context) error   { return nil }
+func down(context.Context) error { return errors.New("connection refused") }
+
+func ready(t *testing.T, h *health.HealthHTTPHandler) (int, health.Readiness200Response) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	require.Nil(t, h.Ready(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil)))
+
+	var body health.Readiness200Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return rec.Code, body
+}
+
+func TestReady(t *testing.T) {
+	testCases := []struct {
+		name           string
+		checks         []health.Check
+		expectedCode   int
+		expectedStatus string
+	}{
+		{
+			"all checks up",
+			[]health.Check{{Name: "postgres", Check: up}, {Name: "redis", Check: up, Optional: true}},
+			http.StatusOK,
+			health.StatusUp,
+		},
+		{
+			"required check down",
+			[]health.Check{{Name: "postgres", Check: down}, {Name: "redis", Check: up, Optional: true}},
+			http.StatusServiceUnavailable,
+			health.StatusDown,
+		},
+		{
+			"optional check down",
+			[]health.Check{{Name: "postgres", Check: up}, {Name: "redis", Check: down, Optional: true}},
+			http.StatusOK,
+			health.StatusDegraded,
+		},
+		{
+			"required and optional checks down",
+			[]health.Check{{Name: "postgres", Check: down}, {Name: "redis", Check: down, Optional: true}},
+			http.StatusServiceUnavailable,
+			health.StatusDown,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, body := ready(t, health.NewHealthHTTPHandler(time.Second, tc.checks...))
+
+			assert.Equal(t, tc.expectedCode, code)
+			assert.Equal(t, tc.expectedStatus, body.Status)
+			assert.Len(t, body.Checks, len(tc.checks))
+		})
+	}
+}
+
+func TestReady_ReportsCheckDetails(t *testing.T) {
+	h := health.NewHealthHTTPHandler(time.Second,
+		health.Check{Name: "postgres", Check: up},
+		health.Check{Name: "redis", Check: down, Optional: true, Breaker: func() string { return "open" }},
+	)
+
+	_, body := ready(t, h)
+
+	assert.Equal(t, health.StatusUp, body.Checks["postgres"].Status)
+	assert.Empty(t, body.Checks["postgres"].Breaker)
+	assert.Equal(t, health.DependencyStatus{Status: health.StatusDown, Breaker: "open", Error: "connection refused"}, body.Checks["redis"])
+}
+
+func TestReady_AppliesTimeout(t *testing.T) {
+	h := health.NewHealthHTTPHandler(10*time.Millisecond, health.Check{Name: "postgres", Check: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+
+	code, body := ready(t, h)
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, context.DeadlineExceeded.Error(), body.Checks["postgres"].Error)
+}
+
+func TestReady_ShuttingDown(t *testing.T) {
+	h := health.NewHealthHTTPHandler(time.Second, health.Check{Name: "postgres", Check: up})
+	h.MarkShuttingDown()
+
+	code, body := ready(t, h)
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, health.StatusDown, body.Status)
+	assert.Equal(t, health.StatusUp, body.Checks["postgres"].Status)
+}
+
+func TestLive(t *testing.T) {
+	h := health.NewHealthHTTPHandler(time.Second, health.Check{Name: "postgres", Check: down})
+	h.MarkShuttingDown()
+
+	rec := httptest.NewRecorder()
+	require.Nil(t, h.Live(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil)))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"status":"up"}`, rec.Body.String())
+}
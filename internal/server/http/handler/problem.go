@@ -0,0 +1,80 @@
+package http_handler
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+	// ProblemTypePrefix is followed by the sub code, so every i18n message
+	// key is its own problem type.
+	ProblemTypePrefix = "urn:url-shortener:problem:"
+)
+
+// Problem is an RFC 9457 problem details document. Code, SubCode, Errors
+// and RequestID are extension members carrying what ErrorResponse has.
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Code      string `json:"code"`
+	SubCode   string `json:"sub_code"`
+	Errors    []any  `json:"errors,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+func NewProblem(r *http.Request, err *HTTPError, requestID string) Problem {
+	problemType := "about:blank"
+	if err.SubCode != "" {
+		problemType = ProblemTypePrefix + err.SubCode
+	}
+
+	return Problem{
+		Type:      problemType,
+		Title:     http.StatusText(err.Status),
+		Status:    err.Status,
+		Detail:    err.Message,
+		Instance:  r.URL.Path,
+		Code:      err.Code,
+		SubCode:   err.SubCode,
+		Errors:    err.Details,
+		RequestID: requestID,
+	}
+}
+
+// AcceptsProblemJSON reports whether application/problem+json is listed in
+// Accept and not ranked below application/json. Wildcards do not count, so
+// clients that never asked keep getting ErrorResponse.
+func AcceptsProblemJSON(r *http.Request) bool {
+	problemQ, jsonQ := -1.0, -1.0
+
+	for _, accept := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil {
+				continue
+			}
+
+			q := 1.0
+			if v, ok := params["q"]; ok {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
+
+			switch mediaType {
+			case ProblemContentType:
+				problemQ = max(problemQ, q)
+			case "application/json":
+				jsonQ = max(jsonQ, q)
+			}
+		}
+	}
+
+	return problemQ > 0 && problemQ >= jsonQ
+}
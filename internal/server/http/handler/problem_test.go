@@ -0,0 +1,80 @@
+package http_handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcceptsProblemJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		accept   []string
+		expected bool
+	}{
+		{"no accept header", nil, false},
+		{"plain json", []string{"application/json"}, false},
+		{"problem json", []string{"application/problem+json"}, true},
+		{"any type", []string{"*/*"}, false},
+		{"any application type", []string{"application/*"}, false},
+		{"problem json among wildcards", []string{"text/html, */*;q=0.8, application/problem+json"}, true},
+		{"ties with json", []string{"application/json, application/problem+json"}, true},
+		{"ranked above json", []string{"application/json;q=0.5, application/problem+json"}, true},
+		{"ranked below json", []string{"application/json, application/problem+json;q=0.9"}, false},
+		{"refused with q=0", []string{"application/problem+json;q=0"}, false},
+		{"invalid q counts as 1", []string{"application/json;q=0.9, application/problem+json;q=high"}, true},
+		{"keeps the highest q of a type", []string{"application/problem+json;q=0.1, application/problem+json;q=0.7, application/json;q=0.5"}, true},
+		{"reads every accept header", []string{"application/json;q=0.5", "application/problem+json"}, true},
+		{"skips malformed entries", []string{"not a media type;;, application/problem+json"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for _, v := range tc.accept {
+				req.Header.Add("Accept", v)
+			}
+
+			assert.Equal(t, tc.expected, http_handler.AcceptsProblemJSON(req))
+		})
+	}
+}
+
+func TestNewProblem(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/url/shorten?x=1", nil)
+
+	t.Run("types the problem by sub code", func(t *testing.T) {
+		problem := http_handler.NewProblem(req, &http_handler.HTTPError{
+			Status:  http.StatusBadRequest,
+			Code:    "VALIDATION_ERROR",
+			SubCode: "invalid_url",
+			Message: "invalid url",
+			Details: []any{"url"},
+		}, "req-1")
+
+		assert.Equal(t, http_handler.Problem{
+			Type:      http_handler.ProblemTypePrefix + "invalid_url",
+			Title:     "Bad Request",
+			Status:    http.StatusBadRequest,
+			Detail:    "invalid url",
+			Instance:  "/url/shorten",
+			Code:      "VALIDATION_ERROR",
+			SubCode:   "invalid_url",
+			Errors:    []any{"url"},
+			RequestID: "req-1",
+		}, problem)
+	})
+
+	t.Run("falls back to about:blank without a sub code", func(t *testing.T) {
+		problem := http_handler.NewProblem(req, &http_handler.HTTPError{
+			Status: http.StatusInternalServerError,
+			Code:   "INTERNAL_ERROR",
+		}, "")
+
+		assert.Equal(t, "about:blank", problem.Type)
+		assert.Equal(t, "Internal Server Error", problem.Title)
+	})
+}
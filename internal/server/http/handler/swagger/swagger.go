@@ -1,7 +1,7 @@
 package http_handler
 
 import (
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -21,13 +21,13 @@ type SwaggerHandler struct {
 func NewSwaggerHandler(specPath string) *SwaggerHandler {
 	absSpecPath, err := filepath.Abs(specPath)
 	if err != nil {
-		log.Printf("Warning: Could not get absolute path for %s: %v", specPath, err)
+		slog.Warn("Could not get absolute path for OpenAPI spec", "path", specPath, "error", err)
 		absSpecPath = specPath
 	}
 
 	baseDir := filepath.Dir(absSpecPath)
 
-	log.Printf("Swagger: specPath=%s, absPath=%s, baseDir=%s", specPath, absSpecPath, baseDir)
+	slog.Debug("Swagger configuration", "spec_path", specPath, "abs_path", absSpecPath, "base_dir", baseDir)
 
 	handler := &SwaggerHandler{
 		specPath: absSpecPath,
@@ -35,24 +35,24 @@ func NewSwaggerHandler(specPath string) *SwaggerHandler {
 	}
 
 	if _, err := os.Stat(absSpecPath); os.IsNotExist(err) {
-		log.Printf("Warning: OpenAPI spec file not found at %s", absSpecPath)
+		slog.Warn("OpenAPI spec file not found", "path", absSpecPath)
 		handler.bundleError = err
 		return handler
 	}
 
-	log.Printf("OpenAPI spec file found at %s", absSpecPath)
+	slog.Debug("OpenAPI spec file found", "path", absSpecPath)
 
-	log.Println("Bundling OpenAPI specification...")
+	slog.Info("Bundling OpenAPI specification")
 	bundler := openapi.NewBundler(absSpecPath)
 	bundled, err := bundler.Bundle(absSpecPath)
 	if err != nil {
-		log.Printf("Error bundling OpenAPI spec: %v", err)
+		slog.Error("Error bundling OpenAPI spec", "error", err)
 		handler.bundleError = err
 		return handler
 	}
 
 	handler.bundledSpec = bundled
-	log.Printf("OpenAPI specification bundled successfully (%d bytes)", len(bundled))
+	slog.Info("OpenAPI specification bundled successfully", "bytes", len(bundled))
 
 	return handler
 }
@@ -6,6 +6,7 @@ import (
 	err "errors"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/brunoibarbosa/url-shortener/internal/app/url/command"
 	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
@@ -17,7 +18,38 @@ import (
 )
 
 type CreateShortURLPayload struct {
-	URL string `json:"url"`
+	URL            string      `json:"url"`
+	AllowDuplicate bool        `json:"allowDuplicate"`
+	RedirectType   int         `json:"redirectType"`
+	ForwardQuery   bool        `json:"forwardQuery"`
+	ForwardPath    bool        `json:"forwardPath"`
+	UTM            *UTMPayload `json:"utm"`
+}
+
+type UTMPayload struct {
+	Source   string `json:"source"`
+	Medium   string `json:"medium"`
+	Campaign string `json:"campaign"`
+	Term     string `json:"term"`
+	Content  string `json:"content"`
+}
+
+func (p CreateShortURLPayload) redirectOptions() domain.RedirectOptions {
+	options := domain.RedirectOptions{
+		RedirectType: domain.RedirectType(p.RedirectType),
+		ForwardQuery: p.ForwardQuery,
+		ForwardPath:  p.ForwardPath,
+	}
+	if p.UTM != nil {
+		options.UTM = domain.UTMParams{
+			Source:   strings.TrimSpace(p.UTM.Source),
+			Medium:   strings.TrimSpace(p.UTM.Medium),
+			Campaign: strings.TrimSpace(p.UTM.Campaign),
+			Term:     strings.TrimSpace(p.UTM.Term),
+			Content:  strings.TrimSpace(p.UTM.Content),
+		}
+	}
+	return options
 }
 
 type CreateShortURL201Response struct {
@@ -25,12 +57,16 @@ type CreateShortURL201Response struct {
 }
 
 type CreateShortURLHTTPHandler struct {
-	cmd *command.CreateShortURLHandler
+	cmd             *command.CreateShortURLHandler
+	shortCodeLength int
+	maxRetries      int
 }
 
-func NewCreateShortURLHTTPHandler(cmd *command.CreateShortURLHandler) *CreateShortURLHTTPHandler {
+func NewCreateShortURLHTTPHandler(cmd *command.CreateShortURLHandler, shortCodeLength, maxRetries int) *CreateShortURLHTTPHandler {
 	return &CreateShortURLHTTPHandler{
-		cmd: cmd,
+		cmd:             cmd,
+		shortCodeLength: shortCodeLength,
+		maxRetries:      maxRetries,
 	}
 }
 
@@ -45,10 +81,14 @@ func (h *CreateShortURLHTTPHandler) Handle(w http.ResponseWriter, r *http.Reques
 	userID := extractUserIDFromContext(r)
 
 	appCmd := command.CreateShortURLCommand{
-		OriginalURL: payload.URL,
-		UserID:      userID,
-		Length:      6,
-		MaxRetries:  10,
+		OriginalURL:     payload.URL,
+		UserID:          userID,
+		Length:          h.shortCodeLength,
+		MaxRetries:      h.maxRetries,
+		AllowDuplicate:  payload.AllowDuplicate,
+		RedirectOptions: payload.redirectOptions(),
+		UserAgent:       r.UserAgent(),
+		IPAddress:       http_middleware.ClientIP(r),
 	}
 	url, handleErr := h.cmd.Handle(r.Context(), appCmd)
 	if handleErr != nil {
@@ -59,8 +99,13 @@ func (h *CreateShortURLHTTPHandler) Handle(w http.ResponseWriter, r *http.Reques
 		ShortCode: url.ShortCode,
 	}
 
+	status := http.StatusCreated
+	if url.Existing {
+		status = http.StatusOK
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
+	w.WriteHeader(status)
 	if encodeErr := json.NewEncoder(w).Encode(response); encodeErr != nil {
 		return http_handler.NewI18nHTTPError(ctx, http.StatusInternalServerError, errors.CodeInternalError, "error.common.encode_failed", nil)
 	}
@@ -99,6 +144,22 @@ func validateShortenPayload(r *http.Request, ctx context.Context) (CreateShortUR
 		ec.AddFieldError("url", detailKey)
 	}
 
+	options := payload.redirectOptions()
+	if options.RedirectType != 0 && !options.RedirectType.IsValid() {
+		ec.AddFieldError("redirectType", "error.details.redirect_type.unsupported")
+	}
+	for _, utm := range []struct{ field, value string }{
+		{"utm.source", options.UTM.Source},
+		{"utm.medium", options.UTM.Medium},
+		{"utm.campaign", options.UTM.Campaign},
+		{"utm.term", options.UTM.Term},
+		{"utm.content", options.UTM.Content},
+	} {
+		if len(utm.value) > domain.MaxUTMValueLength {
+			ec.AddFieldError(utm.field, "error.details.utm.too_long")
+		}
+	}
+
 	if ec.HasErrors() {
 		return CreateShortURLPayload{}, ec.ToHTTPError(http.StatusBadRequest, errors.CodeValidationError, "error.validation.failed")
 	}
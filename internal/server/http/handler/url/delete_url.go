@@ -42,8 +42,10 @@ func (h *DeleteURLHTTPHandler) Handle(w http.ResponseWriter, r *http.Request) *h
 	}
 
 	appCmd := command.DeleteURLCommand{
-		ID:     id,
-		UserID: userID,
+		ID:        id,
+		UserID:    userID,
+		UserAgent: r.UserAgent(),
+		IPAddress: http_middleware.ClientIP(r),
 	}
 
 	if handleErr := h.cmd.Handle(ctx, appCmd); handleErr != nil {
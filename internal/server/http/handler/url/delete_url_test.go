@@ -0,0 +1,72 @@
+package http_handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/url/command"
+	audit_domain "github.com/brunoibarbosa/url-shortener/internal/domain/audit"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	url_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler/url"
+	http_middleware "github.com/brunoibarbosa/url-shortener/internal/server/http/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDeleteURLHTTPHandler_AuditsClientIP(t *testing.T) {
+	testCases := []struct {
+		name       string
+		remoteAddr string
+		expected   string
+	}{
+		{"behind a trusted proxy", "10.0.0.1:1234", "198.51.100.1"},
+		{"from an untrusted peer", "203.0.113.7:1234", "203.0.113.7"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			urlID := uuid.New()
+			userID := uuid.New()
+
+			tx := mocks.NewMockTransactionManager(ctrl)
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			})
+			repo := mocks.NewMockURLRepository(ctrl)
+			repo.EXPECT().SoftDelete(gomock.Any(), urlID, userID).Return("abc123", nil)
+			outbox := mocks.NewMockOutboxRepository(ctrl)
+			outbox.EXPECT().Append(gomock.Any(), gomock.Any()).Return(nil)
+
+			var audited *audit_domain.Event
+			recorder := mocks.NewMockAuditRecorder(ctrl)
+			recorder.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, e *audit_domain.Event) error {
+				audited = e
+				return nil
+			})
+
+			h := url_handler.NewDeleteURLHTTPHandler(command.NewDeleteURLHandler(tx, repo, outbox, recorder))
+			router := chi.NewRouter()
+			router.Use(http_middleware.NewClientIPMiddleware([]netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}).Handler)
+			router.Delete("/url/{id}", http_handler.RequestValidator(h.Handle))
+
+			req := httptest.NewRequest(http.MethodDelete, "/url/"+urlID.String(), nil)
+			req.RemoteAddr = tc.remoteAddr
+			req.Header.Set("X-Forwarded-For", "198.51.100.1")
+			req = req.WithContext(context.WithValue(req.Context(), http_middleware.UserIDKey, userID))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			if assert.NotNil(t, audited) {
+				assert.Equal(t, tc.expected, audited.IPAddress)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package http_handler
 import (
 	"errors"
 	"net/http"
+	"net/url"
 
 	"github.com/brunoibarbosa/url-shortener/internal/app/url/query"
 	domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
@@ -27,8 +28,24 @@ func (h *RedirectHTTPHandler) Handle(w http.ResponseWriter, r *http.Request) *ht
 		return http_handler.NewI18nHTTPError(ctx, http.StatusBadRequest, app_errors.CodeBadRequest, "error.url.expired_url", http_handler.Detail(ctx, "shortCode", "error.details.shortcode.expired"))
 	}
 
-	appQuery := query.GetOriginalURLQuery{ShortCode: shortCode}
-	originalURL, err := h.cmd.Handle(r.Context(), appQuery)
+	// chi matches against the escaped path when the request has one.
+	path := chi.URLParam(r, "*")
+	if r.URL.RawPath != "" {
+		unescaped, err := url.PathUnescape(path)
+		if err != nil {
+			return http_handler.NewI18nHTTPError(ctx, http.StatusBadRequest, app_errors.CodeBadRequest, "error.url.invalid_forward_path", nil)
+		}
+		path = unescaped
+	}
+
+	appQuery := query.GetOriginalURLQuery{
+		ShortCode: shortCode,
+		Path:      path,
+		RawQuery:  r.URL.RawQuery,
+		Referer:   r.Referer(),
+		UserAgent: r.UserAgent(),
+	}
+	result, err := h.cmd.Handle(r.Context(), appQuery)
 
 	if err != nil {
 		if errors.Is(err, domain.ErrExpiredURL) {
@@ -39,11 +56,15 @@ func (h *RedirectHTTPHandler) Handle(w http.ResponseWriter, r *http.Request) *ht
 			return http_handler.NewI18nHTTPError(ctx, http.StatusBadRequest, app_errors.CodeBadRequest, "error.url.required_short_code", nil)
 		}
 
-		if (errors.Is(err, domain.ErrURLNotFound)) || originalURL == "" {
+		if errors.Is(err, domain.ErrInvalidForwardPath) {
+			return http_handler.NewI18nHTTPError(ctx, http.StatusBadRequest, app_errors.CodeBadRequest, "error.url.invalid_forward_path", nil)
+		}
+
+		if (errors.Is(err, domain.ErrURLNotFound)) || result.Location == "" {
 			return http_handler.NewI18nHTTPError(ctx, http.StatusNotFound, app_errors.CodeNotFound, "error.common.not_found", http_handler.Detail(ctx, "shortCode", "error.details.shortcode.not_found"))
 		}
 	}
 
-	http.Redirect(w, r, originalURL, http.StatusFound)
+	http.Redirect(w, r, result.Location, result.Status)
 	return nil
 }
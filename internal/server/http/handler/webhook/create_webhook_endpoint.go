@@ -0,0 +1,128 @@
+package http_handler
+
+import (
+	"context"
+	"encoding/json"
+	err "errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/webhook/command"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	"github.com/brunoibarbosa/url-shortener/pkg/errors"
+	"github.com/google/uuid"
+)
+
+type CreateWebhookEndpointPayload struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+type CreateWebhookEndpoint201Response struct {
+	ID     uuid.UUID `json:"id"`
+	URL    string    `json:"url"`
+	Events []string  `json:"events"`
+	// Secret is only returned here; it signs every delivery to the endpoint.
+	Secret    string    `json:"secret"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type CreateWebhookEndpointHTTPHandler struct {
+	cmd *command.CreateWebhookEndpointHandler
+}
+
+func NewCreateWebhookEndpointHTTPHandler(cmd *command.CreateWebhookEndpointHandler) *CreateWebhookEndpointHTTPHandler {
+	return &CreateWebhookEndpointHTTPHandler{
+		cmd: cmd,
+	}
+}
+
+func (h *CreateWebhookEndpointHTTPHandler) Handle(w http.ResponseWriter, r *http.Request) *http_handler.HTTPError {
+	ctx := r.Context()
+
+	userID, extractErr := extractUserID(ctx)
+	if extractErr != nil {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusUnauthorized, errors.CodeUnauthorized, "error.auth.unauthorized", nil)
+	}
+
+	payload, validationErr := validateCreateWebhookEndpointPayload(r, ctx)
+	if validationErr != nil {
+		return validationErr
+	}
+
+	events := make([]domain.EventType, len(payload.Events))
+	for i, e := range payload.Events {
+		events[i] = domain.EventType(e)
+	}
+
+	appCmd := command.CreateWebhookEndpointCommand{
+		UserID: userID,
+		URL:    payload.URL,
+		Events: events,
+	}
+	endpoint, handleErr := h.cmd.Handle(ctx, appCmd)
+	if handleErr != nil {
+		switch {
+		case err.Is(handleErr, domain.ErrTooManyEndpoints):
+			return http_handler.NewI18nHTTPError(ctx, http.StatusConflict, errors.CodeConflict, "error.webhook.limit_reached", nil)
+		case err.Is(handleErr, domain.ErrInvalidEndpointURL):
+			return http_handler.NewI18nHTTPError(ctx, http.StatusBadRequest, errors.CodeValidationError, "error.validation.failed", http_handler.Detail(ctx, "url", "error.details.webhook.invalid_url"))
+		case err.Is(handleErr, domain.ErrUnknownEventType):
+			return http_handler.NewI18nHTTPError(ctx, http.StatusBadRequest, errors.CodeValidationError, "error.validation.failed", http_handler.Detail(ctx, "events", "error.details.webhook.unknown_event"))
+		case err.Is(handleErr, domain.ErrNoEventTypes):
+			return http_handler.NewI18nHTTPError(ctx, http.StatusBadRequest, errors.CodeValidationError, "error.validation.failed", http_handler.Detail(ctx, "events", "error.details.field_required"))
+		}
+		return http_handler.NewI18nHTTPError(ctx, http.StatusInternalServerError, errors.CodeInternalError, "error.webhook.create_failed", nil)
+	}
+
+	response := CreateWebhookEndpoint201Response{
+		ID:        endpoint.ID,
+		URL:       endpoint.URL,
+		Events:    eventsToStrings(endpoint.Events),
+		Secret:    endpoint.Secret,
+		CreatedAt: endpoint.CreatedAt,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if encodeErr := json.NewEncoder(w).Encode(response); encodeErr != nil {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusInternalServerError, errors.CodeInternalError, "error.common.encode_failed", nil)
+	}
+
+	return nil
+}
+
+func validateCreateWebhookEndpointPayload(r *http.Request, ctx context.Context) (CreateWebhookEndpointPayload, *http_handler.HTTPError) {
+	var payload CreateWebhookEndpointPayload
+	decodeErr := json.NewDecoder(r.Body).Decode(&payload)
+
+	if err.Is(decodeErr, io.EOF) {
+		return CreateWebhookEndpointPayload{}, http_handler.NewI18nHTTPError(ctx, http.StatusBadRequest, errors.CodeBadRequest, "error.common.empty_body", nil)
+	}
+
+	ec := http_handler.NewErrorCollector(ctx)
+
+	if payload.URL == "" {
+		ec.AddFieldError("url", "error.details.field_required")
+	} else if domain.ValidateEndpointURL(payload.URL) != nil {
+		ec.AddFieldError("url", "error.details.webhook.invalid_url")
+	}
+
+	if len(payload.Events) == 0 {
+		ec.AddFieldError("events", "error.details.field_required")
+	}
+	for _, e := range payload.Events {
+		if !domain.EventType(e).IsSubscribable() {
+			ec.AddFieldError("events", "error.details.webhook.unknown_event")
+			break
+		}
+	}
+
+	if ec.HasErrors() {
+		return CreateWebhookEndpointPayload{}, ec.ToHTTPError(http.StatusBadRequest, errors.CodeValidationError, "error.validation.failed")
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,49 @@
+package http_handler
+
+import (
+	err "errors"
+	"net/http"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/webhook/command"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	"github.com/brunoibarbosa/url-shortener/pkg/errors"
+)
+
+type DeleteWebhookEndpointHTTPHandler struct {
+	cmd *command.DeleteWebhookEndpointHandler
+}
+
+func NewDeleteWebhookEndpointHTTPHandler(cmd *command.DeleteWebhookEndpointHandler) *DeleteWebhookEndpointHTTPHandler {
+	return &DeleteWebhookEndpointHTTPHandler{
+		cmd: cmd,
+	}
+}
+
+func (h *DeleteWebhookEndpointHTTPHandler) Handle(w http.ResponseWriter, r *http.Request) *http_handler.HTTPError {
+	ctx := r.Context()
+
+	id, ok := uuidParam(r, "id")
+	if !ok {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusBadRequest, errors.CodeBadRequest, "error.webhook.invalid_id", nil)
+	}
+
+	userID, extractErr := extractUserID(ctx)
+	if extractErr != nil {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusUnauthorized, errors.CodeUnauthorized, "error.auth.unauthorized", nil)
+	}
+
+	appCmd := command.DeleteWebhookEndpointCommand{
+		ID:     id,
+		UserID: userID,
+	}
+	if handleErr := h.cmd.Handle(ctx, appCmd); handleErr != nil {
+		if err.Is(handleErr, domain.ErrEndpointNotFound) {
+			return http_handler.NewI18nHTTPError(ctx, http.StatusNotFound, errors.CodeNotFound, "error.webhook.not_found", nil)
+		}
+		return http_handler.NewI18nHTTPError(ctx, http.StatusInternalServerError, errors.CodeInternalError, "error.webhook.delete_failed", nil)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
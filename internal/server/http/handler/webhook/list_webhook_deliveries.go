@@ -0,0 +1,171 @@
+package http_handler
+
+import (
+	"context"
+	"encoding/json"
+	err "errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/webhook/query"
+	"github.com/brunoibarbosa/url-shortener/internal/domain"
+	webhook_domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	"github.com/brunoibarbosa/url-shortener/pkg/errors"
+	"github.com/google/uuid"
+)
+
+type ListWebhookDeliveriesParams struct {
+	Limit  uint64
+	Page   uint64
+	Status *webhook_domain.DeliveryStatus
+}
+
+type WebhookDeliveryItem struct {
+	ID                 uuid.UUID       `json:"id"`
+	EventID            uuid.UUID       `json:"eventId"`
+	EventType          string          `json:"eventType"`
+	Payload            json.RawMessage `json:"payload"`
+	Status             string          `json:"status"`
+	Attempts           int             `json:"attempts"`
+	NextAttemptAt      *time.Time      `json:"nextAttemptAt"`
+	LastAttemptAt      *time.Time      `json:"lastAttemptAt"`
+	LastResponseStatus *int            `json:"lastResponseStatus"`
+	LastError          string          `json:"lastError,omitempty"`
+	CreatedAt          time.Time       `json:"createdAt"`
+	DeliveredAt        *time.Time      `json:"deliveredAt"`
+}
+
+type ListWebhookDeliveries200Response struct {
+	Data  []WebhookDeliveryItem `json:"data"`
+	Count uint64                `json:"count"`
+	Page  uint64                `json:"page"`
+	Limit uint64                `json:"limit"`
+}
+
+type ListWebhookDeliveriesHTTPHandler struct {
+	qry *query.ListWebhookDeliveriesHandler
+}
+
+func NewListWebhookDeliveriesHTTPHandler(qry *query.ListWebhookDeliveriesHandler) *ListWebhookDeliveriesHTTPHandler {
+	return &ListWebhookDeliveriesHTTPHandler{qry}
+}
+
+func (h *ListWebhookDeliveriesHTTPHandler) Handle(w http.ResponseWriter, r *http.Request) *http_handler.HTTPError {
+	ctx := r.Context()
+
+	endpointID, ok := uuidParam(r, "id")
+	if !ok {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusBadRequest, errors.CodeBadRequest, "error.webhook.invalid_id", nil)
+	}
+
+	userID, extractErr := extractUserID(ctx)
+	if extractErr != nil {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusUnauthorized, errors.CodeUnauthorized, "error.auth.unauthorized", nil)
+	}
+
+	payload, validationErr := validateListWebhookDeliveriesParams(r, ctx)
+	if validationErr != nil {
+		return validationErr
+	}
+
+	params := webhook_domain.ListDeliveriesParams{
+		EndpointID: endpointID,
+		UserID:     userID,
+		Status:     payload.Status,
+		Pagination: domain.Pagination{
+			Number: payload.Page,
+			Size:   payload.Limit,
+		},
+	}
+	list, count, handleErr := h.qry.Handle(ctx, params)
+	if handleErr != nil {
+		if err.Is(handleErr, webhook_domain.ErrEndpointNotFound) {
+			return http_handler.NewI18nHTTPError(ctx, http.StatusNotFound, errors.CodeNotFound, "error.webhook.not_found", nil)
+		}
+		return http_handler.NewI18nHTTPError(ctx, http.StatusInternalServerError, errors.CodeInternalError, "error.server.internal", nil)
+	}
+
+	items := make([]WebhookDeliveryItem, len(list))
+	for i, d := range list {
+		items[i] = WebhookDeliveryItem{
+			ID:                 d.ID,
+			EventID:            d.EventID,
+			EventType:          string(d.EventType),
+			Payload:            d.Payload,
+			Status:             string(d.Status),
+			Attempts:           d.Attempts,
+			NextAttemptAt:      d.NextAttemptAt,
+			LastAttemptAt:      d.LastAttemptAt,
+			LastResponseStatus: d.LastResponseStatus,
+			LastError:          d.LastError,
+			CreatedAt:          d.CreatedAt,
+			DeliveredAt:        d.DeliveredAt,
+		}
+	}
+
+	response := ListWebhookDeliveries200Response{
+		Data:  items,
+		Count: count,
+		Page:  payload.Page,
+		Limit: payload.Limit,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if encodeErr := json.NewEncoder(w).Encode(response); encodeErr != nil {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusInternalServerError, errors.CodeInternalError, "error.common.encode_failed", nil)
+	}
+
+	return nil
+}
+
+func validateListWebhookDeliveriesParams(r *http.Request, ctx context.Context) (ListWebhookDeliveriesParams, *http_handler.HTTPError) {
+	var params ListWebhookDeliveriesParams
+
+	ec := http_handler.NewErrorCollector(ctx)
+	q := r.URL.Query()
+
+	v := q.Get("page")
+	if v == "" {
+		ec.AddFieldError("page", "error.details.field_required")
+	} else {
+		page, parseErr := strconv.ParseUint(v, 10, 64)
+		if page == 0 || parseErr != nil {
+			ec.AddFieldError("page", "error.details.parameter_must_be_positive")
+		}
+		params.Page = page
+	}
+
+	v = q.Get("limit")
+	if v == "" {
+		ec.AddFieldError("limit", "error.details.field_required")
+	} else {
+		limit, parseErr := strconv.ParseUint(v, 10, 64)
+		if limit == 0 || parseErr != nil {
+			ec.AddFieldError("limit", "error.details.parameter_must_be_positive")
+		}
+		params.Limit = limit
+	}
+
+	if ec.HasErrors() {
+		return ListWebhookDeliveriesParams{}, ec.ToHTTPError(http.StatusBadRequest, errors.CodeValidationError, "error.common.required_pagination")
+	}
+
+	if v = q.Get("status"); v != "" {
+		status := webhook_domain.DeliveryStatus(v)
+		switch status {
+		case webhook_domain.DeliveryPending, webhook_domain.DeliverySucceeded, webhook_domain.DeliveryDead:
+			params.Status = &status
+		default:
+			ec.AddFieldError("status", "error.details.parameter_invalid")
+		}
+	}
+
+	if ec.HasErrors() {
+		return ListWebhookDeliveriesParams{}, ec.ToHTTPError(http.StatusBadRequest, errors.CodeValidationError, "error.validation.failed")
+	}
+
+	return params, nil
+}
@@ -0,0 +1,63 @@
+package http_handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/webhook/query"
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	"github.com/brunoibarbosa/url-shortener/pkg/errors"
+	"github.com/google/uuid"
+)
+
+type WebhookEndpointItem struct {
+	ID        uuid.UUID `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ListWebhookEndpoints200Response struct {
+	Data []WebhookEndpointItem `json:"data"`
+}
+
+type ListWebhookEndpointsHTTPHandler struct {
+	qry *query.ListWebhookEndpointsHandler
+}
+
+func NewListWebhookEndpointsHTTPHandler(qry *query.ListWebhookEndpointsHandler) *ListWebhookEndpointsHTTPHandler {
+	return &ListWebhookEndpointsHTTPHandler{qry}
+}
+
+func (h *ListWebhookEndpointsHTTPHandler) Handle(w http.ResponseWriter, r *http.Request) *http_handler.HTTPError {
+	ctx := r.Context()
+
+	userID, extractErr := extractUserID(ctx)
+	if extractErr != nil {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusUnauthorized, errors.CodeUnauthorized, "error.auth.unauthorized", nil)
+	}
+
+	list, handleErr := h.qry.Handle(ctx, userID)
+	if handleErr != nil {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusInternalServerError, errors.CodeInternalError, "error.server.internal", nil)
+	}
+
+	items := make([]WebhookEndpointItem, len(list))
+	for i, e := range list {
+		items[i] = WebhookEndpointItem{
+			ID:        e.ID,
+			URL:       e.URL,
+			Events:    eventsToStrings(e.Events),
+			CreatedAt: e.CreatedAt,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if encodeErr := json.NewEncoder(w).Encode(ListWebhookEndpoints200Response{Data: items}); encodeErr != nil {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusInternalServerError, errors.CodeInternalError, "error.common.encode_failed", nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package http_handler
+
+import (
+	err "errors"
+	"net/http"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/webhook/command"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	"github.com/brunoibarbosa/url-shortener/pkg/errors"
+)
+
+type RetryWebhookDeliveryHTTPHandler struct {
+	cmd *command.RetryWebhookDeliveryHandler
+}
+
+func NewRetryWebhookDeliveryHTTPHandler(cmd *command.RetryWebhookDeliveryHandler) *RetryWebhookDeliveryHTTPHandler {
+	return &RetryWebhookDeliveryHTTPHandler{
+		cmd: cmd,
+	}
+}
+
+func (h *RetryWebhookDeliveryHTTPHandler) Handle(w http.ResponseWriter, r *http.Request) *http_handler.HTTPError {
+	ctx := r.Context()
+
+	endpointID, ok := uuidParam(r, "id")
+	if !ok {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusBadRequest, errors.CodeBadRequest, "error.webhook.invalid_id", nil)
+	}
+	deliveryID, ok := uuidParam(r, "deliveryId")
+	if !ok {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusBadRequest, errors.CodeBadRequest, "error.webhook.invalid_delivery_id", nil)
+	}
+
+	userID, extractErr := extractUserID(ctx)
+	if extractErr != nil {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusUnauthorized, errors.CodeUnauthorized, "error.auth.unauthorized", nil)
+	}
+
+	appCmd := command.RetryWebhookDeliveryCommand{
+		ID:         deliveryID,
+		EndpointID: endpointID,
+		UserID:     userID,
+	}
+	if handleErr := h.cmd.Handle(ctx, appCmd); handleErr != nil {
+		switch {
+		case err.Is(handleErr, domain.ErrDeliveryNotFound):
+			return http_handler.NewI18nHTTPError(ctx, http.StatusNotFound, errors.CodeNotFound, "error.webhook.delivery_not_found", nil)
+		case err.Is(handleErr, domain.ErrDeliveryNotRetryable):
+			return http_handler.NewI18nHTTPError(ctx, http.StatusConflict, errors.CodeConflict, "error.webhook.delivery_not_retryable", nil)
+		}
+		return http_handler.NewI18nHTTPError(ctx, http.StatusInternalServerError, errors.CodeInternalError, "error.server.internal", nil)
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	return nil
+}
@@ -0,0 +1,67 @@
+package http_handler
+
+import (
+	"encoding/json"
+	err "errors"
+	"net/http"
+
+	"github.com/brunoibarbosa/url-shortener/internal/app/webhook/command"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	"github.com/brunoibarbosa/url-shortener/pkg/errors"
+	"github.com/google/uuid"
+)
+
+type SendTestWebhook202Response struct {
+	DeliveryID uuid.UUID `json:"deliveryId"`
+	EventID    uuid.UUID `json:"eventId"`
+}
+
+type SendTestWebhookHTTPHandler struct {
+	cmd *command.SendTestWebhookHandler
+}
+
+func NewSendTestWebhookHTTPHandler(cmd *command.SendTestWebhookHandler) *SendTestWebhookHTTPHandler {
+	return &SendTestWebhookHTTPHandler{
+		cmd: cmd,
+	}
+}
+
+func (h *SendTestWebhookHTTPHandler) Handle(w http.ResponseWriter, r *http.Request) *http_handler.HTTPError {
+	ctx := r.Context()
+
+	id, ok := uuidParam(r, "id")
+	if !ok {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusBadRequest, errors.CodeBadRequest, "error.webhook.invalid_id", nil)
+	}
+
+	userID, extractErr := extractUserID(ctx)
+	if extractErr != nil {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusUnauthorized, errors.CodeUnauthorized, "error.auth.unauthorized", nil)
+	}
+
+	appCmd := command.SendTestWebhookCommand{
+		EndpointID: id,
+		UserID:     userID,
+	}
+	delivery, handleErr := h.cmd.Handle(ctx, appCmd)
+	if handleErr != nil {
+		if err.Is(handleErr, domain.ErrEndpointNotFound) {
+			return http_handler.NewI18nHTTPError(ctx, http.StatusNotFound, errors.CodeNotFound, "error.webhook.not_found", nil)
+		}
+		return http_handler.NewI18nHTTPError(ctx, http.StatusInternalServerError, errors.CodeInternalError, "error.server.internal", nil)
+	}
+
+	response := SendTestWebhook202Response{
+		DeliveryID: delivery.ID,
+		EventID:    delivery.EventID,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if encodeErr := json.NewEncoder(w).Encode(response); encodeErr != nil {
+		return http_handler.NewI18nHTTPError(ctx, http.StatusInternalServerError, errors.CodeInternalError, "error.common.encode_failed", nil)
+	}
+
+	return nil
+}
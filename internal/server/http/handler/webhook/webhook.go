@@ -0,0 +1,39 @@
+package http_handler
+
+import (
+	"context"
+	"net/http"
+
+	user_domain "github.com/brunoibarbosa/url-shortener/internal/domain/user"
+	domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	http_middleware "github.com/brunoibarbosa/url-shortener/internal/server/http/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+func extractUserID(ctx context.Context) (uuid.UUID, error) {
+	userIDValue := ctx.Value(http_middleware.UserIDKey)
+	if userIDValue == nil {
+		return uuid.Nil, user_domain.ErrUserNotAuthenticated
+	}
+
+	userID, ok := userIDValue.(uuid.UUID)
+	if !ok {
+		return uuid.Nil, user_domain.ErrInvalidUserIDContext
+	}
+
+	return userID, nil
+}
+
+func uuidParam(r *http.Request, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, name))
+	return id, err == nil
+}
+
+func eventsToStrings(events []domain.EventType) []string {
+	s := make([]string, len(events))
+	for i, e := range events {
+		s[i] = string(e)
+	}
+	return s
+}
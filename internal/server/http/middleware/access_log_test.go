@@ -0,0 +1,63 @@
+package http_middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/infra/logger"
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	http_middleware "github.com/brunoibarbosa/url-shortener/internal/server/http/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessLogMiddleware(t *testing.T) {
+	testCases := []struct {
+		name          string
+		method        string
+		path          string
+		expectedRoute string
+		expectedCode  int
+		expectedBytes int
+	}{
+		{"logs the route pattern", http.MethodPost, "/url/abc123", "/url/{code}", http.StatusCreated, 7},
+		{"defaults to 200 without a status", http.MethodGet, "/healthz", "/healthz", http.StatusOK, 0},
+		{"logs unmatched routes", http.MethodGet, "/missing", "unmatched", http.StatusNotFound, 19},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			previous := slog.Default()
+			slog.SetDefault(logger.New(&buf, slog.LevelInfo))
+			t.Cleanup(func() { slog.SetDefault(previous) })
+
+			router := chi.NewRouter()
+			router.Use(http_middleware.RequestIDMiddleware, http_middleware.AccessLogMiddleware)
+			router.Post("/url/{code}", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte("created"))
+			})
+			router.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {})
+
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			req.Header.Set(http_handler.RequestIDHeader, "req-123")
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, "HTTP request", entry["msg"])
+			assert.Equal(t, tc.method, entry["method"])
+			assert.Equal(t, tc.expectedRoute, entry["route"])
+			assert.EqualValues(t, tc.expectedCode, entry["status"])
+			assert.EqualValues(t, tc.expectedBytes, entry["bytes"])
+			assert.Contains(t, entry, "latency_ms")
+			assert.Equal(t, "req-123", entry["request_id"])
+		})
+	}
+}
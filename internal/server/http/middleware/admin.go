@@ -0,0 +1,42 @@
+package http_middleware
+
+import (
+	"net/http"
+
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	"github.com/brunoibarbosa/url-shortener/pkg/errors"
+	"github.com/google/uuid"
+)
+
+// AdminMiddleware must run after AuthMiddleware, since it relies on the user
+// ID placed in the request context.
+type AdminMiddleware struct {
+	adminIDs map[uuid.UUID]struct{}
+}
+
+func NewAdminMiddleware(adminIDs []uuid.UUID) *AdminMiddleware {
+	ids := make(map[uuid.UUID]struct{}, len(adminIDs))
+	for _, id := range adminIDs {
+		ids[id] = struct{}{}
+	}
+	return &AdminMiddleware{adminIDs: ids}
+}
+
+func (m *AdminMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := r.Context().Value(UserIDKey).(uuid.UUID)
+		if !ok {
+			httpError := http_handler.NewI18nHTTPError(r.Context(), http.StatusUnauthorized, errors.CodeUnauthorized, "error.session.missing_access_token", nil)
+			http_handler.WriteError(w, r, httpError)
+			return
+		}
+
+		if _, isAdmin := m.adminIDs[userID]; !isAdmin {
+			httpError := http_handler.NewI18nHTTPError(r.Context(), http.StatusForbidden, errors.CodeForbidden, "error.auth.forbidden", nil)
+			http_handler.WriteError(w, r, httpError)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
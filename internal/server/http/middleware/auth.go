@@ -31,14 +31,14 @@ func (m *AuthMiddleware) Handler(next http.Handler) http.Handler {
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
 			httpError := http_handler.NewI18nHTTPError(r.Context(), http.StatusUnauthorized, errors.CodeUnauthorized, "error.session.missing_access_token", nil)
-			http_handler.WriteJSONError(w, httpError.Status, httpError.Code, httpError.Message, httpError.SubCode)
+			http_handler.WriteError(w, r, httpError)
 			return
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
 			httpError := http_handler.NewI18nHTTPError(r.Context(), http.StatusUnauthorized, errors.CodeUnauthorized, "error.session.invalid_access_token", nil)
-			http_handler.WriteJSONError(w, httpError.Status, httpError.Code, httpError.Message, httpError.SubCode)
+			http_handler.WriteError(w, r, httpError)
 			return
 		}
 
@@ -52,21 +52,21 @@ func (m *AuthMiddleware) Handler(next http.Handler) http.Handler {
 
 		if err != nil || !token.Valid {
 			httpError := http_handler.NewI18nHTTPError(r.Context(), http.StatusUnauthorized, errors.CodeUnauthorized, "error.session.invalid_access_token", nil)
-			http_handler.WriteJSONError(w, httpError.Status, httpError.Code, httpError.Message, httpError.SubCode)
+			http_handler.WriteError(w, r, httpError)
 			return
 		}
 
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok {
 			httpError := http_handler.NewI18nHTTPError(r.Context(), http.StatusUnauthorized, errors.CodeUnauthorized, "error.session.invalid_access_token", nil)
-			http_handler.WriteJSONError(w, httpError.Status, httpError.Code, httpError.Message, httpError.SubCode)
+			http_handler.WriteError(w, r, httpError)
 			return
 		}
 
 		sid, ok := claims["sid"].(string)
 		if !ok || sid == "" {
 			httpError := http_handler.NewI18nHTTPError(r.Context(), http.StatusUnauthorized, errors.CodeUnauthorized, "error.session.invalid_access_token", nil)
-			http_handler.WriteJSONError(w, httpError.Status, httpError.Code, httpError.Message, httpError.SubCode)
+			http_handler.WriteError(w, r, httpError)
 			return
 		}
 
@@ -78,6 +78,8 @@ func (m *AuthMiddleware) Handler(next http.Handler) http.Handler {
 			}
 		}
 
+		ctx = withProfileLocale(w, r, ctx, claims["locale"])
+
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
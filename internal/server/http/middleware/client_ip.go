@@ -0,0 +1,125 @@
+package http_middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+const ClientIPKey contextKey = "clientIP"
+
+// ClientIPMiddleware resolves the address of the client. Forwarded and
+// X-Forwarded-For are only honoured when the peer is a trusted proxy, and
+// are read right to left until the first address that is not trusted, so a
+// client cannot pick its own address by sending the headers itself.
+type ClientIPMiddleware struct {
+	trustedProxies []netip.Prefix
+}
+
+func NewClientIPMiddleware(trustedProxies []netip.Prefix) *ClientIPMiddleware {
+	return &ClientIPMiddleware{trustedProxies: trustedProxies}
+}
+
+func (m *ClientIPMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), ClientIPKey, m.resolve(r))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ClientIP returns the address resolved by ClientIPMiddleware, or the peer
+// address when the middleware did not run.
+func ClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(ClientIPKey).(string); ok {
+		return ip
+	}
+	return remoteHost(r)
+}
+
+func (m *ClientIPMiddleware) resolve(r *http.Request) string {
+	remote := remoteHost(r)
+	client, err := netip.ParseAddr(remote)
+	if err != nil || !m.trusted(client) {
+		return remote
+	}
+
+	hops := forwardedFor(r.Header)
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(hops[i])
+		if err != nil {
+			break
+		}
+		client = hop.Unmap()
+		if !m.trusted(client) {
+			break
+		}
+	}
+	return client.String()
+}
+
+func (m *ClientIPMiddleware) trusted(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, p := range m.trustedProxies {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardedFor lists the client addresses of the Forwarded header, or of
+// X-Forwarded-For when there is none, nearest hop last.
+func forwardedFor(h http.Header) []string {
+	var hops []string
+
+	if values := h.Values("Forwarded"); len(values) > 0 {
+		for _, element := range splitList(values) {
+			for _, pair := range strings.Split(element, ";") {
+				k, v, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if ok && strings.EqualFold(k, "for") {
+					hops = append(hops, forwardedNode(v))
+				}
+			}
+		}
+		return hops
+	}
+
+	return splitList(h.Values("X-Forwarded-For"))
+}
+
+// forwardedNode strips the quotes, brackets and port of a Forwarded node.
+func forwardedNode(v string) string {
+	v = strings.Trim(v, `"`)
+	if strings.HasPrefix(v, "[") {
+		if end := strings.Index(v, "]"); end > 0 {
+			return v[1:end]
+		}
+		return v
+	}
+	if host, _, err := net.SplitHostPort(v); err == nil {
+		return host
+	}
+	return v
+}
+
+func splitList(values []string) []string {
+	var items []string
+	for _, v := range values {
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
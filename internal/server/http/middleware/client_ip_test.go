@@ -0,0 +1,92 @@
+package http_middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/domain/ratelimit"
+	http_middleware "github.com/brunoibarbosa/url-shortener/internal/server/http/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIPMiddleware(t *testing.T) {
+	trusted := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}
+
+	testCases := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		expected   string
+	}{
+		{"uses the peer without headers", "10.0.0.1:1234", nil, "10.0.0.1"},
+		{"ignores headers from an untrusted peer", "203.0.113.7:1234", map[string]string{"X-Forwarded-For": "198.51.100.1"}, "203.0.113.7"},
+		{"reads x-forwarded-for from a trusted peer", "10.0.0.1:1234", map[string]string{"X-Forwarded-For": "198.51.100.1"}, "198.51.100.1"},
+		{"skips trusted hops right to left", "10.0.0.1:1234", map[string]string{"X-Forwarded-For": "198.51.100.1, 10.0.0.2"}, "198.51.100.1"},
+		{"stops at the first untrusted hop", "10.0.0.1:1234", map[string]string{"X-Forwarded-For": "192.0.2.66, 198.51.100.1"}, "198.51.100.1"},
+		{"keeps the last valid hop on garbage", "10.0.0.1:1234", map[string]string{"X-Forwarded-For": "nonsense, 10.0.0.2"}, "10.0.0.2"},
+		{"prefers forwarded", "10.0.0.1:1234", map[string]string{"Forwarded": `for=198.51.100.1;proto=https, for="10.0.0.2:8080"`, "X-Forwarded-For": "192.0.2.66"}, "198.51.100.1"},
+		{"reads ipv6 forwarded nodes", "[2001:db8::1]:1234", map[string]string{"Forwarded": `For="[2001:db8:cafe::17]:4711", for=198.51.100.1`}, "198.51.100.1"},
+		{"stops at obfuscated forwarded nodes", "10.0.0.1:1234", map[string]string{"Forwarded": "for=_hidden, for=10.0.0.2"}, "10.0.0.2"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got string
+			handler := http_middleware.NewClientIPMiddleware(trusted).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = http_middleware.ClientIP(r)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tc.remoteAddr
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestClientIP_WithoutMiddleware(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+
+	assert.Equal(t, "192.0.2.1", http_middleware.ClientIP(req))
+}
+
+type recordingLimiter struct {
+	keys []string
+}
+
+func (l *recordingLimiter) Allow(_ context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	l.keys = append(l.keys, key)
+	return ratelimit.Result{Allowed: true, Limit: limit.Requests, Remaining: limit.Requests}, nil
+}
+
+func TestRateLimitMiddleware_KeysProxiedClientsApart(t *testing.T) {
+	limiter := &recordingLimiter{}
+	rateLimit := http_middleware.NewRateLimitMiddleware(limiter, http_middleware.RateLimitPolicy{
+		Name:      "shorten",
+		Anonymous: ratelimit.Limit{Requests: 10, Period: time.Minute},
+	})
+	clientIP := http_middleware.NewClientIPMiddleware([]netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")})
+	handler := clientIP.Handler(rateLimit.Handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})))
+
+	for _, client := range []string{"198.51.100.1", "198.51.100.2"} {
+		req := httptest.NewRequest(http.MethodPost, "/url/shorten", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-Forwarded-For", client)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, []string{"shorten:ip:198.51.100.1", "shorten:ip:198.51.100.2"}, limiter.keys)
+}
@@ -0,0 +1,208 @@
+package http_middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/domain/idempotency"
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	pkg_errors "github.com/brunoibarbosa/url-shortener/pkg/errors"
+	"github.com/google/uuid"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// replayedHeaders are the response headers stored with the body.
+var replayedHeaders = []string{"Content-Type", "Content-Language", "Location", "Vary"}
+
+type IdempotencyPolicy struct {
+	Name string
+	// TTL is how long a finished response is replayed; LockTTL bounds how
+	// long a request that never finishes keeps its key.
+	TTL     time.Duration
+	LockTTL time.Duration
+	// FingerprintKey keys the request fingerprints, so a stored fingerprint
+	// of a body holding a password cannot be brute-forced offline.
+	FingerprintKey []byte
+	// MaxBodyBytes bounds the body buffered for the fingerprint.
+	MaxBodyBytes int64
+}
+
+type IdempotencyMiddleware struct {
+	repo   idempotency.IdempotencyRepository
+	policy IdempotencyPolicy
+}
+
+func NewIdempotencyMiddleware(repo idempotency.IdempotencyRepository, policy IdempotencyPolicy) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{
+		repo:   repo,
+		policy: policy,
+	}
+}
+
+func (m *IdempotencyMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if err := idempotency.ValidateKey(key); err != nil {
+			http_handler.WriteI18nError(w, r, http.StatusBadRequest, pkg_errors.CodeBadRequest, "error.idempotency.invalid_key", nil)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, m.policy.MaxBodyBytes))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				http_handler.WriteI18nError(w, r, http.StatusRequestEntityTooLarge, pkg_errors.CodePayloadTooLarge, "error.common.body_too_large", nil)
+				return
+			}
+			http_handler.WriteI18nError(w, r, http.StatusBadRequest, pkg_errors.CodeBadRequest, "error.common.invalid_body", nil)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		key = m.scope(r, key)
+		fingerprint := m.fingerprint(r, body)
+
+		token := uuid.NewString()
+		record, err := m.repo.Reserve(ctx, key, token, fingerprint, m.policy.LockTTL)
+		if err != nil {
+			slog.WarnContext(ctx, "Idempotency store unavailable, running request without it", "policy", m.policy.Name, "error", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if record != nil {
+			switch {
+			case !record.Matches(fingerprint):
+				http_handler.WriteI18nError(w, r, http.StatusUnprocessableEntity, pkg_errors.CodeValidationError, "error.idempotency.key_reused", nil)
+			case !record.Completed():
+				w.Header().Set("Retry-After", "1")
+				http_handler.WriteI18nError(w, r, http.StatusConflict, pkg_errors.CodeConflict, "error.idempotency.in_progress", nil)
+			default:
+				replay(w, record.Response)
+			}
+			return
+		}
+
+		rec := &recordingResponseWriter{ResponseWriter: w}
+		completed := false
+
+		// Storing must survive the client going away: the work is done and
+		// the retry it will send is exactly the one that needs the response.
+		storeCtx := context.WithoutCancel(ctx)
+		defer func() {
+			if completed {
+				return
+			}
+			if err := m.repo.Release(storeCtx, key, token); err != nil {
+				slog.WarnContext(ctx, "Failed to release idempotency key", "policy", m.policy.Name, "error", err)
+			}
+		}()
+
+		next.ServeHTTP(rec, r)
+
+		status := rec.statusCode()
+		if !idempotency.Storable(status) {
+			return
+		}
+
+		err = m.repo.Complete(storeCtx, key, token, &idempotency.Record{
+			Fingerprint: fingerprint,
+			Response: &idempotency.Response{
+				Status: status,
+				Header: storedHeader(rec.Header()),
+				Body:   rec.body.Bytes(),
+			},
+		}, m.policy.TTL)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to store idempotent response", "policy", m.policy.Name, "error", err)
+			// A lost key belongs to a retry now, so there is nothing to release.
+			completed = errors.Is(err, idempotency.ErrReservationLost)
+			return
+		}
+		completed = true
+	})
+}
+
+// scope keeps keys of different endpoints and clients apart: users by id,
+// anonymous clients by address.
+func (m *IdempotencyMiddleware) scope(r *http.Request, key string) string {
+	identity := "ip:" + ClientIP(r)
+	if userID, ok := r.Context().Value(UserIDKey).(uuid.UUID); ok {
+		identity = "user:" + userID.String()
+	}
+	return m.policy.Name + ":" + identity + ":" + key
+}
+
+func (m *IdempotencyMiddleware) fingerprint(r *http.Request, body []byte) string {
+	h := hmac.New(sha256.New, m.policy.FingerprintKey)
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func storedHeader(h http.Header) http.Header {
+	stored := http.Header{}
+	for _, name := range replayedHeaders {
+		if values := h.Values(name); len(values) > 0 {
+			stored[name] = values
+		}
+	}
+	return stored
+}
+
+func replay(w http.ResponseWriter, res *idempotency.Response) {
+	h := w.Header()
+	for name, values := range res.Header {
+		h[name] = values
+	}
+	h.Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(res.Status)
+	_, _ = w.Write(res.Body)
+}
+
+type recordingResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *recordingResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordingResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingResponseWriter) statusCode() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
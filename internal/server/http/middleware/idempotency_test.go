@@ -0,0 +1,169 @@
+package http_middleware_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/domain/idempotency"
+	http_middleware "github.com/brunoibarbosa/url-shortener/internal/server/http/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryIdempotencyRepository struct {
+	records map[string]*idempotency.Record
+}
+
+func newMemoryIdempotencyRepository() *memoryIdempotencyRepository {
+	return &memoryIdempotencyRepository{records: map[string]*idempotency.Record{}}
+}
+
+func (r *memoryIdempotencyRepository) Reserve(_ context.Context, key, token, fingerprint string, _ time.Duration) (*idempotency.Record, error) {
+	if record, ok := r.records[key]; ok {
+		return record, nil
+	}
+	r.records[key] = &idempotency.Record{Fingerprint: fingerprint, Token: token}
+	return nil, nil
+}
+
+func (r *memoryIdempotencyRepository) Complete(_ context.Context, key, token string, record *idempotency.Record, _ time.Duration) error {
+	if current, ok := r.records[key]; !ok || current.Token != token {
+		return idempotency.ErrReservationLost
+	}
+	r.records[key] = record
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) Release(_ context.Context, key, token string) error {
+	if current, ok := r.records[key]; !ok || current.Token != token {
+		return idempotency.ErrReservationLost
+	}
+	delete(r.records, key)
+	return nil
+}
+
+func newIdempotentHandler(repo idempotency.IdempotencyRepository, calls *int) http.Handler {
+	idempotent := http_middleware.NewIdempotencyMiddleware(repo, http_middleware.IdempotencyPolicy{
+		Name:           "register",
+		TTL:            time.Hour,
+		LockTTL:        time.Minute,
+		FingerprintKey: []byte("secret"),
+		MaxBodyBytes:   64,
+	})
+	clientIP := http_middleware.NewClientIPMiddleware([]netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")})
+
+	return clientIP.Handler(idempotent.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.Header().Add("Vary", "Accept-Language")
+		w.Header().Set("Content-Language", "pt")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	})))
+}
+
+func idempotentRequest(client, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/auth/register", strings.NewReader(body))
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("X-Forwarded-For", client)
+	req.Header.Set(http_middleware.IdempotencyKeyHeader, "key-1")
+	return req
+}
+
+func TestIdempotencyMiddleware_ScopesAnonymousKeysByClient(t *testing.T) {
+	repo := newMemoryIdempotencyRepository()
+	calls := 0
+	handler := newIdempotentHandler(repo, &calls)
+
+	for _, client := range []string{"198.51.100.1", "198.51.100.2"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, idempotentRequest(client, `{"email":"a@b.c"}`))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Empty(t, rec.Header().Get(http_middleware.IdempotentReplayedHeader))
+	}
+
+	assert.Equal(t, 2, calls)
+	assert.Contains(t, repo.records, "register:ip:198.51.100.1:key-1")
+	assert.Contains(t, repo.records, "register:ip:198.51.100.2:key-1")
+}
+
+func TestIdempotencyMiddleware_ReplaysRepresentationHeaders(t *testing.T) {
+	repo := newMemoryIdempotencyRepository()
+	calls := 0
+	handler := newIdempotentHandler(repo, &calls)
+
+	handler.ServeHTTP(httptest.NewRecorder(), idempotentRequest("198.51.100.1", `{"email":"a@b.c"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, idempotentRequest("198.51.100.1", `{"email":"a@b.c"}`))
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "true", rec.Header().Get(http_middleware.IdempotentReplayedHeader))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "pt", rec.Header().Get("Content-Language"))
+	assert.Equal(t, []string{"Accept-Language"}, rec.Header().Values("Vary"))
+	assert.JSONEq(t, `{"id":"1"}`, rec.Body.String())
+}
+
+func TestIdempotencyMiddleware_KeysFingerprints(t *testing.T) {
+	repo := newMemoryIdempotencyRepository()
+	calls := 0
+	body := `{"email":"a@b.c","password":"hunter2"}`
+
+	newIdempotentHandler(repo, &calls).ServeHTTP(httptest.NewRecorder(), idempotentRequest("198.51.100.1", body))
+
+	record := repo.records["register:ip:198.51.100.1:key-1"]
+	require.NotNil(t, record)
+	plain := sha256.Sum256([]byte("POST /auth/register\n" + body))
+	assert.NotEqual(t, hex.EncodeToString(plain[:]), record.Fingerprint)
+	assert.NotContains(t, record.Fingerprint, "hunter2")
+}
+
+func TestIdempotencyMiddleware_RejectsLargeBodies(t *testing.T) {
+	repo := newMemoryIdempotencyRepository()
+	calls := 0
+	rec := httptest.NewRecorder()
+
+	newIdempotentHandler(repo, &calls).ServeHTTP(rec, idempotentRequest("198.51.100.1", strings.Repeat("a", 65)))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Zero(t, calls)
+	assert.Empty(t, repo.records)
+}
+
+func TestIdempotencyMiddleware_KeepsKeyTakenOverByRetry(t *testing.T) {
+	repo := newMemoryIdempotencyRepository()
+	calls := 0
+	retry := &idempotency.Record{Fingerprint: "retry", Token: "retry-token"}
+	idempotent := http_middleware.NewIdempotencyMiddleware(repo, http_middleware.IdempotencyPolicy{
+		Name:           "register",
+		TTL:            time.Hour,
+		LockTTL:        time.Minute,
+		FingerprintKey: []byte("secret"),
+		MaxBodyBytes:   64,
+	})
+	handler := idempotent.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		// The lock expired while running and a retry reserved the key.
+		for key := range repo.records {
+			repo.records[key] = retry
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	req := idempotentRequest("198.51.100.1", `{}`)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, 1, calls)
+	require.Len(t, repo.records, 1)
+	for _, record := range repo.records {
+		assert.Same(t, retry, record)
+	}
+}
@@ -0,0 +1,15 @@
+package http_middleware_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/i18n"
+)
+
+func TestMain(m *testing.M) {
+	if err := i18n.Init(); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
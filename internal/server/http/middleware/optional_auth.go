@@ -62,6 +62,8 @@ func (m *OptionalAuthMiddleware) Handler(next http.Handler) http.Handler {
 			}
 		}
 
+		ctx = withProfileLocale(w, r, ctx, claims["locale"])
+
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
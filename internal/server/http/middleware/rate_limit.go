@@ -0,0 +1,78 @@
+package http_middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/domain/ratelimit"
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	"github.com/brunoibarbosa/url-shortener/pkg/errors"
+	"github.com/google/uuid"
+)
+
+type RateLimitPolicy struct {
+	Name          string
+	Anonymous     ratelimit.Limit
+	Authenticated ratelimit.Limit
+}
+
+type RateLimitMiddleware struct {
+	limiter ratelimit.Limiter
+	policy  RateLimitPolicy
+}
+
+func NewRateLimitMiddleware(limiter ratelimit.Limiter, policy RateLimitPolicy) *RateLimitMiddleware {
+	return &RateLimitMiddleware{
+		limiter: limiter,
+		policy:  policy,
+	}
+}
+
+func (m *RateLimitMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		identity, limit := m.identify(r)
+		if limit.IsZero() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		result, err := m.limiter.Allow(ctx, m.policy.Name+":"+identity, limit)
+		if err != nil {
+			slog.WarnContext(ctx, "Rate limiter unavailable, allowing request", "policy", m.policy.Name, "error", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h := w.Header()
+		h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Requests, int(limit.Period.Seconds())))
+		h.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+		h.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+		if !result.Allowed {
+			h.Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			http_handler.WriteI18nError(w, r, http.StatusTooManyRequests, errors.CodeTooManyRequests, "error.rate_limit.exceeded", nil)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *RateLimitMiddleware) identify(r *http.Request) (string, ratelimit.Limit) {
+	if userID, ok := r.Context().Value(UserIDKey).(uuid.UUID); ok {
+		return "user:" + userID.String(), m.policy.Authenticated
+	}
+
+	return "ip:" + ClientIP(r), m.policy.Anonymous
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
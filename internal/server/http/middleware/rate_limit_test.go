@@ -0,0 +1,132 @@
+package http_middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/domain/ratelimit"
+	"github.com/brunoibarbosa/url-shortener/internal/mocks"
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	http_middleware "github.com/brunoibarbosa/url-shortener/internal/server/http/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var rateLimitPolicy = http_middleware.RateLimitPolicy{
+	Name:          "shorten",
+	Anonymous:     ratelimit.Limit{Requests: 10, Period: time.Minute},
+	Authenticated: ratelimit.Limit{Requests: 100, Period: time.Hour},
+}
+
+func serveRateLimited(limiter ratelimit.Limiter, policy http_middleware.RateLimitPolicy, req *http.Request) (*httptest.ResponseRecorder, bool) {
+	var called bool
+	handler := http_middleware.NewRateLimitMiddleware(limiter, policy).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec, called
+}
+
+func TestRateLimitMiddleware_Buckets(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		userID        *uuid.UUID
+		expectedKey   string
+		expectedLimit ratelimit.Limit
+		policyHeader  string
+	}{
+		{"anonymous requests by client ip", nil, "shorten:ip:192.0.2.1", rateLimitPolicy.Anonymous, "10;w=60"},
+		{"authenticated requests by user", &userID, "shorten:user:" + userID.String(), rateLimitPolicy.Authenticated, "100;w=3600"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			limiter := mocks.NewMockLimiter(ctrl)
+			limiter.EXPECT().Allow(gomock.Any(), tc.expectedKey, tc.expectedLimit).Return(ratelimit.Result{
+				Allowed:    true,
+				Limit:      tc.expectedLimit.Requests,
+				Remaining:  tc.expectedLimit.Requests - 1,
+				ResetAfter: 1500 * time.Millisecond,
+			}, nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/url/shorten", nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			if tc.userID != nil {
+				req = req.WithContext(context.WithValue(req.Context(), http_middleware.UserIDKey, *tc.userID))
+			}
+			rec, called := serveRateLimited(limiter, rateLimitPolicy, req)
+
+			assert.True(t, called)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tc.policyHeader, rec.Header().Get("RateLimit-Policy"))
+			assert.Equal(t, strconv.Itoa(tc.expectedLimit.Requests), rec.Header().Get("RateLimit-Limit"))
+			assert.Equal(t, strconv.Itoa(tc.expectedLimit.Requests-1), rec.Header().Get("RateLimit-Remaining"))
+			assert.Equal(t, "2", rec.Header().Get("RateLimit-Reset"))
+			assert.Empty(t, rec.Header().Get("Retry-After"))
+		})
+	}
+}
+
+func TestRateLimitMiddleware_Exceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	limiter := mocks.NewMockLimiter(ctrl)
+	limiter.EXPECT().Allow(gomock.Any(), "shorten:ip:192.0.2.1", rateLimitPolicy.Anonymous).Return(ratelimit.Result{
+		Limit:      10,
+		RetryAfter: 2100 * time.Millisecond,
+		ResetAfter: 30 * time.Second,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/url/shorten", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set("Accept", "application/problem+json")
+	rec, called := serveRateLimited(limiter, rateLimitPolicy, req)
+
+	assert.False(t, called)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "3", rec.Header().Get("Retry-After"))
+	assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", rec.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, http_handler.ProblemContentType, rec.Header().Get("Content-Type"))
+
+	var problem http_handler.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, http.StatusTooManyRequests, problem.Status)
+	assert.Equal(t, "TOO_MANY_REQUESTS", problem.Code)
+	assert.Equal(t, "Too Many Requests", problem.Title)
+	assert.NotEmpty(t, problem.Detail)
+}
+
+func TestRateLimitMiddleware_FailsOpen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	limiter := mocks.NewMockLimiter(ctrl)
+	limiter.EXPECT().Allow(gomock.Any(), gomock.Any(), gomock.Any()).Return(ratelimit.Result{}, errors.New("redis unavailable"))
+
+	rec, called := serveRateLimited(limiter, rateLimitPolicy, httptest.NewRequest(http.MethodPost, "/url/shorten", nil))
+
+	assert.True(t, called)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimitMiddleware_Unlimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	policy := http_middleware.RateLimitPolicy{Name: "shorten", Authenticated: rateLimitPolicy.Authenticated}
+
+	rec, called := serveRateLimited(mocks.NewMockLimiter(ctrl), policy, httptest.NewRequest(http.MethodPost, "/url/shorten", nil))
+
+	assert.True(t, called)
+	assert.Empty(t, rec.Header().Get("RateLimit-Policy"))
+}
@@ -1,8 +1,9 @@
 package http_middleware
 
 import (
-	"log"
+	"log/slog"
 	"net/http"
+	"runtime/debug"
 
 	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
 	"github.com/brunoibarbosa/url-shortener/pkg/errors"
@@ -13,14 +14,13 @@ func RecoverMiddleware(next http.Handler) http.Handler {
 		defer func() {
 			if rec := recover(); rec != nil {
 				ctx := r.Context()
-				switch err := rec.(type) {
-				case error:
-					log.Printf("Unexpected error: %v", err)
-					http_handler.WriteI18nJSONError(ctx, w, http.StatusInternalServerError, errors.CodeInternalError, "error.server.internal", nil)
-				default:
-					log.Printf("Unknown panic: %v", rec)
-					http_handler.WriteI18nJSONError(ctx, w, http.StatusInternalServerError, errors.CodeInternalError, "error.server.internal", nil)
-				}
+				slog.ErrorContext(ctx, "Recovered from panic",
+					"panic", rec,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"stack", string(debug.Stack()),
+				)
+				http_handler.WriteI18nError(w, r, http.StatusInternalServerError, errors.CodeInternalError, "error.server.internal", nil)
 			}
 		}()
 
@@ -0,0 +1,69 @@
+package http_middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/brunoibarbosa/url-shortener/internal/infra/logger"
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler"
+	http_middleware "github.com/brunoibarbosa/url-shortener/internal/server/http/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	testCases := []struct {
+		name     string
+		incoming string
+		keeps    bool
+	}{
+		{"generates one without a header", "", false},
+		{"keeps a valid id", "req-123", true},
+		{"keeps an id at the length limit", strings.Repeat("a", 128), true},
+		{"replaces an oversized id", strings.Repeat("a", 129), false},
+		{"replaces an id with spaces", "req 123", false},
+		{"replaces an id with control characters", "req\x01123", false},
+		{"replaces an id with non-ascii characters", "req-ção", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var inContext string
+			handler := http_middleware.RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				inContext = logger.RequestIDFromContext(r.Context())
+				http_handler.WriteError(w, r, &http_handler.HTTPError{
+					Status:  http.StatusNotFound,
+					Code:    "NOT_FOUND",
+					Message: "url not found",
+				})
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+			if tc.incoming != "" {
+				req.Header.Set(http_handler.RequestIDHeader, tc.incoming)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			requestID := rec.Header().Get(http_handler.RequestIDHeader)
+			if tc.keeps {
+				assert.Equal(t, tc.incoming, requestID)
+			} else {
+				assert.NoError(t, uuid.Validate(requestID))
+			}
+			assert.Equal(t, requestID, inContext)
+
+			var body struct {
+				Error struct {
+					RequestID string `json:"request_id"`
+				} `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, requestID, body.Error.RequestID)
+		})
+	}
+}
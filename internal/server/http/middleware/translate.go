@@ -1,15 +1,49 @@
 package http_middleware
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/brunoibarbosa/url-shortener/internal/i18n"
+	"golang.org/x/text/language"
 )
 
+const LocaleQueryParam = "lang"
+
+// LocaleMiddleware picks the response language: ?lang= when it names a
+// loaded locale, otherwise the best match for Accept-Language. The auth
+// middlewares replace the latter with the profile locale of the user.
 func LocaleMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		locale := i18n.DetectLanguage(r)
-		ctx := i18n.WithLocale(r.Context(), locale)
-		next.ServeHTTP(w, r.WithContext(ctx))
+		locale := i18n.MatchAcceptLanguage(r.Header.Get("Accept-Language"))
+		if tag, ok := i18n.ParseLocale(r.URL.Query().Get(LocaleQueryParam)); ok {
+			locale = tag
+		}
+
+		w.Header().Add("Vary", "Accept-Language")
+		next.ServeHTTP(w, r.WithContext(setLocale(w, r.Context(), locale)))
 	})
 }
+
+// withProfileLocale applies the locale claim of an access token unless the
+// request chose one with ?lang=.
+func withProfileLocale(w http.ResponseWriter, r *http.Request, ctx context.Context, claim any) context.Context {
+	profileLocale, ok := claim.(string)
+	if !ok || profileLocale == "" {
+		return ctx
+	}
+	if _, ok := i18n.ParseLocale(r.URL.Query().Get(LocaleQueryParam)); ok {
+		return ctx
+	}
+
+	tag, ok := i18n.ParseLocale(profileLocale)
+	if !ok {
+		return ctx
+	}
+	return setLocale(w, ctx, tag)
+}
+
+func setLocale(w http.ResponseWriter, ctx context.Context, locale language.Tag) context.Context {
+	w.Header().Set("Content-Language", locale.String())
+	return i18n.WithLocale(ctx, locale)
+}
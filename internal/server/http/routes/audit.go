@@ -0,0 +1,40 @@
+package http_routes
+
+import (
+	"github.com/brunoibarbosa/url-shortener/internal/container"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
+	pg_audit_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/audit"
+	"github.com/brunoibarbosa/url-shortener/internal/server/http"
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler/audit"
+	http_middleware "github.com/brunoibarbosa/url-shortener/internal/server/http/middleware"
+	"github.com/google/uuid"
+)
+
+type AuditRoutesConfig struct {
+	JWTSecret    string
+	AdminUserIDs []uuid.UUID
+}
+
+func NewAuditRoutes(r *http.AppRouter, pgConn *pg.Router, config AuditRoutesConfig) {
+	authMiddleware := http_middleware.NewAuthMiddleware(config.JWTSecret)
+	adminMiddleware := http_middleware.NewAdminMiddleware(config.AdminUserIDs)
+
+	deps := container.AuditFactoryDependencies{
+		ListEventsRepo: pg_audit_repo.NewListAuditEventsRepository(pgConn),
+	}
+
+	f := container.NewAuditHandlerFactory(deps)
+
+	listUserAuditEventsHTTPHandler := http_handler.NewListUserAuditEventsHTTPHandler(f.ListAuditEventsHandler())
+	listAuditEventsHTTPHandler := http_handler.NewListAuditEventsHTTPHandler(f.ListAuditEventsHandler())
+
+	r.Group(func(r *http.AppRouter) {
+		r.Use(authMiddleware.Handler)
+		r.Get("/user/audit", listUserAuditEventsHTTPHandler.Handle)
+
+		r.Group(func(r *http.AppRouter) {
+			r.Use(adminMiddleware.Handler)
+			r.Get("/admin/audit", listAuditEventsHTTPHandler.Handle)
+		})
+	})
+}
@@ -5,18 +5,25 @@ import (
 	"time"
 
 	"github.com/brunoibarbosa/url-shortener/internal/container"
+	"github.com/brunoibarbosa/url-shortener/internal/domain/ratelimit"
+	session_domain "github.com/brunoibarbosa/url-shortener/internal/domain/session"
 	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
 	oauth_provider "github.com/brunoibarbosa/url-shortener/internal/infra/oauth"
+	pg_audit_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/audit"
+	pg_outbox_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/outbox"
 	pg_session_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/session"
 	pg_user_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/user"
+	redis_idempotency_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/redis/idempotency"
+	redis_ratelimit_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/redis/ratelimit"
 	redis_session_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/redis/session"
+	resilient_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/resilient"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/resilience"
 	"github.com/brunoibarbosa/url-shortener/internal/infra/service/crypto"
 	"github.com/brunoibarbosa/url-shortener/internal/infra/service/jwt"
 	"github.com/brunoibarbosa/url-shortener/internal/server/http"
 	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler/auth"
-	"github.com/jackc/pgx/v5/pgxpool"
+	http_middleware "github.com/brunoibarbosa/url-shortener/internal/server/http/middleware"
 	"github.com/redis/go-redis/v9"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthRoutesConfig struct {
@@ -26,21 +33,39 @@ type AuthRoutesConfig struct {
 	ListenAddress        string
 	RefreshTokenDuration time.Duration
 	AccessTokenDuration  time.Duration
+	BcryptCost           int
+	OAuthStateExpiration time.Duration
+	RateLimit            ratelimit.Limit
+	Metrics              session_domain.AuthMetrics
+	// RedisBreaker guards every Redis call; the policies decide how the
+	// blacklist and OAuth state behave while it is open.
+	RedisBreaker              *resilience.Breaker
+	BlacklistFailurePolicy    resilience.FailurePolicy
+	StateFailurePolicy        resilience.FailurePolicy
+	IdempotencyTTL            time.Duration
+	IdempotencyLockTTL        time.Duration
+	IdempotencyMaxBodyBytes   int64
+	IdempotencyFingerprintKey []byte
 }
 
-func NewAuthRoutes(r *http.AppRouter, pgConn *pgxpool.Pool, redisClient *redis.Client, config AuthRoutesConfig) {
+func NewAuthRoutes(r *http.AppRouter, pgConn *pg.Router, redisClient redis.UniversalClient, config AuthRoutesConfig) {
 	deps := container.AuthFactoryDependencies{
-		TxManager:            pg.NewTxManager(pgConn),
-		UserRepo:             pg_user_repo.NewUserRepository(pgConn),
-		ProviderRepo:         pg_user_repo.NewUserProviderRepository(pgConn),
-		ProfileRepo:          pg_user_repo.NewUserProfileRepository(pgConn),
-		SessionRepo:          pg_session_repo.NewSessionRepository(pgConn),
-		BlacklistRepo:        redis_session_repo.NewBlacklistRepository(redisClient),
-		StateService:         redis_session_repo.NewStateRepository(redisClient),
+		TxManager:    pg.NewTxManager(pgConn),
+		UserRepo:     pg_user_repo.NewUserRepository(pgConn),
+		ProviderRepo: pg_user_repo.NewUserProviderRepository(pgConn),
+		ProfileRepo:  pg_user_repo.NewUserProfileRepository(pgConn),
+		SessionRepo:  pg_session_repo.NewSessionRepository(pgConn),
+		BlacklistRepo: resilient_repo.NewBlacklistRepository(redis_session_repo.NewBlacklistRepository(redisClient),
+			config.RedisBreaker, config.BlacklistFailurePolicy),
+		OutboxRepo: pg_outbox_repo.NewOutboxRepository(pgConn),
+		StateService: resilient_repo.NewStateService(redis_session_repo.NewStateRepository(redisClient, config.OAuthStateExpiration),
+			config.RedisBreaker, config.StateFailurePolicy),
 		OAuthProvider:        oauth_provider.NewGoogleOAuth(config.GoogleID, config.GoogleSecret, fmt.Sprintf("http://%s", config.ListenAddress)),
 		TokenService:         jwt.NewTokenService(config.JWTSecret),
-		PasswordEncrypter:    crypto.NewUserPasswordEncrypter(bcrypt.DefaultCost),
+		PasswordEncrypter:    crypto.NewUserPasswordEncrypter(config.BcryptCost),
 		SessionEncrypter:     crypto.NewSessionEncrypter(),
+		AuditRecorder:        pg_audit_repo.NewAuditRepository(pgConn),
+		Metrics:              config.Metrics,
 		RefreshTokenDuration: config.RefreshTokenDuration,
 		AccessTokenDuration:  config.AccessTokenDuration,
 	}
@@ -54,8 +79,28 @@ func NewAuthRoutes(r *http.AppRouter, pgConn *pgxpool.Pool, redisClient *redis.C
 	refreshTokenHTTPHandler := http_handler.NewRefreshTokenHTTPHandler(f.RefreshTokenHandler(), f.RefreshTokenDuration())
 	logoutHTTPHandler := http_handler.NewLogoutHTTPHandler(f.LogoutHandler())
 
-	r.Post("/auth/register", registerHTTPHandler.Handle)
-	r.Post("/auth/login", loginUserHTTPHandler.Handle)
+	authRateLimit := http_middleware.NewRateLimitMiddleware(resilient_repo.NewRateLimitRepository(redis_ratelimit_repo.NewRateLimitRepository(redisClient), config.RedisBreaker), http_middleware.RateLimitPolicy{
+		Name:          "auth",
+		Anonymous:     config.RateLimit,
+		Authenticated: config.RateLimit,
+	})
+
+	registerIdempotency := http_middleware.NewIdempotencyMiddleware(resilient_repo.NewIdempotencyRepository(redis_idempotency_repo.NewIdempotencyRepository(redisClient), config.RedisBreaker), http_middleware.IdempotencyPolicy{
+		Name:           "register",
+		TTL:            config.IdempotencyTTL,
+		LockTTL:        config.IdempotencyLockTTL,
+		FingerprintKey: config.IdempotencyFingerprintKey,
+		MaxBodyBytes:   config.IdempotencyMaxBodyBytes,
+	})
+
+	r.Group(func(r *http.AppRouter) {
+		r.Use(authRateLimit.Handler)
+		r.Group(func(r *http.AppRouter) {
+			r.Use(registerIdempotency.Handler)
+			r.Post("/auth/register", registerHTTPHandler.Handle)
+		})
+		r.Post("/auth/login", loginUserHTTPHandler.Handle)
+	})
 	r.Get("/auth/google", redirectGoogleHTTPHandler.Handle)
 	r.Get("/auth/google/callback", loginGoogleHTTPHandler.Handle)
 	r.Post("/auth/refresh", refreshTokenHTTPHandler.Handle)
@@ -0,0 +1,56 @@
+package http_routes
+
+import (
+	"context"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/internal/infra/resilience"
+	"github.com/brunoibarbosa/url-shortener/internal/server/http"
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler/health"
+	"github.com/redis/go-redis/v9"
+)
+
+type HealthRoutesConfig struct {
+	CheckTimeout  time.Duration
+	ReplicaMaxLag time.Duration
+	RedisBreaker  *resilience.Breaker
+}
+
+func NewHealthRoutes(r *http.AppRouter, pgConn *pg.Router, redisClient redis.UniversalClient, config HealthRoutesConfig) *http_handler.HealthHTTPHandler {
+	checks := []http_handler.Check{
+		{
+			Name:  "postgres",
+			Check: pgConn.Ping,
+		},
+		{
+			Name: "redis",
+			Check: func(ctx context.Context) error {
+				return redisClient.Ping(ctx).Err()
+			},
+			// Redirects and logins fall back to Postgres or the failure
+			// policies while Redis is down.
+			Optional: true,
+			Breaker: func() string {
+				return config.RedisBreaker.State().String()
+			},
+		},
+	}
+	if pgConn.HasReplica() {
+		checks = append(checks, http_handler.Check{
+			Name: "postgres_replica",
+			Check: func(ctx context.Context) error {
+				return pgConn.CheckReplica(ctx, config.ReplicaMaxLag)
+			},
+			// Reads fall back to the primary while the replica lags.
+			Optional: true,
+		})
+	}
+
+	healthHTTPHandler := http_handler.NewHealthHTTPHandler(config.CheckTimeout, checks...)
+
+	r.Get("/healthz", healthHTTPHandler.Live)
+	r.Get("/readyz", healthHTTPHandler.Ready)
+
+	return healthHTTPHandler
+}
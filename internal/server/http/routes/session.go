@@ -2,18 +2,18 @@ package http_routes
 
 import (
 	"github.com/brunoibarbosa/url-shortener/internal/container"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
 	pg_session_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/session"
 	"github.com/brunoibarbosa/url-shortener/internal/server/http"
 	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler/session"
 	http_middleware "github.com/brunoibarbosa/url-shortener/internal/server/http/middleware"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type SessionRoutesConfig struct {
 	JWTSecret string
 }
 
-func NewSessionRoutes(r *http.AppRouter, pgConn *pgxpool.Pool, config SessionRoutesConfig) {
+func NewSessionRoutes(r *http.AppRouter, pgConn *pg.Router, config SessionRoutesConfig) {
 	authMiddleware := http_middleware.NewAuthMiddleware(config.JWTSecret)
 
 	deps := container.SessionFactoryDependencies{
@@ -1,53 +1,96 @@
 package http_routes
 
 import (
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
 	"time"
 
 	"github.com/brunoibarbosa/url-shortener/internal/container"
+	"github.com/brunoibarbosa/url-shortener/internal/domain/ratelimit"
+	url_domain "github.com/brunoibarbosa/url-shortener/internal/domain/url"
+	webhook_domain "github.com/brunoibarbosa/url-shortener/internal/domain/webhook"
+	pg_audit_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/audit"
+	pg_outbox_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/outbox"
 	pg_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/url"
+	redis_idempotency_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/redis/idempotency"
+	redis_ratelimit_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/redis/ratelimit"
 	redis_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/redis/url"
-	"github.com/brunoibarbosa/url-shortener/internal/infra/service/crypto"
-	"github.com/brunoibarbosa/url-shortener/internal/infra/service/shortcode"
+	resilient_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/resilient"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/resilience"
 	"github.com/brunoibarbosa/url-shortener/internal/server/http"
 	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler/url"
 	http_middleware "github.com/brunoibarbosa/url-shortener/internal/server/http/middleware"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 )
 
 type URLRoutesConfig struct {
 	JWTSecret                    string
-	URLSecret                    string
+	Encrypter                    url_domain.URLEncrypter
+	BlindIndexer                 url_domain.URLBlindIndexer
 	URLPersistExpirationDuration time.Duration
 	URLCacheExpirationDuration   time.Duration
+	LocalCache                   url_domain.URLLocalCache
+	LocalCacheExpiration         time.Duration
+	AnonymousRateLimit           ratelimit.Limit
+	AuthenticatedRateLimit       ratelimit.Limit
+	ShortCodeLength              int
+	ShortCodeMaxRetries          int
+	ShortCodeGenerator           url_domain.ShortCodeGenerator
+	Metrics                      url_domain.URLMetrics
+	ClickPublisher               webhook_domain.WebhookPublisher
+	RedisBreaker                 *resilience.Breaker
+	IdempotencyTTL               time.Duration
+	IdempotencyLockTTL           time.Duration
+	IdempotencyMaxBodyBytes      int64
+	IdempotencyFingerprintKey    []byte
 }
 
-func NewURLRoutes(r *http.AppRouter, pgConn *pgxpool.Pool, redisClient *redis.Client, config URLRoutesConfig) {
+func NewURLRoutes(r *http.AppRouter, pgConn *pg.Router, redisClient redis.UniversalClient, config URLRoutesConfig) {
 	optionalAuth := http_middleware.NewOptionalAuthMiddleware(config.JWTSecret)
 	authMiddleware := http_middleware.NewAuthMiddleware(config.JWTSecret)
+	shortenRateLimit := http_middleware.NewRateLimitMiddleware(resilient_repo.NewRateLimitRepository(redis_ratelimit_repo.NewRateLimitRepository(redisClient), config.RedisBreaker), http_middleware.RateLimitPolicy{
+		Name:          "shorten",
+		Anonymous:     config.AnonymousRateLimit,
+		Authenticated: config.AuthenticatedRateLimit,
+	})
+	shortenIdempotency := http_middleware.NewIdempotencyMiddleware(resilient_repo.NewIdempotencyRepository(redis_idempotency_repo.NewIdempotencyRepository(redisClient), config.RedisBreaker), http_middleware.IdempotencyPolicy{
+		Name:           "shorten",
+		TTL:            config.IdempotencyTTL,
+		LockTTL:        config.IdempotencyLockTTL,
+		FingerprintKey: config.IdempotencyFingerprintKey,
+		MaxBodyBytes:   config.IdempotencyMaxBodyBytes,
+	})
 
 	deps := container.URLFactoryDependencies{
+		TxManager:                 pg.NewTxManager(pgConn),
 		PersistRepo:               pg_repo.NewURLRepository(pgConn),
-		CacheRepo:                 redis_repo.NewURLCacheRepository(redisClient),
+		CacheRepo:                 resilient_repo.NewURLCacheRepository(redis_repo.NewURLCacheRepository(redisClient), config.RedisBreaker),
+		LocalCache:                config.LocalCache,
 		QueryRepo:                 pg_repo.NewListUserURLsRepository(pgConn),
-		Encrypter:                 crypto.NewURLEncrypter(config.URLSecret),
-		ShortCodeGenerator:        shortcode.NewRandomShortCodeGenerator(),
+		Encrypter:                 config.Encrypter,
+		BlindIndexer:              config.BlindIndexer,
+		ShortCodeGenerator:        config.ShortCodeGenerator,
+		AuditRecorder:             pg_audit_repo.NewAuditRepository(pgConn),
+		OutboxRepo:                pg_outbox_repo.NewOutboxRepository(pgConn),
+		ClickPublisher:            config.ClickPublisher,
+		Metrics:                   config.Metrics,
 		PersistExpirationDuration: config.URLPersistExpirationDuration,
 		CacheExpirationDuration:   config.URLCacheExpirationDuration,
+		LocalCacheExpiration:      config.LocalCacheExpiration,
 	}
 
 	f := container.NewURLHandlerFactory(deps)
 
-	createHTTPHandler := http_handler.NewCreateShortURLHTTPHandler(f.CreateShortURLHandler())
+	createHTTPHandler := http_handler.NewCreateShortURLHTTPHandler(f.CreateShortURLHandler(), config.ShortCodeLength, config.ShortCodeMaxRetries)
 	redirectHTTPHandler := http_handler.NewRedirectHTTPHandler(f.GetOriginalURLHandler())
 	listUserURLsHTTPHandler := http_handler.NewListUserURLsHTTPHandler(f.ListUserURLsHandler())
 	deleteURLHTTPHandler := http_handler.NewDeleteURLHTTPHandler(f.DeleteURLHandler())
 
 	r.Group(func(r *http.AppRouter) {
-		r.Use(optionalAuth.Handler)
+		r.Use(optionalAuth.Handler, shortenRateLimit.Handler, shortenIdempotency.Handler)
 		r.Post("/url/shorten", createHTTPHandler.Handle)
 	})
 	r.Get("/r/{shortCode}", redirectHTTPHandler.Handle)
+	r.Get("/r/{shortCode}/*", redirectHTTPHandler.Handle)
 
 	r.Group(func(r *http.AppRouter) {
 		r.Use(authMiddleware.Handler)
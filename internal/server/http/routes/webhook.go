@@ -0,0 +1,45 @@
+package http_routes
+
+import (
+	"github.com/brunoibarbosa/url-shortener/internal/container"
+	"github.com/brunoibarbosa/url-shortener/internal/infra/database/pg"
+	pg_webhook_repo "github.com/brunoibarbosa/url-shortener/internal/infra/repository/pg/webhook"
+	"github.com/brunoibarbosa/url-shortener/internal/server/http"
+	http_handler "github.com/brunoibarbosa/url-shortener/internal/server/http/handler/webhook"
+	http_middleware "github.com/brunoibarbosa/url-shortener/internal/server/http/middleware"
+)
+
+type WebhookRoutesConfig struct {
+	JWTSecret           string
+	MaxEndpointsPerUser int
+}
+
+func NewWebhookRoutes(r *http.AppRouter, pgConn *pg.Router, config WebhookRoutesConfig) {
+	authMiddleware := http_middleware.NewAuthMiddleware(config.JWTSecret)
+
+	deps := container.WebhookFactoryDependencies{
+		EndpointRepo:        pg_webhook_repo.NewWebhookEndpointRepository(pgConn),
+		DeliveryRepo:        pg_webhook_repo.NewWebhookDeliveryRepository(pgConn),
+		DeliveryQueryRepo:   pg_webhook_repo.NewListWebhookDeliveriesRepository(pgConn),
+		MaxEndpointsPerUser: config.MaxEndpointsPerUser,
+	}
+
+	f := container.NewWebhookHandlerFactory(deps)
+
+	createHTTPHandler := http_handler.NewCreateWebhookEndpointHTTPHandler(f.CreateWebhookEndpointHandler())
+	listHTTPHandler := http_handler.NewListWebhookEndpointsHTTPHandler(f.ListWebhookEndpointsHandler())
+	deleteHTTPHandler := http_handler.NewDeleteWebhookEndpointHTTPHandler(f.DeleteWebhookEndpointHandler())
+	sendTestHTTPHandler := http_handler.NewSendTestWebhookHTTPHandler(f.SendTestWebhookHandler())
+	listDeliveriesHTTPHandler := http_handler.NewListWebhookDeliveriesHTTPHandler(f.ListWebhookDeliveriesHandler())
+	retryDeliveryHTTPHandler := http_handler.NewRetryWebhookDeliveryHTTPHandler(f.RetryWebhookDeliveryHandler())
+
+	r.Group(func(r *http.AppRouter) {
+		r.Use(authMiddleware.Handler)
+		r.Post("/user/webhooks", createHTTPHandler.Handle)
+		r.Get("/user/webhooks", listHTTPHandler.Handle)
+		r.Delete("/user/webhooks/{id}", deleteHTTPHandler.Handle)
+		r.Post("/user/webhooks/{id}/test", sendTestHTTPHandler.Handle)
+		r.Get("/user/webhooks/{id}/deliveries", listDeliveriesHTTPHandler.Handle)
+		r.Post("/user/webhooks/{id}/deliveries/{deliveryId}/retry", retryDeliveryHTTPHandler.Handle)
+	})
+}
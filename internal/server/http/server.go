@@ -5,11 +5,18 @@ import (
 	"time"
 )
 
+type ServerTimeouts struct {
+	Read       time.Duration
+	ReadHeader time.Duration
+	Write      time.Duration
+	Idle       time.Duration
+}
+
 type Server struct {
 	addr string
 }
 
-func NewServer(addr string, router *AppRouter) *http.Server {
+func NewServer(addr string, router *AppRouter, timeouts ServerTimeouts) *http.Server {
 	s := &Server{
 		addr,
 	}
@@ -17,10 +24,10 @@ func NewServer(addr string, router *AppRouter) *http.Server {
 	server := &http.Server{
 		Addr:              s.addr,
 		Handler:           router,
-		IdleTimeout:       time.Minute,
-		ReadTimeout:       10 * time.Second,
-		WriteTimeout:      30 * time.Second,
-		ReadHeaderTimeout: 5 * time.Second,
+		IdleTimeout:       timeouts.Idle,
+		ReadTimeout:       timeouts.Read,
+		WriteTimeout:      timeouts.Write,
+		ReadHeaderTimeout: timeouts.ReadHeader,
 	}
 
 	return server
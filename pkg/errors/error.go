@@ -3,6 +3,7 @@ package errors
 const (
 	// Resource errors
 	CodeNotFound = "NOT_FOUND"
+	CodeConflict = "CONFLICT"
 
 	// Validation errors
 	CodeValidationError = "VALIDATION_ERROR"
@@ -11,6 +12,13 @@ const (
 	CodeInternalError = "INTERNAL_ERROR"
 	CodeBadRequest    = "BAD_REQUEST"
 
+	// Payload errors
+	CodePayloadTooLarge = "PAYLOAD_TOO_LARGE"
+
+	// Rate limiting errors
+	CodeTooManyRequests = "TOO_MANY_REQUESTS"
+
 	// Authentication errors
 	CodeUnauthorized = "UNAUTHORIZED"
+	CodeForbidden    = "FORBIDDEN"
 )
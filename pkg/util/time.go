@@ -8,3 +8,16 @@ func MinTimeDuration(a, b time.Duration) time.Duration {
 	}
 	return b
 }
+
+// ExponentialBackoff returns how long to wait after the given failed attempt
+// (1-based): base doubled for every previous failure, capped at maxDelay.
+func ExponentialBackoff(attempt int, base, maxDelay time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= maxDelay || delay <= 0 {
+			return maxDelay
+		}
+	}
+	return min(delay, maxDelay)
+}
@@ -0,0 +1,20 @@
+package util_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brunoibarbosa/url-shortener/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	base := 30 * time.Second
+	maxDelay := 10 * time.Minute
+
+	assert.Equal(t, 30*time.Second, util.ExponentialBackoff(1, base, maxDelay))
+	assert.Equal(t, time.Minute, util.ExponentialBackoff(2, base, maxDelay))
+	assert.Equal(t, 8*time.Minute, util.ExponentialBackoff(5, base, maxDelay))
+	assert.Equal(t, maxDelay, util.ExponentialBackoff(6, base, maxDelay))
+	assert.Equal(t, maxDelay, util.ExponentialBackoff(100, base, maxDelay))
+}